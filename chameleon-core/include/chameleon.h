@@ -54,6 +54,12 @@ enum ChameleonResult chameleon_generate_sql(const char *query_json,
  */
 enum ChameleonResult chameleon_generate_migration(const char *schema_json, char **error_out);
 
+/**
+ * Generate the migration as a JSON array of statements
+ * ({kind, entity, description, sql}) in execution order
+ */
+enum ChameleonResult chameleon_generate_migration_plan(const char *schema_json, char **error_out);
+
 /**
  * Set schema cache for efficient batch operations
  *
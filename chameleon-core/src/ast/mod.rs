@@ -1,16 +1,60 @@
 use serde::{Deserialize, Serialize};
 use std::collections::HashMap;
 
+/// Version of the schema JSON handed to the engine. Bump it (and the
+/// engine's vault.SchemaFormat) when the schema gains information an
+/// older engine would silently drop.
+pub const SCHEMA_FORMAT_VERSION: u32 = 1;
+
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 pub struct Schema {
     pub entities: Vec<Entity>,
+    #[serde(default)]
+    pub format_version: u32,  // SCHEMA_FORMAT_VERSION of the core that parsed it, 0 = unknown
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 pub struct Entity {
     pub name: String,
     pub fields: HashMap<String, Field>,
+    /// Field names in declaration order (`fields` is unordered)
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub field_order: Vec<String>,
     pub relations: HashMap<String, Relation>,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub checks: Vec<CheckConstraint>,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub unique_indexes: Vec<UniqueIndex>,
+    #[serde(default)]
+    pub view: bool,  // @view: backed by a database view, never migrated
+    #[serde(default)]
+    pub read_only: bool,  // @readonly: mutations are rejected
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub schema: Option<String>,  // @schema("billing"): PostgreSQL namespace, None = search_path
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub tenant: Option<String>,  // @tenant(org_id): column every query and mutation is scoped by
+    #[serde(default, skip_serializing_if = "std::ops::Not::not")]
+    pub timestamps: bool,  // @timestamps: created_at/updated_at default now(), maintained by mutations
+    /// From `///` doc comments above the entity
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub description: Option<String>,
+}
+
+/// A CHECK constraint declared on a field or on the whole entity
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct CheckConstraint {
+    pub expression: String,
+    pub field: Option<String>,  // None = table-level (multi-column)
+}
+
+/// A unique index declared on the entity: @unique(email) where deleted_at is null
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct UniqueIndex {
+    pub fields: Vec<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub predicate: Option<String>,  // SQL condition; None = plain multi-column unique
+    #[serde(default, skip_serializing_if = "std::ops::Not::not")]
+    pub concurrent: bool,  // `concurrently`: built with CREATE INDEX CONCURRENTLY, outside the migration transaction
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
@@ -22,6 +66,9 @@ pub struct Field {
     pub primary_key: bool,
     pub default: Option<DefaultValue>,
     pub backend: Option<BackendAnnotation>,
+    /// From `///` doc comments above the field
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub description: Option<String>,
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
@@ -42,6 +89,11 @@ pub enum DefaultValue {
     Now,
     UUIDv4,
     Literal(String),
+    /// `auto`: the database assigns the next value of a sequence (SERIAL)
+    AutoIncrement,
+    /// `generated("price * quantity")`: computed by the database from other
+    /// columns (GENERATED ALWAYS AS ... STORED) and never written
+    Generated(String),
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
@@ -62,10 +114,38 @@ pub enum RelationKind {
 }
 
 // Helper types para el parser LALRPOP
+#[derive(Debug)]
+pub enum SchemaItem {
+    Doc(String),  // one `///` line
+    Entity(Entity),
+}
+
 #[derive(Debug)]
 pub enum EntityItem {
-    Field(Field),
+    Doc(String),  // one `///` line
+    Field(Field, Vec<String>),  // field + its check expressions
     Relation(Relation),
+    Check(CheckConstraint),
+    UniqueIndex(UniqueIndex),
+}
+
+/// Joins the pending doc comment lines into a description and clears them
+pub fn take_doc(lines: &mut Vec<String>) -> Option<String> {
+    if lines.is_empty() {
+        return None;
+    }
+    let doc = lines.join("\n");
+    lines.clear();
+    Some(doc)
+}
+
+#[derive(Debug)]
+pub enum EntityAnnotation {
+    View,
+    ReadOnly,
+    Schema(String),
+    Tenant(String),
+    Timestamps,
 }
 
 #[derive(Debug)]
@@ -74,6 +154,7 @@ pub enum FieldModifier {
     Unique,
     Nullable,
     Default(DefaultValue),
+    Check(String),
 }
 
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
@@ -98,6 +179,7 @@ impl Schema {
     pub fn new() -> Self {
         Schema {
             entities: Vec::new(),
+            format_version: SCHEMA_FORMAT_VERSION,
         }
     }
 
@@ -120,15 +202,62 @@ impl Entity {
         Entity {
             name,
             fields: HashMap::new(),
+            field_order: Vec::new(),
             relations: HashMap::new(),
+            checks: Vec::new(),
+            unique_indexes: Vec::new(),
+            view: false,
+            read_only: false,
+            schema: None,
+            tenant: None,
+            timestamps: false,
+            description: None,
         }
     }
     
     pub fn add_field(&mut self, field: Field) {
+        if !self.fields.contains_key(&field.name) {
+            self.field_order.push(field.name.clone());
+        }
         self.fields.insert(field.name.clone(), field);
     }
     
     pub fn add_relation(&mut self, relation: Relation) {
         self.relations.insert(relation.name.clone(), relation);
     }
-}
\ No newline at end of file
+
+    pub fn add_check(&mut self, check: CheckConstraint) {
+        self.checks.push(check);
+    }
+
+    pub fn add_unique_index(&mut self, index: UniqueIndex) {
+        self.unique_indexes.push(index);
+    }
+
+    /// @timestamps: adds created_at and updated_at as `timestamp default now()`
+    /// when they are not declared, and gives declared ones a now() default
+    pub fn add_timestamps(&mut self) {
+        for name in TIMESTAMP_FIELDS {
+            match self.fields.get_mut(name) {
+                Some(field) => {
+                    if field.default.is_none() {
+                        field.default = Some(DefaultValue::Now);
+                    }
+                }
+                None => self.add_field(Field {
+                    name: name.to_string(),
+                    field_type: FieldType::Timestamp,
+                    nullable: false,
+                    unique: false,
+                    primary_key: false,
+                    default: Some(DefaultValue::Now),
+                    backend: None,
+                    description: None,
+                }),
+            }
+        }
+    }
+}
+
+/// Fields maintained on entities declared with @timestamps
+pub const TIMESTAMP_FIELDS: [&str; 2] = ["created_at", "updated_at"];
\ No newline at end of file
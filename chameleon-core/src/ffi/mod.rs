@@ -338,6 +338,52 @@ pub unsafe extern "C" fn chameleon_generate_migration(
     }
 }
 
+/// Generate the migration as a JSON array of statements
+/// ({kind, entity, description, sql}) in execution order
+#[no_mangle]
+pub unsafe extern "C" fn chameleon_generate_migration_plan(
+    schema_json: *const c_char,
+    error_out: *mut *mut c_char,
+) -> ChameleonResult {
+    if schema_json.is_null() {
+        set_error(error_out, "Schema JSON is null");
+        return ChameleonResult::InternalError;
+    }
+
+    let json_str = match CStr::from_ptr(schema_json).to_str() {
+        Ok(s) => s,
+        Err(e) => {
+            set_error(error_out, &format!("Invalid UTF-8: {}", e));
+            return ChameleonResult::InternalError;
+        }
+    };
+
+    let schema: Schema = match serde_json::from_str(json_str) {
+        Ok(s) => s,
+        Err(e) => {
+            set_error(error_out, &format!("Schema deserialization error: {}", e));
+            return ChameleonResult::InternalError;
+        }
+    };
+
+    match crate::migration::generator::generate_migration(&schema) {
+        Ok(migration) => match serde_json::to_string(&migration.plan) {
+            Ok(json) => {
+                *error_out = CString::new(json).unwrap().into_raw();
+                ChameleonResult::Ok
+            }
+            Err(e) => {
+                set_error(error_out, &format!("Failed to serialize migration plan: {}", e));
+                ChameleonResult::InternalError
+            }
+        },
+        Err(e) => {
+            set_error(error_out, &format!("Migration generation error: {}", e));
+            ChameleonResult::ValidationError
+        }
+    }
+}
+
 // ============================================================
 // NEW: MUTATION SQL GENERATION (v0.1)
 // ============================================================
@@ -1,6 +1,7 @@
 use crate::ast::{Schema, Entity, RelationKind};
-use crate::sql::naming::entity_to_table;
-use super::type_map::{to_postgres_type, to_postgres_default};
+use serde::Serialize;
+use crate::sql::naming::{entity_to_table, qualified_table, quote_ident};
+use super::type_map::{to_postgres_column_type, to_postgres_default, to_postgres_generated};
 
 /// Full migration output
 #[derive(Debug, Clone, PartialEq)]
@@ -9,12 +10,47 @@ pub struct Migration {
     pub sql: String,
     /// Ordered list of (entity_name, CREATE TABLE statement)
     pub statements: Vec<(String, String)>,
+    /// Every statement of `sql`, in execution order, for review and
+    /// statement-by-statement apply
+    pub plan: Vec<MigrationStatement>,
+}
+
+/// One DDL statement of a migration
+#[derive(Debug, Clone, PartialEq, Serialize)]
+pub struct MigrationStatement {
+    pub kind: StatementKind,
+    /// Entity the statement belongs to; None for namespace creation
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub entity: Option<String>,
+    /// Human-readable summary, e.g. "Create table users"
+    pub description: String,
+    pub sql: String,
+    /// Must run outside the migration transaction (CREATE INDEX CONCURRENTLY)
+    #[serde(skip_serializing_if = "std::ops::Not::not")]
+    pub concurrent: bool,
+    /// Undoes a failed concurrent statement, e.g. drops the INVALID index it leaves behind
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub cleanup: Option<String>,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Serialize)]
+#[serde(rename_all = "snake_case")]
+pub enum StatementKind {
+    CreateSchema,
+    DropTable,
+    CreateTable,
+    CreateIndex,
+    Comment,
 }
 
 /// Generate a full migration from a validated schema
 pub fn generate_migration(schema: &Schema) -> Result<Migration, MigrationError> {
     // 1. Resolve creation order (dependencies first)
-    let order = resolve_creation_order(schema)?;
+    // Views are managed outside migrations, so they are never created or dropped
+    let order: Vec<String> = resolve_creation_order(schema)?
+        .into_iter()
+        .filter(|name| !schema.get_entity(name).map_or(false, |e| e.view))
+        .collect();
 
     // 2. Generate CREATE TABLE for each entity in order
     let mut statements = Vec::new();
@@ -24,34 +60,79 @@ pub fn generate_migration(schema: &Schema) -> Result<Migration, MigrationError>
         statements.push((entity_name.clone(), sql));
     }
 
-    // 3. Build full script with DROP statements first (in reverse order for FK safety)
-    let mut sql_parts = Vec::new();
-    
+    // 3. Build the plan with DROP statements first (in reverse order for FK safety)
+    let mut plan = Vec::new();
+
+    // Create non-default namespaces first (@schema("billing"))
+    let mut namespaces: Vec<&str> = order.iter()
+        .filter_map(|name| schema.get_entity(name).and_then(|e| e.schema.as_deref()))
+        .collect();
+    namespaces.sort();
+    namespaces.dedup();
+    for ns in namespaces {
+        plan.push(MigrationStatement {
+            kind: StatementKind::CreateSchema,
+            entity: None,
+            description: format!("Create schema {}", ns),
+            sql: format!("CREATE SCHEMA IF NOT EXISTS {};", quote_ident(ns)),
+            concurrent: false,
+            cleanup: None,
+        });
+    }
+
     // Add DROP statements in reverse order (to handle FKs)
     for entity_name in order.iter().rev() {
-        let table_name = entity_to_table(&entity_name);
-        sql_parts.push(format!("DROP TABLE IF EXISTS {} CASCADE;", table_name));
+        let table_name = qualified_table(schema.get_entity(entity_name).unwrap());
+        plan.push(MigrationStatement {
+            kind: StatementKind::DropTable,
+            entity: Some(entity_name.clone()),
+            description: format!("Drop table {} if it exists", table_name),
+            sql: format!("DROP TABLE IF EXISTS {} CASCADE;", table_name),
+            concurrent: false,
+            cleanup: None,
+        });
     }
-    
-    // Add CREATE statements in order
-    for (_, stmt) in &statements {
-        sql_parts.push(stmt.clone());
+
+    // Add CREATE statements in order, each table followed by its indexes.
+    // Concurrent indexes cannot run in a transaction, so they go last,
+    // after the transactional part has been committed.
+    let mut concurrent = Vec::new();
+    for (entity_name, stmt) in &statements {
+        let entity = schema.get_entity(entity_name).unwrap();
+        plan.push(MigrationStatement {
+            kind: StatementKind::CreateTable,
+            entity: Some(entity_name.clone()),
+            description: format!("Create table {}", qualified_table(entity)),
+            sql: stmt.clone(),
+            concurrent: false,
+            cleanup: None,
+        });
+        plan.extend(generate_comments(entity));
+        let (deferred, immediate): (Vec<_>, Vec<_>) = generate_unique_indexes(entity)
+            .into_iter()
+            .partition(|stmt| stmt.concurrent);
+        plan.extend(immediate);
+        concurrent.extend(deferred);
     }
-    
-    let sql = sql_parts.join("\n\n");
+    plan.extend(concurrent);
+
+    let sql = plan.iter()
+        .map(|stmt| stmt.sql.as_str())
+        .collect::<Vec<_>>()
+        .join("\n\n");
 
-    Ok(Migration { sql, statements })
+    Ok(Migration { sql, statements, plan })
 }
 
 /// Generate a single CREATE TABLE statement
 fn generate_create_table(entity: &Entity, schema: &Schema) -> Result<String, MigrationError> {
-    let table_name = entity_to_table(&entity.name);
+    let table_name = qualified_table(entity);
     let mut columns = Vec::new();
     let mut constraints = Vec::new();
 
     // Columns (skip relation-only fields, keep actual data fields)
     for (_, field) in &entity.fields {
-        let pg_type = to_postgres_type(&field.field_type);
+        let pg_type = to_postgres_column_type(field);
 
         let mut col = format!("    {} {}", field.name, pg_type);
 
@@ -70,9 +151,14 @@ fn generate_create_table(entity: &Entity, schema: &Schema) -> Result<String, Mig
             col.push_str(" UNIQUE");
         }
 
+        // GENERATED ALWAYS AS (...) STORED
+        if let Some(generated) = to_postgres_generated(field) {
+            col.push_str(&format!(" {}", generated));
+        }
+
         // DEFAULT
-        if let Some(default) = &field.default {
-            col.push_str(&format!(" DEFAULT {}", to_postgres_default(&default)));
+        if let Some(default) = field.default.as_ref().and_then(to_postgres_default) {
+            col.push_str(&format!(" DEFAULT {}", default));
         }
 
         columns.push(col);
@@ -104,7 +190,7 @@ fn generate_create_table(entity: &Entity, schema: &Schema) -> Result<String, Mig
                 // other_entity HasMany this entity via FK
                 // The FK field is IN this entity
                 if let Some(fk) = &relation.foreign_key {
-                    let other_table = entity_to_table(&other_entity.name);
+                    let other_table = qualified_table(other_entity);
                     constraints.push(format!(
                         "    FOREIGN KEY ({}) REFERENCES {}(id)",
                         fk, other_table
@@ -114,6 +200,11 @@ fn generate_create_table(entity: &Entity, schema: &Schema) -> Result<String, Mig
         }
     }
 
+    // CHECK constraints (field-level and table-level alike)
+    for check in &entity.checks {
+        constraints.push(format!("    CHECK ({})", check.expression));
+    }
+
     // Build CREATE TABLE
     let mut all_parts = columns;
     all_parts.extend(constraints);
@@ -125,6 +216,99 @@ fn generate_create_table(entity: &Entity, schema: &Schema) -> Result<String, Mig
     ))
 }
 
+/// COMMENT ON statements for `///` descriptions of the entity and its
+/// fields, fields in declaration order
+fn generate_comments(entity: &Entity) -> Vec<MigrationStatement> {
+    let table_name = qualified_table(entity);
+    let mut statements = Vec::new();
+
+    if let Some(description) = &entity.description {
+        statements.push(MigrationStatement {
+            kind: StatementKind::Comment,
+            entity: Some(entity.name.clone()),
+            description: format!("Comment on table {}", table_name),
+            sql: format!("COMMENT ON TABLE {} IS {};", table_name, quote_literal(description)),
+            concurrent: false,
+            cleanup: None,
+        });
+    }
+
+    let mut names: Vec<&String> = entity.field_order.iter().collect();
+    if names.is_empty() {
+        names = entity.fields.keys().collect();
+        names.sort();
+    }
+    for name in names {
+        let Some(description) = entity.fields.get(name).and_then(|f| f.description.as_ref()) else {
+            continue;
+        };
+        statements.push(MigrationStatement {
+            kind: StatementKind::Comment,
+            entity: Some(entity.name.clone()),
+            description: format!("Comment on column {}.{}", table_name, name),
+            sql: format!("COMMENT ON COLUMN {}.{} IS {};", table_name, name, quote_literal(description)),
+            concurrent: false,
+            cleanup: None,
+        });
+    }
+
+    statements
+}
+
+/// Render text as a SQL string literal
+fn quote_literal(text: &str) -> String {
+    format!("'{}'", text.replace('\'', "''"))
+}
+
+/// Entity-level unique indexes; a predicate makes the index partial,
+/// which a table constraint cannot express
+fn generate_unique_indexes(entity: &Entity) -> Vec<MigrationStatement> {
+    let table_name = qualified_table(entity);
+    entity.unique_indexes.iter().map(|index| {
+        let name = unique_index_name(entity, &index.fields);
+        let mut sql = format!(
+            "CREATE UNIQUE INDEX {}{} ON {} ({})",
+            if index.concurrent { "CONCURRENTLY " } else { "" },
+            quote_ident(&name),
+            table_name,
+            index.fields.join(", ")
+        );
+        let mut description = format!("Create unique index {} on {} ({})", name, table_name, index.fields.join(", "));
+        if let Some(predicate) = &index.predicate {
+            sql.push_str(&format!(" WHERE {}", predicate));
+            description.push_str(&format!(" where {}", predicate));
+        }
+        sql.push(';');
+
+        // A failed concurrent build leaves an INVALID index that blocks a retry
+        let cleanup = index.concurrent.then(|| {
+            let qualified = match &entity.schema {
+                Some(ns) => format!("{}.{}", quote_ident(ns), quote_ident(&name)),
+                None => quote_ident(&name),
+            };
+            format!("DROP INDEX CONCURRENTLY IF EXISTS {};", qualified)
+        });
+        if index.concurrent {
+            description.push_str(" concurrently");
+        }
+
+        MigrationStatement {
+            kind: StatementKind::CreateIndex,
+            entity: Some(entity.name.clone()),
+            description,
+            sql,
+            concurrent: index.concurrent,
+            cleanup,
+        }
+    }).collect()
+}
+
+/// Index name following PostgreSQL's own convention for unique constraints
+///   User @unique(tenant_id, email) → users_tenant_id_email_key
+pub fn unique_index_name(entity: &Entity, fields: &[String]) -> String {
+    format!("{}_{}_key", entity_to_table(&entity.name), fields.join("_"))
+}
+
 /// Resolve entity creation order using topological sort
 /// Entities referenced by FKs must be created first
 fn resolve_creation_order(schema: &Schema) -> Result<Vec<String>, MigrationError> {
@@ -1,7 +1,7 @@
 pub mod generator;
 pub mod type_map;
 
-pub use generator::{generate_migration, Migration, MigrationError};
+pub use generator::{generate_migration, Migration, MigrationError, MigrationStatement, StatementKind};
 
 #[cfg(test)]
 mod tests {
@@ -18,31 +18,31 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         user.add_field(Field {
             name: "email".to_string(),
             field_type: FieldType::String,
             nullable: false, unique: true, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         user.add_field(Field {
             name: "name".to_string(),
             field_type: FieldType::String,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         user.add_field(Field {
             name: "age".to_string(),
             field_type: FieldType::Int,
             nullable: true, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         user.add_field(Field {
             name: "created_at".to_string(),
             field_type: FieldType::Timestamp,
             nullable: false, unique: false, primary_key: false,
-            default: Some(DefaultValue::Now), backend: None,
+            default: Some(DefaultValue::Now), backend: None, description: None,
         });
         user.add_relation(Relation {
             name: "orders".to_string(),
@@ -59,25 +59,25 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         order.add_field(Field {
             name: "total".to_string(),
             field_type: FieldType::Decimal,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         order.add_field(Field {
             name: "status".to_string(),
             field_type: FieldType::String,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         order.add_field(Field {
             name: "user_id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         order.add_relation(Relation {
             name: "user".to_string(),
@@ -101,25 +101,25 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         item.add_field(Field {
             name: "quantity".to_string(),
             field_type: FieldType::Int,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         item.add_field(Field {
             name: "price".to_string(),
             field_type: FieldType::Decimal,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         item.add_field(Field {
             name: "order_id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         item.add_relation(Relation {
             name: "order".to_string(),
@@ -169,13 +169,13 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         entity.add_field(Field {
             name: "email".to_string(),
             field_type: FieldType::String,
             nullable: false, unique: true, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         schema.add_entity(entity);
 
@@ -195,13 +195,13 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         entity.add_field(Field {
             name: "age".to_string(),
             field_type: FieldType::Int,
             nullable: true, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         schema.add_entity(entity);
 
@@ -220,13 +220,13 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: Some(DefaultValue::UUIDv4), backend: None,
+            default: Some(DefaultValue::UUIDv4), backend: None, description: None,
         });
         entity.add_field(Field {
             name: "created_at".to_string(),
             field_type: FieldType::Timestamp,
             nullable: false, unique: false, primary_key: false,
-            default: Some(DefaultValue::Now), backend: None,
+            default: Some(DefaultValue::Now), backend: None, description: None,
         });
         schema.add_entity(entity);
 
@@ -236,6 +236,221 @@ mod tests {
         assert!(migration.sql.contains("DEFAULT NOW()"));
     }
 
+    #[test]
+    fn test_check_constraints() {
+        let mut schema = Schema::new();
+        let mut entity = Entity::new("Product".to_string());
+        entity.add_field(Field {
+            name: "id".to_string(),
+            field_type: FieldType::UUID,
+            nullable: false, unique: false, primary_key: true,
+            default: None, backend: None, description: None,
+        });
+        entity.add_field(Field {
+            name: "price".to_string(),
+            field_type: FieldType::Decimal,
+            nullable: false, unique: false, primary_key: false,
+            default: None, backend: None, description: None,
+        });
+        entity.add_check(CheckConstraint {
+            expression: "price > 0".to_string(),
+            field: Some("price".to_string()),
+        });
+        entity.add_check(CheckConstraint {
+            expression: "price < cost * 10".to_string(),
+            field: None,
+        });
+        schema.add_entity(entity);
+
+        let migration = generate_migration(&schema).unwrap();
+
+        assert!(migration.sql.contains("CHECK (price > 0)"));
+        assert!(migration.sql.contains("CHECK (price < cost * 10)"));
+    }
+
+    #[test]
+    fn test_unique_indexes() {
+        let mut schema = Schema::new();
+        let mut entity = Entity::new("User".to_string());
+        for (name, nullable) in [("id", false), ("tenant_id", false), ("email", false), ("deleted_at", true)] {
+            entity.add_field(Field {
+                name: name.to_string(),
+                field_type: FieldType::String,
+                nullable, unique: false, primary_key: name == "id",
+                default: None, backend: None, description: None,
+            });
+        }
+        entity.add_unique_index(UniqueIndex {
+            fields: vec!["email".to_string()],
+            predicate: Some("deleted_at IS NULL".to_string()),
+            concurrent: false,
+        });
+        entity.add_unique_index(UniqueIndex {
+            fields: vec!["tenant_id".to_string(), "email".to_string()],
+            predicate: None,
+            concurrent: false,
+        });
+        schema.add_entity(entity);
+
+        let migration = generate_migration(&schema).unwrap();
+
+        assert!(migration.sql.contains(
+            "CREATE UNIQUE INDEX \"users_email_key\" ON users (email) WHERE deleted_at IS NULL;"
+        ));
+        assert!(migration.sql.contains(
+            "CREATE UNIQUE INDEX \"users_tenant_id_email_key\" ON users (tenant_id, email);"
+        ));
+    }
+
+    #[test]
+    fn test_migration_plan() {
+        let mut schema = test_schema();
+        schema.get_entity_mut("User").unwrap().add_unique_index(UniqueIndex {
+            fields: vec!["email".to_string()],
+            predicate: None,
+            concurrent: false,
+        });
+
+        let migration = generate_migration(&schema).unwrap();
+
+        let kinds: Vec<StatementKind> = migration.plan.iter().map(|s| s.kind).collect();
+        assert_eq!(kinds, vec![
+            StatementKind::DropTable,
+            StatementKind::DropTable,
+            StatementKind::DropTable,
+            StatementKind::CreateTable,
+            StatementKind::CreateIndex,
+            StatementKind::CreateTable,
+            StatementKind::CreateTable,
+        ]);
+        assert_eq!(migration.plan[3].description, "Create table users");
+        assert_eq!(migration.plan[4].entity.as_deref(), Some("User"));
+
+        assert!(migration.plan.iter().all(|s| !s.concurrent && s.cleanup.is_none()));
+
+        // The script is exactly the plan, in order
+        let joined: Vec<&str> = migration.plan.iter().map(|s| s.sql.as_str()).collect();
+        assert_eq!(migration.sql, joined.join("\n\n"));
+    }
+
+    #[test]
+    fn test_concurrent_indexes_run_last() {
+        let mut schema = test_schema();
+        schema.get_entity_mut("User").unwrap().add_unique_index(UniqueIndex {
+            fields: vec!["email".to_string()],
+            predicate: None,
+            concurrent: true,
+        });
+
+        let migration = generate_migration(&schema).unwrap();
+
+        let last = migration.plan.last().unwrap();
+        assert_eq!(last.kind, StatementKind::CreateIndex);
+        assert!(last.concurrent);
+        assert_eq!(last.sql, "CREATE UNIQUE INDEX CONCURRENTLY \"users_email_key\" ON users (email);");
+        assert_eq!(last.cleanup.as_deref(), Some("DROP INDEX CONCURRENTLY IF EXISTS \"users_email_key\";"));
+        assert_eq!(migration.plan.iter().filter(|s| s.concurrent).count(), 1);
+    }
+
+    #[test]
+    fn test_auto_increment_is_serial() {
+        let mut schema = Schema::new();
+        let mut order = Entity::new("Order".to_string());
+        order.add_field(Field {
+            name: "id".to_string(),
+            field_type: FieldType::Int,
+            nullable: false, unique: false, primary_key: true,
+            default: Some(DefaultValue::AutoIncrement), backend: None, description: None,
+        });
+        schema.add_entity(order);
+
+        let migration = generate_migration(&schema).unwrap();
+        assert!(migration.sql.contains("id SERIAL PRIMARY KEY"));
+        assert!(!migration.sql.contains("DEFAULT"));
+    }
+
+    #[test]
+    fn test_generated_column() {
+        let mut schema = Schema::new();
+        let mut item = Entity::new("OrderItem".to_string());
+        item.add_field(Field {
+            name: "total".to_string(),
+            field_type: FieldType::Decimal,
+            nullable: false, unique: false, primary_key: false,
+            default: Some(DefaultValue::Generated("price * quantity".to_string())), backend: None, description: None,
+        });
+        schema.add_entity(item);
+
+        let migration = generate_migration(&schema).unwrap();
+        assert!(migration.sql.contains("total NUMERIC NOT NULL GENERATED ALWAYS AS (price * quantity) STORED"));
+        assert!(!migration.sql.contains("DEFAULT"));
+    }
+
+    #[test]
+    fn test_descriptions_become_comments() {
+        let mut schema = test_schema();
+        let user = schema.get_entity_mut("User").unwrap();
+        user.description = Some("The account owner".to_string());
+        user.fields.get_mut("email").unwrap().description = Some("Login, can't be reused".to_string());
+
+        let migration = generate_migration(&schema).unwrap();
+
+        let comments: Vec<&str> = migration.plan.iter()
+            .filter(|s| s.kind == StatementKind::Comment)
+            .map(|s| s.sql.as_str())
+            .collect();
+        assert_eq!(comments, vec![
+            "COMMENT ON TABLE users IS 'The account owner';",
+            "COMMENT ON COLUMN users.email IS 'Login, can''t be reused';",
+        ]);
+
+        // Comments follow their table
+        let create = migration.plan.iter()
+            .position(|s| s.kind == StatementKind::CreateTable && s.entity.as_deref() == Some("User"))
+            .unwrap();
+        assert_eq!(migration.plan[create + 1].kind, StatementKind::Comment);
+    }
+
+    #[test]
+    fn test_views_are_not_migrated() {
+        let mut schema = test_schema();
+        let mut view = Entity::new("ActiveUser".to_string());
+        view.view = true;
+        view.add_field(Field {
+            name: "id".to_string(),
+            field_type: FieldType::UUID,
+            nullable: false, unique: false, primary_key: false,
+            default: None, backend: None, description: None,
+        });
+        schema.add_entity(view);
+
+        let migration = generate_migration(&schema).unwrap();
+
+        assert_eq!(migration.statements.len(), 3);
+        assert!(!migration.sql.contains("active_users"));
+    }
+
+    #[test]
+    fn test_schema_qualified_tables() {
+        let mut schema = test_schema();
+        let mut invoice = Entity::new("Invoice".to_string());
+        invoice.schema = Some("billing".to_string());
+        invoice.add_field(Field {
+            name: "id".to_string(),
+            field_type: FieldType::UUID,
+            nullable: false, unique: false, primary_key: true,
+            default: None, backend: None, description: None,
+        });
+        schema.add_entity(invoice);
+
+        let migration = generate_migration(&schema).unwrap();
+
+        assert!(migration.sql.contains("CREATE SCHEMA IF NOT EXISTS \"billing\";"));
+        assert!(migration.sql.contains("DROP TABLE IF EXISTS \"billing\".\"invoices\" CASCADE;"));
+        assert!(migration.sql.contains("CREATE TABLE \"billing\".\"invoices\" ("));
+        assert!(migration.sql.contains("CREATE TABLE users ("));
+    }
+
     // ─── FOREIGN KEYS ───
 
     #[test]
@@ -302,19 +517,19 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         entity.add_field(Field {
             name: "views".to_string(),
             field_type: FieldType::Int,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: Some(BackendAnnotation::Cache),
+            default: None, backend: Some(BackendAnnotation::Cache), description: None,
         });
         entity.add_field(Field {
             name: "sales".to_string(),
             field_type: FieldType::Decimal,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: Some(BackendAnnotation::OLAP),
+            default: None, backend: Some(BackendAnnotation::OLAP), description: None,
         });
         schema.add_entity(entity);
 
@@ -1,4 +1,4 @@
-use crate::ast::{FieldType, DefaultValue};
+use crate::ast::{Field, FieldType, DefaultValue};
 
 /// Maps ChameleonDB field types to PostgreSQL column types
 pub fn to_postgres_type(field_type: &FieldType) -> String {
@@ -15,12 +15,33 @@ pub fn to_postgres_type(field_type: &FieldType) -> String {
     }
 }
 
-/// Maps ChameleonDB default values to PostgreSQL expressions
-pub fn to_postgres_default(default: &DefaultValue) -> String {
+/// Maps a field to its column type: `auto` integers become SERIAL,
+/// which brings its own sequence default
+pub fn to_postgres_column_type(field: &Field) -> String {
+    match (&field.field_type, &field.default) {
+        (FieldType::Int, Some(DefaultValue::AutoIncrement)) => "SERIAL".to_string(),
+        (field_type, _) => to_postgres_type(field_type),
+    }
+}
+
+/// Maps ChameleonDB default values to PostgreSQL expressions.
+/// AutoIncrement has none: the SERIAL type supplies it. Generated columns
+/// have none either; see to_postgres_generated.
+pub fn to_postgres_default(default: &DefaultValue) -> Option<String> {
     match default {
-        DefaultValue::Now      => "NOW()".to_string(),
-        DefaultValue::UUIDv4   => "gen_random_uuid()".to_string(),
-        DefaultValue::Literal(s) => format!("'{}'", s),
+        DefaultValue::Now      => Some("NOW()".to_string()),
+        DefaultValue::UUIDv4   => Some("gen_random_uuid()".to_string()),
+        DefaultValue::Literal(s) => Some(format!("'{}'", s)),
+        DefaultValue::AutoIncrement => None,
+        DefaultValue::Generated(_) => None,
+    }
+}
+
+/// The GENERATED ALWAYS AS clause of a computed field, if it is one
+pub fn to_postgres_generated(field: &Field) -> Option<String> {
+    match &field.default {
+        Some(DefaultValue::Generated(expr)) => Some(format!("GENERATED ALWAYS AS ({}) STORED", expr)),
+        _ => None,
     }
 }
 
@@ -60,8 +81,9 @@ mod tests {
 
     #[test]
     fn test_defaults() {
-        assert_eq!(to_postgres_default(&DefaultValue::Now), "NOW()");
-        assert_eq!(to_postgres_default(&DefaultValue::UUIDv4), "gen_random_uuid()");
-        assert_eq!(to_postgres_default(&DefaultValue::Literal("hello".to_string())), "'hello'");
+        assert_eq!(to_postgres_default(&DefaultValue::Now).as_deref(), Some("NOW()"));
+        assert_eq!(to_postgres_default(&DefaultValue::UUIDv4).as_deref(), Some("gen_random_uuid()"));
+        assert_eq!(to_postgres_default(&DefaultValue::Literal("hello".to_string())).as_deref(), Some("'hello'"));
+        assert_eq!(to_postgres_default(&DefaultValue::AutoIncrement), None);
     }
 }
\ No newline at end of file
@@ -4,7 +4,7 @@ use crate::error::{ChameleonError, ParseErrorDetail};
 // Incluir el módulo parser generado por lalrpop
 #[allow(clippy::all)]
 pub mod schema {
-    use crate::ast::{EntityItem, FieldModifier};
+    use crate::ast::{EntityAnnotation, EntityItem, FieldModifier};
     use crate::ast::*;
     
     include!(concat!(env!("OUT_DIR"), "/parser/schema.rs"));
@@ -185,6 +185,7 @@ mod tests {
         
         let schema = parse_schema(input).unwrap();
         assert_eq!(schema.entities.len(), 1);
+        assert_eq!(schema.format_version, crate::ast::SCHEMA_FORMAT_VERSION);
         
         let user = schema.get_entity("User").unwrap();
         assert_eq!(user.fields.len(), 3);
@@ -312,4 +313,275 @@ fn test_complex_multi_backend_schema() {
     assert_eq!(product.fields.get("embedding").unwrap().field_type, FieldType::Vector(384));
     assert_eq!(product.fields.get("tags").unwrap().field_type, FieldType::Array(Box::new(FieldType::String)));
 }
-}
\ No newline at end of file
+}
+
+#[test]
+fn test_check_constraints() {
+    let input = r#"
+        entity Product {
+            id: uuid primary,
+            price: decimal check("price > 0"),
+            cost: decimal,
+            check("price >= cost"),
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let product = schema.get_entity("Product").unwrap();
+
+    assert_eq!(product.checks.len(), 2);
+    assert_eq!(product.checks[0].expression, "price > 0");
+    assert_eq!(product.checks[0].field, Some("price".to_string()));
+    assert_eq!(product.checks[1].expression, "price >= cost");
+    assert_eq!(product.checks[1].field, None);
+}
+
+#[test]
+fn test_view_annotation() {
+    let input = r#"
+        entity ActiveUser @view {
+            id: uuid,
+            email: string,
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let view = schema.get_entity("ActiveUser").unwrap();
+
+    assert!(view.view);
+    assert_eq!(view.fields.len(), 2);
+}
+
+#[test]
+fn test_readonly_annotation() {
+    let input = r#"
+        entity Country @readonly {
+            id: uuid primary,
+            code: string unique,
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let country = schema.get_entity("Country").unwrap();
+
+    assert!(country.read_only);
+    assert!(!country.view);
+}
+
+#[test]
+fn test_schema_annotation() {
+    let input = r#"
+        entity Invoice @schema("billing") {
+            id: uuid primary,
+            total: decimal,
+        }
+
+        entity User {
+            id: uuid primary,
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+
+    assert_eq!(schema.get_entity("Invoice").unwrap().schema.as_deref(), Some("billing"));
+    assert_eq!(schema.get_entity("User").unwrap().schema, None);
+}
+
+#[test]
+fn test_unique_index() {
+    use crate::ast::UniqueIndex;
+
+    let input = r#"
+        entity User {
+            id: uuid primary,
+            tenant_id: uuid,
+            email: string,
+            deleted_at: timestamp nullable,
+            @unique(email) where deleted_at is null,
+            @unique(tenant_id, email) concurrently,
+            @unique(email) where "tenant_id IS NOT NULL",
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let user = schema.get_entity("User").unwrap();
+
+    assert_eq!(user.unique_indexes, vec![
+        UniqueIndex {
+            fields: vec!["email".to_string()],
+            predicate: Some("deleted_at IS NULL".to_string()),
+            concurrent: false,
+        },
+        UniqueIndex {
+            fields: vec!["tenant_id".to_string(), "email".to_string()],
+            predicate: None,
+            concurrent: true,
+        },
+        UniqueIndex {
+            fields: vec!["email".to_string()],
+            predicate: Some("tenant_id IS NOT NULL".to_string()),
+            concurrent: false,
+        },
+    ]);
+}
+
+#[test]
+fn test_doc_comments() {
+    let input = r#"
+        /// The account owner.
+        ///
+        /// One per login.
+        entity User {
+            /// Primary key
+            id: uuid primary,
+            // plain comment, not a description
+            email: string unique,
+            //// not a description either
+            name: string,
+            /// Relations accept doc comments but do not store them
+            orders: [Order] via user_id,
+        }
+
+        entity Order {
+            id: uuid primary,
+            ///Unspaced
+            user_id: uuid,
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let user = schema.get_entity("User").unwrap();
+
+    assert_eq!(user.description.as_deref(), Some("The account owner.\n\nOne per login."));
+    assert_eq!(user.fields["id"].description.as_deref(), Some("Primary key"));
+    assert_eq!(user.fields["email"].description, None);
+    assert_eq!(user.fields["name"].description, None);
+
+    let order = schema.get_entity("Order").unwrap();
+    assert_eq!(order.description, None);
+    assert_eq!(order.fields["user_id"].description.as_deref(), Some("Unspaced"));
+}
+
+#[test]
+fn test_doc_comment_before_closing_brace_is_discarded() {
+    let input = r#"
+        entity User {
+            id: uuid primary,
+            /// Left over after the last field
+        }
+
+        entity Order {
+            /// Documents the relation, not user_id
+            user: User,
+            user_id: uuid,
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let user = schema.get_entity("User").unwrap();
+    assert_eq!(user.fields.len(), 1);
+    assert_eq!(user.fields["id"].description, None);
+
+    let order = schema.get_entity("Order").unwrap();
+    assert_eq!(order.fields["user_id"].description, None);
+}
+
+#[test]
+fn test_doc_comment_at_end_of_file_is_discarded() {
+    let input = r#"
+        /// The account owner.
+        entity User {
+            id: uuid primary,
+        }
+
+        /// Nothing follows this one
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    assert_eq!(schema.entities.len(), 1);
+    assert_eq!(schema.get_entity("User").unwrap().description.as_deref(), Some("The account owner."));
+
+    // A file with only a doc comment is an empty schema
+    let schema = parse_schema("/// TODO: entities").unwrap();
+    assert!(schema.entities.is_empty());
+}
+
+#[test]
+fn test_auto_increment() {
+    use crate::ast::DefaultValue;
+
+    let input = r#"
+        entity Order {
+            id: int primary auto,
+            total: decimal,
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let order = schema.get_entity("Order").unwrap();
+    assert!(order.fields["id"].primary_key);
+    assert_eq!(order.fields["id"].default, Some(DefaultValue::AutoIncrement));
+}
+
+#[test]
+fn test_tenant_annotation() {
+    let input = r#"
+        entity Document @tenant(org_id) @schema("docs") {
+            id: uuid primary,
+            org_id: uuid,
+        }
+
+        entity Org {
+            id: uuid primary,
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let document = schema.get_entity("Document").unwrap();
+    assert_eq!(document.tenant.as_deref(), Some("org_id"));
+    assert_eq!(document.schema.as_deref(), Some("docs"));
+    assert_eq!(schema.get_entity("Org").unwrap().tenant, None);
+}
+
+#[test]
+fn test_timestamps_annotation() {
+    use crate::ast::{DefaultValue, FieldType};
+
+    let input = r#"
+        entity Post @timestamps {
+            id: uuid primary,
+            created_at: timestamp,
+            title: string,
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let post = schema.get_entity("Post").unwrap();
+    assert!(post.timestamps);
+    assert_eq!(post.field_order, vec!["id", "created_at", "title", "updated_at"]);
+    for name in ["created_at", "updated_at"] {
+        assert_eq!(post.fields[name].field_type, FieldType::Timestamp);
+        assert_eq!(post.fields[name].default, Some(DefaultValue::Now));
+    }
+}
+
+#[test]
+fn test_generated_field() {
+    use crate::ast::DefaultValue;
+
+    let input = r#"
+        entity OrderItem {
+            id: uuid primary,
+            price: decimal,
+            quantity: int,
+            total: decimal generated("price * quantity"),
+        }
+    "#;
+
+    let schema = parse_schema(input).unwrap();
+    let item = schema.get_entity("OrderItem").unwrap();
+    assert_eq!(
+        item.fields["total"].default,
+        Some(DefaultValue::Generated("price * quantity".to_string()))
+    );
+}
@@ -8,11 +8,30 @@ pub enum SortDirection {
     Desc,
 }
 
+/// Aggregate over a relation used to order parent rows
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub enum Aggregate {
+    Count,
+    Sum,
+    Avg,
+    Min,
+    Max,
+}
+
 /// A single order-by clause
+/// With a relation, orders by an aggregate of `field` over the related rows:
+/// "orders" + Sum + "total" → (SELECT COALESCE(SUM(total), 0) FROM orders WHERE ...)
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 pub struct OrderByClause {
     pub field: String,
     pub direction: SortDirection,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub relation: Option<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub aggregate: Option<Aggregate>,
+    /// Filters on the related rows aggregated over (combined with AND)
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub filters: Vec<FilterExpr>,
 }
 
 /// An include path for eager loading
@@ -31,6 +50,16 @@ impl IncludePath {
     }
 }
 
+/// A related-record count exposed as a virtual column
+/// "orders" → (SELECT COUNT(*) FROM orders WHERE ...) AS orders_count
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct RelationCount {
+    pub relation: String,
+    /// Filters on the related entity (combined with AND)
+    #[serde(default)]
+    pub filters: Vec<FilterExpr>,
+}
+
 /// The complete query representation
 /// This is what gets serialized over FFI and translated to SQL
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
@@ -56,6 +85,13 @@ pub struct Query {
     // Select fields for projection (e.g., ["name", "email"])
     pub select_fields: Vec<String>,
 
+    /// Related-record counts added to the SELECT list
+    #[serde(default)]
+    pub counts: Vec<RelationCount>,
+
+    /// Force SELECT DISTINCT, even without relation filters
+    #[serde(default)]
+    pub distinct: bool,
 }
 
 impl Query {
@@ -69,6 +105,8 @@ impl Query {
             limit: None,
             offset: None,
             select_fields: Vec::new(),
+            counts: Vec::new(),
+            distinct: false,
         }
     }
 
@@ -89,6 +127,28 @@ impl Query {
         self.order_by.push(OrderByClause {
             field: field.to_string(),
             direction,
+            relation: None,
+            aggregate: None,
+            filters: Vec::new(),
+        });
+        self
+    }
+
+    /// Add an order-by clause on an aggregate over a relation
+    /// (`field` is ignored for Count)
+    pub fn order_by_relation(
+        mut self,
+        relation: &str,
+        aggregate: Aggregate,
+        field: &str,
+        direction: SortDirection,
+    ) -> Self {
+        self.order_by.push(OrderByClause {
+            field: field.to_string(),
+            direction,
+            relation: Some(relation.to_string()),
+            aggregate: Some(aggregate),
+            filters: Vec::new(),
         });
         self
     }
@@ -105,6 +165,21 @@ impl Query {
         self
     }
 
+    /// Add a related-record count column
+    pub fn with_count(mut self, relation: &str, filters: Vec<FilterExpr>) -> Self {
+        self.counts.push(RelationCount {
+            relation: relation.to_string(),
+            filters,
+        });
+        self
+    }
+
+    /// Return distinct rows only
+    pub fn distinct(mut self) -> Self {
+        self.distinct = true;
+        self
+    }
+
     /// Set select fields
     pub fn select(mut self, fields: Vec<&str>) -> Self {
         self.select_fields = fields.into_iter().map(|s| s.to_string()).collect();
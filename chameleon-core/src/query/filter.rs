@@ -8,6 +8,7 @@ pub enum FilterValue {
     Float(f64),
     Bool(bool),
     Null,
+    List(Vec<FilterValue>),  // for In, NotIn, Between and IlikeAny
 }
 
 /// Comparison operators
@@ -21,6 +22,12 @@ pub enum ComparisonOp {
     Lte,     // <=
     Like,    // LIKE '%value%'
     In,      // IN (v1, v2, v3)
+    NotIn,   // NOT IN (v1, v2, v3)
+    Between, // BETWEEN v1 AND v2
+    IsNull,    // IS NULL (value ignored)
+    IsNotNull, // IS NOT NULL (value ignored)
+    Is,      // IS TRUE / IS FALSE / IS NULL
+    IlikeAny, // ILIKE ANY (ARRAY[p1, p2, p3])
 }
 
 /// Logical operators to combine filters
@@ -79,6 +86,15 @@ pub enum FilterExpr {
         op: LogicalOp,
         right: Box<FilterExpr>,
     },
+    /// Correlated EXISTS subquery on a relation
+    /// Filters are relative to the related entity
+    /// negated = true produces NOT EXISTS
+    Exists {
+        relation: String,
+        filters: Vec<FilterExpr>,
+        #[serde(default)]
+        negated: bool,
+    },
 }
 
 impl FilterExpr {
@@ -108,4 +124,22 @@ impl FilterExpr {
             right: Box::new(other),
         }
     }
+
+    /// Match rows with at least one related row satisfying all filters
+    pub fn exists(relation: &str, filters: Vec<FilterExpr>) -> Self {
+        FilterExpr::Exists {
+            relation: relation.to_string(),
+            filters,
+            negated: false,
+        }
+    }
+
+    /// Match rows with no related row satisfying all filters
+    pub fn not_exists(relation: &str, filters: Vec<FilterExpr>) -> Self {
+        FilterExpr::Exists {
+            relation: relation.to_string(),
+            filters,
+            negated: true,
+        }
+    }
 }
\ No newline at end of file
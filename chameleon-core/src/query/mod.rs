@@ -1,7 +1,7 @@
 pub mod ast;
 pub mod filter;
 
-pub use ast::{Query, IncludePath, OrderByClause, SortDirection};
+pub use ast::{Query, IncludePath, OrderByClause, SortDirection, RelationCount, Aggregate};
 pub use filter::{FilterExpr, FilterValue, ComparisonOp, LogicalOp, FieldPath, FilterCondition};
 
 #[cfg(test)]
@@ -11,25 +11,25 @@ pub fn test_schema() -> Schema {
         name: "id".to_string(),
         field_type: FieldType::UUID,
         nullable: false, unique: false, primary_key: true,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     user.add_field(Field {
         name: "email".to_string(),
         field_type: FieldType::String,
         nullable: false, unique: true, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     user.add_field(Field {
         name: "name".to_string(),
         field_type: FieldType::String,
         nullable: false, unique: false, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     user.add_field(Field {
         name: "age".to_string(),
         field_type: FieldType::Int,
         nullable: true, unique: false, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     user.add_relation(Relation {
         name: "orders".to_string(),
@@ -46,25 +46,25 @@ pub fn test_schema() -> Schema {
         name: "id".to_string(),
         field_type: FieldType::UUID,
         nullable: false, unique: false, primary_key: true,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     order.add_field(Field {
         name: "total".to_string(),
         field_type: FieldType::Decimal,
         nullable: false, unique: false, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     order.add_field(Field {
         name: "status".to_string(),
         field_type: FieldType::String,
         nullable: false, unique: false, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     order.add_field(Field {
         name: "user_id".to_string(),
         field_type: FieldType::UUID,
         nullable: false, unique: false, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     order.add_relation(Relation {
         name: "user".to_string(),
@@ -88,25 +88,25 @@ pub fn test_schema() -> Schema {
         name: "id".to_string(),
         field_type: FieldType::UUID,
         nullable: false, unique: false, primary_key: true,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     item.add_field(Field {
         name: "quantity".to_string(),
         field_type: FieldType::Int,
         nullable: false, unique: false, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     item.add_field(Field {
         name: "price".to_string(),
         field_type: FieldType::Decimal,
         nullable: false, unique: false, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     item.add_field(Field {
         name: "order_id".to_string(),
         field_type: FieldType::UUID,
         nullable: false, unique: false, primary_key: false,
-        default: None, backend: None,
+        default: None, backend: None, description: None,
     });
     item.add_relation(Relation {
         name: "order".to_string(),
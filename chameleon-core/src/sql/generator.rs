@@ -1,9 +1,10 @@
 use crate::ast::Schema;
 use crate::query::{
     Query, FilterExpr, FilterCondition, FilterValue,
-    ComparisonOp, LogicalOp, SortDirection,
+    ComparisonOp, LogicalOp, SortDirection, RelationCount, Aggregate,
 };
-use super::naming::entity_to_table;
+use crate::migration::type_map::to_postgres_type;
+use super::naming::schema_table;
 use serde::{Deserialize, Serialize};
 
 /// Result of generating SQL for a query
@@ -16,6 +17,9 @@ pub struct GeneratedSQL {
     /// Eager loading queries (one per include level)
     /// Each tuple is (relation_name, sql)
     pub eager_queries: Vec<(String, String)>,
+    /// Values bound to the $1, $2, ... placeholders of the main query
+    #[serde(default)]
+    pub params: Vec<FilterValue>,
 }
 
 /// Generate SQL from a Query + Schema
@@ -23,13 +27,14 @@ pub fn generate_sql(query: &Query, schema: &Schema) -> Result<GeneratedSQL, SqlG
    let entity = schema.get_entity(&query.entity)
         .ok_or_else(|| SqlGenError::UnknownEntity(query.entity.clone()))?;
 
-    let table_name = entity_to_table(&query.entity);
+    let table_name = schema_table(schema, &query.entity);
 
     // Determine if we need JOINs (filters on relations)
     let join_filters = extract_join_filters(query);
     let needs_join = !join_filters.is_empty();
 
     // Build main query
+    let mut params = Vec::new();
     let main_query = build_main_query(
         &table_name,
         &query.entity,
@@ -41,7 +46,10 @@ pub fn generate_sql(query: &Query, schema: &Schema) -> Result<GeneratedSQL, SqlG
         query.limit,
         query.offset,
         &query.select_fields,
+        &query.counts,
+        query.distinct,
         schema,
+        &mut params,
     )?;
 
     // Build eager loading queries
@@ -54,6 +62,7 @@ pub fn generate_sql(query: &Query, schema: &Schema) -> Result<GeneratedSQL, SqlG
     Ok(GeneratedSQL {
         main_query,
         eager_queries,
+        params,
     })
 }
 
@@ -71,6 +80,8 @@ fn filter_expr_is_nested(expr: &FilterExpr) -> bool {
         FilterExpr::Binary { left, right, .. } => {
             filter_expr_is_nested(left) || filter_expr_is_nested(right)
         }
+        // EXISTS is a correlated subquery, no JOIN needed
+        FilterExpr::Exists { .. } => false,
     }
 }
 
@@ -86,13 +97,29 @@ fn build_main_query(
     limit: Option<u64>,
     offset: Option<u64>,
     select_fields: &[String],
+    counts: &[RelationCount],
+    distinct: bool,
     schema: &Schema,
+    params: &mut Vec<FilterValue>,
 ) -> Result<String, SqlGenError> {
     let mut parts: Vec<String> = Vec::new();
 
     // SELECT
-    let columns = build_select_columns(table_name, entity, needs_join, select_fields);  // ← PASS NEW PARAM
-    let distinct = if needs_join { "DISTINCT " } else { "" };
+    let mut columns = build_select_columns(table_name, entity, needs_join, select_fields);  // ← PASS NEW PARAM
+    for count in counts {
+        let subquery = correlated_subquery(
+            "COUNT(*)",
+            &count.relation,
+            &count.filters,
+            table_name,
+            schema,
+            entity_name,
+            params,
+        )?;
+        columns.push_str(&format!(", {} AS {}_count", subquery, count.relation));
+    }
+    // Relation filters join to-many tables, which duplicates rows
+    let distinct = if distinct || needs_join { "DISTINCT " } else { "" };
     parts.push(format!("SELECT {}{}", distinct, columns));
 
     // FROM
@@ -105,14 +132,14 @@ fn build_main_query(
     }
 
     // WHERE
-    let where_clause = build_where(filters, table_name, needs_join, schema, entity_name)?;
+    let where_clause = build_where(filters, table_name, needs_join, schema, entity_name, params)?;
     if !where_clause.is_empty() {
         parts.push(format!("WHERE {}", where_clause));
     }
 
     // ORDER BY
     if !order_by.is_empty() {
-        let order = build_order_by(order_by, table_name, needs_join);
+        let order = build_order_by(order_by, table_name, needs_join, schema, entity_name, params)?;
         parts.push(order);
     }
 
@@ -190,8 +217,8 @@ fn build_joins(
                 relation: rel_name.clone(),
             })?;
 
-        let target_table = entity_to_table(&relation.target_entity);
-        let source_table = entity_to_table(entity_name);
+        let target_table = schema_table(schema, &relation.target_entity);
+        let source_table = schema_table(schema, entity_name);
 
         let fk = relation.foreign_key.as_ref()
             .ok_or_else(|| SqlGenError::MissingForeignKey {
@@ -220,6 +247,7 @@ fn collect_join_relations(expr: &FilterExpr, relations: &mut Vec<String>) {
             collect_join_relations(left, relations);
             collect_join_relations(right, relations);
         }
+        FilterExpr::Exists { .. } => {}
     }
 }
 
@@ -230,13 +258,14 @@ fn build_where(
     qualify: bool,
     schema: &Schema,
     entity_name: &str,
+    params: &mut Vec<FilterValue>,
 ) -> Result<String, SqlGenError> {
     if filters.is_empty() {
         return Ok(String::new());
     }
 
     let conditions: Vec<String> = filters.iter()
-        .map(|f| filter_expr_to_sql(f, table_name, qualify, schema, entity_name))
+        .map(|f| filter_expr_to_sql(f, table_name, qualify, schema, entity_name, params))
         .collect::<Result<Vec<_>, _>>()?;
 
     Ok(conditions.join(" AND "))
@@ -249,21 +278,79 @@ fn filter_expr_to_sql(
     qualify: bool,
     schema: &Schema,
     entity_name: &str,
+    params: &mut Vec<FilterValue>,
 ) -> Result<String, SqlGenError> {
     match expr {
         FilterExpr::Condition(cond) => {
-            condition_to_sql(cond, table_name, qualify, schema, entity_name)
+            condition_to_sql(cond, table_name, qualify, schema, entity_name, params)
         }
         FilterExpr::Binary { left, op, right } => {
-            let left_sql = filter_expr_to_sql(left, table_name, qualify, schema, entity_name)?;
-            let right_sql = filter_expr_to_sql(right, table_name, qualify, schema, entity_name)?;
+            let left_sql = filter_expr_to_sql(left, table_name, qualify, schema, entity_name, params)?;
+            let right_sql = filter_expr_to_sql(right, table_name, qualify, schema, entity_name, params)?;
             let op_sql = match op {
                 LogicalOp::And => "AND",
                 LogicalOp::Or => "OR",
             };
             Ok(format!("({} {} {})", left_sql, op_sql, right_sql))
         }
+        FilterExpr::Exists { relation, filters, negated } => {
+            let subquery = correlated_subquery("1", relation, filters, table_name, schema, entity_name, params)?;
+            if *negated {
+                Ok(format!("NOT EXISTS {}", subquery))
+            } else {
+                Ok(format!("EXISTS {}", subquery))
+            }
+        }
+    }
+}
+
+/// Build a subquery over a relation, correlated on its foreign key
+/// ("1", orders, total > 100) →
+/// (SELECT 1 FROM orders WHERE orders.user_id = users.id AND orders.total > 100)
+fn correlated_subquery(
+    select: &str,
+    rel_name: &str,
+    filters: &[FilterExpr],
+    table_name: &str,
+    schema: &Schema,
+    entity_name: &str,
+    params: &mut Vec<FilterValue>,
+) -> Result<String, SqlGenError> {
+    let entity = schema.get_entity(entity_name)
+        .ok_or_else(|| SqlGenError::UnknownEntity(entity_name.to_string()))?;
+
+    let relation = entity.relations.get(rel_name)
+        .ok_or_else(|| SqlGenError::UnknownRelation {
+            entity: entity_name.to_string(),
+            relation: rel_name.to_string(),
+        })?;
+
+    let fk = relation.foreign_key.as_ref()
+        .ok_or_else(|| SqlGenError::MissingForeignKey {
+            entity: entity_name.to_string(),
+            relation: rel_name.to_string(),
+        })?;
+
+    let target_table = schema_table(schema, &relation.target_entity);
+
+    let mut conditions = vec![format!("{}.{} = {}.id", target_table, fk, table_name)];
+    for f in filters {
+        conditions.push(filter_expr_to_sql(
+            f,
+            &target_table,
+            true,
+            schema,
+            &relation.target_entity,
+            params,
+        )?);
     }
+
+    Ok(format!(
+        "(SELECT {} FROM {} WHERE {})",
+        select,
+        target_table,
+        conditions.join(" AND "),
+    ))
 }
 
 /// Convert a single condition to SQL
@@ -273,7 +360,10 @@ fn condition_to_sql(
     qualify: bool,
     schema: &Schema,
     entity_name: &str,
+    params: &mut Vec<FilterValue>,
 ) -> Result<String, SqlGenError> {
+    // Entity owning the filtered column, for the type of bound lists
+    let mut field_entity = entity_name.to_string();
     let field_sql = if cond.field.is_nested() {
         // Nested: "orders.total" → "orders.total"
         let rel_name = cond.field.root();
@@ -283,8 +373,9 @@ fn condition_to_sql(
                 entity: entity_name.to_string(),
                 relation: rel_name.to_string(),
             })?;
-        let target_table = entity_to_table(&relation.target_entity);
+        let target_table = schema_table(schema, &relation.target_entity);
         let field_name = &cond.field.segments[1];
+        field_entity = relation.target_entity.clone();
         format!("{}.{}", target_table, field_name)
     } else if qualify {
         format!("{}.{}", table_name, cond.field.root())
@@ -292,14 +383,73 @@ fn condition_to_sql(
         cond.field.root().to_string()
     };
 
+    let field_name = cond.field.segments.last().map(String::as_str).unwrap_or("");
+    let column_type = schema.get_entity(&field_entity)
+        .and_then(|e| e.fields.get(field_name))
+        .map(|f| to_postgres_type(&f.field_type));
+
     let (op_sql, value_sql) = match (&cond.op, &cond.value) {
         (ComparisonOp::Like, FilterValue::String(s)) => {
-            ("LIKE".to_string(), format!("'%{}%'", s))
+            ("LIKE".to_string(), format!("'%{}%'", escape_literal(s)))
+        }
+        // Unlike = true, IS TRUE/IS FALSE never evaluate to NULL
+        (ComparisonOp::Is, FilterValue::Bool(b)) => {
+            ("IS".to_string(), if *b { "TRUE".to_string() } else { "FALSE".to_string() })
+        }
+        (ComparisonOp::Is, FilterValue::Null) => {
+            ("IS".to_string(), "NULL".to_string())
+        }
+        (ComparisonOp::IsNull, _) => {
+            ("IS".to_string(), "NULL".to_string())
+        }
+        (ComparisonOp::IsNotNull, _) => {
+            ("IS NOT".to_string(), "NULL".to_string())
+        }
+        (ComparisonOp::Is, _) => {
+            return Err(SqlGenError::InvalidFilter(format!(
+                "'is' on {} needs a boolean or null value",
+                cond.field.segments.join("."),
+            )));
+        }
+        // = ANY($n) instead of IN (a, b, ...): the list is bound as one
+        // array parameter, so the statement does not depend on its length
+        (ComparisonOp::In, list @ FilterValue::List(_)) => {
+            ("=".to_string(), format!("ANY({})", bind_list(params, list, column_type.as_deref())))
         }
         (ComparisonOp::In, _) => {
-            // In is handled specially - value should be a list
-            // For now, placeholder
-            ("IN".to_string(), "($IN_VALUES)".to_string())
+            return Err(SqlGenError::InvalidFilter(format!(
+                "'in' on {} needs a list value",
+                cond.field.segments.join("."),
+            )));
+        }
+        // != ALL($n) is NOT IN: an empty list excludes nothing
+        (ComparisonOp::NotIn, list @ FilterValue::List(_)) => {
+            ("!=".to_string(), format!("ALL({})", bind_list(params, list, column_type.as_deref())))
+        }
+        (ComparisonOp::NotIn, _) => {
+            return Err(SqlGenError::InvalidFilter(format!(
+                "'nin' on {} needs a list value",
+                cond.field.segments.join("."),
+            )));
+        }
+        (ComparisonOp::Between, FilterValue::List(bounds)) if bounds.len() == 2 => {
+            ("BETWEEN".to_string(), format!("{} AND {}", value_to_sql(&bounds[0]), value_to_sql(&bounds[1])))
+        }
+        (ComparisonOp::Between, _) => {
+            return Err(SqlGenError::InvalidFilter(format!(
+                "'between' on {} needs a list with the two bounds",
+                cond.field.segments.join("."),
+            )));
+        }
+        // Patterns arrive escaped and wrapped in % from the Go side
+        (ComparisonOp::IlikeAny, list @ FilterValue::List(_)) => {
+            ("ILIKE".to_string(), format!("ANY({})", bind_list(params, list, Some("TEXT"))))
+        }
+        (ComparisonOp::IlikeAny, _) => {
+            return Err(SqlGenError::InvalidFilter(format!(
+                "'ilike_any' on {} needs a list of patterns",
+                cond.field.segments.join("."),
+            )));
         }
         (op, value) => {
             let op_str = match op {
@@ -318,39 +468,78 @@ fn condition_to_sql(
     Ok(format!("{} {} {}", field_sql, op_sql, value_sql))
 }
 
+/// Bind a list as the next array parameter, cast to the column type
+/// when it is known: $1::UUID[]
+fn bind_list(params: &mut Vec<FilterValue>, list: &FilterValue, column_type: Option<&str>) -> String {
+    params.push(list.clone());
+    match column_type {
+        Some(t) => format!("${}::{}[]", params.len(), t),
+        None => format!("${}", params.len()),
+    }
+}
+
+/// Double the single quotes of a string literal's contents
+fn escape_literal(s: &str) -> String {
+    s.replace('\'', "''")
+}
+
 /// Convert a FilterValue to SQL literal
 fn value_to_sql(value: &FilterValue) -> String {
     match value {
-        FilterValue::String(s) => format!("'{}'", s),
+        FilterValue::String(s) => format!("'{}'", escape_literal(s)),
         FilterValue::Int(n)    => n.to_string(),
         FilterValue::Float(f)  => f.to_string(),
         FilterValue::Bool(b)   => if *b { "true".to_string() } else { "false".to_string() },
         FilterValue::Null      => "NULL".to_string(),
+        FilterValue::List(items) => format!(
+            "ARRAY[{}]",
+            items.iter().map(value_to_sql).collect::<Vec<_>>().join(", "),
+        ),
     }
 }
 
 /// Build ORDER BY clause
+/// Clauses on a relation aggregate sort by a correlated subquery
 fn build_order_by(
     order_by: &[crate::query::OrderByClause],
     table_name: &str,
     qualify: bool,
-) -> String {
-    let clauses: Vec<String> = order_by.iter()
-        .map(|o| {
-            let field = if qualify {
-                format!("{}.{}", table_name, o.field)
-            } else {
-                o.field.clone()
-            };
-            let dir = match o.direction {
-                SortDirection::Asc  => "ASC",
-                SortDirection::Desc => "DESC",
+    schema: &Schema,
+    entity_name: &str,
+    params: &mut Vec<FilterValue>,
+) -> Result<String, SqlGenError> {
+    let mut clauses: Vec<String> = Vec::new();
+    for o in order_by {
+        let dir = match o.direction {
+            SortDirection::Asc  => "ASC",
+            SortDirection::Desc => "DESC",
+        };
+
+        if let Some(relation) = &o.relation {
+            let aggregate = o.aggregate.as_ref().unwrap_or(&Aggregate::Count);
+            // COUNT and SUM are 0 without related rows; the others are
+            // NULL, which sorts last either way
+            let (select, nulls) = match aggregate {
+                Aggregate::Count => ("COUNT(*)".to_string(), ""),
+                Aggregate::Sum => (format!("COALESCE(SUM({}), 0)", o.field), ""),
+                Aggregate::Avg => (format!("AVG({})", o.field), " NULLS LAST"),
+                Aggregate::Min => (format!("MIN({})", o.field), " NULLS LAST"),
+                Aggregate::Max => (format!("MAX({})", o.field), " NULLS LAST"),
             };
-            format!("{} {}", field, dir)
-        })
-        .collect();
+            let subquery = correlated_subquery(&select, relation, &o.filters, table_name, schema, entity_name, params)?;
+            clauses.push(format!("{} {}{}", subquery, dir, nulls));
+            continue;
+        }
+
+        let field = if qualify {
+            format!("{}.{}", table_name, o.field)
+        } else {
+            o.field.clone()
+        };
+        clauses.push(format!("{} {}", field, dir));
+    }
 
-    format!("ORDER BY {}", clauses.join(", "))
+    Ok(format!("ORDER BY {}", clauses.join(", ")))
 }
 
 /// Build eager loading queries
@@ -426,7 +615,7 @@ fn build_eager_query_for_path(
     let target_entity = schema.get_entity(&relation.target_entity)
         .ok_or_else(|| SqlGenError::UnknownEntity(relation.target_entity.clone()))?;
 
-    let target_table = entity_to_table(&relation.target_entity);
+    let target_table = schema_table(schema, &relation.target_entity);
 
     let fk = relation.foreign_key.as_ref()
         .ok_or_else(|| SqlGenError::MissingForeignKey {
@@ -469,6 +658,7 @@ pub enum SqlGenError {
     UnknownEntity(String),
     UnknownRelation { entity: String, relation: String },
     MissingForeignKey { entity: String, relation: String },
+    InvalidFilter(String),
 }
 
 impl std::fmt::Display for SqlGenError {
@@ -480,6 +670,8 @@ impl std::fmt::Display for SqlGenError {
                 write!(f, "Unknown relation '{}' in entity '{}'", relation, entity),
             SqlGenError::MissingForeignKey { entity, relation } =>
                 write!(f, "Missing foreign key for relation '{}' in '{}'", relation, entity),
+            SqlGenError::InvalidFilter(msg) =>
+                write!(f, "Invalid filter: {}", msg),
         }
     }
 }
\ No newline at end of file
@@ -20,25 +20,25 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         user.add_field(Field {
             name: "email".to_string(),
             field_type: FieldType::String,
             nullable: false, unique: true, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         user.add_field(Field {
             name: "name".to_string(),
             field_type: FieldType::String,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         user.add_field(Field {
             name: "age".to_string(),
             field_type: FieldType::Int,
             nullable: true, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         user.add_relation(Relation {
             name: "orders".to_string(),
@@ -55,25 +55,25 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         order.add_field(Field {
             name: "total".to_string(),
             field_type: FieldType::Decimal,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         order.add_field(Field {
             name: "status".to_string(),
             field_type: FieldType::String,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         order.add_field(Field {
             name: "user_id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         order.add_relation(Relation {
             name: "user".to_string(),
@@ -97,25 +97,25 @@ mod tests {
             name: "id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: true,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         item.add_field(Field {
             name: "quantity".to_string(),
             field_type: FieldType::Int,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         item.add_field(Field {
             name: "price".to_string(),
             field_type: FieldType::Decimal,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         item.add_field(Field {
             name: "order_id".to_string(),
             field_type: FieldType::UUID,
             nullable: false, unique: false, primary_key: false,
-            default: None, backend: None,
+            default: None, backend: None, description: None,
         });
         item.add_relation(Relation {
             name: "order".to_string(),
@@ -189,6 +189,22 @@ mod tests {
         assert!(result.main_query.contains("AND"));
     }
 
+    #[test]
+    fn test_or_group_is_parenthesized() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(
+                FilterExpr::condition("age", ComparisonOp::Lt, FilterValue::Int(18))
+                    .or(FilterExpr::condition("age", ComparisonOp::Gt, FilterValue::Int(65))),
+            )
+            .filter(FilterExpr::condition(
+                "name", ComparisonOp::Eq, FilterValue::String("ana".to_string()),
+            ));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("WHERE (age < 18 OR age > 65) AND name = 'ana'"));
+    }
+
     #[test]
     fn test_like_filter() {
         let schema = test_schema();
@@ -202,6 +218,190 @@ mod tests {
         assert!(result.main_query.contains("LIKE '%ana%'"));
     }
 
+    #[test]
+    fn test_is_filter() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::condition("name", ComparisonOp::Is, FilterValue::Bool(true)))
+            .filter(FilterExpr::condition("age", ComparisonOp::Is, FilterValue::Null));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("name IS TRUE"));
+        assert!(result.main_query.contains("age IS NULL"));
+
+        let invalid = Query::new("User")
+            .filter(FilterExpr::condition("age", ComparisonOp::Is, FilterValue::Int(1)));
+        assert!(matches!(generate_sql(&invalid, &schema), Err(SqlGenError::InvalidFilter(_))));
+    }
+
+    #[test]
+    fn test_null_filters() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::condition("age", ComparisonOp::IsNull, FilterValue::Null))
+            .filter(FilterExpr::condition("name", ComparisonOp::IsNotNull, FilterValue::Null));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("WHERE age IS NULL AND name IS NOT NULL"));
+    }
+
+    #[test]
+    fn test_in_filter_uses_any() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::condition(
+                "name", ComparisonOp::In,
+                FilterValue::List(vec![
+                    FilterValue::String("ana".to_string()),
+                    FilterValue::String("bob".to_string()),
+                ]),
+            ));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("name = ANY($1::VARCHAR[])"));
+        assert_eq!(result.params, vec![FilterValue::List(vec![
+            FilterValue::String("ana".to_string()),
+            FilterValue::String("bob".to_string()),
+        ])]);
+
+        let empty = Query::new("User")
+            .filter(FilterExpr::condition("name", ComparisonOp::In, FilterValue::List(vec![])));
+        let result = generate_sql(&empty, &schema).unwrap();
+        assert!(result.main_query.contains("name = ANY($1::VARCHAR[])"));
+        assert_eq!(result.params, vec![FilterValue::List(vec![])]);
+    }
+
+    #[test]
+    fn test_in_filter_sql_does_not_depend_on_list_length() {
+        let schema = test_schema();
+        let ids = |n: usize| FilterValue::List(
+            (0..n).map(|i| FilterValue::String(format!("00000000-0000-0000-0000-00000000000{}", i))).collect(),
+        );
+
+        let one = generate_sql(&Query::new("User")
+            .filter(FilterExpr::condition("id", ComparisonOp::In, ids(1))), &schema).unwrap();
+        let five = generate_sql(&Query::new("User")
+            .filter(FilterExpr::condition("id", ComparisonOp::In, ids(5))), &schema).unwrap();
+
+        assert_eq!(one.main_query, five.main_query);
+        assert!(one.main_query.contains("id = ANY($1::UUID[])"));
+        assert_eq!(five.params, vec![ids(5)]);
+    }
+
+    #[test]
+    fn test_in_filter_params_are_numbered_across_subqueries() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::condition(
+                "name", ComparisonOp::In,
+                FilterValue::List(vec![FilterValue::String("ana".to_string())]),
+            ))
+            .filter(FilterExpr::exists("orders", vec![
+                FilterExpr::condition(
+                    "status", ComparisonOp::In,
+                    FilterValue::List(vec![FilterValue::String("paid".to_string())]),
+                ),
+            ]));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("name = ANY($1::VARCHAR[])"));
+        assert!(result.main_query.contains("orders.status = ANY($2::VARCHAR[])"));
+        assert_eq!(result.params.len(), 2);
+    }
+
+    #[test]
+    fn test_not_in_filter_uses_all() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::condition(
+                "age", ComparisonOp::NotIn,
+                FilterValue::List(vec![FilterValue::Int(18), FilterValue::Int(21)]),
+            ));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("age != ALL($1::INTEGER[])"));
+        assert_eq!(result.params, vec![
+            FilterValue::List(vec![FilterValue::Int(18), FilterValue::Int(21)]),
+        ]);
+
+        let empty = Query::new("User")
+            .filter(FilterExpr::condition("age", ComparisonOp::NotIn, FilterValue::List(vec![])));
+        let result = generate_sql(&empty, &schema).unwrap();
+        assert!(result.main_query.contains("age != ALL($1::INTEGER[])"));
+        assert_eq!(result.params, vec![FilterValue::List(vec![])]);
+
+        let invalid = Query::new("User")
+            .filter(FilterExpr::condition("age", ComparisonOp::NotIn, FilterValue::Int(18)));
+        assert!(matches!(generate_sql(&invalid, &schema), Err(SqlGenError::InvalidFilter(_))));
+    }
+
+    #[test]
+    fn test_between_filter() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::condition(
+                "age", ComparisonOp::Between,
+                FilterValue::List(vec![FilterValue::Int(18), FilterValue::Int(65)]),
+            ));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("WHERE age BETWEEN 18 AND 65"));
+
+        let invalid = Query::new("User")
+            .filter(FilterExpr::condition(
+                "age", ComparisonOp::Between, FilterValue::List(vec![FilterValue::Int(18)]),
+            ));
+        assert!(matches!(generate_sql(&invalid, &schema), Err(SqlGenError::InvalidFilter(_))));
+    }
+
+    #[test]
+    fn test_ilike_any_filter() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::condition(
+                "name", ComparisonOp::IlikeAny,
+                FilterValue::List(vec![
+                    FilterValue::String("%ana%".to_string()),
+                    FilterValue::String("%50\\%%".to_string()),
+                ]),
+            ));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("name ILIKE ANY($1::TEXT[])"));
+        assert_eq!(result.params.len(), 1);
+
+        let invalid = Query::new("User")
+            .filter(FilterExpr::condition("name", ComparisonOp::IlikeAny, FilterValue::String("ana".to_string())));
+        assert!(matches!(generate_sql(&invalid, &schema), Err(SqlGenError::InvalidFilter(_))));
+    }
+
+    #[test]
+    fn test_quoted_terms_stay_out_of_the_sql() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::condition(
+                "name", ComparisonOp::IlikeAny,
+                FilterValue::List(vec![FilterValue::String("%O'Brien%".to_string())]),
+            ));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(!result.main_query.contains("O'Brien"));
+        assert_eq!(result.params, vec![
+            FilterValue::List(vec![FilterValue::String("%O'Brien%".to_string())]),
+        ]);
+
+        // Inlined literals double their quotes
+        let eq = Query::new("User")
+            .filter(FilterExpr::condition("name", ComparisonOp::Eq, FilterValue::String("O'Brien".to_string())));
+        let result = generate_sql(&eq, &schema).unwrap();
+        assert!(result.main_query.contains("WHERE name = 'O''Brien'"));
+
+        let like = Query::new("User")
+            .filter(FilterExpr::condition("name", ComparisonOp::Like, FilterValue::String("O'B".to_string())));
+        let result = generate_sql(&like, &schema).unwrap();
+        assert!(result.main_query.contains("WHERE name LIKE '%O''B%'"));
+    }
+
     // ─── RELATIONS ───
 
     #[test]
@@ -218,6 +418,116 @@ mod tests {
         assert!(result.main_query.contains("orders.total > 100"));
     }
 
+    #[test]
+    fn test_explicit_distinct() {
+        let schema = test_schema();
+        let query = Query::new("User").select(vec!["name"]).distinct();
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.starts_with("SELECT DISTINCT name\nFROM users"));
+        assert!(!result.main_query.contains("JOIN"));
+
+        // Plain queries stay non-distinct
+        let query = Query::new("User").select(vec!["name"]);
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(!result.main_query.contains("DISTINCT"));
+    }
+
+    #[test]
+    fn test_exists_on_relation() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::exists("orders", vec![
+                FilterExpr::condition("total", ComparisonOp::Gt, FilterValue::Int(100)),
+            ]));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(!result.main_query.contains("DISTINCT"));
+        assert!(!result.main_query.contains("JOIN"));
+        assert!(result.main_query.contains(
+            "WHERE EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id AND orders.total > 100)"
+        ));
+    }
+
+    #[test]
+    fn test_exists_without_filters() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::exists("orders", vec![]));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains(
+            "WHERE EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id)"
+        ));
+    }
+
+    #[test]
+    fn test_not_exists_on_relation() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::not_exists("orders", vec![
+                FilterExpr::condition("status", ComparisonOp::Eq, FilterValue::String("paid".to_string())),
+            ]));
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains(
+            "WHERE NOT EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id AND orders.status = 'paid')"
+        ));
+    }
+
+    #[test]
+    fn test_with_count() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .with_count("orders", vec![]);
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains(
+            ", (SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id) AS orders_count"
+        ));
+        assert!(result.main_query.contains("FROM users"));
+    }
+
+    #[test]
+    fn test_with_count_filtered() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .with_count("orders", vec![
+                FilterExpr::condition("total", ComparisonOp::Gt, FilterValue::Int(100)),
+            ]);
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains(
+            "(SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id AND orders.total > 100) AS orders_count"
+        ));
+    }
+
+    #[test]
+    fn test_exists_unknown_relation() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .filter(FilterExpr::exists("invoices", vec![]));
+
+        let result = generate_sql(&query, &schema);
+        assert!(matches!(result, Err(SqlGenError::UnknownRelation { .. })));
+    }
+
+    #[test]
+    fn test_schema_qualified_table() {
+        let mut schema = test_schema();
+        for entity in schema.entities.iter_mut() {
+            entity.schema = Some("shop".to_string());
+        }
+        let query = Query::new("User")
+            .filter(FilterExpr::condition("orders.total", ComparisonOp::Gt, FilterValue::Int(100)))
+            .include("orders");
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains(r#"FROM "shop"."users""#));
+        assert!(result.main_query.contains(r#"INNER JOIN "shop"."orders" ON "shop"."orders".user_id = "shop"."users".id"#));
+        assert!(result.eager_queries[0].1.contains(r#"FROM "shop"."orders""#));
+    }
+
     #[test]
     fn test_include_single() {
         let schema = test_schema();
@@ -264,6 +574,51 @@ mod tests {
         assert!(result.main_query.contains("ORDER BY name ASC, age DESC"));
     }
 
+    #[test]
+    fn test_order_by_relation() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .order_by_relation("orders", Aggregate::Sum, "total", SortDirection::Desc)
+            .order_by("name", SortDirection::Asc);
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains(
+            "ORDER BY (SELECT COALESCE(SUM(total), 0) FROM orders WHERE orders.user_id = users.id) DESC, name ASC"
+        ));
+
+        let query = Query::new("User")
+            .order_by_relation("orders", Aggregate::Count, "", SortDirection::Desc)
+            .order_by_relation("orders", Aggregate::Max, "total", SortDirection::Asc);
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains("(SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id) DESC"));
+        assert!(result.main_query.contains("(SELECT MAX(total) FROM orders WHERE orders.user_id = users.id) ASC NULLS LAST"));
+    }
+
+    #[test]
+    fn test_order_by_relation_filtered() {
+        let schema = test_schema();
+        let mut query = Query::new("User")
+            .order_by_relation("orders", Aggregate::Count, "", SortDirection::Desc);
+        query.order_by[0].filters = vec![
+            FilterExpr::condition("total", ComparisonOp::Gt, FilterValue::Int(100)),
+        ];
+
+        let result = generate_sql(&query, &schema).unwrap();
+        assert!(result.main_query.contains(
+            "ORDER BY (SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id AND orders.total > 100) DESC"
+        ));
+    }
+
+    #[test]
+    fn test_order_by_relation_unknown() {
+        let schema = test_schema();
+        let query = Query::new("User")
+            .order_by_relation("invoices", Aggregate::Sum, "total", SortDirection::Desc);
+
+        let result = generate_sql(&query, &schema);
+        assert!(matches!(result, Err(SqlGenError::UnknownRelation { .. })));
+    }
+
     #[test]
     fn test_limit_offset() {
         let schema = test_schema();
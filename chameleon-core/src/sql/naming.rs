@@ -1,3 +1,5 @@
+use crate::ast::{Entity, Schema};
+
 /// Converts schema names to PostgreSQL naming conventions
 ///
 /// Entity names: PascalCase → snake_case plural
@@ -14,6 +16,33 @@ pub fn entity_to_table(entity_name: &str) -> String {
     pluralize(&snake)
 }
 
+/// Table name qualified with the entity's PostgreSQL schema, if any.
+/// Qualified names are quoted; unqualified ones stay bare for compatibility
+///   Invoice @schema("billing") → "billing"."invoices"
+///   User                       → users
+pub fn qualified_table(entity: &Entity) -> String {
+    let table = entity_to_table(&entity.name);
+    match &entity.schema {
+        Some(ns) => format!("{}.{}", quote_ident(ns), quote_ident(&table)),
+        None => table,
+    }
+}
+
+/// Quote a PostgreSQL identifier, doubling embedded quotes
+///   billing → "billing"
+pub fn quote_ident(ident: &str) -> String {
+    format!("\"{}\"", ident.replace('"', "\"\""))
+}
+
+/// Qualified table name for an entity looked up by name
+/// Unknown entities fall back to the unqualified table name
+pub fn schema_table(schema: &Schema, entity_name: &str) -> String {
+    match schema.get_entity(entity_name) {
+        Some(entity) => qualified_table(entity),
+        None => entity_to_table(entity_name),
+    }
+}
+
 /// Convert PascalCase to snake_case
 /// "OrderItem" → "order_item"
 /// "User"      → "user"
@@ -1,4 +1,4 @@
-use crate::ast::{Schema, FieldType, BackendAnnotation};
+use crate::ast::{Schema, FieldType, BackendAnnotation, DefaultValue, TIMESTAMP_FIELDS};
 use super::errors::TypeCheckError;
 
 /// Validates primary key constraints
@@ -6,6 +6,11 @@ pub fn check_primary_keys(schema: &Schema) -> Vec<TypeCheckError> {
     let mut errors = Vec::new();
     
     for entity in &schema.entities {
+        // Views have no primary key of their own
+        if entity.view {
+            continue;
+        }
+
         let primary_keys: Vec<String> = entity.fields.iter()
             .filter(|(_, field)| field.primary_key)
             .map(|(name, _)| name.clone())
@@ -68,4 +73,82 @@ pub fn check_annotations(schema: &Schema) -> Vec<TypeCheckError> {
     }
 
     errors
-}
\ No newline at end of file
+}
+
+/// Validates that `auto` is only used on int fields (SERIAL)
+pub fn check_auto_increment(schema: &Schema) -> Vec<TypeCheckError> {
+    let mut errors = Vec::new();
+
+    for entity in &schema.entities {
+        for (_, field) in &entity.fields {
+            if field.default == Some(DefaultValue::AutoIncrement) && field.field_type != FieldType::Int {
+                errors.push(TypeCheckError::InvalidAutoIncrement {
+                    entity: entity.name.clone(),
+                    field: field.name.clone(),
+                    actual_type: format!("{:?}", field.field_type),
+                });
+            }
+        }
+    }
+
+    errors
+}
+
+/// Validates that @tenant names a field of the entity
+pub fn check_tenant_fields(schema: &Schema) -> Vec<TypeCheckError> {
+    let mut errors = Vec::new();
+
+    for entity in &schema.entities {
+        if let Some(field) = &entity.tenant {
+            if !entity.fields.contains_key(field) {
+                errors.push(TypeCheckError::UnknownTenantField {
+                    entity: entity.name.clone(),
+                    field: field.clone(),
+                });
+            }
+        }
+    }
+
+    errors
+}
+
+/// Validates that fields maintained by @timestamps are timestamps
+pub fn check_timestamp_fields(schema: &Schema) -> Vec<TypeCheckError> {
+    let mut errors = Vec::new();
+
+    for entity in schema.entities.iter().filter(|e| e.timestamps) {
+        for name in TIMESTAMP_FIELDS {
+            if let Some(field) = entity.fields.get(name) {
+                if field.field_type != FieldType::Timestamp {
+                    errors.push(TypeCheckError::InvalidTimestampField {
+                        entity: entity.name.clone(),
+                        field: field.name.clone(),
+                        actual_type: format!("{:?}", field.field_type),
+                    });
+                }
+            }
+        }
+    }
+
+    errors
+}
+
+/// Validates that entity-level unique indexes only name existing fields
+pub fn check_unique_indexes(schema: &Schema) -> Vec<TypeCheckError> {
+    let mut errors = Vec::new();
+
+    for entity in &schema.entities {
+        for index in &entity.unique_indexes {
+            for field in &index.fields {
+                if !entity.fields.contains_key(field) {
+                    errors.push(TypeCheckError::UnknownIndexField {
+                        entity: entity.name.clone(),
+                        field: field.clone(),
+                    });
+                }
+            }
+        }
+    }
+
+    errors
+}
@@ -52,6 +52,32 @@ pub enum TypeCheckError {
         annotation: String,
     },
 
+    #[error("Field '{field}' in '{entity}' is auto but type is '{actual_type}', expected int")]
+    InvalidAutoIncrement {
+        entity: String,
+        field: String,
+        actual_type: String,
+    },
+
+    #[error("Field '{field}' in '{entity}' is maintained by @timestamps but type is '{actual_type}', expected timestamp")]
+    InvalidTimestampField {
+        entity: String,
+        field: String,
+        actual_type: String,
+    },
+
+    #[error("Entity '{entity}' is scoped by unknown tenant field '{field}'")]
+    UnknownTenantField {
+        entity: String,
+        field: String,
+    },
+
+    #[error("Unique index in '{entity}' references unknown field '{field}'")]
+    UnknownIndexField {
+        entity: String,
+        field: String,
+    },
+
     // Circular dependencies
     #[error("Circular dependency detected: {cycle:?}")]
     CircularDependency {
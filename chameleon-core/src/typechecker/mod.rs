@@ -90,6 +90,10 @@ pub fn type_check(schema: &Schema) -> TypeCheckResult {
     // Constraints
     errors.extend(constraints::check_primary_keys(schema));
     errors.extend(constraints::check_annotations(schema));
+    errors.extend(constraints::check_auto_increment(schema));
+    errors.extend(constraints::check_tenant_fields(schema));
+    errors.extend(constraints::check_timestamp_fields(schema));
+    errors.extend(constraints::check_unique_indexes(schema));
 
     TypeCheckResult { errors }
 }
@@ -116,6 +120,7 @@ mod tests {
                     primary_key: primary,
                     default: None,
                     backend: annotation,
+                    description: None,
                 });
             }
 
@@ -304,6 +309,57 @@ mod tests {
         assert!(result.errors.iter().any(|e| matches!(e, TypeCheckError::AnnotationOnConstrainedField { .. })));
     }
 
+    // ─── AUTO INCREMENT ───
+
+    #[test]
+    fn test_auto_increment_requires_int() {
+        let mut schema = build_schema(vec![
+            ("User",
+                vec![("id", FieldType::Int, true, false, None),
+                     ("code", FieldType::String, false, false, None)],
+                vec![]),
+        ]);
+        let user = schema.get_entity_mut("User").unwrap();
+        user.fields.get_mut("id").unwrap().default = Some(DefaultValue::AutoIncrement);
+        assert!(type_check(&schema).is_valid());
+
+        let user = schema.get_entity_mut("User").unwrap();
+        user.fields.get_mut("code").unwrap().default = Some(DefaultValue::AutoIncrement);
+        let result = type_check(&schema);
+        assert!(result.errors.iter().any(|e| matches!(e, TypeCheckError::InvalidAutoIncrement { field, .. } if field == "code")));
+    }
+
+    #[test]
+    fn test_tenant_field_must_exist() {
+        let mut schema = build_schema(vec![
+            ("Document",
+                vec![("id", FieldType::UUID, true, false, None),
+                     ("org_id", FieldType::UUID, false, false, None)],
+                vec![]),
+        ]);
+        schema.get_entity_mut("Document").unwrap().tenant = Some("org_id".to_string());
+        assert!(type_check(&schema).is_valid());
+
+        schema.get_entity_mut("Document").unwrap().tenant = Some("team_id".to_string());
+        let result = type_check(&schema);
+        assert!(result.errors.iter().any(|e| matches!(e, TypeCheckError::UnknownTenantField { field, .. } if field == "team_id")));
+    }
+
+    #[test]
+    fn test_timestamp_fields_must_be_timestamps() {
+        let mut schema = build_schema(vec![
+            ("Post",
+                vec![("id", FieldType::UUID, true, false, None),
+                     ("created_at", FieldType::String, false, false, None)],
+                vec![]),
+        ]);
+        assert!(type_check(&schema).is_valid());
+
+        schema.get_entity_mut("Post").unwrap().timestamps = true;
+        let result = type_check(&schema);
+        assert!(result.errors.iter().any(|e| matches!(e, TypeCheckError::InvalidTimestampField { field, .. } if field == "created_at")));
+    }
+
     // ─── CIRCULAR DEPENDENCY ───
 
     #[test]
@@ -325,6 +381,27 @@ mod tests {
         assert!(result.errors.iter().any(|e| matches!(e, TypeCheckError::CircularDependency { .. })));
     }
 
+    #[test]
+    fn test_unique_index_unknown_field() {
+        let mut schema = build_schema(vec![
+            ("User",
+                vec![("id", FieldType::UUID, true, false, None),
+                     ("email", FieldType::String, false, false, None)],
+                vec![]),
+        ]);
+        schema.entities[0].add_unique_index(UniqueIndex {
+            fields: vec!["email".to_string(), "tenant_id".to_string()],
+            predicate: Some("deleted_at IS NULL".to_string()),
+            concurrent: false,
+        });
+
+        let result = type_check(&schema);
+        assert_eq!(result.errors, vec![TypeCheckError::UnknownIndexField {
+            entity: "User".to_string(),
+            field: "tenant_id".to_string(),
+        }]);
+    }
+
     // ─── ERROR REPORT ───
 
     #[test]
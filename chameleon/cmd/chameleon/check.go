@@ -1,18 +1,24 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
 
+	"github.com/chameleon-db/chameleondb/chameleon/internal/schema"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
 	"github.com/spf13/cobra"
 )
 
 var (
-	outputJSON bool
+	outputJSON    bool
+	checkWatchDir string
 )
 
 // NOTE:
@@ -30,16 +36,41 @@ It validates the schema and outputs structured error information.
 Examples:
   chameleon check schema.cham
   chameleon check schema.cham --json
-  chameleon check --json < schema.cham`,
+  chameleon check --json < schema.cham
+  cat schema.cham | chameleon check -
+  chameleon check --watch schemas/  # Re-validate .cham files on change (JSON lines)
+
+With --watch, the .cham files under the directory are validated together,
+as migrate merges them. Every change prints one JSON line per file, and
+{"file": ..., "deleted": true} for each file removed.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if checkWatchDir != "" {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return watchSchemas(ctx, checkWatchDir, cmd.OutOrStdout())
+		}
+
 		eng := engine.NewEngineForCLI()
 		// Determine schema file or read from stdin
 		var input string
 		var filename string
 
-		if len(args) > 0 {
-			filename = args[0]
+		source := schemaSource(args, "", stdinPiped())
+		switch {
+		case source == stdinSource:
+			// Read from stdin
+			filename = "schema.cham"
+			content, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				if outputJSON {
+					return printJSONError(filename, fmt.Sprintf("Failed to read stdin: %v", err))
+				}
+				return fmt.Errorf("failed to read stdin: %w", err)
+			}
+			input = string(content)
+		case source != "":
+			filename = source
 			content, err := os.ReadFile(filename)
 			if err != nil {
 				if outputJSON {
@@ -48,51 +79,47 @@ Examples:
 				return fmt.Errorf("failed to read file: %w", err)
 			}
 			input = string(content)
-		} else {
-			// Read from stdin
+		default:
+			// No stdin, look for schema.cham
 			filename = "schema.cham"
-			stat, _ := os.Stdin.Stat()
-			if (stat.Mode() & os.ModeCharDevice) != 0 {
-				// No stdin, look for schema.cham
-				if _, err := os.Stat("schema.cham"); err == nil {
-					content, err := os.ReadFile("schema.cham")
-					if err != nil {
-						if outputJSON {
-							return printJSONError(filename, fmt.Sprintf("Failed to read file: %v", err))
-						}
-						return fmt.Errorf("failed to read schema.cham: %w", err)
-					}
-					input = string(content)
-				} else {
-					if outputJSON {
-						return printJSONError(filename, "No input provided and schema.cham not found")
-					}
-					return fmt.Errorf("no input provided")
-				}
-			} else {
-				// Read from stdin
-				content, err := io.ReadAll(os.Stdin)
+			if _, err := os.Stat("schema.cham"); err == nil {
+				content, err := os.ReadFile("schema.cham")
 				if err != nil {
 					if outputJSON {
-						return printJSONError(filename, fmt.Sprintf("Failed to read stdin: %v", err))
+						return printJSONError(filename, fmt.Sprintf("Failed to read file: %v", err))
 					}
-					return fmt.Errorf("failed to read stdin: %w", err)
+					return fmt.Errorf("failed to read schema.cham: %w", err)
 				}
 				input = string(content)
+			} else {
+				if outputJSON {
+					return printJSONError(filename, "No input provided and schema.cham not found")
+				}
+				return fmt.Errorf("no input provided")
 			}
 		}
 
-		// Check the schema
-		_, rawErr, err := eng.LoadSchemaFromStringRaw(input)
+		// Merge the files it imports, then check the schema
+		merged, err := schema.MergeWithImports(filename, input, importDir(source))
+		if err != nil {
+			if outputJSON {
+				return printJSONError(filename, err.Error())
+			}
+			return fmt.Errorf("failed to resolve imports: %w", err)
+		}
+		_, rawErr, err := eng.LoadSchemaFromStringRaw(merged.Content)
 
 		if err != nil {
 			if outputJSON {
-				return printCheckErrors(filename, rawErr)
+				return printCheckErrors(filename, rawErr, merged.LineMap)
 			}
 			// Human-readable output (use formatted error)
-			_, normalErr := eng.LoadSchemaFromString(input)
+			_, normalErr := eng.LoadSchemaFromString(merged.Content)
 			if normalErr != nil {
 				fmt.Println(normalErr.Error())
+				if sourceInfo := tryMapErrorToSource(normalErr.Error(), merged.LineMap); sourceInfo != "" {
+					fmt.Println(sourceInfo)
+				}
 			}
 			return fmt.Errorf("validation failed")
 		}
@@ -110,9 +137,35 @@ Examples:
 
 func init() {
 	checkCmd.Flags().BoolVar(&outputJSON, "json", false, "output errors in JSON format")
+	checkCmd.Flags().StringVar(&checkWatchDir, "watch", "", "watch a directory and re-validate its .cham files together on change")
 	rootCmd.AddCommand(checkCmd)
 }
 
+// stdinSource is the file argument meaning "read the schema from stdin"
+const stdinSource = "-"
+
+// stdinPiped reports whether stdin is a pipe or redirected file rather than a terminal
+func stdinPiped() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) == 0
+}
+
+// schemaSource resolves where a command reads its schema from.
+// An explicit file argument wins ("-" means stdin); without one, piped
+// stdin is used, then defaultFile. It returns stdinSource for stdin.
+func schemaSource(args []string, defaultFile string, piped bool) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	if piped {
+		return stdinSource
+	}
+	return defaultFile
+}
+
 // CheckError represents a single validation error
 type CheckError struct {
 	Message    string  `json:"message"`
@@ -130,7 +183,35 @@ type CheckResult struct {
 	Errors []CheckError `json:"errors"`
 }
 
-func printCheckErrors(filename string, rawErrMsg string) error {
+func printCheckErrors(filename string, rawErrMsg string, lineMap map[int]schema.SourceLine) error {
+	output, _ := json.MarshalIndent(mapCheckErrors(checkResultFromRaw(filename, rawErrMsg), lineMap), "", "  ")
+	fmt.Println(string(output))
+	return nil
+}
+
+// importDir is where the imports of a schema read from source are looked
+// up: next to the file, or the current directory for stdin
+func importDir(source string) string {
+	if source == stdinSource || source == "" {
+		return "."
+	}
+	return filepath.Dir(source)
+}
+
+// mapCheckErrors points errors at lines of a merged schema back to the
+// file and line they come from. A nil lineMap leaves result unchanged.
+func mapCheckErrors(result CheckResult, lineMap map[int]schema.SourceLine) CheckResult {
+	for i, checkErr := range result.Errors {
+		if source, ok := lineMap[checkErr.Line]; ok {
+			result.Errors[i].File = source.File
+			result.Errors[i].Line = source.LineNumber
+		}
+	}
+	return result
+}
+
+// checkResultFromRaw converts the core's raw validation error into a CheckResult
+func checkResultFromRaw(filename string, rawErrMsg string) CheckResult {
 	var result struct {
 		Valid  bool `json:"valid"`
 		Errors []struct {
@@ -172,13 +253,10 @@ func printCheckErrors(filename string, rawErrMsg string) error {
 			errors = append(errors, checkErr)
 		}
 
-		output := CheckResult{
+		return CheckResult{
 			Valid:  false,
 			Errors: errors,
 		}
-		data, _ := json.MarshalIndent(output, "", "  ")
-		fmt.Println(string(data))
-		return nil
 	}
 	fallback := CheckResult{
 		Valid: false,
@@ -195,11 +273,9 @@ func printCheckErrors(filename string, rawErrMsg string) error {
 	if fallback.Errors[0].Message == "" {
 		fallback.Errors[0].Message = "schema validation failed"
 	}
-
-	output, _ := json.MarshalIndent(fallback, "", "  ")
-	fmt.Println(string(output))
-	return nil
+	return fallback
 }
+
 func printJSONError(filename, message string) error {
 	result := CheckResult{
 		Valid: false,
@@ -1,8 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/internal/schema"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
 )
 
 func TestCheckErrorStructure(t *testing.T) {
@@ -165,3 +176,164 @@ func TestCheckErrorOmitEmptyFields(t *testing.T) {
 		t.Error("Expected suggestion to be omitted from JSON")
 	}
 }
+
+func TestSchemaSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		piped bool
+		want  string
+	}{
+		{"file argument", []string{"app.cham"}, false, "app.cham"},
+		{"file argument wins over pipe", []string{"app.cham"}, true, "app.cham"},
+		{"dash means stdin", []string{"-"}, false, stdinSource},
+		{"piped stdin", nil, true, stdinSource},
+		{"default file", nil, false, "schema.cham"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schemaSource(tt.args, "schema.cham", tt.piped); got != tt.want {
+				t.Errorf("schemaSource(%v, piped=%v) = %q, want %q", tt.args, tt.piped, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapCheckErrors(t *testing.T) {
+	lineMap := map[int]schema.SourceLine{
+		8:  {File: "entities/user.cham", LineNumber: 3},
+		14: {File: "schema.cham", LineNumber: 5},
+	}
+	result := mapCheckErrors(CheckResult{Errors: []CheckError{
+		{Message: "unknown entity", Line: 8, Column: 4, File: "schema.cham"},
+		{Message: "bad field", Line: 14, Column: 2, File: "schema.cham"},
+		{Message: "no line", Line: 1, Column: 1, File: "schema.cham"},
+	}}, lineMap)
+
+	want := []CheckError{
+		{Message: "unknown entity", Line: 3, Column: 4, File: "entities/user.cham"},
+		{Message: "bad field", Line: 5, Column: 2, File: "schema.cham"},
+		{Message: "no line", Line: 1, Column: 1, File: "schema.cham"},
+	}
+	if !reflect.DeepEqual(result.Errors, want) {
+		t.Errorf("mapCheckErrors() = %+v, want %+v", result.Errors, want)
+	}
+}
+
+func TestWatchScansSchemaFiles(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.cham")
+	if err := os.WriteFile(schemaPath, []byte("entity User {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "billing"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	extraPath := filepath.Join(dir, "billing", "invoice.cham")
+	if err := os.WriteFile(extraPath, []byte("entity Invoice {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := scanChamFiles(dir)
+	if err != nil {
+		t.Fatalf("scanChamFiles() error = %v", err)
+	}
+	if want := []string{extraPath, schemaPath}; !reflect.DeepEqual(before, want) {
+		t.Fatalf("scanChamFiles() = %v, want %v", before, want)
+	}
+
+	after := []string{schemaPath}
+	if got := removedFiles(before, after); !reflect.DeepEqual(got, []string{extraPath}) {
+		t.Errorf("removedFiles() = %v, want %v", got, []string{extraPath})
+	}
+	if removed := removedFiles(after, after); len(removed) != 0 {
+		t.Errorf("unchanged scan should report nothing, got %v", removed)
+	}
+}
+
+func TestCheckMergedReportsMergeErrors(t *testing.T) {
+	dir := t.TempDir()
+	aPath, bPath := filepath.Join(dir, "a.cham"), filepath.Join(dir, "b.cham")
+	if err := os.WriteFile(aPath, []byte(`import "b.cham"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bPath, []byte(`import "a.cham"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := checkMerged(engine.NewEngineForCLI(), dir, []string{aPath, bPath})
+	files := make([]string, len(results))
+	for i, result := range results {
+		files[i] = result.File
+	}
+	if want := []string{dir, aPath, bPath}; !reflect.DeepEqual(files, want) {
+		t.Fatalf("results for %v, want %v", files, want)
+	}
+	if results[0].Valid || !strings.Contains(results[0].Errors[0].Message, "import cycle") {
+		t.Errorf("expected the import cycle under %s, got %+v", dir, results[0])
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to read while the watcher writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchReportsDeletedFiles(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(dir, "user.cham")
+	postPath := filepath.Join(dir, "post.cham")
+	for _, path := range []string{userPath, postPath} {
+		if err := os.WriteFile(path, []byte("entity X {}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() { done <- watchSchemas(ctx, dir, &out) }()
+
+	waitFor := func(what string, found func(WatchResult) bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				var result WatchResult
+				if json.Unmarshal([]byte(line), &result) == nil && found(result) {
+					return
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("no %s reported; output:\n%s", what, out.String())
+	}
+
+	waitFor("startup result", func(r WatchResult) bool { return r.File == postPath && !r.Deleted })
+	if err := os.Remove(postPath); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("deleted file", func(r WatchResult) bool { return r.File == postPath && r.Deleted })
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watchSchemas() error = %v", err)
+	}
+}
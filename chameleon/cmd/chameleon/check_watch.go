@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/internal/schema"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Editors often write a file several times per save; results wait until
+// the directory has been quiet this long
+const watchDebounce = 300 * time.Millisecond
+
+// WatchResult is one line of `check --watch` output: the check result for
+// one file of the watched set, or Deleted for a file that is gone
+type WatchResult struct {
+	File    string `json:"file"`
+	Deleted bool   `json:"deleted,omitempty"`
+	CheckResult
+}
+
+// watchSchemas validates the .cham files under dir together, as migrate
+// merges them, then again after every change until ctx is cancelled. Each
+// round writes one JSON line per file to out, and one per deleted file.
+// Errors rescanning dir are logged and the watch goes on.
+func watchSchemas(ctx context.Context, dir string, out io.Writer) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	files, err := scanChamFiles(dir)
+	if err != nil {
+		return err
+	}
+	if err := watchDirs(watcher, dir); err != nil {
+		return err
+	}
+
+	eng := engine.NewEngineForCLI()
+	enc := json.NewEncoder(out)
+	if err := encodeResults(enc, checkMerged(eng, dir, files)); err != nil {
+		return err
+	}
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				// New directories are not watched until added
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := watchDirs(watcher, event.Name); err != nil {
+						printError("Failed to watch %s: %v", event.Name, err)
+					}
+				}
+			}
+			debounce.Reset(watchDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			printError("Watch error: %v", err)
+
+		case <-debounce.C:
+			next, err := scanChamFiles(dir)
+			if err != nil {
+				printError("Failed to scan %s: %v", dir, err)
+				continue
+			}
+			results := checkMerged(eng, dir, next)
+			for _, path := range removedFiles(files, next) {
+				results = append(results, WatchResult{
+					File:        path,
+					Deleted:     true,
+					CheckResult: CheckResult{Valid: true, Errors: []CheckError{}},
+				})
+			}
+			files = next
+			if err := encodeResults(enc, results); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchDirs adds dir and every directory under it to watcher
+func watchDirs(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+func encodeResults(enc *json.Encoder, results []WatchResult) error {
+	for _, result := range results {
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMerged validates files together and returns one result per file
+// with the errors that point into it. Errors that map to no file, such as
+// a failed merge, are reported under dir.
+func checkMerged(eng *engine.Engine, dir string, files []string) []WatchResult {
+	results := make(map[string]*WatchResult, len(files))
+	report := func(path string, checkErr CheckError) {
+		result := results[path]
+		if result == nil {
+			result = &WatchResult{File: path, CheckResult: CheckResult{Valid: true, Errors: []CheckError{}}}
+			results[path] = result
+		}
+		if checkErr.Message != "" {
+			result.Valid = false
+			result.Errors = append(result.Errors, checkErr)
+		}
+	}
+
+	// The merger resolves imports by basename, like FileLoader
+	var names, contents []string
+	paths := make(map[string]string, len(files))
+	for _, path := range files {
+		report(path, CheckError{})
+		content, err := os.ReadFile(path)
+		if err != nil {
+			report(path, CheckError{Message: "Failed to read file: " + err.Error(), Line: 1, Column: 1, File: path, Severity: "error"})
+			continue
+		}
+		names = append(names, filepath.Base(path))
+		contents = append(contents, string(content))
+		paths[filepath.Base(path)] = path
+	}
+
+	if len(names) > 0 {
+		if merged, err := schema.NewSimpleMerger().Merge(names, contents); err != nil {
+			report(dir, CheckError{Message: err.Error(), Line: 1, Column: 1, File: dir, Severity: "error"})
+		} else if _, rawErr, err := eng.LoadSchemaFromStringRaw(merged.Content); err != nil {
+			for _, checkErr := range mapCheckErrors(checkResultFromRaw(dir, rawErr), merged.LineMap).Errors {
+				if path, ok := paths[checkErr.File]; ok {
+					checkErr.File = path
+				}
+				report(checkErr.File, checkErr)
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(results))
+	for path := range results {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+	out := make([]WatchResult, len(sorted))
+	for i, path := range sorted {
+		out[i] = *results[path]
+	}
+	return out
+}
+
+// scanChamFiles returns the .cham files under dir, sorted
+func scanChamFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".cham") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// removedFiles lists the files of prev missing from next, sorted
+func removedFiles(prev, next []string) []string {
+	present := make(map[string]bool, len(next))
+	for _, path := range next {
+		present[path] = true
+	}
+	var removed []string
+	for _, path := range prev {
+		if !present[path] {
+			removed = append(removed, path)
+		}
+	}
+	return removed
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/chameleon-db/chameleondb/chameleon/internal/admin"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -45,7 +44,7 @@ Examples:
 			return fmt.Errorf("failed to get working directory: %w", err)
 		}
 
-		factory := admin.NewManagerFactory(workDir)
+		factory := newManagerFactory(workDir)
 		journalLogger, _ := factory.CreateJournalLogger()
 
 		parts := strings.SplitN(args[0], "=", 2)
@@ -354,7 +353,7 @@ Tip: for non-interactive usage, set CHAMELEON_MODE_PASSWORD.`,
 			return err
 		}
 
-		factory := admin.NewManagerFactory(workDir)
+		factory := newManagerFactory(workDir)
 		journalLogger, _ := factory.CreateJournalLogger()
 		if journalLogger != nil {
 			_ = journalLogger.Log("config_mode_auth", "success", map[string]interface{}{
@@ -409,7 +408,7 @@ Examples:
 				return fmt.Errorf("failed to get working directory: %w", err)
 			}
 
-			factory := admin.NewManagerFactory(workDir)
+			factory := newManagerFactory(workDir)
 			configLoader := factory.CreateConfigLoader()
 			cfg, err := configLoader.Load()
 			if err != nil {
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var diffFormat string
+
+// DiffResult is the `chameleon diff --format=json` output
+type DiffResult struct {
+	From        string                `json:"from"`
+	To          string                `json:"to"`
+	Destructive bool                  `json:"destructive"`
+	Changes     []engine.SchemaChange `json:"changes"`
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [from] [to]",
+	Short: "Show changes between schema versions",
+	Long: `Compare two schemas and list what changed.
+
+Each side is a vault version (v001, v002, ...) or a path to a .cham file.
+Without arguments, compares the current vault version with its parent.
+With one argument, compares it with the current vault version.
+
+Destructive changes (drops, type narrowing, new NOT NULL/UNIQUE constraints)
+are flagged so editors and CI bots can warn about them.
+
+Examples:
+  chameleon diff                          # Last registered change
+  chameleon diff v002 v003
+  chameleon diff v003 schemas/schema.cham  # Working copy vs v003
+  chameleon diff v002 --format=json`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if diffFormat != "text" && diffFormat != "json" {
+			return fmt.Errorf("invalid --format %q (expected text or json)", diffFormat)
+		}
+
+		workDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+
+		v := vault.NewVault(workDir)
+		from, to, err := resolveDiffSources(v, args)
+		if err != nil {
+			return err
+		}
+
+		result, err := diffVersions(v, from, to)
+		if err != nil {
+			return err
+		}
+
+		if diffFormat == "json" {
+			data, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+
+		printDiffText(result)
+		return nil
+	},
+}
+
+// resolveDiffSources fills in the defaults for omitted diff arguments
+func resolveDiffSources(v *vault.Vault, args []string) (string, string, error) {
+	if len(args) == 2 {
+		return args[0], args[1], nil
+	}
+
+	if !v.Exists() {
+		return "", "", fmt.Errorf("no vault found; pass two schema files or run 'chameleon migrate' first")
+	}
+	current, err := v.GetCurrentVersion()
+	if err != nil {
+		return "", "", fmt.Errorf("no current schema version: %w", err)
+	}
+
+	if len(args) == 1 {
+		return args[0], current.Version, nil
+	}
+	if current.Parent == nil {
+		return "", "", fmt.Errorf("%s has no parent version to compare with", current.Version)
+	}
+	return *current.Parent, current.Version, nil
+}
+
+// diffVersions loads both sides (vault versions or .cham files) and compares them
+func diffVersions(v *vault.Vault, from, to string) (*DiffResult, error) {
+	before, err := loadDiffSource(v, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := loadDiffSource(v, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DiffResult{
+		From:    from,
+		To:      to,
+		Changes: engine.DiffSchemas(before, after),
+	}
+	if result.Changes == nil {
+		result.Changes = []engine.SchemaChange{}
+	}
+	for _, change := range result.Changes {
+		if change.Destructive {
+			result.Destructive = true
+			break
+		}
+	}
+	return result, nil
+}
+
+// loadDiffSource parses a vault version, or a schema file when no such version exists
+func loadDiffSource(v *vault.Vault, source string) (*engine.Schema, error) {
+	if v.Exists() {
+		if _, err := v.GetVersion(source); err == nil {
+			return engine.LoadVersionSchema(v, source)
+		}
+	}
+
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a vault version nor a readable schema file: %w", source, err)
+	}
+
+	schema, err := engine.NewEngineForCLI().LoadSchemaFromString(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+	}
+	return schema, nil
+}
+
+func printDiffText(result *DiffResult) {
+	if len(result.Changes) == 0 {
+		printSuccess("No changes between %s and %s", result.From, result.To)
+		return
+	}
+
+	printInfo("Changes from %s to %s:", result.From, result.To)
+	for _, change := range result.Changes {
+		if change.Destructive {
+			fmt.Fprintf(color.Output, "  %s %s\n", color.RedString("!"), change)
+		} else {
+			fmt.Fprintf(color.Output, "  %s %s\n", color.GreenString("~"), change)
+		}
+	}
+	if result.Destructive {
+		fmt.Println()
+		printWarning("Destructive changes detected (marked with !)")
+	}
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "output format (text|json)")
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fmtWrite bool
+	fmtCheck bool
+	fmtForce bool
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt <file>...",
+	Short: "Format .cham schema files",
+	Long: `Parse schema files and re-emit them in the canonical style:
+fields in declared order, relations sorted by name, types aligned.
+
+Without flags the formatted schema is printed to stdout.
+
+Comments are not preserved. --write refuses to rewrite a file that has
+comments unless --force is given.
+
+Examples:
+  chameleon fmt schema.cham
+  chameleon fmt --write schemas/*.cham
+  chameleon fmt --check schemas/*.cham  # CI: exit non-zero if unformatted`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fmtWrite && fmtCheck {
+			return fmt.Errorf("--write and --check are mutually exclusive")
+		}
+
+		var unformatted []string
+		for _, path := range args {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read file: %w", err)
+			}
+
+			formatted, err := formatSchemaSource(string(content))
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+
+			switch {
+			case fmtCheck:
+				if formatted != string(content) {
+					unformatted = append(unformatted, path)
+					fmt.Fprintln(cmd.OutOrStdout(), path)
+				}
+			case fmtWrite:
+				if formatted == string(content) {
+					continue
+				}
+				if hasChamComments(string(content)) && !fmtForce {
+					return fmt.Errorf("%s has comments that fmt would drop; use --force to rewrite it anyway", path)
+				}
+				if err := os.WriteFile(path, []byte(formatted), 0644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", path, err)
+				}
+				printSuccess("Formatted %s", path)
+			default:
+				fmt.Fprint(cmd.OutOrStdout(), formatted)
+			}
+		}
+
+		if len(unformatted) > 0 {
+			return fmt.Errorf("%d file(s) need formatting", len(unformatted))
+		}
+		return nil
+	},
+}
+
+// formatSchemaSource parses .cham source and renders it canonically
+func formatSchemaSource(source string) (string, error) {
+	schema, err := engine.NewEngineForCLI().LoadSchemaFromString(source)
+	if err != nil {
+		return "", err
+	}
+	return schema.ToCham()
+}
+
+// hasChamComments reports whether source has // comments outside string literals
+func hasChamComments(source string) bool {
+	inString := false
+	for i := 0; i < len(source); i++ {
+		switch c := source[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case !inString && c == '/' && i+1 < len(source) && source[i+1] == '/':
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	fmtCmd.Flags().BoolVarP(&fmtWrite, "write", "w", false, "rewrite files in place")
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "list unformatted files and exit non-zero if any")
+	fmtCmd.Flags().BoolVar(&fmtForce, "force", false, "with --write, rewrite files even if comments would be lost")
+	rootCmd.AddCommand(fmtCmd)
+}
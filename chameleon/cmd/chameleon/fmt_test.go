@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestHasChamComments(t *testing.T) {
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{"entity User {\n    id: uuid primary,\n}\n", false},
+		{"// Users\nentity User {}\n", true},
+		{"entity User {\n    id: uuid, // key\n}\n", true},
+		{"entity Link {\n    url: string default \"https://example.com\",\n}\n", false},
+		{"entity Quote {\n    text: string default \"say \\\"//\\\"\",\n}\n", false},
+	}
+
+	for _, tt := range tests {
+		if got := hasChamComments(tt.source); got != tt.want {
+			t.Errorf("hasChamComments(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/chameleon-db/chameleondb/chameleon/internal/admin"
 	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
 )
 
@@ -62,7 +61,7 @@ If no name is provided, initializes in current directory.`,
 
 		// Initialize admin structure (.chameleon/)
 		printInfo("Creating .chameleon/ structure...")
-		factory := admin.NewManagerFactory(workDir)
+		factory := newManagerFactory(workDir)
 		if err := factory.Initialize(); err != nil {
 			return fmt.Errorf("failed to create admin structure: %w", err)
 		}
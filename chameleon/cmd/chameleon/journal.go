@@ -5,10 +5,11 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
-	"github.com/chameleon-db/chameleondb/chameleon/internal/admin"
 	"github.com/chameleon-db/chameleondb/chameleon/internal/journal"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
 )
@@ -65,7 +66,7 @@ Examples:
 		}
 
 		// Initialize journal logger
-		factory := admin.NewManagerFactory(workDir)
+		factory := newManagerFactory(workDir)
 		logger, err := factory.CreateJournalLogger()
 		if err != nil {
 			return fmt.Errorf("failed to initialize journal: %w", err)
@@ -108,7 +109,7 @@ Examples:
 		}
 
 		// Initialize journal logger
-		factory := admin.NewManagerFactory(workDir)
+		factory := newManagerFactory(workDir)
 		logger, err := factory.CreateJournalLogger()
 		if err != nil {
 			return fmt.Errorf("failed to initialize journal: %w", err)
@@ -151,7 +152,7 @@ Examples:
 		}
 
 		// Initialize journal logger
-		factory := admin.NewManagerFactory(workDir)
+		factory := newManagerFactory(workDir)
 		logger, err := factory.CreateJournalLogger()
 		if err != nil {
 			return fmt.Errorf("failed to initialize journal: %w", err)
@@ -204,7 +205,7 @@ func runJournalSchema(cmd *cobra.Command, args []string) error {
 	v := vault.NewVault(workDir)
 
 	if !v.Exists() {
-		fmt.Println("❌ No vault found")
+		fmt.Println(sym("❌ No vault found"))
 		fmt.Println("   Run 'chameleon migrate' to initialize")
 		return nil
 	}
@@ -223,17 +224,17 @@ func runJournalSchema(cmd *cobra.Command, args []string) error {
 func showVersionHistory(v *vault.Vault) {
 	history, err := v.GetVersionHistory()
 	if err != nil {
-		fmt.Printf("❌ Failed to read history: %v\n", err)
+		fmt.Printf(sym("❌ Failed to read history: %v\n"), err)
 		return
 	}
 
 	if len(history) == 0 {
-		fmt.Println("📖 No schema versions yet")
+		fmt.Println(sym("📖 No schema versions yet"))
 		return
 	}
 
 	fmt.Println()
-	fmt.Println("📖 Schema Version History")
+	fmt.Println(sym("📖 Schema Version History"))
 	fmt.Println()
 
 	// Show in reverse order (newest first)
@@ -243,7 +244,7 @@ func showVersionHistory(v *vault.Vault) {
 		// Mark current version
 		marker := ""
 		if err := v.Load(); err == nil && v.Manifest.CurrentVersion == entry.Version {
-			marker = " (current) ✓"
+			marker = sym(" (current) ✓")
 		}
 
 		fmt.Println(vault.FormatVersion(&entry) + marker)
@@ -254,12 +255,12 @@ func showVersionHistory(v *vault.Vault) {
 func showVersionDetail(v *vault.Vault, version string) {
 	entry, err := v.GetVersion(version)
 	if err != nil {
-		fmt.Printf("❌ Version %s not found\n", version)
+		fmt.Printf(sym("❌ Version %s not found\n"), version)
 		return
 	}
 
 	fmt.Println()
-	fmt.Printf("📋 Schema Version: %s\n", version)
+	fmt.Printf(sym("📋 Schema Version: %s\n"), version)
 	fmt.Println("─────────────────────────────────────────")
 	fmt.Printf("Hash:      %s\n", entry.Hash)
 	fmt.Printf("Timestamp: %s\n", entry.Timestamp.Format("2006-01-02T15:04:05Z"))
@@ -275,13 +276,13 @@ func showVersionDetail(v *vault.Vault, version string) {
 	fmt.Println()
 
 	if entry.ChangesSummary != "" {
-		fmt.Println("📝 Changes Summary:")
+		fmt.Println(sym("📝 Changes Summary:"))
 		fmt.Printf("  %s\n", entry.ChangesSummary)
 		fmt.Println()
 	}
 
 	if len(entry.Files) > 0 {
-		fmt.Println("📂 Files:")
+		fmt.Println(sym("📂 Files:"))
 		for _, file := range entry.Files {
 			fmt.Printf("  • %s\n", file)
 		}
@@ -291,7 +292,7 @@ func showVersionDetail(v *vault.Vault, version string) {
 
 func statusString(locked bool) string {
 	if locked {
-		return "locked ✓"
+		return sym("locked ✓")
 	}
 	return "unlocked"
 }
@@ -313,31 +314,101 @@ func init() {
 // printEntriesTable prints entries in table format
 func printEntriesTable(entries []*journal.Entry) {
 	fmt.Println()
-	fmt.Println("Timestamp                Action      Status      Details")
-	fmt.Println("─────────────────────────────────────────────────────────────────")
+	fmt.Print(formatEntriesTable(entries, terminalWidth()))
+	fmt.Println()
+}
+
+// Journal table layout
+const (
+	timestampWidth      = 19 // 2006-01-02 15:04:05
+	minDetailsWidth     = 20
+	journalColumnMargin = 2
+)
 
+// terminalWidth returns the width of stdout, or 0 when it is not a terminal
+// (piped output is never truncated)
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 0
+	}
+	return width
+}
+
+// formatEntriesTable renders entries with columns sized to their content.
+// The details column takes the remaining width (0 = unlimited).
+func formatEntriesTable(entries []*journal.Entry, width int) string {
+	actionWidth, statusWidth := len("Action"), len("Status")
 	for _, entry := range entries {
-		timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
-		status := entry.Status
-		if entry.Error != "" {
-			status = "error"
-		}
+		actionWidth = max(actionWidth, len(entry.Action))
+		statusWidth = max(statusWidth, len(entryStatus(entry)))
+	}
 
-		details := ""
-		if entry.Duration > 0 {
-			details = fmt.Sprintf("duration=%dms", entry.Duration)
-		}
-		if entry.Error != "" {
-			if details != "" {
-				details += " "
-			}
-			details += fmt.Sprintf("error=%s", truncate(entry.Error, 50))
+	fixedWidth := timestampWidth + actionWidth + statusWidth + 3*journalColumnMargin
+	detailsWidth := 0
+	if width > 0 {
+		detailsWidth = max(width-fixedWidth, minDetailsWidth)
+	}
+
+	ruleWidth := fixedWidth + len("Details")
+	if width > 0 {
+		ruleWidth = min(width, fixedWidth+detailsWidth)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-*s  %-*s  %-*s  %s\n",
+		timestampWidth, "Timestamp", actionWidth, "Action", statusWidth, "Status", "Details"))
+	sb.WriteString(strings.Repeat("─", ruleWidth) + "\n")
+
+	for _, entry := range entries {
+		status := entryStatus(entry)
+		details := entryDetails(entry)
+		if detailsWidth > 0 {
+			details = truncate(details, detailsWidth)
 		}
 
-		fmt.Printf("%-25s %-11s %-11s %s\n", timestamp, entry.Action, status, details)
+		// Pad before coloring so escape codes don't break alignment
+		sb.WriteString(fmt.Sprintf("%-*s  %-*s  %s  %s\n",
+			timestampWidth, entry.Timestamp.Format("2006-01-02 15:04:05"),
+			actionWidth, entry.Action,
+			colorStatus(fmt.Sprintf("%-*s", statusWidth, status), status),
+			details,
+		))
 	}
+	return sb.String()
+}
 
-	fmt.Println()
+func entryStatus(entry *journal.Entry) string {
+	if entry.Error != "" {
+		return "error"
+	}
+	return entry.Status
+}
+
+func entryDetails(entry *journal.Entry) string {
+	details := ""
+	if entry.Duration > 0 {
+		details = fmt.Sprintf("duration=%dms", entry.Duration)
+	}
+	if entry.Error != "" {
+		if details != "" {
+			details += " "
+		}
+		details += fmt.Sprintf("error=%s", entry.Error)
+	}
+	return details
+}
+
+// colorStatus colors text by status: green for success, red for failures.
+// fatih/color disables itself when stdout is not a terminal.
+func colorStatus(text, status string) string {
+	switch {
+	case status == "success" || status == "completed" || status == "applied" || status == "ok":
+		return successColor.Sprint(text)
+	case status == "error" || status == "failed" || status == "denied" || strings.HasPrefix(status, "aborted"):
+		return errorColor.Sprint(text)
+	}
+	return text
 }
 
 // printMigrationsTable prints migration entries in table format
@@ -360,7 +431,7 @@ func printMigrationsTable(entries []*journal.Entry) {
 			duration = fmt.Sprintf("%dms", entry.Duration)
 		}
 
-		fmt.Printf("%-25s %-20s %-9s %s\n", timestamp, version, status, duration)
+		fmt.Printf("%-25s %-20s %s %s\n", timestamp, version, colorStatus(fmt.Sprintf("%-9s", status), status), duration)
 	}
 
 	fmt.Println()
@@ -406,5 +477,8 @@ func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
+	if maxLen <= 3 {
+		return s[:maxLen]
+	}
 	return s[:maxLen-3] + "..."
 }
@@ -1,6 +1,13 @@
 package main
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chameleon-db/chameleondb/chameleon/internal/journal"
+)
 
 func TestStatusString(t *testing.T) {
 	tests := []struct {
@@ -76,3 +83,32 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatEntriesTable(t *testing.T) {
+	entries := []*journal.Entry{
+		{Timestamp: time.Date(2026, 2, 12, 10, 15, 0, 0, time.UTC), Action: "config_schema_paths", Status: "changed"},
+		{Timestamp: time.Date(2026, 2, 12, 10, 16, 0, 0, time.UTC), Action: "migrate", Status: "failed", Error: strings.Repeat("x", 200)},
+	}
+
+	got := formatEntriesTable(entries, 80)
+	lines := strings.Split(strings.TrimRight(got, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header, rule and 2 rows, got %d lines:\n%s", len(lines), got)
+	}
+	for _, line := range lines {
+		if n := utf8.RuneCountInString(line); n > 80 {
+			t.Errorf("line exceeds terminal width (%d > 80): %q", n, line)
+		}
+	}
+	if !strings.Contains(lines[2], "config_schema_paths  changed") {
+		t.Errorf("action column should fit the longest action: %q", lines[2])
+	}
+	if !strings.HasSuffix(lines[3], "...") {
+		t.Errorf("long error should be truncated: %q", lines[3])
+	}
+
+	unlimited := formatEntriesTable(entries, 0)
+	if !strings.Contains(unlimited, strings.Repeat("x", 200)) {
+		t.Error("piped output (width 0) should not truncate details")
+	}
+}
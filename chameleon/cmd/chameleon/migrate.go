@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,18 +13,24 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/chameleon-db/chameleondb/chameleon/internal/admin"
+	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
 	"github.com/chameleon-db/chameleondb/chameleon/internal/schema"
 	"github.com/chameleon-db/chameleondb/chameleon/internal/state"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 var (
 	dryRun         bool
 	applyMigration bool
 	checkOnly      bool
+	ifNotExists    bool
+
+	allowDestructive bool
+
+	migrateDatabaseURL string
 )
 
 var migrateCmd = &cobra.Command{
@@ -38,12 +45,12 @@ Use --dry-run to preview without applying.
 Examples:
   chameleon migrate              # Check for pending migrations
   chameleon migrate --dry-run    # Preview SQL without applying
-  chameleon migrate --apply      # Apply pending migrations`,
+  chameleon migrate --apply      # Apply pending migrations
+  chameleon migrate --apply --if-not-exists   # Retry a partially applied migration
+  chameleon migrate --apply --allow-destructive   # Remove or narrow columns holding data (privileged mode)
+  chameleon migrate --apply --database-url env:STAGING_DATABASE_URL`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
 		// Get working directory
 		workDir, err := os.Getwd()
 		if err != nil {
@@ -52,7 +59,7 @@ Examples:
 
 		// Initialize admin factory
 		printInfo("Loading configuration...")
-		factory := admin.NewManagerFactory(workDir)
+		factory := newManagerFactory(workDir)
 
 		// Load config
 		configLoader := factory.CreateConfigLoader()
@@ -62,6 +69,19 @@ Examples:
 		}
 		printSuccess("Configuration loaded from .chameleon.yml")
 
+		// Load validates the timeouts, so both are at least one second
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Database.MigrationTimeout)*time.Second)
+		defer cancel()
+
+		// Resolve the database before registering anything, so a bad
+		// --database-url fails the run up front
+		var connStr string
+		if applyMigration {
+			if connStr, err = resolveDatabaseURL(migrateDatabaseURL, cfg); err != nil {
+				return err
+			}
+		}
+
 		// Create journal logger
 		journalLogger, err := factory.CreateJournalLogger()
 		if err != nil {
@@ -131,6 +151,7 @@ Examples:
 		journalLogger.Log("migrate", "started", logDetails, nil)
 
 		// Show current vault status
+		paranoidMode := ""
 		if v.Manifest != nil && v.Manifest.CurrentVersion != "" {
 			current, _ := v.GetCurrentVersion()
 			if current != nil {
@@ -154,37 +175,33 @@ Examples:
 				}
 
 				printInfo("Paranoid Mode active: %s", mode)
+				paranoidMode = mode
 			}
 		} else {
 			printInfo("No schema versions registered yet")
 		}
 
 		// Load and merge schemas
-		printInfo("Loading schemas from: %v", cfg.Schema.Paths)
+		if schemaOverride != "" {
+			printInfo("Loading merged schema from: %s", schemaOverride)
+		} else {
+			printInfo("Loading schemas from: %v", cfg.Schema.Paths)
+		}
 		eng := engine.NewEngineForCLI()
 
-		// Load all schema files using FileLoader
-		loader := schema.NewFileLoader(cfg.Schema.Paths)
-		filenames, schemaContents, err := loader.LoadAll()
+		filenames, mergedResult, err := loadMigrationSchema(cfg)
 		if err != nil {
 			journalLogger.LogError("migrate", err, map[string]interface{}{"action": "load_schemas"})
-			return fmt.Errorf("failed to load schemas: %w", err)
+			return err
 		}
 
 		printSuccess("Found %d schema file(s): %v", len(filenames), filenames)
 
-		// Merge schemas using SimpleMerger with source tracking
-		merger := schema.NewSimpleMerger()
-		mergedResult, err := merger.Merge(filenames, schemaContents)
-		if err != nil {
-			journalLogger.LogError("migrate", err, map[string]interface{}{"action": "merge_schemas"})
-			return fmt.Errorf("failed to merge schemas: %w", err)
-		}
-
 		mergedSchema := mergedResult.Content
 		lineMap := mergedResult.LineMap
 
 		// Validate merged schema
+		merger := schema.NewSimpleMerger()
 		if err := merger.Validate(mergedSchema); err != nil {
 			journalLogger.LogError("migrate", err, map[string]interface{}{"action": "validate_schemas"})
 			return fmt.Errorf("schema validation failed: %w", err)
@@ -227,18 +244,11 @@ Examples:
 
 		printSuccess("Schema loaded and validated")
 
-		// Save merged schema to temp file for vault registration
-		mergedSchemaPath := cfg.Schema.MergedOutput
-		if strings.TrimSpace(mergedSchemaPath) == "" {
-			mergedSchemaPath = filepath.Join(workDir, ".chameleon", "state", "schema.merged.cham")
-		}
-		if err := os.MkdirAll(filepath.Dir(mergedSchemaPath), 0755); err != nil {
-			journalLogger.LogError("migrate", err, map[string]interface{}{"action": "ensure_merged_schema_dir"})
-			return fmt.Errorf("failed to prepare merged schema directory: %w", err)
-		}
-		if err := os.WriteFile(mergedSchemaPath, []byte(mergedSchema), 0644); err != nil {
+		// Save merged schema for vault registration
+		mergedSchemaPath, err := saveMergedSchema(workDir, cfg, mergedSchema)
+		if err != nil {
 			journalLogger.LogError("migrate", err, map[string]interface{}{"action": "save_merged_schema"})
-			return fmt.Errorf("failed to save merged schema: %w", err)
+			return err
 		}
 
 		// Get current state early (needed for both normal and retry paths)
@@ -291,28 +301,70 @@ Examples:
 
 		// Generate migration
 		printInfo("Generating migration SQL...")
-		migrationSQL, err := eng.GenerateMigration()
+		plan, err := eng.GenerateMigrationPlan()
 		if err != nil {
 			journalLogger.LogError("migrate", err, map[string]interface{}{"action": "generate"})
 			return fmt.Errorf("failed to generate migration: %w", err)
 		}
+		migrationSQL := engine.MigrationSQL(plan)
+		if ifNotExists {
+			// migrationSQL stays the plain DDL: it is what the version's
+			// DDL hash records, whichever way the plan is applied
+			plan = engine.IdempotentPlan(plan)
+		}
 		printSuccess("Migration SQL generated")
 
 		// Display migration plan
 		fmt.Println()
 		fmt.Println("─────────────────────────────────────────────────")
-		fmt.Println("Migration SQL:")
+		fmt.Printf("Migration plan (%d statements):\n", len(plan))
 		fmt.Println("─────────────────────────────────────────────────")
-		fmt.Println(migrationSQL)
+		printMigrationPlan(plan)
 		fmt.Println("─────────────────────────────────────────────────")
 		fmt.Println()
 
+		// A retry must apply the SQL that was registered for the version
+		if !changed {
+			recorded, err := stateTracker.GetMigration(currentVaultVersion)
+			if err != nil {
+				journalLogger.LogError("migrate", err, map[string]interface{}{"action": "load_pending_migration"})
+				return fmt.Errorf("failed to load pending migration: %w", err)
+			}
+			if err := checkPendingDDL(recorded, migrationSQL); err != nil {
+				journalLogger.LogError("migrate", err, map[string]interface{}{
+					"action":        "verify_pending_ddl",
+					"vault_version": currentVaultVersion,
+				})
+				return err
+			}
+		}
+
+		// Entities and fields removed or narrowed since the applied version
+		destructive := destructiveChanges(plan, previousAppliedSchema(v, lastAppliedMigration), eng.GetSchema())
+		if len(destructive) > 0 {
+			printDestructiveWarning(destructive)
+		}
+
 		if dryRun || !applyMigration {
 			printInfo("Dry-run mode. Use --apply to execute migration.")
 			journalLogger.Log("migrate", "dry_run", map[string]interface{}{"action": "check"}, nil)
 			return nil
 		}
 
+		if len(destructive) > 0 {
+			if err := destructiveAllowed(paranoidMode, allowDestructive); err != nil {
+				journalLogger.Log("migrate", "blocked_destructive", map[string]interface{}{
+					"mode":    paranoidMode,
+					"changes": destructive,
+				}, nil)
+				return err
+			}
+			journalLogger.Log("migrate", "allowed_destructive", map[string]interface{}{
+				"mode":    paranoidMode,
+				"changes": destructive,
+			}, nil)
+		}
+
 		// ========================================
 		// REGISTER VERSION IN VAULT (before applying)
 		// ========================================
@@ -339,10 +391,11 @@ Examples:
 		printInfo("Connecting to database...")
 
 		// Connect to database
-		connCtx, connCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		connCtx, connCancel := context.WithTimeout(ctx, time.Duration(cfg.Database.ConnectionTimeout)*time.Second)
 		defer connCancel()
 
-		conn, err := pgx.Connect(connCtx, cfg.Database.ConnectionString)
+		conn, err := pgx.Connect(connCtx, connStr)
+		err = redactConnError(err, connStr)
 		if err != nil {
 			currentState.Status = "pending_migration"
 			if saveErr := stateTracker.SaveCurrent(currentState); saveErr != nil {
@@ -387,7 +440,20 @@ Examples:
 		printInfo("Applying migration...")
 		startTime := time.Now()
 
-		_, err = conn.Exec(ctx, migrationSQL)
+		timings, err := applyMigrationPlan(ctx, conn, plan, planProgress{
+			before: func(i int, stmt engine.MigrationStatement) {
+				fmt.Printf("  Applying %d/%d: %s...\n", i+1, len(plan), stmt.Description)
+			},
+			after: func(i int, stmt engine.MigrationStatement, elapsed time.Duration) {
+				journalLogger.Log("migrate_statement", "statement_applied", map[string]interface{}{
+					"version":     newVersion.Version,
+					"statement":   fmt.Sprintf("%d/%d", i+1, len(plan)),
+					"kind":        stmt.Kind,
+					"description": stmt.Description,
+					"duration":    fmt.Sprintf("%dms", elapsed.Milliseconds()),
+				}, nil)
+			},
+		})
 		if err != nil {
 			duration := time.Since(startTime).Milliseconds()
 
@@ -421,6 +487,21 @@ Examples:
 			})
 
 			printError("Migration failed")
+
+			// Find out what the failure left behind, so only the rest is retried
+			var failure *planFailure
+			if errors.As(err, &failure) {
+				analysisCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				statuses, analysisErr := analyzeFailedMigration(analysisCtx, conn, failure)
+				cancel()
+				if analysisErr != nil {
+					journalLogger.LogError("migrate", analysisErr, map[string]interface{}{"action": "recovery_analysis"})
+					printError("Could not inspect the database after the failure: %v", analysisErr)
+				} else {
+					printRecoveryAnalysis(statuses)
+					journalLogger.Log("migrate", "recovery_analysis", recoveryJournalDetails(newVersion.Version, statuses), nil)
+				}
+			}
 			return fmt.Errorf("failed to execute migration: %w", err)
 		}
 
@@ -479,6 +560,7 @@ Examples:
 		fmt.Printf("  Duration: %dms\n", duration)
 		fmt.Printf("  Status:   applied\n")
 		fmt.Println()
+		printStatementTimings(timings)
 
 		return nil
 	},
@@ -488,10 +570,265 @@ func init() {
 	migrateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show migration SQL without applying")
 	migrateCmd.Flags().BoolVar(&applyMigration, "apply", false, "apply migration to database")
 	migrateCmd.Flags().BoolVar(&checkOnly, "check", false, "only check for pending migrations (default)")
+	migrateCmd.Flags().BoolVar(&ifNotExists, "if-not-exists", false, "skip tables, indexes and columns that already exist (may hide conflicting definitions)")
+	migrateCmd.Flags().BoolVar(&allowDestructive, "allow-destructive", false, "apply migrations that remove or narrow tables and columns holding data (privileged or emergency mode only)")
+	migrateCmd.Flags().StringVar(&migrateDatabaseURL, "database-url", "", "connection string or env:NAME / $NAME reference (default: .chameleon.yml)")
 
 	rootCmd.AddCommand(migrateCmd)
 }
 
+// checkPendingDDL verifies that the DDL regenerated for a pending version
+// matches the DDL recorded when it was first attempted. A mismatch means
+// the generated SQL changed between attempts (e.g. a different chameleon
+// release), and applying it would record different SQL under the same
+// version. Versions without a recorded hash are not checked.
+func checkPendingDDL(recorded *state.Migration, migrationSQL string) error {
+	if recorded == nil || recorded.DDLHash == "" {
+		return nil
+	}
+	if hash := state.HashDDL(migrationSQL); hash != recorded.DDLHash {
+		return fmt.Errorf(
+			"pending migration %s no longer matches its recorded DDL (recorded %s..., regenerated %s...)\n"+
+				"The SQL generated for this schema changed since the first attempt; "+
+				"review the plan above before applying it under a new version",
+			recorded.Version, recorded.DDLHash[:12], hash[:12],
+		)
+	}
+	return nil
+}
+
+// destructiveChanges lists what a migration from previous, the last
+// applied schema, to current loses: removed tables and fields, and type
+// changes that narrow a column. previous is nil when it could not be
+// loaded; then every DROP TABLE of plan counts.
+func destructiveChanges(plan []engine.MigrationStatement, previous, current *engine.Schema) []string {
+	var destructive []string
+	if previous == nil {
+		for _, stmt := range plan {
+			if stmt.Kind == engine.StatementDropTable {
+				destructive = append(destructive, stmt.Description)
+			}
+		}
+		return destructive
+	}
+
+	for _, change := range engine.DiffSchemas(previous, current) {
+		switch change.Kind {
+		case engine.ChangeEntityRemoved, engine.ChangeFieldRemoved, engine.ChangeFieldTypeChanged:
+			if change.Destructive {
+				destructive = append(destructive, change.String())
+			}
+		}
+	}
+	return destructive
+}
+
+// previousAppliedSchema loads the schema of the last applied migration.
+// With none applied, no table holds data yet: the result is an empty
+// schema. nil means it could not be loaded.
+func previousAppliedSchema(v *vault.Vault, last *state.Migration) *engine.Schema {
+	if last == nil {
+		return &engine.Schema{}
+	}
+	previous, err := engine.LoadVersionSchema(v, last.Version)
+	if err != nil {
+		printWarning("Could not load applied version %s (%v); treating every dropped table as holding data", last.Version, err)
+		return nil
+	}
+	return previous
+}
+
+// destructiveAllowed gates destructive statements on the paranoid mode
+// and --allow-destructive: refused in readonly and standard mode whatever
+// the flag, applied in privileged and emergency mode (or without a vault
+// mode) only with the flag.
+func destructiveAllowed(mode string, allow bool) error {
+	switch canonicalParanoidMode(mode) {
+	case "readonly", "standard":
+		return fmt.Errorf("destructive migration refused in %s mode; review the changes above, then switch to privileged mode (chameleon config set mode=privileged) and rerun with --allow-destructive", mode)
+	}
+	if allow {
+		return nil
+	}
+	return fmt.Errorf("destructive migration refused; review the changes above and rerun with --allow-destructive to apply it")
+}
+
+// printDestructiveWarning lists the changes that lose data
+func printDestructiveWarning(changes []string) {
+	printError("DESTRUCTIVE MIGRATION: %d change(s) remove or narrow data", len(changes))
+	for _, change := range changes {
+		fmt.Printf("   - %s\n", change)
+	}
+	printWarning("The data in these tables and columns is lost; back up before applying")
+	fmt.Println()
+}
+
+// printMigrationPlan lists each statement with its number and SQL
+func printMigrationPlan(plan []engine.MigrationStatement) {
+	for i, stmt := range plan {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%d. %s\n", i+1, stmt.Description)
+		fmt.Println(stmt.SQL)
+	}
+}
+
+// migrationExecer runs one statement (satisfied by *pgx.Conn and pgx.Tx)
+type migrationExecer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// migrationTx is the part of pgx.Tx used to apply a plan
+type migrationTx interface {
+	migrationExecer
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// planProgress is notified around each statement of a plan; either hook
+// may be nil. after only sees committed statements: those of the
+// transaction once it commits, concurrent ones as each finishes.
+type planProgress struct {
+	before func(i int, stmt engine.MigrationStatement)
+	after  func(i int, stmt engine.MigrationStatement, elapsed time.Duration)
+}
+
+// statementTiming is how long one applied statement took
+type statementTiming struct {
+	statement engine.MigrationStatement
+	elapsed   time.Duration
+}
+
+// applyMigrationPlan runs the plan statement by statement in one
+// transaction. Any failure rolls back the whole migration and names the
+// statement that failed. Concurrent statements run afterwards on the
+// connection itself, see execMigrationPlan.
+func applyMigrationPlan(ctx context.Context, conn *pgx.Conn, plan []engine.MigrationStatement, progress planProgress) ([]statementTiming, error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	return execMigrationPlan(ctx, conn, tx, plan, progress)
+}
+
+// execMigrationPlan applies the transactional statements in tx and commits,
+// then runs concurrent statements one by one on conn. A concurrent failure
+// cannot undo the committed part, so the error says so and carries the
+// statement's cleanup SQL. Failures are returned as *planFailure.
+func execMigrationPlan(ctx context.Context, conn migrationExecer, tx migrationTx, plan []engine.MigrationStatement, progress planProgress) ([]statementTiming, error) {
+	var transactional, concurrent []engine.MigrationStatement
+	for _, stmt := range plan {
+		if stmt.Concurrent {
+			concurrent = append(concurrent, stmt)
+		} else {
+			transactional = append(transactional, stmt)
+		}
+	}
+	ordered := append(transactional, concurrent...)
+
+	timings := make([]statementTiming, 0, len(ordered))
+	run := func(db migrationExecer, i int, stmt engine.MigrationStatement) error {
+		if progress.before != nil {
+			progress.before(i, stmt)
+		}
+		start := time.Now()
+		if _, err := db.Exec(ctx, stmt.SQL); err != nil {
+			return fmt.Errorf("statement %d/%d (%s) failed after %s: %w",
+				i+1, len(ordered), stmt.Description, time.Since(start).Round(time.Millisecond), err)
+		}
+		timings = append(timings, statementTiming{statement: stmt, elapsed: time.Since(start)})
+		return nil
+	}
+	// applied reports the timings from index from on as committed
+	applied := func(from int) {
+		if progress.after == nil {
+			return
+		}
+		for i := from; i < len(timings); i++ {
+			progress.after(i, timings[i].statement, timings[i].elapsed)
+		}
+	}
+
+	for i, stmt := range transactional {
+		if err := run(tx, i, stmt); err != nil {
+			_ = tx.Rollback(ctx)
+			return timings, &planFailure{ordered: ordered, err: err}
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return timings, &planFailure{ordered: ordered, err: fmt.Errorf("failed to commit migration: %w", err)}
+	}
+	applied(0)
+
+	for j, stmt := range concurrent {
+		if err := run(conn, len(transactional)+j, stmt); err != nil {
+			hint := fmt.Sprintf("the %d statement(s) before it were already committed", len(transactional))
+			if stmt.Cleanup != "" {
+				hint += "; run " + stmt.Cleanup + " before retrying"
+			}
+			return timings, &planFailure{
+				ordered:   ordered,
+				committed: len(transactional) + j,
+				err:       fmt.Errorf("%w (%s)", err, hint),
+			}
+		}
+		applied(len(transactional) + j)
+	}
+	return timings, nil
+}
+
+// printStatementTimings lists how long each applied statement took
+func printStatementTimings(timings []statementTiming) {
+	if len(timings) == 0 {
+		return
+	}
+	fmt.Println("Statements:")
+	for i, timing := range timings {
+		fmt.Printf("  %3d. %6dms  %s\n", i+1, timing.elapsed.Milliseconds(), timing.statement.Description)
+	}
+	fmt.Println()
+}
+
+// loadMigrationSchema returns the schema migrate applies and the files it
+// comes from: the file given with --schema-file as is, otherwise the
+// sources under schema.paths merged. A --schema-file has no line map.
+func loadMigrationSchema(cfg *config.Config) ([]string, *schema.MergedSchemaResult, error) {
+	if schemaOverride != "" {
+		content, err := os.ReadFile(schemaOverride)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read schema file: %w", err)
+		}
+		return []string{schemaOverride}, &schema.MergedSchemaResult{Content: string(content)}, nil
+	}
+
+	filenames, contents, err := schema.NewFileLoader(cfg.Schema.Paths).LoadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load schemas: %w", err)
+	}
+	merged, err := schema.NewSimpleMerger().Merge(filenames, contents)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to merge schemas: %w", err)
+	}
+	return filenames, merged, nil
+}
+
+// saveMergedSchema writes the merged schema where the vault registers it
+// from and returns that path. A --schema-file is registered from where it is.
+func saveMergedSchema(workDir string, cfg *config.Config, content string) (string, error) {
+	path := mergedSchemaPath(workDir, cfg)
+	if schemaOverride != "" {
+		return path, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to prepare merged schema directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to save merged schema: %w", err)
+	}
+	return path, nil
+}
+
 // tryMapErrorToSource maps parser line numbers to source schema files.
 func tryMapErrorToSource(errMsg string, lineMap map[int]schema.SourceLine) string {
 	// Supported patterns: "line 25", "--> file:25:5", " 25 │".
@@ -1,9 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
 	"github.com/chameleon-db/chameleondb/chameleon/internal/schema"
+	"github.com/chameleon-db/chameleondb/chameleon/internal/state"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 func TestTryMapErrorToSource(t *testing.T) {
@@ -78,3 +89,258 @@ func TestTryMapErrorToSourceEmptyMap(t *testing.T) {
 		t.Errorf("tryMapErrorToSource with empty map should return empty string, got %q", got)
 	}
 }
+
+// fakeTx records executed statements and fails on failAt (1-based)
+type fakeTx struct {
+	failAt     int
+	executed   []string
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	tx.executed = append(tx.executed, sql)
+	if len(tx.executed) == tx.failAt {
+		return pgconn.CommandTag{}, errors.New("relation already exists")
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error   { tx.committed = true; return nil }
+func (tx *fakeTx) Rollback(ctx context.Context) error { tx.rolledBack = true; return nil }
+
+func testPlan() []engine.MigrationStatement {
+	return []engine.MigrationStatement{
+		{Kind: engine.StatementDropTable, Entity: "User", Description: "Drop table users if it exists", SQL: "DROP TABLE IF EXISTS users CASCADE;"},
+		{Kind: engine.StatementCreateTable, Entity: "User", Description: "Create table users", SQL: "CREATE TABLE users (id UUID PRIMARY KEY);"},
+		{Kind: engine.StatementCreateIndex, Entity: "User", Description: "Create unique index users_id_key on users (id)", SQL: `CREATE UNIQUE INDEX "users_id_key" ON users (id);`},
+	}
+}
+
+func TestExecMigrationPlan(t *testing.T) {
+	plan := testPlan()
+	tx := &fakeTx{}
+	var started, finished []int
+
+	timings, err := execMigrationPlan(context.Background(), &fakeTx{}, tx, plan, planProgress{
+		before: func(i int, _ engine.MigrationStatement) { started = append(started, i) },
+		after: func(i int, _ engine.MigrationStatement, _ time.Duration) {
+			if !tx.committed {
+				t.Errorf("statement %d reported as applied before commit", i+1)
+			}
+			finished = append(finished, i)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tx.executed) != 3 || !tx.committed || tx.rolledBack {
+		t.Errorf("expected 3 statements committed, got %d (committed=%t, rolledBack=%t)", len(tx.executed), tx.committed, tx.rolledBack)
+	}
+	if len(started) != 3 || len(finished) != 3 || finished[2] != 2 {
+		t.Errorf("expected progress for every statement, got started=%v finished=%v", started, finished)
+	}
+	if len(timings) != 3 || timings[1].statement.Description != "Create table users" {
+		t.Errorf("expected a timing per statement, got %+v", timings)
+	}
+}
+
+func TestExecMigrationPlan_ReportsFailingStatement(t *testing.T) {
+	tx := &fakeTx{failAt: 2}
+
+	var applied []int
+	timings, err := execMigrationPlan(context.Background(), &fakeTx{}, tx, testPlan(), planProgress{
+		after: func(i int, _ engine.MigrationStatement, _ time.Duration) { applied = append(applied, i) },
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(applied) != 0 {
+		t.Errorf("rolled back statements reported as applied: %v", applied)
+	}
+	if !strings.Contains(err.Error(), "statement 2/3 (Create table users)") {
+		t.Errorf("error should name the failing statement: %v", err)
+	}
+	if len(timings) != 1 {
+		t.Errorf("expected timings for the statement that succeeded, got %d", len(timings))
+	}
+	if len(tx.executed) != 2 || tx.committed || !tx.rolledBack {
+		t.Errorf("expected rollback after statement 2, executed=%d committed=%t", len(tx.executed), tx.committed)
+	}
+}
+
+func TestExecMigrationPlan_ConcurrentAfterCommit(t *testing.T) {
+	plan := []engine.MigrationStatement{
+		{Kind: engine.StatementCreateIndex, Description: "Create unique index users_email_key on users (email) concurrently",
+			SQL: `CREATE UNIQUE INDEX CONCURRENTLY "users_email_key" ON users (email);`, Concurrent: true,
+			Cleanup: `DROP INDEX CONCURRENTLY IF EXISTS "users_email_key";`},
+	}
+	plan = append(plan, testPlan()...)
+
+	conn := &fakeTx{failAt: 1}
+	tx := &fakeTx{}
+	var order []string
+
+	timings, err := execMigrationPlan(context.Background(), conn, tx, plan, planProgress{
+		before: func(i int, stmt engine.MigrationStatement) { order = append(order, stmt.Kind) },
+	})
+	if err == nil {
+		t.Fatal("expected the concurrent statement to fail")
+	}
+
+	// Transactional statements run and commit first, the concurrent one last
+	want := []string{engine.StatementDropTable, engine.StatementCreateTable, engine.StatementCreateIndex, engine.StatementCreateIndex}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("execution order = %v, want %v", order, want)
+	}
+	if len(tx.executed) != 3 || !tx.committed {
+		t.Errorf("expected 3 committed statements in the transaction, got %d (committed=%t)", len(tx.executed), tx.committed)
+	}
+	if len(conn.executed) != 1 || len(timings) != 3 {
+		t.Errorf("expected the concurrent statement on the connection, got %d (timings %d)", len(conn.executed), len(timings))
+	}
+	for _, part := range []string{"statement 4/4", "already committed", `DROP INDEX CONCURRENTLY IF EXISTS "users_email_key";`} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("error should mention %q: %v", part, err)
+		}
+	}
+}
+
+func TestMigrationSQL(t *testing.T) {
+	got := engine.MigrationSQL(testPlan())
+	want := "DROP TABLE IF EXISTS users CASCADE;\n\nCREATE TABLE users (id UUID PRIMARY KEY);\n\n" + `CREATE UNIQUE INDEX "users_id_key" ON users (id);`
+	if got != want {
+		t.Errorf("MigrationSQL() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCheckPendingDDL(t *testing.T) {
+	sql := engine.MigrationSQL(testPlan())
+	recorded := &state.Migration{Version: "v002", Status: "failed", DDLHash: state.HashDDL(sql)}
+
+	if err := checkPendingDDL(recorded, sql); err != nil {
+		t.Errorf("same DDL should pass: %v", err)
+	}
+	if err := checkPendingDDL(nil, sql); err != nil {
+		t.Errorf("a version never attempted should pass: %v", err)
+	}
+	if err := checkPendingDDL(&state.Migration{Version: "v002"}, sql); err != nil {
+		t.Errorf("a record without a DDL hash should pass: %v", err)
+	}
+
+	err := checkPendingDDL(recorded, sql+"\nCREATE INDEX extra ON users (id);")
+	if err == nil || !strings.Contains(err.Error(), "pending migration v002 no longer matches its recorded DDL") {
+		t.Errorf("expected a DDL mismatch error, got %v", err)
+	}
+}
+
+func TestDestructiveChanges(t *testing.T) {
+	plan := []engine.MigrationStatement{
+		{Kind: engine.StatementDropTable, Entity: "User", Description: "Drop table users if it exists"},
+		{Kind: engine.StatementDropTable, Entity: "Invoice", Description: "Drop table invoices if it exists"},
+		{Kind: engine.StatementCreateTable, Entity: "User", Description: "Create table users"},
+	}
+	field := func(name, kind string) *engine.Field {
+		return &engine.Field{Name: name, Type: engine.FieldType{Kind: kind}, Nullable: true}
+	}
+	previous := &engine.Schema{Entities: []*engine.Entity{
+		{Name: "User", Fields: map[string]*engine.Field{
+			"id":    field("id", "UUID"),
+			"age":   field("age", "Int"),
+			"email": field("email", "String"),
+			"bio":   field("bio", "String"),
+		}},
+		{Name: "Invoice", Fields: map[string]*engine.Field{"id": field("id", "UUID")}},
+		{Name: "Report", View: true},
+	}}
+
+	unchanged := &engine.Schema{Entities: previous.Entities}
+	if got := destructiveChanges(plan, previous, unchanged); len(got) != 0 {
+		t.Errorf("recreating unchanged tables should not be destructive, got %v", got)
+	}
+
+	current := &engine.Schema{Entities: []*engine.Entity{
+		{Name: "User", Fields: map[string]*engine.Field{
+			"id":    field("id", "UUID"),
+			"age":   field("age", "Float"),
+			"email": field("email", "Int"),
+			"name":  field("name", "String"),
+		}},
+	}}
+	want := []string{
+		"entity_removed Invoice",
+		"field_removed User.bio: String",
+		"field_type_changed User.email: String → Int",
+	}
+	if got := destructiveChanges(plan, previous, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("destructiveChanges() = %v, want %v", got, want)
+	}
+
+	if got := destructiveChanges(plan, &engine.Schema{}, current); len(got) != 0 {
+		t.Errorf("nothing applied yet should mean nothing destructive, got %v", got)
+	}
+	if got := destructiveChanges(plan, nil, current); len(got) != 2 {
+		t.Errorf("an unknown applied schema should count every drop, got %v", got)
+	}
+}
+
+func TestDestructiveAllowed(t *testing.T) {
+	tests := []struct {
+		mode    string
+		allow   bool
+		wantErr bool
+	}{
+		{"readonly", true, true},
+		{"standard", true, true},
+		{"privileged", false, true},
+		{"privileged", true, false},
+		{"emergency", true, false},
+		{"", false, true},
+		{"", true, false},
+	}
+	for _, tt := range tests {
+		if err := destructiveAllowed(tt.mode, tt.allow); (err != nil) != tt.wantErr {
+			t.Errorf("destructiveAllowed(%q, %v) = %v, wantErr %v", tt.mode, tt.allow, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadMigrationSchema(t *testing.T) {
+	defer func() { schemaOverride = "" }()
+
+	dir := t.TempDir()
+	source := "entity User {\n    id: uuid primary,\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "user.cham"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Schema: config.SchemaConfig{Paths: []string{dir}}}
+
+	filenames, merged, err := loadMigrationSchema(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filenames) != 1 || filenames[0] != "user.cham" || !strings.Contains(merged.Content, "entity User") {
+		t.Errorf("expected schema.paths merged, got %v: %q", filenames, merged.Content)
+	}
+
+	// --schema-file replaces schema.paths and is used as is
+	candidate := filepath.Join(dir, "candidate.merged.cham")
+	candidateSource := "entity Post {\n    id: uuid primary,\n}\n"
+	if err := os.WriteFile(candidate, []byte(candidateSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+	schemaOverride = candidate
+
+	filenames, merged, err = loadMigrationSchema(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filenames) != 1 || filenames[0] != candidate || merged.Content != candidateSource || merged.LineMap != nil {
+		t.Errorf("expected the --schema-file content, got %v: %q", filenames, merged.Content)
+	}
+
+	schemaOverride = filepath.Join(dir, "missing.cham")
+	if _, _, err := loadMigrationSchema(cfg); err == nil {
+		t.Error("expected an error for a missing --schema-file")
+	}
+}
@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 
+	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
 	"github.com/spf13/cobra"
 )
@@ -12,6 +15,7 @@ var (
 	queryDebug   bool
 	queryTrace   bool
 	queryExplain bool
+	queryExport  string
 )
 
 var queryCmd = &cobra.Command{
@@ -22,17 +26,34 @@ var queryCmd = &cobra.Command{
 Examples:
   chameleon query User --debug
   chameleon query Post --trace
-  chameleon query Order --explain`,
+  chameleon query Order --explain
+  chameleon query User --export=csv > users.csv
+  chameleon query User --export=ndjson`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		entity := args[0]
+		if err := checkExportFormat(queryExport); err != nil {
+			return err
+		}
 
 		// Setup engine.
-		eng, err := engine.NewEngine()
+		eng, err := newEngine()
 		if err != nil {
 			return fmt.Errorf("failed to initialize engine: %w", err)
 		}
 
+		// Apply query limits, timeouts and the drift check from
+		// .chameleon.yml, if there is one.
+		if workDir, err := os.Getwd(); err == nil {
+			if cfg, err := config.NewLoader(workDir).Load(); err == nil {
+				eng.WithLimits(limitConfig(cfg.Query))
+				eng.WithTimeouts(timeoutConfig(cfg.Database))
+				if cfg.Safety.DriftCheck {
+					eng.WithDriftCheck(printDrift)
+				}
+			}
+		}
+
 		// Set debug level.
 		if queryExplain {
 			eng.Debug.Level = engine.DebugExplain
@@ -56,17 +77,54 @@ Examples:
 			return err
 		}
 
+		if queryExport != "" {
+			return exportResult(os.Stdout, result, queryExport)
+		}
+
 		// Display results
-		fmt.Printf("\n✓ Retrieved %d row(s)\n", len(result.Rows))
+		fmt.Printf(sym("\n✓ Retrieved %d row(s)\n"), len(result.Rows))
 
 		return nil
 	},
 }
 
+// checkExportFormat rejects --export values other than csv and ndjson
+func checkExportFormat(format string) error {
+	switch format {
+	case "", "csv", "ndjson":
+		return nil
+	}
+	return fmt.Errorf("unknown export format %q (use csv or ndjson)", format)
+}
+
+// exportResult writes the query rows to w in the given format
+func exportResult(w io.Writer, result *engine.QueryResult, format string) error {
+	if format == "ndjson" {
+		return result.ToNDJSON(w)
+	}
+	return result.ToCSV(w)
+}
+
 func init() {
 	queryCmd.Flags().BoolVar(&queryDebug, "debug", false, "show generated SQL")
 	queryCmd.Flags().BoolVar(&queryTrace, "trace", false, "show full query trace")
 	queryCmd.Flags().BoolVar(&queryExplain, "explain", false, "show query plan")
+	queryCmd.Flags().StringVar(&queryExport, "export", "", "write rows to stdout as csv or ndjson")
 
 	rootCmd.AddCommand(queryCmd)
 }
+
+// printDrift reports the drift check run on connect; a failed check is
+// only a warning, since it must not keep the query from running
+func printDrift(issues []engine.DriftIssue, err error) {
+	if err != nil {
+		printWarning("schema drift check failed: %v", err)
+		return
+	}
+	for _, issue := range issues {
+		printWarning("schema drift: %s", issue)
+	}
+	if len(issues) > 0 {
+		printWarning("the database does not match the schema; run 'chameleon migrate' or check for manual changes")
+	}
+}
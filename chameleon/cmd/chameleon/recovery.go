@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/jackc/pgx/v5"
+)
+
+// Statement states reported by analyzeFailedMigration
+const (
+	statementApplied = "applied"
+	statementPending = "pending"
+	statementInvalid = "invalid" // a concurrent index left INVALID; run its cleanup before retrying
+)
+
+// planFailure is the error of execMigrationPlan. committed is how many
+// statements of the execution order took effect before the failure: none
+// when the transaction rolled back, the transactional part plus the
+// concurrent statements that finished when a concurrent one failed.
+type planFailure struct {
+	ordered   []engine.MigrationStatement
+	committed int
+	err       error
+}
+
+func (f *planFailure) Error() string { return f.err.Error() }
+func (f *planFailure) Unwrap() error { return f.err }
+
+// catalogQuerier runs one catalog query (satisfied by *pgx.Conn)
+type catalogQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// statementStatus is what the live database shows for one plan statement
+type statementStatus struct {
+	statement engine.MigrationStatement
+	state     string
+}
+
+var (
+	createSchemaPattern = regexp.MustCompile(`(?i)^CREATE\s+SCHEMA\s+(?:IF\s+NOT\s+EXISTS\s+)?("(?:[^"]|"")+"|[^\s;]+)`)
+	createTablePattern  = regexp.MustCompile(`(?i)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?((?:"(?:[^"]|"")+"\.)?(?:"(?:[^"]|"")+"|[^\s(]+))`)
+	createIndexPattern  = regexp.MustCompile(`(?i)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?("(?:[^"]|"")+"|\S+)\s+ON\s+(?:ONLY\s+)?("(?:[^"]|"")+"\.)?`)
+)
+
+// analyzeFailedMigration compares a failed plan with the live schema and
+// reports, in execution order, which statements took effect. Statements
+// whose object can be looked up in the catalog (schemas, tables, indexes)
+// are checked there; the rest are known from failure.committed. A
+// transactional statement past the committed part was rolled back even
+// when an older table of the same name exists, so it is pending without a
+// lookup.
+func analyzeFailedMigration(ctx context.Context, db catalogQuerier, failure *planFailure) ([]statementStatus, error) {
+	statuses := make([]statementStatus, 0, len(failure.ordered))
+	for i, stmt := range failure.ordered {
+		state := statementPending
+		if i < failure.committed {
+			state = statementApplied
+		}
+		if i < failure.committed || stmt.Concurrent {
+			found, err := lookupStatementObject(ctx, db, stmt)
+			if err != nil {
+				return nil, fmt.Errorf("recovery analysis of %q: %w", stmt.Description, err)
+			}
+			if found != "" {
+				state = found
+			}
+		}
+		statuses = append(statuses, statementStatus{statement: stmt, state: state})
+	}
+	return statuses, nil
+}
+
+// lookupStatementObject returns the state of the object stmt creates, or
+// "" when the statement's effect cannot be seen in the catalog (drops,
+// comments)
+func lookupStatementObject(ctx context.Context, db catalogQuerier, stmt engine.MigrationStatement) (string, error) {
+	sql := strings.TrimSpace(stmt.SQL)
+	exists := func(query, name string) (string, error) {
+		var found bool
+		if err := db.QueryRow(ctx, query, name).Scan(&found); err != nil {
+			return "", err
+		}
+		if found {
+			return statementApplied, nil
+		}
+		return statementPending, nil
+	}
+
+	switch stmt.Kind {
+	case engine.StatementCreateSchema:
+		if m := createSchemaPattern.FindStringSubmatch(sql); m != nil {
+			return exists("SELECT to_regnamespace($1) IS NOT NULL", m[1])
+		}
+	case engine.StatementCreateTable:
+		if m := createTablePattern.FindStringSubmatch(sql); m != nil {
+			return exists("SELECT to_regclass($1) IS NOT NULL", m[1])
+		}
+	case engine.StatementCreateIndex:
+		if m := createIndexPattern.FindStringSubmatch(sql); m != nil {
+			// Indexes live in the namespace of their table
+			var valid bool
+			err := db.QueryRow(ctx, "SELECT indisvalid FROM pg_index WHERE indexrelid = to_regclass($1)", m[2]+m[1]).Scan(&valid)
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				return statementPending, nil
+			case err != nil:
+				return "", err
+			case !valid:
+				return statementInvalid, nil
+			}
+			return statementApplied, nil
+		}
+	}
+	return "", nil
+}
+
+// remainingSQL is the SQL that completes a partially applied plan: the
+// cleanup of every invalid index, then each statement not applied yet
+func remainingSQL(statuses []statementStatus) []string {
+	var sql []string
+	for _, status := range statuses {
+		if status.state == statementInvalid && status.statement.Cleanup != "" {
+			sql = append(sql, status.statement.Cleanup)
+		}
+	}
+	for _, status := range statuses {
+		if status.state != statementApplied {
+			sql = append(sql, status.statement.SQL)
+		}
+	}
+	return sql
+}
+
+// printRecoveryAnalysis lists what a failed migration left in the database
+// and the SQL that finishes it
+func printRecoveryAnalysis(statuses []statementStatus) {
+	fmt.Println()
+	fmt.Println("Recovery analysis (live schema):")
+	for i, status := range statuses {
+		fmt.Printf("  %3d. %-8s  %s\n", i+1, status.state, status.statement.Description)
+	}
+
+	applied := 0
+	for _, status := range statuses {
+		if status.state == statementApplied {
+			applied++
+		}
+	}
+	if applied == 0 && len(remainingSQL(statuses)) == len(statuses) {
+		fmt.Println()
+		printInfo("Nothing was applied; fix the error and run 'chameleon migrate --apply' again")
+		return
+	}
+	fmt.Println()
+	printInfo("Part of the migration is already in the database. To finish it, run only:")
+	fmt.Println()
+	fmt.Println(strings.Join(remainingSQL(statuses), "\n\n"))
+	fmt.Println()
+}
+
+// recoveryJournalDetails summarizes an analysis for the journal
+func recoveryJournalDetails(version string, statuses []statementStatus) map[string]interface{} {
+	byState := make(map[string][]string)
+	for _, status := range statuses {
+		byState[status.state] = append(byState[status.state], status.statement.Description)
+	}
+	details := map[string]interface{}{
+		"version":   version,
+		"remaining": remainingSQL(statuses),
+	}
+	for _, state := range []string{statementApplied, statementPending, statementInvalid} {
+		if len(byState[state]) > 0 {
+			details[state] = byState[state]
+		}
+	}
+	return details
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeCatalog answers catalog lookups by object name: existing relations
+// and namespaces, and the validity of existing indexes
+type fakeCatalog struct {
+	objects map[string]bool
+	indexes map[string]bool
+	lookups []string
+}
+
+type fakeRow struct {
+	value interface{}
+	err   error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*bool) = r.value.(bool)
+	return nil
+}
+
+func (c *fakeCatalog) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	name := args[0].(string)
+	c.lookups = append(c.lookups, name)
+	if strings.Contains(sql, "pg_index") {
+		valid, ok := c.indexes[name]
+		if !ok {
+			return fakeRow{err: pgx.ErrNoRows}
+		}
+		return fakeRow{value: valid}
+	}
+	return fakeRow{value: c.objects[name]}
+}
+
+func recoveryPlan() []engine.MigrationStatement {
+	return []engine.MigrationStatement{
+		{Kind: engine.StatementCreateSchema, Description: "Create schema billing", SQL: `CREATE SCHEMA IF NOT EXISTS "billing";`},
+		{Kind: engine.StatementDropTable, Description: "Drop table invoices if it exists", SQL: `DROP TABLE IF EXISTS "billing"."invoices" CASCADE;`},
+		{Kind: engine.StatementCreateTable, Description: `Create table "billing"."invoices"`, SQL: "CREATE TABLE \"billing\".\"invoices\" (\nid UUID PRIMARY KEY\n);"},
+		{Kind: engine.StatementCreateIndex, Description: "Create unique index invoices_number_key concurrently", Concurrent: true,
+			SQL:     `CREATE UNIQUE INDEX CONCURRENTLY "invoices_number_key" ON "billing"."invoices" (number);`,
+			Cleanup: `DROP INDEX CONCURRENTLY IF EXISTS "billing"."invoices_number_key";`},
+		{Kind: engine.StatementCreateIndex, Description: "Create unique index invoices_ref_key concurrently", Concurrent: true,
+			SQL:     `CREATE UNIQUE INDEX CONCURRENTLY "invoices_ref_key" ON "billing"."invoices" (ref);`,
+			Cleanup: `DROP INDEX CONCURRENTLY IF EXISTS "billing"."invoices_ref_key";`},
+	}
+}
+
+func TestAnalyzeFailedMigration_ConcurrentFailure(t *testing.T) {
+	catalog := &fakeCatalog{
+		objects: map[string]bool{`"billing"`: true, `"billing"."invoices"`: true},
+		indexes: map[string]bool{`"billing"."invoices_number_key"`: false},
+	}
+	failure := &planFailure{ordered: recoveryPlan(), committed: 3, err: errors.New("duplicate key")}
+
+	statuses, err := analyzeFailedMigration(context.Background(), catalog, failure)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{statementApplied, statementApplied, statementApplied, statementInvalid, statementPending}
+	for i, status := range statuses {
+		if status.state != want[i] {
+			t.Errorf("statement %d (%s) = %s, want %s", i+1, status.statement.Description, status.state, want[i])
+		}
+	}
+
+	remaining := remainingSQL(statuses)
+	if len(remaining) != 3 || !strings.HasPrefix(remaining[0], "DROP INDEX CONCURRENTLY") ||
+		!strings.Contains(remaining[1], "invoices_number_key") || !strings.Contains(remaining[2], "invoices_ref_key") {
+		t.Errorf("remaining SQL should clean up the invalid index, then retry both indexes: %q", remaining)
+	}
+
+	details := recoveryJournalDetails("v003", statuses)
+	if len(details[statementApplied].([]string)) != 3 || details[statementInvalid] == nil || details["version"] != "v003" {
+		t.Errorf("unexpected journal details: %v", details)
+	}
+}
+
+func TestAnalyzeFailedMigration_RolledBack(t *testing.T) {
+	// The previous version's table still exists after the rollback
+	catalog := &fakeCatalog{objects: map[string]bool{`"billing"`: true, `"billing"."invoices"`: true}}
+	failure := &planFailure{ordered: recoveryPlan(), committed: 0, err: errors.New("syntax error")}
+
+	statuses, err := analyzeFailedMigration(context.Background(), catalog, failure)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, status := range statuses {
+		if status.state != statementPending {
+			t.Errorf("statement %d should be pending after a rollback, got %s", i+1, status.state)
+		}
+	}
+	for _, name := range catalog.lookups {
+		if !strings.Contains(name, "_key") {
+			t.Errorf("rolled back transactional statements should not be looked up, got %s", name)
+		}
+	}
+}
+
+func TestExecMigrationPlan_ReturnsPlanFailure(t *testing.T) {
+	plan := recoveryPlan()
+	conn := &fakeTx{failAt: 2}
+
+	_, err := execMigrationPlan(context.Background(), conn, &fakeTx{}, plan, planProgress{})
+	var failure *planFailure
+	if !errors.As(err, &failure) {
+		t.Fatalf("expected *planFailure, got %v", err)
+	}
+	if failure.committed != 4 || len(failure.ordered) != 5 {
+		t.Errorf("expected 4 of 5 statements committed, got %d of %d", failure.committed, len(failure.ordered))
+	}
+
+	_, err = execMigrationPlan(context.Background(), &fakeTx{}, &fakeTx{failAt: 1}, plan, planProgress{})
+	if !errors.As(err, &failure) || failure.committed != 0 {
+		t.Errorf("a transactional failure should commit nothing, got %v", err)
+	}
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"os"
+	"strings"
 
+	"github.com/chameleon-db/chameleondb/chameleon/internal/admin"
+	"github.com/chameleon-db/chameleondb/chameleon/internal/journal"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -11,6 +14,12 @@ import (
 var (
 	// Global flags
 	verbose bool
+	quiet   bool
+	noColor bool
+	noEmoji bool
+
+	// schemaOverride is the merged schema file given with --schema-file
+	schemaOverride string
 
 	// Colors
 	successColor = color.New(color.FgGreen, color.Bold)
@@ -35,32 +44,99 @@ Get started:
 }
 
 func init() {
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output (also journals debug-level entries)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "only journal errors and migrations")
+	rootCmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "replace emoji with plain-text markers (also set by CHAMELEON_NO_EMOJI)")
+	rootCmd.PersistentFlags().StringVar(&schemaOverride, "schema-file", "", "merged schema file to load instead of schema.merged_output (migrate applies it instead of merging schema.paths)")
+	cobra.OnInitialize(applyOutputFlags)
+}
+
+// applyOutputFlags turns off colors and emoji from flags or the environment.
+// NO_COLOR follows https://no-color.org: any non-empty value disables color.
+func applyOutputFlags() {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		color.NoColor = true
+	}
+	if os.Getenv("CHAMELEON_NO_EMOJI") != "" {
+		noEmoji = true
+	}
+}
+
+// emojiReplacer maps the emoji used in CLI output to plain-text markers.
+// Variants with a trailing variation selector must come first.
+var emojiReplacer = strings.NewReplacer(
+	"✓", "[ok]",
+	"✔", "[ok]",
+	"✅", "[ok]",
+	"✗", "[x]",
+	"❌", "[x]",
+	"⚠️", "[!]",
+	"⚠", "[!]",
+	"🚨", "[!!]",
+	"ℹ", "[i]",
+	"❓", "[?]",
+	"🛡️", "",
+	"⚙️", "",
+	"👑", "",
+	"🗂️ ", "",
+	"🔍 ", "",
+	"🔧 ", "",
+	"📖 ", "",
+	"📋 ", "",
+	"📝 ", "",
+	"📂 ", "",
+	"🦎 ", "",
+	" 🦎", "",
+	"👉 ", "",
+)
+
+// sym returns s unchanged, or with emoji replaced when --no-emoji is set
+func sym(s string) string {
+	if !noEmoji {
+		return s
+	}
+	return emojiReplacer.Replace(s)
+}
+
+// newManagerFactory creates the admin factory, applying --quiet/--verbose
+// over the journal level from .chameleon.yml
+func newManagerFactory(workDir string) *admin.ManagerFactory {
+	factory := admin.NewManagerFactory(workDir)
+	switch {
+	case quiet:
+		factory.SetJournalLevel(journal.LevelError)
+	case verbose:
+		factory.SetJournalLevel(journal.LevelDebug)
+	}
+	return factory
 }
 
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		errorColor.Fprintf(os.Stderr, "Error: %v\n", err)
+		errorColor.Fprintf(color.Error, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-// Helper functions for consistent output
+// Helper functions for consistent output; writes go through
+// color.Output/color.Error, which honor --no-color and NO_COLOR
 func printSuccess(format string, args ...interface{}) {
-	successColor.Printf("✓ "+format+"\n", args...)
+	successColor.Fprintf(color.Output, sym("✓ "+format+"\n"), args...)
 }
 
 func printError(format string, args ...interface{}) {
-	errorColor.Fprintf(os.Stderr, "✗ "+format+"\n", args...)
+	errorColor.Fprintf(color.Error, sym("✗ "+format+"\n"), args...)
 }
 
 func printWarning(format string, args ...interface{}) {
-	warningColor.Printf("⚠ "+format+"\n", args...)
+	warningColor.Fprintf(color.Output, sym("⚠ "+format+"\n"), args...)
 }
 
 func printInfo(format string, args ...interface{}) {
-	infoColor.Printf("ℹ "+format+"\n", args...)
+	infoColor.Fprintf(color.Output, sym("ℹ "+format+"\n"), args...)
 }
 
 func getConfigFromEnv() engine.ConnectorConfig {
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestSym(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"✓ Up to date", "[ok] Up to date"},
+		{"  ⚠️  Modified", "  [!]  Modified"},
+		{"⚠ plain warning", "[!] plain warning"},
+		{"❌ 3 issues", "[x] 3 issues"},
+		{"📖 Schema Version History", "Schema Version History"},
+		{"🛡️", ""},
+		{"no emoji here", "no emoji here"},
+	}
+
+	defer func() { noEmoji = false }()
+
+	noEmoji = false
+	for _, tt := range tests {
+		if got := sym(tt.input); got != tt.input {
+			t.Errorf("sym(%q) with emoji enabled = %q, want unchanged", tt.input, got)
+		}
+	}
+
+	noEmoji = true
+	for _, tt := range tests {
+		if got := sym(tt.input); got != tt.expected {
+			t.Errorf("sym(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+)
+
+func TestLoadSeedFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("User.json", `[{"id": "u1", "email": "ana@mail.com"}, {"id": "u2", "email": "bob@mail.com"}]`)
+	write("Country.json", `[]`)
+	write("notes.txt", `ignored`)
+
+	files, err := loadSeedFiles(dir)
+	if err != nil {
+		t.Fatalf("loadSeedFiles failed: %v", err)
+	}
+	if len(files) != 2 || files[0].Entity != "Country" || files[1].Entity != "User" {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+	if len(files[1].Rows) != 2 || files[1].Rows[1]["email"] != "bob@mail.com" {
+		t.Errorf("unexpected rows: %v", files[1].Rows)
+	}
+
+	write("Broken.json", `{"id": "x"}`)
+	if _, err := loadSeedFiles(dir); err == nil {
+		t.Error("expected an error for a seed file that is not an array")
+	}
+
+	files, err = loadSeedFiles(filepath.Join(dir, "missing"))
+	if err != nil || len(files) != 0 {
+		t.Errorf("missing directory should load nothing, got %v, %v", files, err)
+	}
+}
+
+func TestOrderSeedFiles(t *testing.T) {
+	userID, orderID := "user_id", "order_id"
+	schema := &engine.Schema{Entities: []*engine.Entity{
+		{Name: "Item", Fields: map[string]*engine.Field{}, Relations: map[string]*engine.Relation{}},
+		{Name: "Order", Fields: map[string]*engine.Field{}, Relations: map[string]*engine.Relation{
+			"items": {Name: "items", Kind: engine.RelationHasMany, TargetEntity: "Item", ForeignKey: &orderID},
+		}},
+		{Name: "User", Fields: map[string]*engine.Field{}, Relations: map[string]*engine.Relation{
+			"orders": {Name: "orders", Kind: engine.RelationHasMany, TargetEntity: "Order", ForeignKey: &userID},
+		}},
+	}}
+
+	files := []seedFile{{Entity: "Item"}, {Entity: "Order"}, {Entity: "User"}}
+	ordered, err := orderSeedFiles(schema, files)
+	if err != nil {
+		t.Fatalf("orderSeedFiles failed: %v", err)
+	}
+
+	var names []string
+	for _, file := range ordered {
+		names = append(names, file.Entity)
+	}
+	if want := []string{"User", "Order", "Item"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("seed order = %v, want %v", names, want)
+	}
+
+	_, err = orderSeedFiles(schema, []seedFile{{Entity: "Usr"}})
+	var unknown *engine.UnknownEntityError
+	if !errors.As(err, &unknown) {
+		t.Errorf("expected UnknownEntityError, got %v", err)
+	}
+}
@@ -1,34 +1,109 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
+	"github.com/chameleon-db/chameleondb/chameleon/internal/journal"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
 	"github.com/spf13/cobra"
 )
 
+var statusJSON bool
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show ChameleonDB status",
-	Long:  `Display current status of schema, vault, and database connection.`,
-	Run:   runStatus,
+	Long: `Display current status of schema, vault, and database connection.
+
+Use --json for a machine-readable report (dashboards, CI gates).`,
+	Run: runStatus,
 }
 
 func init() {
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "output the status as JSON")
 	rootCmd.AddCommand(statusCmd)
 }
 
+// statusReport is the output of 'chameleon status --json'
+type statusReport struct {
+	Schema schemaStatusJSON `json:"schema"`
+	Vault  vaultStatusJSON  `json:"vault"`
+	Config configStatusJSON `json:"config"`
+}
+
+type schemaStatusJSON struct {
+	// Status is no_vault, no_versions, up_to_date, modified, registered
+	// (no schema file to compare) or error
+	Status         string `json:"status"`
+	CurrentVersion string `json:"current_version,omitempty"`
+	Branch         string `json:"branch,omitempty"`
+	Hash           string `json:"hash,omitempty"`
+	LastModified   string `json:"last_modified,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+type vaultStatusJSON struct {
+	Initialized bool           `json:"initialized"`
+	Versions    int            `json:"versions"`
+	Integrity   *integrityJSON `json:"integrity,omitempty"`
+	Mode        string         `json:"mode,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+type integrityJSON struct {
+	Valid  bool     `json:"valid"`
+	Issues []string `json:"issues"`
+	Error  string   `json:"error,omitempty"`
+}
+
+type configStatusJSON struct {
+	DebugLevel   string   `json:"debug_level"`   // CHAMELEON_DEBUG: off, sql, trace or explain
+	JournalLevel string   `json:"journal_level"` // journal.level after --quiet/--verbose
+	SchemaPaths  []string `json:"schema_paths,omitempty"`
+	SchemaFile   string   `json:"schema_file,omitempty"` // --schema-file
+	Error        string   `json:"error,omitempty"`       // .chameleon.yml failed to load
+}
+
 func runStatus(cmd *cobra.Command, args []string) {
 	v := vault.NewVault(".")
 
-	fmt.Println("🗂️  ChameleonDB Status")
+	workDir, err := os.Getwd()
+	if err != nil {
+		printError("Failed to get working directory: %v", err)
+		return
+	}
+	cfg, cfgErr := config.NewLoader(workDir).Load()
+	cfgStatus := collectConfigStatus(cfg, cfgErr)
+
+	schemaPath, cleanup, schemaErr := statusSchemaFile(cfg, cfgErr)
+	defer cleanup()
+
+	if statusJSON {
+		report := collectStatus(v, schemaPath, cfgStatus)
+		if schemaErr != nil && report.Schema.Error == "" {
+			report.Schema.Error = schemaErr.Error()
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			printError("Failed to encode status as JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return
+	}
+
+	fmt.Println(sym("🗂️  ChameleonDB Status"))
 	fmt.Println("────────────────────────────────────────────")
 	fmt.Println()
 
 	// Schema status
-	showSchemaStatus(v)
+	showSchemaStatus(v, schemaPath, schemaErr)
 	fmt.Println()
 
 	// Vault status
@@ -36,30 +111,104 @@ func runStatus(cmd *cobra.Command, args []string) {
 	fmt.Println()
 
 	// Configuration
-	showConfiguration()
+	showConfiguration(cfgStatus)
+}
+
+// statusSchemaFile returns the schema file status compares with the
+// current version, built the way migrate builds it: the --schema-file as
+// is, or the sources under schema.paths merged into a temporary file.
+// cleanup removes that file. Without a config there is nothing to merge.
+func statusSchemaFile(cfg *config.Config, cfgErr error) (path string, cleanup func(), err error) {
+	cleanup = func() {}
+	if schemaOverride != "" {
+		return schemaOverride, cleanup, nil
+	}
+	if cfgErr != nil {
+		return "", cleanup, nil
+	}
+
+	_, merged, err := loadMigrationSchema(cfg)
+	if err != nil {
+		return "", cleanup, err
+	}
+	f, err := os.CreateTemp("", "chameleon-status-*.cham")
+	if err != nil {
+		return "", cleanup, fmt.Errorf("failed to write merged schema: %w", err)
+	}
+	cleanup = func() { os.Remove(f.Name()) }
+	if _, err := f.WriteString(merged.Content); err != nil {
+		f.Close()
+		return "", cleanup, fmt.Errorf("failed to write merged schema: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", cleanup, fmt.Errorf("failed to write merged schema: %w", err)
+	}
+	return f.Name(), cleanup, nil
 }
 
-func showSchemaStatus(v *vault.Vault) {
+// collectConfigStatus reports the settings in effect: the journal level
+// from .chameleon.yml with the --quiet/--verbose overrides, the schema
+// sources, and the engine debug level, which only CHAMELEON_DEBUG sets
+func collectConfigStatus(cfg *config.Config, cfgErr error) configStatusJSON {
+	status := configStatusJSON{
+		DebugLevel:   debugLevelName(engine.DebugContextFromEnv().Level),
+		JournalLevel: journal.LevelInfo.String(),
+		SchemaFile:   schemaOverride,
+	}
+	if cfgErr != nil {
+		status.Error = cfgErr.Error()
+	} else {
+		status.SchemaPaths = cfg.Schema.Paths
+		if level, err := journal.ParseLevel(cfg.Journal.Level); err == nil {
+			status.JournalLevel = level.String()
+		}
+	}
+
+	switch {
+	case quiet:
+		status.JournalLevel = journal.LevelError.String()
+	case verbose:
+		status.JournalLevel = journal.LevelDebug.String()
+	}
+	return status
+}
+
+func debugLevelName(level engine.DebugLevel) string {
+	switch level {
+	case engine.DebugSQL:
+		return "sql"
+	case engine.DebugTrace:
+		return "trace"
+	case engine.DebugExplain:
+		return "explain"
+	}
+	return "off"
+}
+
+func showSchemaStatus(v *vault.Vault, schemaPath string, schemaErr error) {
 	fmt.Println("Schema:")
 
 	if !v.Exists() {
-		fmt.Println("  Status:          ⚠️  No vault initialized")
+		fmt.Println(sym("  Status:          ⚠️  No vault initialized"))
 		fmt.Println("  Action:          Run 'chameleon migrate' to start")
 		return
 	}
 
 	status, err := v.GetStatus()
 	if err != nil {
-		fmt.Printf("  Status:          ❌ Error: %v\n", err)
+		fmt.Printf(sym("  Status:          ❌ Error: %v\n"), err)
 		return
 	}
 
 	if status.CurrentVersion == "" {
-		fmt.Println("  Status:          ⚠️  No versions registered")
+		fmt.Println(sym("  Status:          ⚠️  No versions registered"))
 		return
 	}
 
 	fmt.Printf("  Current version:  %s\n", status.CurrentVersion)
+	if v.Manifest != nil && len(v.Manifest.Heads) > 1 {
+		fmt.Printf("  Branch:          %s\n", v.Manifest.CurrentBranch())
+	}
 
 	// Get current version details
 	entry, err := v.GetCurrentVersion()
@@ -68,14 +217,17 @@ func showSchemaStatus(v *vault.Vault) {
 		fmt.Printf("  Last modified:   %s\n", formatTimeSince(entry.Timestamp))
 	}
 
-	// Check if schema file matches current version
-	schemaPath := "schema.cham"
-	if _, err := os.Stat(schemaPath); err == nil {
+	// Check if the schema migrate would apply matches current version
+	if schemaErr != nil {
+		fmt.Printf(sym("  Status:          ❌ Cannot load schema: %v\n"), schemaErr)
+		return
+	}
+	if _, err := os.Stat(schemaPath); schemaPath != "" && err == nil {
 		changed, _, _ := v.DetectChanges(schemaPath)
 		if changed {
-			fmt.Println("  Status:          ⚠️  Schema modified (not registered)")
+			fmt.Println(sym("  Status:          ⚠️  Schema modified (not registered)"))
 		} else {
-			fmt.Println("  Status:          ✓ Up to date")
+			fmt.Println(sym("  Status:          ✓ Up to date"))
 		}
 	}
 }
@@ -99,11 +251,11 @@ func showVaultStatus(v *vault.Vault) {
 	// Verify integrity
 	result, err := v.VerifyIntegrity()
 	if err != nil {
-		fmt.Printf("  Integrity:       ❌ Error: %v\n", err)
+		fmt.Printf(sym("  Integrity:       ❌ Error: %v\n"), err)
 	} else if result.Valid {
-		fmt.Println("  Integrity:       ✓ OK")
+		fmt.Println(sym("  Integrity:       ✓ OK"))
 	} else {
-		fmt.Printf("  Integrity:       ❌ %d issues\n", len(result.Issues))
+		fmt.Printf(sym("  Integrity:       ❌ %d issues\n"), len(result.Issues))
 	}
 
 	mode, err := v.GetParanoidMode()
@@ -113,10 +265,76 @@ func showVaultStatus(v *vault.Vault) {
 	}
 }
 
-func showConfiguration() {
+func showConfiguration(cfg configStatusJSON) {
 	fmt.Println("Configuration:")
-	fmt.Println("  Debug Level:     off")
-	// More config options can be added here
+	if cfg.Error != "" {
+		fmt.Printf(sym("  Config:          ⚠️  %s\n"), cfg.Error)
+	}
+	fmt.Printf("  Debug Level:     %s\n", cfg.DebugLevel)
+	fmt.Printf("  Journal Level:   %s\n", cfg.JournalLevel)
+	if cfg.SchemaFile != "" {
+		fmt.Printf("  Schema file:     %s\n", cfg.SchemaFile)
+	} else if len(cfg.SchemaPaths) > 0 {
+		fmt.Printf("  Schema paths:    %s\n", strings.Join(cfg.SchemaPaths, ", "))
+	}
+}
+
+// collectStatus gathers what runStatus prints, for --json; schemaPath
+// (see statusSchemaFile; "" for none) is compared with the current
+// version like in the text report
+func collectStatus(v *vault.Vault, schemaPath string, cfg configStatusJSON) statusReport {
+	report := statusReport{Config: cfg}
+
+	if !v.Exists() {
+		report.Schema.Status = "no_vault"
+		return report
+	}
+	report.Vault.Initialized = true
+
+	status, err := v.GetStatus()
+	if err != nil {
+		report.Schema.Status = "error"
+		report.Schema.Error = err.Error()
+		report.Vault.Error = err.Error()
+		return report
+	}
+	report.Vault.Versions = status.TotalVersions
+
+	result, err := v.VerifyIntegrity()
+	if err != nil {
+		report.Vault.Integrity = &integrityJSON{Issues: []string{}, Error: err.Error()}
+	} else {
+		issues := result.Issues
+		if issues == nil {
+			issues = []string{}
+		}
+		report.Vault.Integrity = &integrityJSON{Valid: result.Valid, Issues: issues}
+	}
+	if mode, err := v.GetParanoidMode(); err == nil {
+		report.Vault.Mode = mode
+	}
+
+	if status.CurrentVersion == "" {
+		report.Schema.Status = "no_versions"
+		return report
+	}
+	report.Schema.CurrentVersion = status.CurrentVersion
+	if v.Manifest != nil {
+		report.Schema.Branch = v.Manifest.CurrentBranch()
+	}
+	if entry, err := v.GetCurrentVersion(); err == nil {
+		report.Schema.Hash = entry.Hash
+		report.Schema.LastModified = entry.Timestamp.UTC().Format(time.RFC3339)
+	}
+
+	report.Schema.Status = "registered"
+	if _, err := os.Stat(schemaPath); schemaPath != "" && err == nil {
+		report.Schema.Status = "up_to_date"
+		if changed, _, _ := v.DetectChanges(schemaPath); changed {
+			report.Schema.Status = "modified"
+		}
+	}
+	return report
 }
 
 func formatTimeSince(t time.Time) string {
@@ -142,14 +360,14 @@ func formatTimeSince(t time.Time) string {
 func getModeIcon(mode string) string {
 	switch mode {
 	case "readonly":
-		return "🛡️"
+		return sym("🛡️")
 	case "standard":
-		return "⚙️"
+		return sym("⚙️")
 	case "privileged":
-		return "👑"
+		return sym("👑")
 	case "emergency":
-		return "🚨"
+		return sym("🚨")
 	default:
-		return "❓"
+		return sym("❓")
 	}
 }
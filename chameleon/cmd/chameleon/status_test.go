@@ -1,8 +1,13 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
 )
 
 func TestFormatTimeSince(t *testing.T) {
@@ -99,3 +104,93 @@ func TestGetModeIcon(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectStatus(t *testing.T) {
+	dir := t.TempDir()
+	v := vault.NewVault(dir)
+
+	report := collectStatus(v, filepath.Join(dir, "schema.cham"), configStatusJSON{})
+	if report.Schema.Status != "no_vault" || report.Vault.Initialized {
+		t.Errorf("expected no vault, got %+v", report)
+	}
+
+	schemaPath := filepath.Join(dir, "schema.cham")
+	if err := os.WriteFile(schemaPath, []byte("entity User { id: uuid primary, }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.RegisterVersion(schemaPath, "test", "initial"); err != nil {
+		t.Fatalf("RegisterVersion() error = %v", err)
+	}
+
+	report = collectStatus(v, schemaPath, configStatusJSON{})
+	if report.Schema.Status != "up_to_date" || report.Schema.CurrentVersion != "v001" || report.Schema.Branch != vault.DefaultBranch {
+		t.Errorf("unexpected schema status: %+v", report.Schema)
+	}
+	if report.Vault.Versions != 1 || report.Vault.Integrity == nil || !report.Vault.Integrity.Valid {
+		t.Errorf("unexpected vault status: %+v", report.Vault)
+	}
+
+	if err := os.WriteFile(schemaPath, []byte("entity User { id: uuid primary, name: string, }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if report = collectStatus(v, schemaPath, configStatusJSON{}); report.Schema.Status != "modified" {
+		t.Errorf("expected modified schema, got %s", report.Schema.Status)
+	}
+}
+
+func TestStatusReadsConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("CHAMELEON_DEBUG", "trace")
+
+	yml := "schema:\n  paths: [schemas]\njournal:\n  level: debug\ndatabase:\n  driver: postgresql\n  connection_string: postgresql://app@db.internal:5432/app\n"
+	if err := os.WriteFile(filepath.Join(dir, ".chameleon.yml"), []byte(yml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "schemas"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	source := "entity User {\n    id: uuid primary,\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "schemas", "user.cham"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, cfgErr := config.NewLoader(dir).Load()
+	if cfgErr != nil {
+		t.Fatal(cfgErr)
+	}
+	cfgStatus := collectConfigStatus(cfg, cfgErr)
+	if cfgStatus.DebugLevel != "trace" || cfgStatus.JournalLevel != "debug" || len(cfgStatus.SchemaPaths) != 1 || cfgStatus.SchemaPaths[0] != filepath.Join(dir, "schemas") {
+		t.Errorf("unexpected config status: %+v", cfgStatus)
+	}
+
+	quiet = true
+	t.Cleanup(func() { quiet = false })
+	if got := collectConfigStatus(cfg, cfgErr).JournalLevel; got != "error" {
+		t.Errorf("--quiet should report the error level, got %s", got)
+	}
+
+	// status compares the merged schema.paths sources, like migrate
+	schemaPath, cleanup, err := statusSchemaFile(cfg, cfgErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := vault.NewVault(dir)
+	if _, err := v.RegisterVersion(schemaPath, "test", "initial"); err != nil {
+		t.Fatalf("RegisterVersion() error = %v", err)
+	}
+	cleanup()
+	if _, err := os.Stat(schemaPath); !os.IsNotExist(err) {
+		t.Errorf("the merged schema %s was not removed", schemaPath)
+	}
+
+	schemaPath, cleanup, err = statusSchemaFile(cfg, cfgErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	report := collectStatus(v, schemaPath, cfgStatus)
+	if report.Schema.Status != "up_to_date" || report.Config.DebugLevel != "trace" {
+		t.Errorf("unexpected status: %+v", report)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
+	"github.com/spf13/cobra"
+)
+
+var truncateConfirm bool
+
+var truncateCmd = &cobra.Command{
+	Use:   "truncate <entity>...",
+	Short: "Remove every row from entity tables (test databases)",
+	Long: `Empty the tables of the given entities with
+TRUNCATE ... RESTART IDENTITY CASCADE. Tables that reference them through
+foreign keys are emptied too.
+
+This cannot be undone, so it requires --confirm and is refused unless
+paranoid mode is privileged (or emergency), or .chameleon.yml marks the
+database as a test database:
+
+  safety:
+    allow_truncate: true
+
+Examples:
+  chameleon truncate --confirm Order User`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !truncateConfirm {
+			return fmt.Errorf("truncate removes every row from %s; pass --confirm to proceed", strings.Join(args, ", "))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		workDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+
+		factory := newManagerFactory(workDir)
+		cfg, err := factory.CreateConfigLoader().Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		journalLogger, err := factory.CreateJournalLogger()
+		if err != nil {
+			return fmt.Errorf("failed to initialize journal: %w", err)
+		}
+
+		mode := ""
+		if v := vault.NewVault(workDir); v.Exists() {
+			if mode, err = v.GetParanoidMode(); err != nil {
+				return fmt.Errorf("failed to read paranoid mode: %w", err)
+			}
+		}
+		if err := truncateAllowed(mode, cfg.Safety.AllowTruncate); err != nil {
+			journalLogger.Log("truncate", "refused", map[string]interface{}{
+				"entities": strings.Join(args, ","),
+				"mode":     mode,
+			}, nil)
+			return err
+		}
+
+		eng, err := newEngine()
+		if err != nil {
+			return fmt.Errorf("failed to initialize engine: %w", err)
+		}
+
+		connConfig, err := databaseConnectorConfig(cfg.Database)
+		if err == nil {
+			err = eng.Connect(ctx, connConfig)
+			err = redactConnError(err, cfg.Database.ConnectionString)
+		}
+		if err != nil {
+			journalLogger.LogError("truncate", err, map[string]interface{}{"action": "connect"})
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer eng.Close()
+
+		result, err := eng.WithJournal(journalLogger).Truncate(args...).Force().Execute(ctx)
+		if err != nil {
+			return err
+		}
+
+		printSuccess("Truncated %s", strings.Join(result.Tables, ", "))
+		return nil
+	},
+}
+
+// truncateAllowed refuses truncate unless paranoid mode is privileged or
+// emergency, or the config marks the database as a test database
+func truncateAllowed(mode string, allowTruncate bool) error {
+	if allowTruncate {
+		return nil
+	}
+
+	switch canonicalParanoidMode(mode) {
+	case "privileged", "emergency":
+		return nil
+	}
+
+	if mode == "" {
+		mode = "none"
+	}
+	return fmt.Errorf("truncate is refused in %s mode; use privileged mode or set safety.allow_truncate for a test database", mode)
+}
+
+func init() {
+	truncateCmd.Flags().BoolVar(&truncateConfirm, "confirm", false, "confirm that every row should be removed")
+	rootCmd.AddCommand(truncateCmd)
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestTruncateAllowed(t *testing.T) {
+	tests := []struct {
+		mode          string
+		allowTruncate bool
+		allowed       bool
+	}{
+		{"readonly", false, false},
+		{"standard", false, false},
+		{"", false, false},
+		{"privileged", false, true},
+		{"admin", false, true},
+		{"emergency", false, true},
+		{"standard", true, true},
+		{"", true, true},
+	}
+
+	for _, tt := range tests {
+		err := truncateAllowed(tt.mode, tt.allowTruncate)
+		if (err == nil) != tt.allowed {
+			t.Errorf("truncateAllowed(%q, %v) = %v, want allowed=%v", tt.mode, tt.allowTruncate, err, tt.allowed)
+		}
+	}
+}
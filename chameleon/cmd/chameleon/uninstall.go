@@ -54,7 +54,7 @@ func runUninstall(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	fmt.Println("🦎 ChameleonDB Uninstaller")
+	fmt.Println(sym("🦎 ChameleonDB Uninstaller"))
 	fmt.Println("──────────────────────────")
 	fmt.Println()
 
@@ -86,7 +86,7 @@ func runUninstall(cmd *cobra.Command, args []string) {
 
 	// Remove binary
 	if err := os.Remove(binPath); err != nil {
-		fmt.Println("❌ Cannot remove binary (permission denied)")
+		fmt.Println(sym("❌ Cannot remove binary (permission denied)"))
 		fmt.Println()
 		fmt.Println("ChameleonDB was installed system-wide.")
 		fmt.Println("Please re-run uninstall with elevated privileges:")
@@ -98,7 +98,7 @@ func runUninstall(cmd *cobra.Command, args []string) {
 	// Remove libraries
 	for _, lib := range libs {
 		if err := os.Remove(lib); err != nil {
-			fmt.Println("❌ Failed to remove library:", lib)
+			fmt.Println(sym("❌ Failed to remove library:"), lib)
 			fmt.Println("Try manually: sudo rm", lib)
 			return
 		}
@@ -106,7 +106,7 @@ func runUninstall(cmd *cobra.Command, args []string) {
 
 	if headerExists {
 		if err := os.Remove(headerPath); err != nil {
-			fmt.Println("❌ Failed to remove header:", headerPath)
+			fmt.Println(sym("❌ Failed to remove header:"), headerPath)
 			fmt.Println("Try manually: sudo rm", headerPath)
 			return
 		}
@@ -114,17 +114,17 @@ func runUninstall(cmd *cobra.Command, args []string) {
 
 	if pkgConfigExists {
 		if err := os.Remove(pkgConfigPath); err != nil {
-			fmt.Println("❌ Failed to remove pkg-config file:", pkgConfigPath)
+			fmt.Println(sym("❌ Failed to remove pkg-config file:"), pkgConfigPath)
 			fmt.Println("Try manually: sudo rm", pkgConfigPath)
 			return
 		}
 	}
 
 	fmt.Println()
-	fmt.Println("✔ ChameleonDB uninstalled successfully")
+	fmt.Println(sym("✔ ChameleonDB uninstalled successfully"))
 	fmt.Println()
-	fmt.Println("If you want to come back, we’ll be waiting 🦎")
-	fmt.Println("👉 curl -sSL https://chameleondb.dev/install | sh")
+	fmt.Println(sym("If you want to come back, we’ll be waiting 🦎"))
+	fmt.Println(sym("👉 curl -sSL https://chameleondb.dev/install | sh"))
 }
 
 func fileExists(path string) bool {
@@ -3,48 +3,90 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/chameleon-db/chameleondb/chameleon/internal/schema"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
 	"github.com/spf13/cobra"
 )
 
+// validateJSON prints the result in the JSON format of 'check --json'
+var validateJSON bool
+
 var validateCmd = &cobra.Command{
 	Use:   "validate [file]",
 	Short: "Validate a ChameleonDB schema",
 	Long: `Validate a schema file for syntax and semantic errors.
 
-If no file is specified, looks for 'schema.cham' in current directory.
+If no file is specified, looks for 'schema.cham' in the current directory.
+Use '-' to read from stdin; without it, stdin is never read, so CI jobs
+whose stdin is not a terminal still validate the file.
+
+With --json, prints the same result as 'chameleon check --json' and exits
+non-zero when the schema is invalid, for CI gates.
 
 Examples:
   chameleon validate
   chameleon validate schema.cham
-  chameleon validate path/to/schema.cham`,
+  chameleon validate path/to/schema.cham
+  cat schema.cham | chameleon validate -
+  chameleon validate - < schema.cham
+  chameleon validate --json schema.cham`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		eng := engine.NewEngineForCLI()
 
-		// Determine schema file
-		schemaFile := "schema.cham"
-		if len(args) > 0 {
-			schemaFile = args[0]
-		}
+		// Determine schema file, or read from stdin ("-" only)
+		var content []byte
+		schemaFile := schemaSource(args, "schema.cham", false)
+		if schemaFile == stdinSource {
+			if !validateJSON {
+				printInfo("Validating schema from stdin...")
+			}
+
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return validateReadError(cmd.OutOrStdout(), "schema.cham", fmt.Errorf("failed to read stdin: %w", err))
+			}
+			content = data
+		} else {
+			// Check file exists
+			if _, err := os.Stat(schemaFile); os.IsNotExist(err) {
+				return validateReadError(cmd.OutOrStdout(), schemaFile, fmt.Errorf("schema file not found: %s", schemaFile))
+			}
 
-		// Check file exists
-		if _, err := os.Stat(schemaFile); os.IsNotExist(err) {
-			return fmt.Errorf("schema file not found: %s", schemaFile)
+			if !validateJSON {
+				printInfo("Validating %s...", schemaFile)
+			}
+
+			// Read file content
+			data, err := os.ReadFile(schemaFile)
+			if err != nil {
+				return validateReadError(cmd.OutOrStdout(), schemaFile, fmt.Errorf("failed to read file: %w", err))
+			}
+			content = data
 		}
 
-		printInfo("Validating %s...", schemaFile)
+		filename := schemaFile
+		if filename == stdinSource {
+			filename = "schema.cham"
+		}
 
-		// Read file content
-		content, err := os.ReadFile(schemaFile)
+		// Merge the files it imports, then validate using LoadSchemaFromStringRaw
+		merged, err := schema.MergeWithImports(filename, string(content), importDir(schemaFile))
 		if err != nil {
-			return fmt.Errorf("failed to read file: %w", err)
+			return validateReadError(cmd.OutOrStdout(), filename, fmt.Errorf("failed to resolve imports: %w", err))
 		}
+		_, rawErr, err := eng.LoadSchemaFromStringRaw(merged.Content)
 
-		// Validate using LoadSchemaFromStringRaw
-		_, rawErr, err := eng.LoadSchemaFromStringRaw(string(content))
+		if validateJSON {
+			result := CheckResult{Valid: true, Errors: []CheckError{}}
+			if err != nil {
+				result = mapCheckErrors(checkResultFromRaw(filename, rawErr), merged.LineMap)
+			}
+			return writeValidateJSON(cmd.OutOrStdout(), result)
+		}
 
 		if err != nil {
 			printError("Validation failed")
@@ -55,6 +97,7 @@ Examples:
 				Valid  bool `json:"valid"`
 				Errors []struct {
 					Message    string  `json:"message"`
+					Line       *int    `json:"line"`
 					Snippet    *string `json:"snippet"`
 					Suggestion *string `json:"suggestion"`
 				} `json:"errors"`
@@ -63,6 +106,11 @@ Examples:
 			if jsonErr := json.Unmarshal([]byte(rawErr), &validationResult); jsonErr == nil {
 				for _, errItem := range validationResult.Errors {
 					fmt.Println(errItem.Message)
+					if errItem.Line != nil {
+						if source, ok := merged.LineMap[*errItem.Line]; ok {
+							fmt.Printf("  --> %s:%d\n", source.File, source.LineNumber)
+						}
+					}
 					if errItem.Snippet != nil {
 						fmt.Println(*errItem.Snippet)
 					}
@@ -74,6 +122,9 @@ Examples:
 				// Fallback: try to format as old error format
 				formatted := engine.FormatError(rawErr)
 				fmt.Println(formatted)
+				if sourceInfo := tryMapErrorToSource(rawErr, merged.LineMap); sourceInfo != "" {
+					fmt.Println(sourceInfo)
+				}
 			}
 
 			return fmt.Errorf("schema validation failed")
@@ -82,16 +133,45 @@ Examples:
 		printSuccess("Schema is valid")
 		if verbose {
 			fmt.Println("\nValidation checks passed:")
-			fmt.Println("  ✓ Syntax is correct")
-			fmt.Println("  ✓ All entity references exist")
-			fmt.Println("  ✓ Foreign keys are consistent")
-			fmt.Println("  ✓ Primary keys are defined")
-			fmt.Println("  ✓ No circular dependencies")
+			fmt.Println(sym("  ✓ Syntax is correct"))
+			fmt.Println(sym("  ✓ All entity references exist"))
+			fmt.Println(sym("  ✓ Foreign keys are consistent"))
+			fmt.Println(sym("  ✓ Primary keys are defined"))
+			fmt.Println(sym("  ✓ No circular dependencies"))
 		}
 		return nil
 	},
 }
 
 func init() {
+	validateCmd.Flags().BoolVar(&validateJSON, "json", false, "output the result as JSON (same format as 'check --json')")
 	rootCmd.AddCommand(validateCmd)
 }
+
+// writeValidateJSON prints result as JSON. Unlike 'check --json', which
+// editors run on every keystroke, an invalid schema is an error, so CI
+// gates fail on the exit status.
+func writeValidateJSON(w io.Writer, result CheckResult) error {
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode result as JSON: %w", err)
+	}
+	fmt.Fprintln(w, string(output))
+	if !result.Valid {
+		return fmt.Errorf("schema validation failed")
+	}
+	return nil
+}
+
+// validateReadError reports a schema that could not be read, as a JSON
+// result with --json
+func validateReadError(w io.Writer, filename string, err error) error {
+	if !validateJSON {
+		return err
+	}
+	writeValidateJSON(w, CheckResult{
+		Valid:  false,
+		Errors: []CheckError{{Message: err.Error(), Line: 1, Column: 1, File: filename, Severity: "error"}},
+	})
+	return err
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteValidateJSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeValidateJSON(&out, CheckResult{Valid: true, Errors: []CheckError{}}); err != nil {
+		t.Fatalf("a valid schema should not fail: %v", err)
+	}
+	if got := out.String(); got != "{\n  \"valid\": true,\n  \"errors\": []\n}\n" {
+		t.Errorf("unexpected output for a valid schema: %q", got)
+	}
+
+	out.Reset()
+	invalid := checkResultFromRaw("schema.cham", `{"valid":false,"errors":[{"kind":"parse","message":"Unexpected token","line":3,"column":7}]}`)
+	if err := writeValidateJSON(&out, invalid); err == nil {
+		t.Error("an invalid schema should fail for CI gates")
+	}
+
+	var result CheckResult
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if result.Valid || len(result.Errors) != 1 || result.Errors[0].Line != 3 || result.Errors[0].File != "schema.cham" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestValidateReadErrorJSON(t *testing.T) {
+	validateJSON = true
+	defer func() { validateJSON = false }()
+
+	var out bytes.Buffer
+	err := validateReadError(&out, "missing.cham", errors.New("schema file not found: missing.cham"))
+	if err == nil {
+		t.Fatal("a read error should still fail")
+	}
+
+	var result CheckResult
+	if jsonErr := json.Unmarshal(out.Bytes(), &result); jsonErr != nil || result.Valid || result.Errors[0].File != "missing.cham" {
+		t.Errorf("expected a JSON error for missing.cham, got %q", out.String())
+	}
+}
+
+func TestValidateIgnoresPipedStdinWithoutDash(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	// A CI job's stdin is a pipe even when nothing is piped in
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	err = validateCmd.RunE(validateCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "schema file not found: schema.cham") {
+		t.Errorf("expected validate to look for schema.cham, got %v", err)
+	}
+}
+
+func TestValidateResolvesImports(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll("entities", 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"schema.cham":        "import \"entities/user.cham\"\n\nentity Post {\n    id: uuid primary,\n    author_id: uuid,\n    author: User,\n}\n",
+		"entities/user.cham": "entity User {\n    id: uuid primary,\n    posts: [Post] via author_id,\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := validateCmd.RunE(validateCmd, []string{"schema.cham"}); err != nil {
+		t.Errorf("a schema split with imports should validate, got %v", err)
+	}
+}
+
+func TestValidateReportsMissingImport(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.cham")
+	if err := os.WriteFile(schemaPath, []byte("import \"missing.cham\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	validateJSON = true
+	defer func() { validateJSON = false }()
+
+	var out bytes.Buffer
+	validateCmd.SetOut(&out)
+	defer validateCmd.SetOut(nil)
+	err := validateCmd.RunE(validateCmd, []string{schemaPath})
+	if err == nil || !strings.Contains(err.Error(), `import "missing.cham" not found`) {
+		t.Fatalf("expected a missing import error, got %v", err)
+	}
+
+	var result CheckResult
+	if jsonErr := json.Unmarshal(out.Bytes(), &result); jsonErr != nil || result.Valid || result.Errors[0].File != schemaPath {
+		t.Errorf("expected a JSON error for %s, got %q", schemaPath, out.String())
+	}
+}
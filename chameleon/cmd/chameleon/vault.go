@@ -0,0 +1,302 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
+	"github.com/chameleon-db/chameleondb/chameleon/internal/schema"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
+	"github.com/spf13/cobra"
+)
+
+var vaultCmd = &cobra.Command{
+	Use:   "vault <subcommand>",
+	Short: "Manage schema version branches",
+	Long: `Work with parallel lineages of schema versions.
+
+Teams on feature branches register versions on separate vault branches
+and merge them back, instead of forcing a single linear history.
+
+Subcommands:
+  vault branch [name]     List branches, or create one at the current version
+  vault checkout <name>   Register the next versions on another branch
+  vault merge <branch>    Merge a branch's schema changes into the current one`,
+	Args: cobra.MinimumNArgs(1),
+}
+
+var vaultBranchCmd = &cobra.Command{
+	Use:   "branch [name]",
+	Short: "List or create vault branches",
+	Long: `Without arguments, list the branches and their head versions.
+With a name, start a branch at the current version and switch to it.
+
+Examples:
+  chameleon vault branch
+  chameleon vault branch feature/billing`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		v, err := openVault()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			if err := v.CreateBranch(args[0]); err != nil {
+				return err
+			}
+			printSuccess("Created branch %s at %s", args[0], v.Manifest.CurrentVersion)
+			return nil
+		}
+
+		names, heads, err := v.Branches()
+		if err != nil {
+			return err
+		}
+		current := v.Manifest.CurrentBranch()
+		for _, name := range names {
+			marker := " "
+			if name == current {
+				marker = "*"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %-24s %s\n", marker, name, heads[name])
+		}
+		return nil
+	},
+}
+
+var vaultCheckoutCmd = &cobra.Command{
+	Use:   "checkout <name>",
+	Short: "Switch the current vault branch",
+	Long: `Make a branch current: its head becomes the current version and
+the next 'chameleon migrate' registers on it.
+
+Switching vault branches does not touch your .cham files; check out the
+matching Git branch as well.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		v, err := openVault()
+		if err != nil {
+			return err
+		}
+		if err := v.SwitchBranch(args[0]); err != nil {
+			return err
+		}
+		printSuccess("Switched to branch %s (%s)", args[0], v.Manifest.CurrentVersion)
+		return nil
+	},
+}
+
+var vaultMergeCmd = &cobra.Command{
+	Use:   "merge <branch>",
+	Short: "Merge another branch into the current one",
+	Long: `Reconcile the schema of another branch with the current one.
+
+Changes made on only one side since the branches diverged are combined.
+Entities, fields or relations changed on both sides in different ways are
+reported as conflicts and nothing is registered; resolve them in your .cham
+files and run 'chameleon migrate'.
+
+A clean merge writes the merged schema back into your .cham files and
+registers it as a version with both heads as parents; 'chameleon migrate'
+then applies it. Each entity stays in the file that declares it and new
+entities go to the first file; plain // comments are not kept. When the
+current branch has no changes of its own, it simply moves to the other
+branch's head.
+
+Examples:
+  chameleon vault merge feature/billing`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		branch := args[0]
+		v, err := openVault()
+		if err != nil {
+			return err
+		}
+		mode, err := v.GetParanoidMode()
+		if err != nil {
+			return fmt.Errorf("failed to read paranoid mode: %w", err)
+		}
+		if mode == "readonly" {
+			return fmt.Errorf("readonly mode: schema locked")
+		}
+
+		moved, err := v.FastForward(branch)
+		if err != nil {
+			return err
+		}
+		if moved {
+			printSuccess("Fast-forwarded %s to %s", v.Manifest.CurrentBranch(), v.Manifest.CurrentVersion)
+			return nil
+		}
+
+		_, heads, err := v.Branches()
+		if err != nil {
+			return err
+		}
+		ours, theirs := v.Manifest.CurrentVersion, heads[branch]
+		if ancestors, err := v.Ancestors(ours); err != nil {
+			return err
+		} else if ancestors[theirs] {
+			printInfo("Already up to date with %s", branch)
+			return nil
+		}
+
+		merged, conflicts, err := mergeVersions(v, ours, theirs)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			printError("Cannot merge %s (%s) into %s (%s):", branch, theirs, v.Manifest.CurrentBranch(), ours)
+			for _, conflict := range conflicts {
+				fmt.Fprintf(cmd.OutOrStdout(), "  ! %s\n", conflict)
+			}
+			return fmt.Errorf("%d merge conflict(s); resolve them in your .cham files and run 'chameleon migrate'", len(conflicts))
+		}
+
+		workDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+		cfg, _ := config.NewLoader(workDir).Load()
+		files, err := writeMergedSources(cfg, merged)
+		if err != nil {
+			return err
+		}
+
+		// Register the schema as migrate will load it from the sources, so
+		// migrate finds no new changes and applies the merge version
+		_, mergedResult, err := loadMigrationSchema(cfg)
+		if err != nil {
+			return err
+		}
+		schemaPath, err := saveMergedSchema(workDir, cfg, mergedResult.Content)
+		if err != nil {
+			return err
+		}
+
+		author := os.Getenv("USER")
+		if author == "" {
+			author = "unknown"
+		}
+		entry, err := v.RegisterMerge(schemaPath, author, branch)
+		if err != nil {
+			return fmt.Errorf("failed to register merge: %w", err)
+		}
+		printSuccess("Merged %s into %s as %s", branch, v.Manifest.CurrentBranch(), entry.Version)
+		printInfo("Updated %s", strings.Join(files, ", "))
+		printInfo("Run 'chameleon migrate' to apply the merged schema")
+		return nil
+	},
+}
+
+// mergeVersions three-way merges two vault versions from their merge base
+func mergeVersions(v *vault.Vault, ours, theirs string) (*engine.Schema, []engine.MergeConflict, error) {
+	base, err := v.MergeBase(ours, theirs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var baseSchema *engine.Schema
+	if base != "" {
+		if baseSchema, err = engine.LoadVersionSchema(v, base); err != nil {
+			return nil, nil, err
+		}
+	}
+	oursSchema, err := engine.LoadVersionSchema(v, ours)
+	if err != nil {
+		return nil, nil, err
+	}
+	theirsSchema, err := engine.LoadVersionSchema(v, theirs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged, conflicts := engine.MergeSchemas(baseSchema, oursSchema, theirsSchema)
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+	return merged, nil, nil
+}
+
+// writeMergedSources writes a merged schema back into the .cham sources
+// (or the --schema-file) and returns the files written. Entities go to the
+// file that declares them, new ones to the first file, and each file keeps
+// its import directives.
+func writeMergedSources(cfg *config.Config, merged *engine.Schema) ([]string, error) {
+	if schemaOverride != "" {
+		source, err := merged.ToCham()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize merged schema: %w", err)
+		}
+		if err := os.WriteFile(schemaOverride, []byte(source), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write merged schema: %w", err)
+		}
+		return []string{schemaOverride}, nil
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("no .chameleon.yml found: cannot locate the schema files to write the merge into")
+	}
+
+	files, err := schema.NewFileLoader(cfg.Schema.Paths).Files()
+	if err != nil {
+		return nil, err
+	}
+	contents := make([]string, len(files))
+	owner := make(map[string]int)
+	for i, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		contents[i] = string(content)
+		for _, name := range schema.DeclaredEntities(contents[i]) {
+			owner[name] = i
+		}
+	}
+
+	parts := make([]engine.Schema, len(files))
+	for _, entity := range merged.Entities {
+		i := owner[entity.Name] // 0 for entities only the other branch has
+		parts[i].Entities = append(parts[i].Entities, entity)
+	}
+
+	for i, file := range files {
+		source, err := parts[i].ToCham()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize merged schema: %w", err)
+		}
+		if imports := schema.ImportDirectives(contents[i]); len(imports) > 0 {
+			source = strings.Join(imports, "\n") + "\n\n" + source
+		}
+		if err := os.WriteFile(file, []byte(source), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write merged schema: %w", err)
+		}
+	}
+	return files, nil
+}
+
+// openVault loads the vault of the working directory
+func openVault() (*vault.Vault, error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	v := vault.NewVault(workDir)
+	if !v.Exists() {
+		return nil, fmt.Errorf("no vault found; run 'chameleon migrate' first")
+	}
+	if err := v.Load(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultBranchCmd)
+	vaultCmd.AddCommand(vaultCheckoutCmd)
+	vaultCmd.AddCommand(vaultMergeCmd)
+	rootCmd.AddCommand(vaultCmd)
+}
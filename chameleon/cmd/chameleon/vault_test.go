@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
+)
+
+func TestVaultMergeWritesSchemaSources(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	yml := "schema:\n  paths: [schemas]\ndatabase:\n  driver: postgresql\n  connection_string: postgresql://app@db.internal:5432/app\n"
+	if err := os.WriteFile(filepath.Join(dir, ".chameleon.yml"), []byte(yml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "schemas"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	userFile := filepath.Join(dir, "schemas", "user.cham")
+	postFile := filepath.Join(dir, "schemas", "post.cham")
+	if err := os.WriteFile(userFile, []byte("entity User {\n    id: uuid primary,\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(postFile, []byte("import \"user.cham\"\n\nentity Post {\n    id: uuid primary,\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Versions are registered as schema snapshots so they load without the parser
+	v := vault.NewVault(dir)
+	register := func(userFields, postFields []string) {
+		t.Helper()
+		fields := func(names []string) map[string]*engine.Field {
+			out := map[string]*engine.Field{"id": {Name: "id", Type: engine.FieldTypeUUID, PrimaryKey: true}}
+			for _, name := range names {
+				out[name] = &engine.Field{Name: name, Type: engine.FieldTypeString}
+			}
+			return out
+		}
+		data, err := vault.SerializeSchema(&engine.Schema{Entities: []*engine.Entity{
+			{Name: "User", Fields: fields(userFields)},
+			{Name: "Post", Fields: fields(postFields)},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		snapshot := filepath.Join(t.TempDir(), "schema.json")
+		if err := os.WriteFile(snapshot, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := v.RegisterVersion(snapshot, "test", "snapshot"); err != nil {
+			t.Fatalf("RegisterVersion() error = %v", err)
+		}
+	}
+
+	register(nil, nil)
+	if err := v.SetParanoidMode("standard"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.CreateBranch("feature"); err != nil {
+		t.Fatal(err)
+	}
+	register([]string{"name"}, nil)
+	if err := v.SwitchBranch(vault.DefaultBranch); err != nil {
+		t.Fatal(err)
+	}
+	register(nil, []string{"title"})
+	ours := v.Manifest.CurrentVersion
+
+	if err := vaultMergeCmd.RunE(vaultMergeCmd, []string{"feature"}); err != nil {
+		t.Fatalf("vault merge error = %v", err)
+	}
+
+	user, err := os.ReadFile(userFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(user), "entity User") || !strings.Contains(string(user), "name:") {
+		t.Errorf("user.cham lacks the feature branch field:\n%s", user)
+	}
+	if strings.Contains(string(user), "entity Post") {
+		t.Errorf("Post was written into user.cham:\n%s", user)
+	}
+	post, err := os.ReadFile(postFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(post), "import \"user.cham\"\n") || !strings.Contains(string(post), "title:") {
+		t.Errorf("post.cham lost its import or the main branch field:\n%s", post)
+	}
+
+	// migrate loads the same sources: no new changes, the merge version pending
+	if err := v.Load(); err != nil {
+		t.Fatal(err)
+	}
+	merge, err := v.GetCurrentVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merge.Version == ours || merge.MergeParent == nil {
+		t.Fatalf("current version %s is not a merge version", merge.Version)
+	}
+
+	cfg, err := config.NewLoader(dir).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, mergedResult, err := loadMigrationSchema(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaPath, err := saveMergedSchema(dir, cfg, mergedResult.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, _, err := v.DetectChanges(schemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Errorf("migrate would register the sources as a new version instead of applying %s", merge.Version)
+	}
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
@@ -31,95 +30,84 @@ func runVerify(cmd *cobra.Command, args []string) {
 	v := vault.NewVault(".")
 
 	if !v.Exists() {
-		fmt.Println("❌ No vault found")
+		fmt.Println(sym("❌ No vault found"))
 		fmt.Println("   Run 'chameleon migrate' to initialize")
 		os.Exit(1)
 	}
 
-	fmt.Println("🔍 Running Integrity Verification...")
-	fmt.Println()
-
-	// Load manifest
-	fmt.Print("Vault:")
-	if err := v.Load(); err != nil {
-		fmt.Printf(" ❌\n")
-		fmt.Printf("   Failed to load manifest: %v\n", err)
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf(sym("❌ Failed to get working directory: %v\n"), err)
 		os.Exit(1)
 	}
-	fmt.Println()
+	cfg, _ := config.NewLoader(workDir).Load()
 
-	// Verify manifest is valid JSON
-	fmt.Print("  ✓ manifest.json is valid\n")
+	fmt.Println(sym("🔍 Running Integrity Verification..."))
+	fmt.Println()
 
-	// Verify each version
-	result, err := v.VerifyIntegrity()
+	report, err := v.FullVerify(mergedSchemaPath(workDir, cfg))
 	if err != nil {
-		fmt.Printf("❌ Verification failed: %v\n", err)
+		fmt.Printf(sym("❌ Verification failed: %v\n"), err)
 		os.Exit(1)
 	}
 
-	for _, version := range result.VersionsOK {
-		fmt.Printf("  ✓ %s integrity OK\n", version)
-	}
-
-	for i, version := range result.VersionsFail {
-		fmt.Printf("  ❌ %s integrity FAILED\n", version)
-		if i < len(result.Issues) {
-			fmt.Printf("     %s\n", result.Issues[i])
-		}
+	printVerifyReport(report)
+	if !report.Valid {
+		os.Exit(1)
 	}
+}
 
-	if len(result.VersionsFail) == 0 {
-		fmt.Println("  ✓ No tampering detected")
+// printVerifyReport formats a vault.FullVerify report
+func printVerifyReport(report *vault.VerifyReport) {
+	fmt.Print("Vault:")
+	if report.ManifestError != "" {
+		fmt.Print(sym(" ❌\n"))
+		fmt.Printf("   Failed to load manifest: %s\n", report.ManifestError)
+		return
 	}
-
 	fmt.Println()
+	fmt.Print(sym("  ✓ manifest.json is valid\n"))
 
-	// Verify schema files
-	fmt.Println("Schema Files:")
-	workDir, err := os.Getwd()
-	if err != nil {
-		fmt.Printf("❌ Failed to get working directory: %v\n", err)
-		os.Exit(1)
-	}
-
-	schemaPath := filepath.Join(workDir, ".chameleon", "state", "schema.merged.cham")
-	loader := config.NewLoader(workDir)
-	if cfg, loadErr := loader.Load(); loadErr == nil && cfg.Schema.MergedOutput != "" {
-		schemaPath = cfg.Schema.MergedOutput
+	for _, check := range report.Versions {
+		if check.OK {
+			fmt.Printf(sym("  ✓ %s integrity OK\n"), check.Version)
+		}
 	}
-
-	if _, err := os.Stat(schemaPath); err != nil {
-		fmt.Println("  ⚠️  schema *.cham not found")
-	} else {
-		fmt.Println("  ✓ schema *.cham exists")
-
-		// Check if matches current version
-		if v.Manifest.CurrentVersion != "" {
-			current, _ := v.GetCurrentVersion()
-			currentHash, _ := v.ComputeSchemaHash(schemaPath)
-
-			if current != nil && currentHash == current.Hash {
-				fmt.Printf("  ✓ Matches %s hash\n", v.Manifest.CurrentVersion)
-			} else {
-				fmt.Printf("  ⚠️  Modified (not matching %s)\n", v.Manifest.CurrentVersion)
-			}
+	for _, check := range report.Versions {
+		if !check.OK {
+			fmt.Printf(sym("  ❌ %s integrity FAILED\n"), check.Version)
+			fmt.Printf("     %s: %s\n", check.Version, check.Issue)
 		}
 	}
+	if report.Valid {
+		fmt.Println(sym("  ✓ No tampering detected"))
+	}
+	fmt.Println()
 
+	fmt.Println("Schema Files:")
+	switch report.SchemaFile {
+	case vault.SchemaFileMissing:
+		fmt.Println(sym("  ⚠️  schema *.cham not found"))
+	case vault.SchemaFileMatches:
+		fmt.Println(sym("  ✓ schema *.cham exists"))
+		fmt.Printf(sym("  ✓ Matches %s hash\n"), report.CurrentVersion)
+	case vault.SchemaFileModified:
+		fmt.Println(sym("  ✓ schema *.cham exists"))
+		fmt.Printf(sym("  ⚠️  Modified (not matching %s)\n"), report.CurrentVersion)
+	default:
+		fmt.Println(sym("  ✓ schema *.cham exists"))
+	}
 	fmt.Println()
 
 	// Summary
-	if result.Valid {
-		fmt.Println("✅ All checks passed")
-		os.Exit(0)
-	} else {
-		fmt.Printf("❌ %d integrity issues found\n", len(result.Issues))
-		fmt.Println()
-		fmt.Println("🔧 Recovery options:")
-		fmt.Println("   • Check integrity.log for audit trail")
-		fmt.Println("   • Review recent changes to vault files")
-		fmt.Println("   • Contact your DBA if tampering is suspected")
-		os.Exit(1)
+	if report.Valid {
+		fmt.Println(sym("✅ All checks passed"))
+		return
 	}
+	fmt.Printf(sym("❌ %d integrity issues found\n"), len(report.Issues))
+	fmt.Println()
+	fmt.Println(sym("🔧 Recovery options:"))
+	fmt.Println("   • Check integrity.log for audit trail")
+	fmt.Println("   • Review recent changes to vault files")
+	fmt.Println("   • Contact your DBA if tampering is suspected")
 }
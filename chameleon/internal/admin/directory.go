@@ -93,8 +93,9 @@ type DirectoryPaths struct {
 
 // ManagerFactory creates and initializes all managers
 type ManagerFactory struct {
-	workDir string
-	dir     *Directory
+	workDir      string
+	dir          *Directory
+	journalLevel *journal.Level // overrides journal.level from .chameleon.yml
 }
 
 // NewManagerFactory creates a new manager factory
@@ -120,10 +121,28 @@ func (mf *ManagerFactory) CreateConfigLoader() *config.Loader {
 	return config.NewLoader(mf.workDir)
 }
 
-// CreateJournalLogger creates a journal logger
+// SetJournalLevel overrides the journal level configured in .chameleon.yml
+func (mf *ManagerFactory) SetJournalLevel(level journal.Level) {
+	mf.journalLevel = &level
+}
+
+// CreateJournalLogger creates a journal logger at the configured level
 func (mf *ManagerFactory) CreateJournalLogger() (*journal.Logger, error) {
 	paths := mf.dir.GetPaths()
-	return journal.NewLogger(paths.Journal)
+	logger, err := journal.NewLogger(paths.Journal)
+	if err != nil {
+		return nil, err
+	}
+
+	if mf.journalLevel != nil {
+		logger.SetLevel(*mf.journalLevel)
+	} else if cfg, err := mf.CreateConfigLoader().Load(); err == nil {
+		// Load validates journal.level, so parsing cannot fail here
+		if level, err := journal.ParseLevel(cfg.Journal.Level); err == nil {
+			logger.SetLevel(level)
+		}
+	}
+	return logger, nil
 }
 
 // CreateStateTracker creates a state tracker
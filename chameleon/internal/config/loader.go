@@ -147,6 +147,9 @@ database:
   max_connections: 10
   connection_timeout: 30  # seconds
   migration_timeout: 300  # seconds
+  # Default timeouts for queries and mutations whose context has no deadline
+  # query_timeout: 30     # seconds
+  # mutation_timeout: 60  # seconds
 
 # Schema management
 schema:
@@ -187,5 +190,31 @@ safety:
   
   # Validate schema before applying
   validate_schema: true
+
+  # Allow 'chameleon truncate' outside privileged mode (test databases only)
+  allow_truncate: false
+
+# Operation journal (.chameleon/journal/)
+journal:
+  # error: failures and migrations only
+  # info:  + completed operations
+  # debug: + intermediate steps
+  level: info
+
+# Query limits (0 = none)
+query:
+  # Limit applied to queries that don't set one
+  default_limit: 0
+  # Largest Limit a query may ask for
+  max_limit: 0
+  # true: lower larger limits to max_limit; false: fail the query
+  clamp_limit: false
+
+# chameleon introspect
+# introspect:
+#   # Types the default rules don't know (domains, extensions, enums)
+#   type_map:
+#     citext: string
+#     order_status: string
 `
 }
@@ -68,6 +68,11 @@ safety:
   require_confirmation: false
   backup_before_apply: true
   validate_schema: true
+
+query:
+  default_limit: 100
+  max_limit: 1000
+  clamp_limit: true
 `
 
 	err := os.WriteFile(configPath, []byte(configContent), 0644)
@@ -100,6 +105,10 @@ safety:
 	if !cfg.Safety.BackupBeforeApply {
 		t.Error("Expected backup_before_apply to be true")
 	}
+
+	if cfg.Query.DefaultLimit != 100 || cfg.Query.MaxLimit != 1000 || !cfg.Query.ClampLimit {
+		t.Errorf("Unexpected query limits: %+v", cfg.Query)
+	}
 }
 
 func TestLoad_InvalidYAML(t *testing.T) {
@@ -336,4 +345,8 @@ func TestTemplate(t *testing.T) {
 	if !strings.Contains(template, "safety:") {
 		t.Error("Template should contain safety section")
 	}
+
+	if !strings.Contains(template, "query:") {
+		t.Error("Template should contain query section")
+	}
 }
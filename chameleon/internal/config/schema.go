@@ -1,17 +1,21 @@
 package config
 
 import (
+	"fmt"
 	"time"
 )
 
 // Config represents the complete .chameleon.yml configuration
 type Config struct {
-	Version   string         `yaml:"version"`
-	CreatedAt time.Time      `yaml:"created_at"`
-	Database  DatabaseConfig `yaml:"database"`
-	Schema    SchemaConfig   `yaml:"schema"`
-	Features  FeaturesConfig `yaml:"features"`
-	Safety    SafetyConfig   `yaml:"safety"`
+	Version    string           `yaml:"version"`
+	CreatedAt  time.Time        `yaml:"created_at"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Schema     SchemaConfig     `yaml:"schema"`
+	Features   FeaturesConfig   `yaml:"features"`
+	Safety     SafetyConfig     `yaml:"safety"`
+	Journal    JournalConfig    `yaml:"journal,omitempty"`
+	Query      QueryConfig      `yaml:"query,omitempty"`
+	Introspect IntrospectConfig `yaml:"introspect,omitempty"`
 }
 
 // DatabaseConfig holds database connection settings
@@ -21,6 +25,8 @@ type DatabaseConfig struct {
 	MaxConnections    int    `yaml:"max_connections,omitempty"`
 	ConnectionTimeout int    `yaml:"connection_timeout,omitempty"` // seconds
 	MigrationTimeout  int    `yaml:"migration_timeout,omitempty"`  // seconds
+	QueryTimeout      int    `yaml:"query_timeout,omitempty"`      // seconds; queries without a deadline (0 = none)
+	MutationTimeout   int    `yaml:"mutation_timeout,omitempty"`   // seconds; mutations without a deadline (0 = none)
 }
 
 // SchemaConfig holds schema management settings
@@ -44,6 +50,25 @@ type SafetyConfig struct {
 	RequireConfirmation bool `yaml:"require_confirmation,omitempty"` // Ask before apply
 	BackupBeforeApply   bool `yaml:"backup_before_apply,omitempty"`  // Always backup
 	ValidateSchema      bool `yaml:"validate_schema,omitempty"`      // Validate before apply
+	AllowTruncate       bool `yaml:"allow_truncate,omitempty"`       // Test database: allow `chameleon truncate`
+	DriftCheck          bool `yaml:"drift_check,omitempty"`          // Warn on connect when the database lacks schema tables/columns
+}
+
+// JournalConfig holds operation journal settings
+type JournalConfig struct {
+	Level string `yaml:"level,omitempty"` // error, info (default) or debug
+}
+
+// QueryConfig holds query builder limits
+type QueryConfig struct {
+	DefaultLimit uint64 `yaml:"default_limit,omitempty"` // Limit for queries that set none (0 = none)
+	MaxLimit     uint64 `yaml:"max_limit,omitempty"`     // Cap on any Limit (0 = none)
+	ClampLimit   bool   `yaml:"clamp_limit,omitempty"`   // Lower limits above max_limit instead of failing
+}
+
+// IntrospectConfig holds `chameleon introspect` settings
+type IntrospectConfig struct {
+	TypeMap map[string]string `yaml:"type_map,omitempty"` // Database type -> ChameleonDB type, e.g. citext: string
 }
 
 // Defaults returns a Config with sensible defaults
@@ -75,6 +100,9 @@ func Defaults() *Config {
 			BackupBeforeApply:   true,
 			ValidateSchema:      true,
 		},
+		Journal: JournalConfig{
+			Level: "info",
+		},
 	}
 }
 
@@ -94,6 +122,37 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	switch c.Journal.Level {
+	case "", "error", "info", "debug":
+	default:
+		return &ConfigError{
+			Field:      "journal.level",
+			Reason:     fmt.Sprintf("Unknown journal level '%s'", c.Journal.Level),
+			Suggestion: "Use one of: error, info, debug",
+		}
+	}
+
+	for field, timeout := range map[string]int{
+		"database.query_timeout":    c.Database.QueryTimeout,
+		"database.mutation_timeout": c.Database.MutationTimeout,
+	} {
+		if timeout < 0 {
+			return &ConfigError{
+				Field:      field,
+				Reason:     fmt.Sprintf("Timeout cannot be negative (%d)", timeout),
+				Suggestion: "Use a number of seconds, or 0 for no timeout",
+			}
+		}
+	}
+
+	if c.Database.MaxConnections < 0 {
+		return &ConfigError{
+			Field:      "database.max_connections",
+			Reason:     fmt.Sprintf("Max connections cannot be negative (%d)", c.Database.MaxConnections),
+			Suggestion: "Use a positive pool size, or 0 for the default",
+		}
+	}
+
 	if c.Database.ConnectionTimeout < 1 {
 		c.Database.ConnectionTimeout = 30
 	}
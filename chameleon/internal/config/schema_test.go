@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -30,3 +31,39 @@ func TestConfigValidation(t *testing.T) {
 		t.Errorf("Expected valid config, got error: %v", err)
 	}
 }
+
+func TestConfigValidationJournalLevel(t *testing.T) {
+	cfg := Defaults()
+	cfg.Journal.Level = "verbose"
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for unknown journal level")
+	}
+
+	cfg.Journal.Level = "debug"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid config, got error: %v", err)
+	}
+}
+
+func TestConfigValidationTimeouts(t *testing.T) {
+	cfg := Defaults()
+	cfg.Database.MutationTimeout = -1
+
+	var cfgErr *ConfigError
+	if err := cfg.Validate(); !errors.As(err, &cfgErr) || cfgErr.Field != "database.mutation_timeout" {
+		t.Errorf("Expected a database.mutation_timeout error, got %v", err)
+	}
+
+	cfg.Database.MutationTimeout = 60
+	cfg.Database.QueryTimeout = 30
+	cfg.Database.MaxConnections = -1
+	if err := cfg.Validate(); !errors.As(err, &cfgErr) || cfgErr.Field != "database.max_connections" {
+		t.Errorf("Expected a database.max_connections error, got %v", err)
+	}
+
+	cfg.Database.MaxConnections = 20
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid config, got error: %v", err)
+	}
+}
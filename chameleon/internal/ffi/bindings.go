@@ -19,6 +19,7 @@ void chameleon_free_string(char* s);
 const char* chameleon_version(void);
 extern int chameleon_generate_sql(const char* query_json, const char* schema_json, char** error_out);
 extern int chameleon_generate_migration(const char* schema_json, char** error_out);
+extern int chameleon_generate_migration_plan(const char* schema_json, char** error_out);
 */
 import "C"
 import (
@@ -180,6 +181,34 @@ func GenerateMigration(schemaJSON string) (string, error) {
 	return output, nil
 }
 
+// GenerateMigrationPlan calls the Rust migration generator and returns the
+// statements as a JSON array
+func GenerateMigrationPlan(schemaJSON string) (string, error) {
+	cSchema := C.CString(schemaJSON)
+	defer C.free(unsafe.Pointer(cSchema))
+
+	var errorOut *C.char
+
+	result := C.chameleon_generate_migration_plan(cSchema, &errorOut)
+
+	if result != 0 {
+		if errorOut != nil {
+			errMsg := C.GoString(errorOut)
+			C.chameleon_free_string(errorOut)
+			return "", fmt.Errorf("%s", errMsg)
+		}
+		return "", fmt.Errorf("migration generation failed with code %d", result)
+	}
+
+	if errorOut == nil {
+		return "", fmt.Errorf("migration generation returned null")
+	}
+
+	output := C.GoString(errorOut)
+	C.chameleon_free_string(errorOut)
+	return output, nil
+}
+
 // GenerateMutationSQL calls Rust FFI to generate mutation SQL
 // If schemaJSON is "", uses cached schema from previous SetSchemaCache call
 func GenerateMutationSQL(mutationJSON string, schemaJSON string) string {
@@ -20,9 +20,72 @@ type Entry struct {
 	Duration  int64                  `json:"duration_ms,omitempty"`
 }
 
+// Level controls which entries are written to the journal
+type Level int
+
+const (
+	// LevelError records only errors, denials and migrations
+	LevelError Level = iota
+	// LevelInfo also records completed operations (default)
+	LevelInfo
+	// LevelDebug also records intermediate steps (started, scanned, ...)
+	LevelDebug
+)
+
+// ParseLevel parses "error", "info" or "debug"
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "error":
+		return LevelError, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "debug":
+		return LevelDebug, nil
+	}
+	return LevelInfo, fmt.Errorf("invalid journal level %q (expected error, info or debug)", s)
+}
+
+func (l Level) String() string {
+	switch l {
+	case LevelError:
+		return "error"
+	case LevelDebug:
+		return "debug"
+	}
+	return "info"
+}
+
+// debugStatuses are progress steps of a longer operation
+var debugStatuses = map[string]bool{
+	"started":            true,
+	"attempt":            true,
+	"mode_checked":       true,
+	"mode_check_skipped": true,
+	"database_detected":  true,
+	"tables_scanned":     true,
+	"views_scanned":      true,
+	"statement_applied":  true,
+}
+
+// entryLevel returns the lowest level at which an entry is recorded.
+// Failures and migrations are always recorded.
+func entryLevel(action, status string) Level {
+	if action == "migrate" {
+		return LevelError
+	}
+	switch {
+	case status == "error", status == "failed", status == "denied", strings.HasPrefix(status, "aborted"):
+		return LevelError
+	case debugStatuses[status]:
+		return LevelDebug
+	}
+	return LevelInfo
+}
+
 // Logger is an append-only journal logger
 type Logger struct {
 	journalDir string
+	level      Level
 	mu         sync.Mutex
 	indexMu    sync.Mutex
 }
@@ -36,11 +99,26 @@ func NewLogger(journalDir string) (*Logger, error) {
 
 	return &Logger{
 		journalDir: journalDir,
+		level:      LevelInfo,
 	}, nil
 }
 
-// Log appends an entry to the journal
+// SetLevel sets the verbosity of the journal
+func (l *Logger) SetLevel(level Level) {
+	l.level = level
+}
+
+// Level returns the verbosity of the journal
+func (l *Logger) Level() Level {
+	return l.level
+}
+
+// Log appends an entry to the journal, unless the logger's level filters it out
 func (l *Logger) Log(action, status string, details map[string]interface{}, err error) error {
+	if entryLevel(action, status) > l.level {
+		return nil
+	}
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
@@ -0,0 +1,54 @@
+package journal
+
+import (
+	"testing"
+)
+
+func TestLoggerLevelFiltersEntries(t *testing.T) {
+	tests := []struct {
+		level  Level
+		action string
+		status string
+		want   bool
+	}{
+		{LevelError, "introspect", "error", true},
+		{LevelError, "config_mode", "denied", true},
+		{LevelError, "introspect", "aborted_readonly", true},
+		{LevelError, "migrate", "dry_run", true},
+		{LevelError, "introspect", "completed", false},
+		{LevelInfo, "introspect", "completed", true},
+		{LevelInfo, "introspect", "started", false},
+		{LevelDebug, "introspect", "started", true},
+	}
+
+	for _, tt := range tests {
+		logger, err := NewLogger(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger.SetLevel(tt.level)
+
+		if err := logger.Log(tt.action, tt.status, nil, nil); err != nil {
+			t.Fatalf("Log() error = %v", err)
+		}
+		entries, err := logger.Last(10)
+		if err != nil {
+			t.Fatalf("Last() error = %v", err)
+		}
+		if got := len(entries) == 1; got != tt.want {
+			t.Errorf("level %s: %s/%s recorded = %v, want %v", tt.level, tt.action, tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for input, want := range map[string]Level{"error": LevelError, "INFO": LevelInfo, "": LevelInfo, "debug": LevelDebug} {
+		got, err := ParseLevel(input)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// importPattern reconoce una directiva `import "base.cham"` en su propia línea
+var importPattern = regexp.MustCompile(`^\s*import\s+"([^"]+)"\s*;?\s*(//.*)?$`)
+
+// parseImports devuelve los archivos que importa content, en orden de
+// aparición, y el contenido con cada directiva convertida en comentario.
+// Las líneas se conservan, así el LineMap sigue apuntando al original.
+func parseImports(content string) ([]string, string) {
+	lines := strings.Split(content, "\n")
+	var imports []string
+	for i, line := range lines {
+		match := importPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		imports = append(imports, match[1])
+		lines[i] = "// " + strings.TrimSpace(line)
+	}
+	return imports, strings.Join(lines, "\n")
+}
+
+// ImportDirectives devuelve las directivas import de content tal como
+// están escritas
+func ImportDirectives(content string) []string {
+	var directives []string
+	for _, line := range strings.Split(content, "\n") {
+		if importPattern.MatchString(line) {
+			directives = append(directives, strings.TrimSpace(line))
+		}
+	}
+	return directives
+}
+
+// declarationPattern reconoce una línea que declara una entidad
+var declarationPattern = regexp.MustCompile(`(?m)^\s*entity\s+([A-Za-z_][A-Za-z0-9_]*)\b`)
+
+// DeclaredEntities devuelve los nombres de las entidades que declara
+// content, en orden de aparición
+func DeclaredEntities(content string) []string {
+	var names []string
+	for _, match := range declarationPattern.FindAllStringSubmatch(content, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// MergeWithImports fusiona content, leído de filename, con los archivos que
+// importa, buscados recursivamente junto al archivo que los importa (dir
+// para content). Un archivo sin imports se devuelve tal cual. Como en
+// FileLoader, los imports se resuelven por basename; el LineMap apunta a las
+// rutas de los archivos.
+func MergeWithImports(filename, content, dir string) (*MergedSchemaResult, error) {
+	paths := map[string]string{filepath.Base(filename): filename}
+	names := []string{filepath.Base(filename)}
+	contents := []string{content}
+	dirs := []string{dir}
+
+	for i := 0; i < len(names); i++ {
+		targets, _ := parseImports(contents[i])
+		for _, target := range targets {
+			cleaned := path.Clean(strings.ReplaceAll(target, "\\", "/"))
+			name := path.Base(cleaned)
+			if _, seen := paths[name]; seen {
+				continue
+			}
+			file := filepath.Join(dirs[i], filepath.FromSlash(cleaned))
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("%s: import %q not found: %w", paths[names[i]], target, err)
+			}
+			paths[name] = file
+			names = append(names, name)
+			contents = append(contents, string(data))
+			dirs = append(dirs, filepath.Dir(file))
+		}
+	}
+	if len(names) == 1 {
+		return &MergedSchemaResult{Content: content}, nil
+	}
+
+	merged, err := NewSimpleMerger().Merge(names, contents)
+	if err != nil {
+		return nil, err
+	}
+	for line, source := range merged.LineMap {
+		source.File = paths[source.File]
+		merged.LineMap[line] = source
+	}
+	return merged, nil
+}
+
+// resolveImport busca el archivo importado entre los cargados, por nombre
+// o por basename (el loader guarda solo el basename)
+func resolveImport(target string, filenames []string) (int, bool) {
+	cleaned := path.Clean(strings.ReplaceAll(target, "\\", "/"))
+	for i, name := range filenames {
+		if name == cleaned || path.Base(cleaned) == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// orderByImports ordena los archivos para que cada uno vaya después de los
+// que importa. Los archivos sin relación entre sí mantienen el orden
+// recibido. Devuelve error si un import no existe o hay un ciclo.
+func orderByImports(filenames []string, contents []string) ([]string, []string, error) {
+	imports := make([][]int, len(filenames))
+	stripped := make([]string, len(filenames))
+	for i, content := range contents {
+		targets, rest := parseImports(content)
+		stripped[i] = rest
+		for _, target := range targets {
+			j, ok := resolveImport(target, filenames)
+			if !ok {
+				return nil, nil, fmt.Errorf("%s: import %q not found among the schema files %v", filenames[i], target, filenames)
+			}
+			imports[i] = append(imports[i], j)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(filenames))
+	var orderedNames, orderedContents []string
+	var stack []string
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			// El ciclo empieza donde el archivo aparece en la pila
+			start := 0
+			for k, name := range stack {
+				if name == filenames[i] {
+					start = k
+				}
+			}
+			cycle := append(append([]string{}, stack[start:]...), filenames[i])
+			return fmt.Errorf("import cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[i] = visiting
+		stack = append(stack, filenames[i])
+		for _, j := range imports[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = done
+
+		orderedNames = append(orderedNames, filenames[i])
+		orderedContents = append(orderedContents, stripped[i])
+		return nil
+	}
+
+	for i := range filenames {
+		if err := visit(i); err != nil {
+			return nil, nil, err
+		}
+	}
+	return orderedNames, orderedContents, nil
+}
@@ -56,11 +56,40 @@ func (fl *FileLoader) LoadAll() ([]string, []string, error) {
 		return nil, nil, fmt.Errorf("no schema files found in %v", fl.schemaPaths)
 	}
 
-	// Ordenar alfabéticamente para consistencia
-	sort.Strings(filenames)
-	// TODO: Reordenar contents según filenames ordenados
+	// Ordenar alfabéticamente para consistencia, moviendo cada contenido
+	// junto a su archivo (el orden de los imports depende de esto)
+	order := make([]int, len(filenames))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return filenames[order[a]] < filenames[order[b]] })
+
+	sortedNames := make([]string, len(order))
+	sortedContents := make([]string, len(order))
+	for i, j := range order {
+		sortedNames[i] = filenames[j]
+		sortedContents[i] = contents[j]
+	}
 
-	return filenames, contents, nil
+	return sortedNames, sortedContents, nil
+}
+
+// Files devuelve las rutas completas de los .cham de los schema paths, en
+// el mismo orden en que LoadAll devuelve sus contenidos
+func (fl *FileLoader) Files() ([]string, error) {
+	var files []string
+	for _, schemaPath := range fl.schemaPaths {
+		found, err := fl.findSchemaFiles(schemaPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find schema files in %s: %w", schemaPath, err)
+		}
+		files = append(files, found...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no schema files found in %v", fl.schemaPaths)
+	}
+	sort.SliceStable(files, func(a, b int) bool { return filepath.Base(files[a]) < filepath.Base(files[b]) })
+	return files, nil
 }
 
 // Load carga un archivo específico
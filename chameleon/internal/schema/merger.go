@@ -30,7 +30,9 @@ type SourceLine struct {
 // SimpleMerger implementa merge básico para v0.1 con source tracking
 type SimpleMerger struct{}
 
-// Merge concatena múltiples archivos de schema con source line tracking
+// Merge concatena múltiples archivos de schema con source line tracking.
+// Un archivo con `import "base.cham"` se escribe después de base.cham; el
+// resto mantiene el orden recibido (alfabético desde FileLoader).
 func (m *SimpleMerger) Merge(filenames []string, contents []string) (*MergedSchemaResult, error) {
 	if len(filenames) != len(contents) {
 		return nil, fmt.Errorf("filenames and contents length mismatch")
@@ -40,6 +42,11 @@ func (m *SimpleMerger) Merge(filenames []string, contents []string) (*MergedSche
 		return nil, fmt.Errorf("no schema files to merge")
 	}
 
+	filenames, contents, err := orderByImports(filenames, contents)
+	if err != nil {
+		return nil, err
+	}
+
 	var merged strings.Builder
 	lineMap := make(map[int]SourceLine)
 	currentMergedLine := 1
@@ -0,0 +1,126 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeOrdersByImports(t *testing.T) {
+	filenames := []string{"a_orders.cham", "base.cham", "users.cham"}
+	contents := []string{
+		"import \"users.cham\"\nentity Order {\n  id: uuid primary,\n}\n",
+		"entity Base {\n  id: uuid primary,\n}\n",
+		"import \"base.cham\"\n\nentity User {\n  id: uuid primary,\n}\n",
+	}
+
+	result, err := NewSimpleMerger().Merge(filenames, contents)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	var order []string
+	for _, line := range strings.Split(result.Content, "\n") {
+		if name, ok := strings.CutPrefix(line, "// From: "); ok {
+			order = append(order, name)
+		}
+	}
+	if want := []string{"base.cham", "users.cham", "a_orders.cham"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("merge order = %v, want %v", order, want)
+	}
+	if strings.Contains(result.Content, "\nimport ") {
+		t.Errorf("import directives should not reach the parser:\n%s", result.Content)
+	}
+
+	// The LineMap still points at the original files
+	for i, line := range strings.Split(result.Content, "\n") {
+		if strings.HasPrefix(line, "entity User") {
+			if source := result.LineMap[i+1]; source != (SourceLine{File: "users.cham", LineNumber: 3}) {
+				t.Errorf("entity User maps to %s:%d, want users.cham:3", source.File, source.LineNumber)
+			}
+		}
+	}
+}
+
+func TestMergeImportErrors(t *testing.T) {
+	merger := NewSimpleMerger()
+
+	_, err := merger.Merge(
+		[]string{"a.cham", "b.cham", "c.cham"},
+		[]string{`import "c.cham"`, `import "a.cham"`, `import "b.cham"`},
+	)
+	if err == nil || !strings.Contains(err.Error(), "a.cham -> c.cham -> b.cham -> a.cham") {
+		t.Errorf("expected the import cycle, got %v", err)
+	}
+
+	_, err = merger.Merge([]string{"a.cham"}, []string{`import "missing.cham"`})
+	if err == nil || !strings.Contains(err.Error(), `import "missing.cham" not found`) {
+		t.Errorf("expected an unknown import error, got %v", err)
+	}
+}
+
+func TestLoadAllKeepsContentsWithFilenames(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(first, "users.cham"), []byte("users"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(second, "base.cham"), []byte("base"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	filenames, contents, err := NewFileLoader([]string{first, second}).LoadAll()
+	if err != nil {
+		t.Fatalf("LoadAll() error = %v", err)
+	}
+	if !reflect.DeepEqual(filenames, []string{"base.cham", "users.cham"}) || !reflect.DeepEqual(contents, []string{"base", "users"}) {
+		t.Errorf("contents do not follow their files: %v %v", filenames, contents)
+	}
+}
+
+func TestMergeWithImports(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "entities"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"entities/user.cham": "import \"base.cham\"\n\nentity User {\n    id: uuid primary,\n}\n",
+		"entities/base.cham": "entity Account {\n    id: uuid primary,\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := filepath.Join(dir, "schema.cham")
+	result, err := MergeWithImports(root, "import \"entities/user.cham\"\n\nentity Post {\n    id: uuid primary,\n}\n", dir)
+	if err != nil {
+		t.Fatalf("MergeWithImports() error = %v", err)
+	}
+	if strings.Contains(result.Content, "\nimport ") {
+		t.Errorf("import directives should not reach the parser:\n%s", result.Content)
+	}
+	want := map[string]SourceLine{
+		"entity Account": {File: filepath.Join(dir, "entities", "base.cham"), LineNumber: 1},
+		"entity User":    {File: filepath.Join(dir, "entities", "user.cham"), LineNumber: 3},
+		"entity Post":    {File: root, LineNumber: 3},
+	}
+	for i, line := range strings.Split(result.Content, "\n") {
+		for prefix, source := range want {
+			if strings.HasPrefix(line, prefix) && result.LineMap[i+1] != source {
+				t.Errorf("%s maps to %+v, want %+v", prefix, result.LineMap[i+1], source)
+			}
+		}
+	}
+
+	plain, err := MergeWithImports(root, "entity Post {}", dir)
+	if err != nil || plain.Content != "entity Post {}" || plain.LineMap != nil {
+		t.Errorf("a schema without imports should be returned as is, got %+v, %v", plain, err)
+	}
+
+	if _, err := MergeWithImports(root, `import "missing.cham"`, dir); err == nil || !strings.Contains(err.Error(), `import "missing.cham" not found`) {
+		t.Errorf("expected a missing import error, got %v", err)
+	}
+}
@@ -213,6 +213,23 @@ func (t *Tracker) GetLastMigration() (*Migration, error) {
 	return nil, nil
 }
 
+// GetMigration returns the latest record for a version, whatever its
+// status, or nil if the version was never attempted
+func (t *Tracker) GetMigration(version string) (*Migration, error) {
+	manifest, err := t.LoadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(manifest.Migrations) - 1; i >= 0; i-- {
+		if manifest.Migrations[i].Version == version {
+			return manifest.Migrations[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
 // HashSchema computes SHA256 hash of schema
 func HashSchema(schema string) string {
 	hash := sha256.Sum256([]byte(schema))
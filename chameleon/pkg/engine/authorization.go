@@ -0,0 +1,51 @@
+package engine
+
+import "context"
+
+// Operation names passed to an Authorizer
+const (
+	OperationSelect   = "SELECT"
+	OperationInsert   = "INSERT"
+	OperationUpdate   = "UPDATE"
+	OperationDelete   = "DELETE"
+	OperationTruncate = "TRUNCATE"
+)
+
+// Authorizer decides whether an operation on an entity may run.
+// Returning a non-nil error denies the operation; the error message is
+// surfaced to the caller inside an AuthorizationError.
+//
+// Example:
+//
+//	eng.WithAuthorizer(func(op, entity string, ctx context.Context) error {
+//		if op != engine.OperationSelect && entity == "AuditLog" {
+//			return fmt.Errorf("audit log is append-only")
+//		}
+//		return nil
+//	})
+type Authorizer func(op string, entity string, ctx context.Context) error
+
+// Check runs the authorizer, if any, and converts a denial into an
+// AuthorizationError. A nil Authorizer allows everything.
+func (a Authorizer) Check(ctx context.Context, op string, entity string) error {
+	if a == nil {
+		return nil
+	}
+
+	if err := a(op, entity, ctx); err != nil {
+		return &AuthorizationError{
+			Operation: op,
+			Entity:    entity,
+			Message:   err.Error(),
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+// WithAuthorizer installs a policy hook consulted before every query
+// and mutation. Pass nil to remove it.
+func (e *Engine) WithAuthorizer(fn Authorizer) *Engine {
+	e.authorizer = fn
+	return e
+}
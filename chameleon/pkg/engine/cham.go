@@ -0,0 +1,356 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ToCham renders the schema back into .cham source in the canonical style
+// used by `chameleon fmt`:
+//
+//   - entities keep their schema order
+//   - fields in their declared order (FieldOrder); without one, primary
+//     key fields first, then the remaining fields by name
+//   - relations after fields, by name, then table-level checks
+//   - field types aligned within each entity
+//
+// Descriptions are emitted as `///` doc comments; plain `//` comments are
+// not part of the schema and are not emitted.
+func (s *Schema) ToCham() (string, error) {
+	var sb strings.Builder
+	for i, entity := range s.Entities {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if err := writeChamEntity(&sb, entity); err != nil {
+			return "", err
+		}
+	}
+	return sb.String(), nil
+}
+
+// chamLine is one `name: definition,` line before alignment
+type chamLine struct {
+	name        string
+	definition  string
+	description string
+}
+
+func writeChamEntity(sb *strings.Builder, entity *Entity) error {
+	writeChamDoc(sb, "", entity.Description)
+	sb.WriteString("entity " + entity.Name)
+	if entity.View {
+		sb.WriteString(" @view")
+	}
+	if entity.ReadOnly {
+		sb.WriteString(" @readonly")
+	}
+	if entity.Schema != "" {
+		sb.WriteString(" @schema(" + chamString(entity.Schema) + ")")
+	}
+	if entity.Tenant != "" {
+		sb.WriteString(" @tenant(" + entity.Tenant + ")")
+	}
+	if entity.Timestamps {
+		sb.WriteString(" @timestamps")
+	}
+	sb.WriteString(" {\n")
+
+	// Inline checks belong to their field; the rest stay table-level
+	fieldChecks := make(map[string][]string)
+	var tableChecks []string
+	for _, check := range entity.Checks {
+		if check.Field != nil {
+			fieldChecks[*check.Field] = append(fieldChecks[*check.Field], check.Expression)
+		} else {
+			tableChecks = append(tableChecks, check.Expression)
+		}
+	}
+
+	var fields []chamLine
+	for _, field := range chamFields(entity) {
+		definition, err := chamFieldDefinition(field, fieldChecks[field.Name])
+		if err != nil {
+			return fmt.Errorf("entity %s: %w", entity.Name, err)
+		}
+		fields = append(fields, chamLine{name: field.Name, definition: definition, description: field.Description})
+	}
+
+	var relations []chamLine
+	for _, name := range sortedKeys(entity.Relations) {
+		definition, err := chamRelationDefinition(entity.Relations[name])
+		if err != nil {
+			return fmt.Errorf("entity %s: %w", entity.Name, err)
+		}
+		relations = append(relations, chamLine{name: name, definition: definition})
+	}
+
+	width := 0
+	for _, line := range append(append([]chamLine{}, fields...), relations...) {
+		if len(line.name) > width {
+			width = len(line.name)
+		}
+	}
+
+	writeChamLines(sb, fields, width)
+	if len(fields) > 0 && len(relations) > 0 {
+		sb.WriteString("\n")
+	}
+	writeChamLines(sb, relations, width)
+	if len(tableChecks) > 0 && (len(fields) > 0 || len(relations) > 0) {
+		sb.WriteString("\n")
+	}
+	for _, expression := range tableChecks {
+		sb.WriteString(fmt.Sprintf("    check(%s),\n", chamString(expression)))
+	}
+	if len(entity.UniqueIndexes) > 0 && (len(fields) > 0 || len(relations) > 0 || len(tableChecks) > 0) {
+		sb.WriteString("\n")
+	}
+	for _, index := range entity.UniqueIndexes {
+		sb.WriteString("    " + chamUniqueIndex(index) + ",\n")
+	}
+
+	sb.WriteString("}\n")
+	return nil
+}
+
+func writeChamLines(sb *strings.Builder, lines []chamLine, width int) {
+	for _, line := range lines {
+		writeChamDoc(sb, "    ", line.description)
+		label := line.name + ":"
+		sb.WriteString(fmt.Sprintf("    %-*s %s,\n", width+1, label, line.definition))
+	}
+}
+
+// writeChamDoc writes a description as `///` lines
+func writeChamDoc(sb *strings.Builder, indent, description string) {
+	if description == "" {
+		return
+	}
+	for _, line := range strings.Split(description, "\n") {
+		if line == "" {
+			sb.WriteString(indent + "///\n")
+		} else {
+			sb.WriteString(indent + "/// " + line + "\n")
+		}
+	}
+}
+
+// nullPredicate matches the predicates the parser builds from
+// `where field is null` / `where field is not null`
+var nullPredicate = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*) IS (NOT )?NULL$`)
+
+func chamUniqueIndex(index *UniqueIndex) string {
+	out := "@unique(" + strings.Join(index.Fields, ", ") + ")"
+	if index.Predicate != nil {
+		if m := nullPredicate.FindStringSubmatch(*index.Predicate); m != nil {
+			out += " where " + m[1] + " is " + strings.ToLower(m[2]) + "null"
+		} else {
+			out += " where " + chamString(*index.Predicate)
+		}
+	}
+	if index.Concurrent {
+		out += " concurrently"
+	}
+	return out
+}
+
+// chamFields returns the entity's fields in declared order, so the output
+// parses back to the same FieldOrder. Without a known order, primary key
+// fields come first and the rest follow by name.
+func chamFields(entity *Entity) []*Field {
+	if len(entity.FieldOrder) == 0 {
+		return sortedChamFields(entity.Fields)
+	}
+	fields := make([]*Field, 0, len(entity.Fields))
+	for _, name := range entity.FieldNames() {
+		fields = append(fields, entity.Fields[name])
+	}
+	return fields
+}
+
+// sortedChamFields orders primary key fields first, then the rest by name
+func sortedChamFields(fields map[string]*Field) []*Field {
+	sorted := make([]*Field, 0, len(fields))
+	for _, field := range fields {
+		sorted = append(sorted, field)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].PrimaryKey != sorted[j].PrimaryKey {
+			return sorted[i].PrimaryKey
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func chamFieldDefinition(field *Field, checks []string) (string, error) {
+	fieldType, err := chamFieldType(field.Type)
+	if err != nil {
+		return "", fmt.Errorf("field %s: %w", field.Name, err)
+	}
+
+	parts := []string{fieldType}
+	if field.PrimaryKey {
+		parts = append(parts, "primary")
+	}
+	if field.Unique {
+		parts = append(parts, "unique")
+	}
+	if field.Nullable {
+		parts = append(parts, "nullable")
+	}
+	if field.IsAutoIncrement() {
+		parts = append(parts, "auto")
+	} else if expression := field.GeneratedExpression(); expression != "" {
+		parts = append(parts, fmt.Sprintf("generated(%s)", chamString(expression)))
+	} else if field.Default != nil {
+		value, err := chamDefault(*field.Default)
+		if err != nil {
+			return "", fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		parts = append(parts, "default "+value)
+	}
+	for _, expression := range checks {
+		parts = append(parts, fmt.Sprintf("check(%s)", chamString(expression)))
+	}
+	if field.Backend != nil && *field.Backend != "OLTP" {
+		annotation, ok := chamBackends[*field.Backend]
+		if !ok {
+			return "", fmt.Errorf("field %s: unknown backend %q", field.Name, *field.Backend)
+		}
+		parts = append(parts, annotation)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// chamTypes maps FieldType kinds to their .cham keywords
+var chamTypes = map[string]string{
+	"UUID":      "uuid",
+	"String":    "string",
+	"Int":       "int",
+	"Decimal":   "decimal",
+	"Bool":      "bool",
+	"Timestamp": "timestamp",
+	"Float":     "float",
+}
+
+// chamBackends maps backend annotations to their .cham syntax
+var chamBackends = map[string]string{
+	"Cache":  "@cache",
+	"OLAP":   "@olap",
+	"Vector": "@vector",
+	"ML":     "@ml",
+}
+
+func chamFieldType(ft FieldType) (string, error) {
+	if keyword, ok := chamTypes[ft.Kind]; ok && ft.Param == nil {
+		return keyword, nil
+	}
+
+	switch ft.Kind {
+	case "Vector":
+		// Params decoded from JSON are float64
+		switch size := ft.Param.(type) {
+		case float64:
+			return fmt.Sprintf("vector(%d)", int(size)), nil
+		case int:
+			return fmt.Sprintf("vector(%d)", size), nil
+		}
+	case "Array":
+		inner, err := fieldTypeFromParam(ft.Param)
+		if err != nil {
+			return "", err
+		}
+		innerType, err := chamFieldType(inner)
+		if err != nil {
+			return "", err
+		}
+		return "[" + innerType + "]", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", ft)
+}
+
+// fieldTypeFromParam decodes the inner type of an Array, as produced by
+// FieldType.UnmarshalJSON ("String" or {"Vector": 384})
+func fieldTypeFromParam(param interface{}) (FieldType, error) {
+	switch value := param.(type) {
+	case FieldType:
+		return value, nil
+	case string:
+		return FieldType{Kind: value}, nil
+	case map[string]interface{}:
+		if len(value) == 1 {
+			for kind, inner := range value {
+				return FieldType{Kind: kind, Param: inner}, nil
+			}
+		}
+	}
+	return FieldType{}, fmt.Errorf("unsupported array element type %v", param)
+}
+
+// chamDefault renders a default value as decoded from the core's JSON
+// ("Now", "UUIDv4" or {"Literal": "..."})
+func chamDefault(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		switch v {
+		case "Now":
+			return "now()", nil
+		case "UUIDv4":
+			return "uuid_v4()", nil
+		}
+	case map[string]interface{}:
+		if literal, ok := v["Literal"].(string); ok && len(v) == 1 {
+			return chamString(literal), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported default %v", value)
+}
+
+func chamRelationDefinition(rel *Relation) (string, error) {
+	switch {
+	case rel.Kind == RelationHasMany && rel.ForeignKey != nil && rel.Through == nil:
+		return fmt.Sprintf("[%s] via %s", rel.TargetEntity, *rel.ForeignKey), nil
+	case rel.Kind == RelationBelongsTo && rel.ForeignKey == nil && rel.Through == nil:
+		return rel.TargetEntity, nil
+	}
+	return "", fmt.Errorf("relation %s: %s relations have no .cham syntax", rel.Name, rel.Kind)
+}
+
+// chamString quotes a string literal. The parser keeps escape sequences
+// verbatim, so only bare double quotes need escaping.
+func chamString(value string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	if escaped {
+		// A trailing lone backslash would escape the closing quote
+		sb.WriteByte('\\')
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,283 @@
+package engine
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSchemaToCham(t *testing.T) {
+	userID := "user_id"
+	backend := "Cache"
+	field := "age"
+	var now interface{} = "Now"
+	var literal interface{} = map[string]interface{}{"Literal": "draft"}
+	var auto interface{} = "AutoIncrement"
+	var generated interface{} = map[string]interface{}{"Generated": "lower(label)"}
+	notNull := "status IS NOT NULL"
+	rawPredicate := "status = 'live'"
+
+	schema := &Schema{
+		Entities: []*Entity{
+			{
+				Name:        "User",
+				Description: "The account owner.\n\nOne per login.",
+				Fields: map[string]*Field{
+					"id":         {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+					"email":      {Name: "email", Type: FieldTypeString, Unique: true, Description: "Used to sign in"},
+					"age":        {Name: "age", Type: FieldTypeInt, Nullable: true},
+					"created_at": {Name: "created_at", Type: FieldTypeTimestamp, Default: &now},
+					"session":    {Name: "session", Type: FieldTypeString, Backend: &backend},
+				},
+				Relations: map[string]*Relation{
+					"posts": {Name: "posts", Kind: RelationHasMany, TargetEntity: "Post", ForeignKey: &userID},
+				},
+				Checks: []*CheckConstraint{{Expression: "age >= 0", Field: &field}},
+			},
+			{
+				Name:     "Post",
+				Schema:   "blog",
+				Tenant:   "org_id",
+				ReadOnly: true,
+				Fields: map[string]*Field{
+					"id":        {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+					"status":    {Name: "status", Type: FieldTypeString, Default: &literal},
+					"embedding": {Name: "embedding", Type: FieldType{Kind: "Vector", Param: float64(384)}},
+					"org_id":    {Name: "org_id", Type: FieldTypeUUID},
+					"tags":      {Name: "tags", Type: FieldType{Kind: "Array", Param: "String"}},
+				},
+				Relations: map[string]*Relation{
+					"author": {Name: "author", Kind: RelationBelongsTo, TargetEntity: "User"},
+				},
+				Checks: []*CheckConstraint{{Expression: `status <> ''`}},
+				UniqueIndexes: []*UniqueIndex{
+					{Fields: []string{"status", "tags"}},
+					{Fields: []string{"status"}, Predicate: &notNull, Concurrent: true},
+					{Fields: []string{"embedding"}, Predicate: &rawPredicate},
+				},
+			},
+			{
+				Name:       "Tag",
+				Timestamps: true,
+				Fields: map[string]*Field{
+					"id":         {Name: "id", Type: FieldTypeInt, PrimaryKey: true, Default: &auto},
+					"label":      {Name: "label", Type: FieldTypeString},
+					"slug":       {Name: "slug", Type: FieldTypeString, Default: &generated},
+					"created_at": {Name: "created_at", Type: FieldTypeTimestamp, Default: &now},
+					"updated_at": {Name: "updated_at", Type: FieldTypeTimestamp, Default: &now},
+				},
+			},
+		},
+	}
+
+	got, err := schema.ToCham()
+	if err != nil {
+		t.Fatalf("ToCham() error = %v", err)
+	}
+
+	want := `/// The account owner.
+///
+/// One per login.
+entity User {
+    id:         uuid primary,
+    age:        int nullable check("age >= 0"),
+    created_at: timestamp default now(),
+    /// Used to sign in
+    email:      string unique,
+    session:    string @cache,
+
+    posts:      [Post] via user_id,
+}
+
+entity Post @readonly @schema("blog") @tenant(org_id) {
+    id:        uuid primary,
+    embedding: vector(384),
+    org_id:    uuid,
+    status:    string default "draft",
+    tags:      [string],
+
+    author:    User,
+
+    check("status <> ''"),
+
+    @unique(status, tags),
+    @unique(status) where status is not null concurrently,
+    @unique(embedding) where "status = 'live'",
+}
+
+entity Tag @timestamps {
+    id:         int primary auto,
+    created_at: timestamp default now(),
+    label:      string,
+    slug:       string generated("lower(label)"),
+    updated_at: timestamp default now(),
+}
+`
+	if got != want {
+		t.Errorf("ToCham() mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSchemaToCham_DeclaredFieldOrder(t *testing.T) {
+	schema := &Schema{Entities: []*Entity{{
+		Name:       "User",
+		FieldOrder: []string{"email", "id", "name"},
+		Fields: map[string]*Field{
+			"id":    {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+			"email": {Name: "email", Type: FieldTypeString},
+			"name":  {Name: "name", Type: FieldTypeString},
+		},
+	}}}
+
+	got, err := schema.ToCham()
+	if err != nil {
+		t.Fatalf("ToCham() error = %v", err)
+	}
+	want := "entity User {\n    email: string,\n    id:    uuid primary,\n    name:  string,\n}\n"
+	if got != want {
+		t.Errorf("fields should keep their declared order\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSchemaToCham_UnsupportedRelation(t *testing.T) {
+	schema := &Schema{
+		Entities: []*Entity{
+			{
+				Name:   "User",
+				Fields: map[string]*Field{},
+				Relations: map[string]*Relation{
+					"profile": {Name: "profile", Kind: RelationHasOne, TargetEntity: "Profile"},
+				},
+			},
+		},
+	}
+
+	if _, err := schema.ToCham(); err == nil {
+		t.Fatal("expected error for relation without .cham syntax")
+	}
+}
+
+func TestChamString(t *testing.T) {
+	tests := map[string]string{
+		`plain`:       `"plain"`,
+		`say "hi"`:    `"say \"hi\""`,
+		`already \"`:  `"already \""`,
+		`trailing \`:  `"trailing \\"`,
+		`a\nb`:        `"a\nb"`,
+		`price > 0.5`: `"price > 0.5"`,
+	}
+	for input, want := range tests {
+		if got := chamString(input); got != want {
+			t.Errorf("chamString(%q) = %s, want %s", input, got, want)
+		}
+	}
+}
+
+// normalizeChecks orders checks by field then expression; serialization
+// moves inline checks next to their (sorted) field
+func normalizeChecks(schema *Schema) {
+	for _, entity := range schema.Entities {
+		sort.SliceStable(entity.Checks, func(i, j int) bool {
+			fi, fj := "", ""
+			if entity.Checks[i].Field != nil {
+				fi = *entity.Checks[i].Field
+			}
+			if entity.Checks[j].Field != nil {
+				fj = *entity.Checks[j].Field
+			}
+			if fi != fj {
+				return fi < fj
+			}
+			return entity.Checks[i].Expression < entity.Checks[j].Expression
+		})
+	}
+}
+
+func assertChamRoundTrip(t *testing.T, source string) {
+	t.Helper()
+
+	original, err := NewEngineForCLI().LoadSchemaFromString(source)
+	if err != nil {
+		t.Fatalf("parse original: %v", err)
+	}
+
+	rendered, err := original.ToCham()
+	if err != nil {
+		t.Fatalf("ToCham() error = %v", err)
+	}
+
+	reparsed, err := NewEngineForCLI().LoadSchemaFromString(rendered)
+	if err != nil {
+		t.Fatalf("serialized schema does not parse: %v\n%s", err, rendered)
+	}
+
+	normalizeChecks(original)
+	normalizeChecks(reparsed)
+	if !reflect.DeepEqual(original, reparsed) {
+		t.Errorf("round trip changed the schema\n%s", rendered)
+	}
+
+	again, err := reparsed.ToCham()
+	if err != nil {
+		t.Fatalf("ToCham() on reparsed schema error = %v", err)
+	}
+	if again != rendered {
+		t.Errorf("ToCham() is not stable\nfirst:\n%s\nsecond:\n%s", rendered, again)
+	}
+}
+
+func TestSchemaToCham_RoundTrip(t *testing.T) {
+	assertChamRoundTrip(t, `
+/// Registered accounts
+///
+/// One per login
+entity User {
+    id: uuid primary default uuid_v4(),
+    /// Used to sign in, can't be reused
+    email: string unique check("email LIKE '%@%'"),
+    age: int nullable check("age >= 0") check("age < 150"),
+    status: string default "active",
+    score: float,
+    balance: decimal,
+    verified: bool,
+    created_at: timestamp default now(),
+    embedding: vector(384) @vector,
+    tags: [string],
+    session: string @cache,
+    orders: [Order] via user_id,
+    check("balance >= 0 OR verified"),
+}
+
+entity Order @schema("sales") @tenant(org_id) {
+    id: uuid primary,
+    org_id: uuid,
+    user_id: uuid,
+    note: string default "say \"hi\"",
+    user: User,
+}
+
+entity ActiveUser @view {
+    id: uuid primary,
+    email: string,
+}
+
+entity Country @readonly {
+    code: string primary,
+}
+
+entity Tag @timestamps {
+    id: int primary auto,
+    label: string,
+    slug: string generated("lower(label)"),
+}
+`)
+}
+
+func TestSchemaToCham_RoundTripExample(t *testing.T) {
+	content, err := os.ReadFile("../../../examples/basic_schema.cham")
+	if err != nil {
+		t.Fatalf("Failed to read schema from file: %v", err)
+	}
+	assertChamRoundTrip(t, string(content))
+}
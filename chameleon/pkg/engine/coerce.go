@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// CoerceValue prepares a mutation value for field name before it is bound:
+// date strings on Timestamp fields become time.Time (see
+// CoerceFieldValue) and, with CoerceStrings, numeric and boolean strings
+// are converted to the field's type. Decimal strings are checked but kept
+// as text so no precision is lost.
+func (v *Validator) CoerceValue(field *Field, name string, value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok || field == nil || !v.config.CoerceStrings {
+		return CoerceFieldValue(field, name, value)
+	}
+
+	switch field.Type.Kind {
+	case FieldTypeInt.Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, coerceError(name, "integer", s, `Send digits only, e.g. "25"`)
+		}
+		return n, nil
+
+	case FieldTypeFloat.Kind:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, coerceError(name, "number", s, `Send a number, e.g. "19.99"`)
+		}
+		return f, nil
+
+	case FieldTypeDecimal.Kind:
+		if !decimalPattern.MatchString(s) {
+			return nil, coerceError(name, "decimal", s, `Send a number, e.g. "19.99"`)
+		}
+		return s, nil
+
+	case FieldTypeBool.Kind:
+		switch strings.ToLower(s) {
+		case "true", "t", "1", "yes", "on":
+			return true, nil
+		case "false", "f", "0", "no", "off":
+			return false, nil
+		}
+		return nil, coerceError(name, "boolean", s, `Send "true" or "false"`)
+	}
+	return CoerceFieldValue(field, name, value)
+}
+
+func coerceError(field, format, value, suggestion string) error {
+	return &FieldFormatError{
+		Field:      field,
+		Format:     format,
+		Value:      value,
+		Suggestion: suggestion,
+	}
+}
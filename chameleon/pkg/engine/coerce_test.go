@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidatorCoerceValue(t *testing.T) {
+	cfg := DefaultValidatorConfig()
+	cfg.CoerceStrings = true
+	v := NewValidator(&Schema{}, cfg)
+
+	tests := []struct {
+		kind  FieldType
+		input string
+		want  interface{}
+	}{
+		{FieldTypeInt, "25", int64(25)},
+		{FieldTypeInt, "-3", int64(-3)},
+		{FieldTypeFloat, "19.5", 19.5},
+		{FieldTypeDecimal, "19.99", "19.99"},
+		{FieldTypeBool, "true", true},
+		{FieldTypeBool, "on", true},
+		{FieldTypeBool, "0", false},
+		{FieldTypeString, "25", "25"},
+	}
+	for _, tt := range tests {
+		got, err := v.CoerceValue(&Field{Type: tt.kind}, "f", tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("%s %q = %v (%v), want %v", tt.kind.Kind, tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		kind  FieldType
+		input string
+	}{
+		{FieldTypeInt, "25.0"},
+		{FieldTypeInt, " 25"},
+		{FieldTypeFloat, "abc"},
+		{FieldTypeDecimal, "1,5"},
+		{FieldTypeBool, "maybe"},
+	} {
+		_, err := v.CoerceValue(&Field{Type: tt.kind}, "f", tt.input)
+		var format *FieldFormatError
+		if !errors.As(err, &format) {
+			t.Errorf("%s %q: expected a FieldFormatError, got %v", tt.kind.Kind, tt.input, err)
+		}
+	}
+
+	// Off by default: strings are bound as they are
+	strict := NewValidator(&Schema{}, DefaultValidatorConfig())
+	if got, err := strict.CoerceValue(&Field{Type: FieldTypeInt}, "f", "25"); err != nil || got != "25" {
+		t.Errorf("without CoerceStrings the value should be unchanged, got %v (%v)", got, err)
+	}
+}
@@ -1,6 +1,9 @@
 package engine
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ============================================================
 // MUTATION TYPES
@@ -25,19 +28,57 @@ type Mutation struct {
 // MUTATION RESULT TYPES
 // ============================================================
 
+// MutationPlan is the statement a mutation would have run, returned in
+// place of its effects when the engine is in dry-run mode (see WithDryRun)
+type MutationPlan struct {
+	SQL  string
+	Args []interface{}
+
+	// Statements lists every statement of a multi-row insert too large
+	// for one (see InsertMutation.Rows); SQL and Args are the first
+	Statements []MutationPlan
+}
+
 type InsertResult struct {
-	ID       interface{}            // Primary key
+	ID       interface{}            // Primary key value; map[string]interface{} for composite keys
 	Record   map[string]interface{} // Full record (if RETURNING)
 	Affected int
+
+	// Every inserted row, in input order (a single entry unless Rows was used).
+	// ID and Record are IDs[0] and Records[0].
+	IDs     []interface{}
+	Records []map[string]interface{}
+
+	DryRun *MutationPlan // Set when nothing was applied (dry-run mode)
 }
 
 type UpdateResult struct {
 	Records  []map[string]interface{}
 	Affected int
+
+	DryRun *MutationPlan // Set when nothing was applied (dry-run mode)
 }
 
 type DeleteResult struct {
 	Affected int
+	Batches  int // Statements run by a batched delete (0 when not batched)
+
+	DryRun *MutationPlan // Set when nothing was applied (dry-run mode)
+}
+
+// TruncateResult reports a completed Truncate
+type TruncateResult struct {
+	Tables []string // Tables emptied, in the order given
+
+	DryRun *MutationPlan // Set when nothing was applied (dry-run mode)
+}
+
+// BulkLoadResult reports a completed BulkLoad
+type BulkLoadResult struct {
+	Loaded  int64    // Rows copied into the table
+	Columns []string // Columns copied, in declared order
+
+	DryRun *MutationPlan // Set when nothing was applied (dry-run mode); SQL is the COPY statement
 }
 
 // ============================================================
@@ -49,11 +90,33 @@ type InsertMutation interface {
 	// Set adds a field to insert
 	Set(field string, value interface{}) InsertMutation
 
+	// Rows inserts several rows in one statement. Fields given with Set
+	// apply to every row; a row's own values take precedence. Rows past
+	// PostgreSQL's 65535 bind parameters go into further statements in
+	// the same transaction.
+	Rows(rows ...map[string]interface{}) InsertMutation
+
+	// Upsert updates the existing row instead of failing when the insert
+	// conflicts on conflictFields (default: the primary key)
+	Upsert(conflictFields ...string) InsertMutation
+
+	// AllTenants inserts into a @tenant entity without the context
+	// tenant; rows must set the tenant column themselves
+	AllTenants() InsertMutation
+
+	// WithValidatorConfig replaces the validation settings for this
+	// mutation (default: DefaultValidatorConfig)
+	WithValidatorConfig(cfg ValidatorConfig) InsertMutation
+
 	// Debug enables debug output for this mutation
 	Debug() InsertMutation
 
 	// Execute validates and runs the mutation
 	Execute(ctx context.Context) (*InsertResult, error)
+
+	// ToSQL validates the mutation and returns the SQL and its ordered
+	// arguments without executing it
+	ToSQL() (string, []interface{}, error)
 }
 
 // UpdateMutation builds and executes UPDATE operations
@@ -64,11 +127,23 @@ type UpdateMutation interface {
 	// Filter adds a filter condition (WHERE clause)
 	Filter(field string, operator string, value interface{}) UpdateMutation
 
+	// AllTenants updates the matching rows of every tenant of a @tenant
+	// entity instead of only the context tenant's
+	AllTenants() UpdateMutation
+
+	// WithValidatorConfig replaces the validation settings for this
+	// mutation (default: DefaultValidatorConfig)
+	WithValidatorConfig(cfg ValidatorConfig) UpdateMutation
+
 	// Debug enables debug output for this mutation
 	Debug() UpdateMutation
 
 	// Execute validates and runs the mutation
 	Execute(ctx context.Context) (*UpdateResult, error)
+
+	// ToSQL validates the mutation and returns the SQL and its ordered
+	// arguments without executing it
+	ToSQL() (string, []interface{}, error)
 }
 
 // DeleteMutation builds and executes DELETE operations
@@ -76,11 +151,53 @@ type DeleteMutation interface {
 	// Filter adds a filter condition (WHERE clause)
 	Filter(field string, operator string, value interface{}) DeleteMutation
 
+	// AllTenants deletes the matching rows of every tenant of a @tenant
+	// entity instead of only the context tenant's
+	AllTenants() DeleteMutation
+
+	// WithValidatorConfig replaces the validation settings for this
+	// mutation (default: DefaultValidatorConfig)
+	WithValidatorConfig(cfg ValidatorConfig) DeleteMutation
+
+	// Batch deletes the matching rows in chunks of at most size rows,
+	// one statement per chunk, until none remain. Affected is the total.
+	Batch(size int) DeleteMutation
+
+	// OnBatch makes a batched delete return the rows of each chunk
+	// (RETURNING *) and pass them to fn once the chunk is committed, so
+	// they need not be held in memory. An error from fn stops the delete.
+	// Requires Batch.
+	OnBatch(fn func(records []map[string]interface{}) error) DeleteMutation
+
 	// Debug enables debug output for this mutation
 	Debug() DeleteMutation
 
 	// Execute validates and runs the mutation
 	Execute(ctx context.Context) (*DeleteResult, error)
+
+	// ToSQL validates the mutation and returns the SQL and its ordered
+	// arguments without executing it
+	ToSQL() (string, []interface{}, error)
+}
+
+// TruncateMutation builds and executes TRUNCATE operations
+type TruncateMutation interface {
+	// Force confirms that every row in the tables may be removed.
+	// Without it Execute returns a SafetyError.
+	Force() TruncateMutation
+
+	// AllTenants confirms truncating @tenant entities, which removes the
+	// rows of every tenant. Without it Execute returns a TenantScopeError.
+	AllTenants() TruncateMutation
+
+	// Debug enables debug output for this mutation
+	Debug() TruncateMutation
+
+	// Execute validates and runs the mutation
+	Execute(ctx context.Context) (*TruncateResult, error)
+
+	// ToSQL validates the mutation and returns the SQL without executing it
+	ToSQL() (string, []interface{}, error)
 }
 
 // ============================================================
@@ -98,19 +215,68 @@ type DeleteMutation interface {
 type MutationFactory interface {
 	// NewInsert creates a builder for INSERT operations
 	// Schema and Connector are passed in to keep factory stateless
-	NewInsert(entity string, schema *Schema, connector *Connector) InsertMutation
+	NewInsert(entity string, schema *Schema, connector *Connector, opts MutationOptions) InsertMutation
 
 	// NewUpdate creates a builder for UPDATE operations
-	NewUpdate(entity string, schema *Schema, connector *Connector) UpdateMutation
+	NewUpdate(entity string, schema *Schema, connector *Connector, opts MutationOptions) UpdateMutation
 
 	// NewDelete creates a builder for DELETE operations
-	NewDelete(entity string, schema *Schema, connector *Connector) DeleteMutation
+	NewDelete(entity string, schema *Schema, connector *Connector, opts MutationOptions) DeleteMutation
+
+	// NewTruncate creates a builder for TRUNCATE operations
+	NewTruncate(entities []string, schema *Schema, connector *Connector, opts MutationOptions) TruncateMutation
+
+	// BulkLoad validates rows and copies them into the entity's table with COPY
+	BulkLoad(ctx context.Context, entity string, rows []map[string]interface{}, schema *Schema, connector *Connector, opts MutationOptions) (*BulkLoadResult, error)
+
+	// TableName returns the table an entity is stored in (User → users)
+	TableName(entity string) string
+}
+
+// MutationOptions carries engine-level settings into mutation builders.
+// Like Schema and Connector, it is passed per call to keep factories stateless.
+type MutationOptions struct {
+	// Authorizer is consulted before the mutation runs (nil = allow all)
+	Authorizer Authorizer
+
+	// Journal records the mutation once it finishes (nil = not journaled)
+	Journal JournalLogger
+
+	// Validator replaces DefaultValidatorConfig for new builders
+	// (nil = default)
+	Validator *ValidatorConfig
+
+	// DryRun validates and generates SQL without touching the database
+	DryRun bool
+
+	// Timeout bounds mutations whose context has no deadline
+	// (0 = no timeout)
+	Timeout time.Duration
 }
 
 // ============================================================
 // AUXILIARY CONTRACTS
 // ============================================================
 
+// QueryExecutor runs built queries. Executor runs them against
+// PostgreSQL; WithBackend gives an engine another one.
+type QueryExecutor interface {
+	// Execute runs the query. qb already carries the tenant filter and
+	// the engine's limits.
+	Execute(ctx context.Context, qb *QueryBuilder) (*QueryResult, error)
+
+	// Count returns how many rows the query matches, ignoring its limit,
+	// offset, order and includes (see Paginate)
+	Count(ctx context.Context, qb *QueryBuilder) (int64, error)
+}
+
+// Backend stands in for PostgreSQL: it runs queries and creates the
+// mutation builders (see Engine.WithBackend)
+type Backend interface {
+	QueryExecutor
+	MutationFactory
+}
+
 type ExecutionResult struct {
 	RowsAffected int64
 	LastInsertID interface{}
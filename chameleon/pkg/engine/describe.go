@@ -0,0 +1,101 @@
+package engine
+
+import "fmt"
+
+// SchemaDescription lists the schema's entities in a stable, serializable
+// shape for admin and CRUD tooling. Unlike Schema.ToJSON, field types are
+// plain strings (FieldType.String) and table names are resolved.
+type SchemaDescription struct {
+	Entities []EntityDescription `json:"entities"`
+}
+
+// EntityDescription describes one entity and the table it is stored in
+type EntityDescription struct {
+	Name        string                `json:"name"`
+	Table       string                `json:"table"`
+	Schema      string                `json:"schema,omitempty"` // PostgreSQL namespace from @schema
+	View        bool                  `json:"view"`
+	ReadOnly    bool                  `json:"read_only"` // Mutations are rejected (@readonly or view)
+	Description string                `json:"description,omitempty"`
+	Fields      []FieldDescription    `json:"fields"`    // In declaration order
+	Relations   []RelationDescription `json:"relations"` // Sorted by name
+}
+
+// FieldDescription describes one column
+type FieldDescription struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // e.g. "UUID", "Vector(1536)"
+	Nullable    bool   `json:"nullable"`
+	Unique      bool   `json:"unique"`
+	PrimaryKey  bool   `json:"primary_key"`
+	Description string `json:"description,omitempty"`
+}
+
+// RelationDescription describes one relation to another entity
+type RelationDescription struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"` // HasOne, HasMany, BelongsTo or ManyToMany
+	Target     string `json:"target"`
+	ForeignKey string `json:"foreign_key,omitempty"`
+	Through    string `json:"through,omitempty"`
+}
+
+// DescribeSchema returns the loaded schema as a SchemaDescription.
+// Entities keep schema order.
+func (e *Engine) DescribeSchema() (*SchemaDescription, error) {
+	if e.schema == nil {
+		return nil, fmt.Errorf("schema not loaded")
+	}
+	factory := e.mutationFactory()
+	if factory == nil {
+		return nil, fmt.Errorf("no mutation factory registered")
+	}
+	return describeSchema(e.schema, factory.TableName), nil
+}
+
+func describeSchema(schema *Schema, tableName func(string) string) *SchemaDescription {
+	desc := &SchemaDescription{Entities: make([]EntityDescription, 0, len(schema.Entities))}
+	for _, entity := range schema.Entities {
+		ent := EntityDescription{
+			Name:        entity.Name,
+			Table:       tableName(entity.Name),
+			Schema:      entity.Schema,
+			View:        entity.View,
+			ReadOnly:    entity.IsReadOnly(),
+			Description: entity.Description,
+			Fields:      make([]FieldDescription, 0, len(entity.Fields)),
+			Relations:   make([]RelationDescription, 0, len(entity.Relations)),
+		}
+
+		for _, name := range entity.FieldNames() {
+			field := entity.Fields[name]
+			ent.Fields = append(ent.Fields, FieldDescription{
+				Name:        field.Name,
+				Type:        field.Type.String(),
+				Nullable:    field.Nullable,
+				Unique:      field.Unique,
+				PrimaryKey:  field.PrimaryKey,
+				Description: field.Description,
+			})
+		}
+
+		for _, name := range entity.RelationNames() {
+			rel := entity.Relations[name]
+			relation := RelationDescription{
+				Name:   name,
+				Kind:   string(rel.Kind),
+				Target: rel.TargetEntity,
+			}
+			if rel.ForeignKey != nil {
+				relation.ForeignKey = *rel.ForeignKey
+			}
+			if rel.Through != nil {
+				relation.Through = *rel.Through
+			}
+			ent.Relations = append(ent.Relations, relation)
+		}
+
+		desc.Entities = append(desc.Entities, ent)
+	}
+	return desc
+}
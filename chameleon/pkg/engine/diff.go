@@ -0,0 +1,253 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Schema change kinds reported by DiffSchemas
+const (
+	ChangeEntityAdded        = "entity_added"
+	ChangeEntityRemoved      = "entity_removed"
+	ChangeFieldAdded         = "field_added"
+	ChangeFieldRemoved       = "field_removed"
+	ChangeFieldTypeChanged   = "field_type_changed"
+	ChangeFieldNullability   = "field_nullability_changed"
+	ChangeFieldUniqueness    = "field_uniqueness_changed"
+	ChangeFieldPrimaryKey    = "field_primary_key_changed"
+	ChangeRelationAdded      = "relation_added"
+	ChangeRelationRemoved    = "relation_removed"
+	ChangeRelationRetargeted = "relation_changed"
+)
+
+// SchemaChange is one difference between two schema versions.
+// Destructive changes can lose data or fail against existing rows
+// (drops, type narrowing, new NOT NULL/UNIQUE constraints).
+type SchemaChange struct {
+	Kind        string `json:"kind"`
+	Entity      string `json:"entity"`
+	Field       string `json:"field,omitempty"`
+	Before      string `json:"before,omitempty"`
+	After       string `json:"after,omitempty"`
+	Destructive bool   `json:"destructive"`
+}
+
+// String renders the change for terminal output
+func (c SchemaChange) String() string {
+	target := c.Entity
+	if c.Field != "" {
+		target += "." + c.Field
+	}
+	switch {
+	case c.Before != "" && c.After != "":
+		return fmt.Sprintf("%s %s: %s → %s", c.Kind, target, c.Before, c.After)
+	case c.After != "":
+		return fmt.Sprintf("%s %s: %s", c.Kind, target, c.After)
+	case c.Before != "":
+		return fmt.Sprintf("%s %s: %s", c.Kind, target, c.Before)
+	}
+	return fmt.Sprintf("%s %s", c.Kind, target)
+}
+
+// wideningConversions lists type changes that never lose data
+var wideningConversions = map[string][]string{
+	"Int":   {"Float", "Decimal", "String"},
+	"Float": {"Decimal", "String"},
+	"UUID":  {"String"},
+	"Bool":  {"String"},
+}
+
+// isWidening reports whether changing a column from before to after keeps every value
+func isWidening(before, after FieldType) bool {
+	if before.Param != nil || after.Param != nil {
+		return false
+	}
+	for _, kind := range wideningConversions[before.Kind] {
+		if kind == after.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffSchemas compares two schemas and returns the changes needed to go
+// from before to after, ordered by entity, then field/relation name.
+// Either schema may be nil (treated as empty).
+func DiffSchemas(before, after *Schema) []SchemaChange {
+	beforeEntities := entitiesByName(before)
+	afterEntities := entitiesByName(after)
+
+	var changes []SchemaChange
+	for _, name := range unionKeys(beforeEntities, afterEntities) {
+		old, inBefore := beforeEntities[name]
+		cur, inAfter := afterEntities[name]
+
+		switch {
+		case !inBefore:
+			changes = append(changes, SchemaChange{Kind: ChangeEntityAdded, Entity: name})
+		case !inAfter:
+			changes = append(changes, SchemaChange{Kind: ChangeEntityRemoved, Entity: name, Destructive: !old.View})
+		default:
+			changes = append(changes, diffFields(name, old.Fields, cur.Fields)...)
+			changes = append(changes, diffRelations(name, old.Relations, cur.Relations)...)
+		}
+	}
+	return changes
+}
+
+func diffFields(entity string, before, after map[string]*Field) []SchemaChange {
+	var changes []SchemaChange
+	for _, name := range unionKeys(before, after) {
+		old, inBefore := before[name]
+		cur, inAfter := after[name]
+
+		if !inBefore {
+			changes = append(changes, SchemaChange{
+				Kind:   ChangeFieldAdded,
+				Entity: entity,
+				Field:  name,
+				After:  cur.Type.String(),
+				// Existing rows cannot satisfy a required column without a default
+				Destructive: !cur.Nullable && cur.Default == nil,
+			})
+			continue
+		}
+		if !inAfter {
+			changes = append(changes, SchemaChange{
+				Kind:        ChangeFieldRemoved,
+				Entity:      entity,
+				Field:       name,
+				Before:      old.Type.String(),
+				Destructive: true,
+			})
+			continue
+		}
+
+		if old.Type.String() != cur.Type.String() {
+			changes = append(changes, SchemaChange{
+				Kind:        ChangeFieldTypeChanged,
+				Entity:      entity,
+				Field:       name,
+				Before:      old.Type.String(),
+				After:       cur.Type.String(),
+				Destructive: !isWidening(old.Type, cur.Type),
+			})
+		}
+		if old.Nullable != cur.Nullable {
+			changes = append(changes, SchemaChange{
+				Kind:        ChangeFieldNullability,
+				Entity:      entity,
+				Field:       name,
+				Before:      nullability(old.Nullable),
+				After:       nullability(cur.Nullable),
+				Destructive: !cur.Nullable,
+			})
+		}
+		if old.Unique != cur.Unique {
+			changes = append(changes, SchemaChange{
+				Kind:        ChangeFieldUniqueness,
+				Entity:      entity,
+				Field:       name,
+				Before:      fmt.Sprintf("unique=%t", old.Unique),
+				After:       fmt.Sprintf("unique=%t", cur.Unique),
+				Destructive: cur.Unique,
+			})
+		}
+		if old.PrimaryKey != cur.PrimaryKey {
+			changes = append(changes, SchemaChange{
+				Kind:        ChangeFieldPrimaryKey,
+				Entity:      entity,
+				Field:       name,
+				Before:      fmt.Sprintf("primary=%t", old.PrimaryKey),
+				After:       fmt.Sprintf("primary=%t", cur.PrimaryKey),
+				Destructive: true,
+			})
+		}
+	}
+	return changes
+}
+
+func diffRelations(entity string, before, after map[string]*Relation) []SchemaChange {
+	var changes []SchemaChange
+	for _, name := range unionKeys(before, after) {
+		old, inBefore := before[name]
+		cur, inAfter := after[name]
+
+		switch {
+		case !inBefore:
+			changes = append(changes, SchemaChange{
+				Kind:   ChangeRelationAdded,
+				Entity: entity,
+				Field:  name,
+				After:  describeRelation(cur),
+			})
+		case !inAfter:
+			changes = append(changes, SchemaChange{
+				Kind:   ChangeRelationRemoved,
+				Entity: entity,
+				Field:  name,
+				Before: describeRelation(old),
+			})
+		case describeRelation(old) != describeRelation(cur):
+			// The new foreign key may not hold for existing rows
+			changes = append(changes, SchemaChange{
+				Kind:        ChangeRelationRetargeted,
+				Entity:      entity,
+				Field:       name,
+				Before:      describeRelation(old),
+				After:       describeRelation(cur),
+				Destructive: true,
+			})
+		}
+	}
+	return changes
+}
+
+func describeRelation(rel *Relation) string {
+	desc := fmt.Sprintf("%s %s", rel.Kind, rel.TargetEntity)
+	if rel.ForeignKey != nil {
+		desc += " via " + *rel.ForeignKey
+	}
+	if rel.Through != nil {
+		desc += " through " + *rel.Through
+	}
+	return desc
+}
+
+func nullability(nullable bool) string {
+	if nullable {
+		return "nullable"
+	}
+	return "not null"
+}
+
+func entitiesByName(schema *Schema) map[string]*Entity {
+	entities := make(map[string]*Entity)
+	if schema == nil {
+		return entities
+	}
+	for _, entity := range schema.Entities {
+		entities[entity.Name] = entity
+	}
+	return entities
+}
+
+// unionKeys returns the sorted keys present in either map
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for key := range a {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	for key := range b {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"testing"
+)
+
+func diffTestSchema() *Schema {
+	userID := "user_id"
+	return &Schema{
+		Entities: []*Entity{
+			{
+				Name: "User",
+				Fields: map[string]*Field{
+					"id":    {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+					"email": {Name: "email", Type: FieldTypeString, Unique: true},
+					"age":   {Name: "age", Type: FieldTypeInt, Nullable: true},
+				},
+				Relations: map[string]*Relation{
+					"posts": {Name: "posts", Kind: RelationHasMany, TargetEntity: "Post", ForeignKey: &userID},
+				},
+			},
+			{
+				Name: "Post",
+				Fields: map[string]*Field{
+					"id":      {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+					"user_id": {Name: "user_id", Type: FieldTypeUUID},
+				},
+				Relations: map[string]*Relation{},
+			},
+		},
+	}
+}
+
+func findChange(changes []SchemaChange, kind, entity, field string) *SchemaChange {
+	for i := range changes {
+		if changes[i].Kind == kind && changes[i].Entity == entity && changes[i].Field == field {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func TestDiffSchemas_NoChanges(t *testing.T) {
+	if changes := DiffSchemas(diffTestSchema(), diffTestSchema()); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+}
+
+func TestDiffSchemas_Changes(t *testing.T) {
+	before := diffTestSchema()
+	after := diffTestSchema()
+
+	user := after.GetEntity("User")
+	user.Fields["age"] = &Field{Name: "age", Type: FieldTypeFloat, Nullable: false}
+	user.Fields["bio"] = &Field{Name: "bio", Type: FieldTypeString, Nullable: true}
+	delete(user.Fields, "email")
+	delete(user.Relations, "posts")
+	after.Entities = []*Entity{user, {Name: "Tag", Fields: map[string]*Field{}}}
+
+	changes := DiffSchemas(before, after)
+
+	tests := []struct {
+		kind        string
+		entity      string
+		field       string
+		destructive bool
+	}{
+		{ChangeFieldTypeChanged, "User", "age", false}, // Int → Float widens
+		{ChangeFieldNullability, "User", "age", true},
+		{ChangeFieldAdded, "User", "bio", false},
+		{ChangeFieldRemoved, "User", "email", true},
+		{ChangeRelationRemoved, "User", "posts", false},
+		{ChangeEntityRemoved, "Post", "", true},
+		{ChangeEntityAdded, "Tag", "", false},
+	}
+	for _, tt := range tests {
+		change := findChange(changes, tt.kind, tt.entity, tt.field)
+		if change == nil {
+			t.Errorf("missing %s on %s.%s in %v", tt.kind, tt.entity, tt.field, changes)
+			continue
+		}
+		if change.Destructive != tt.destructive {
+			t.Errorf("%s on %s.%s: destructive = %v, want %v", tt.kind, tt.entity, tt.field, change.Destructive, tt.destructive)
+		}
+	}
+	if len(changes) != len(tests) {
+		t.Errorf("expected %d changes, got %d: %v", len(tests), len(changes), changes)
+	}
+}
+
+func TestDiffSchemas_TypeNarrowingIsDestructive(t *testing.T) {
+	before := diffTestSchema()
+	after := diffTestSchema()
+	after.GetEntity("User").Fields["email"].Type = FieldTypeInt
+
+	change := findChange(DiffSchemas(before, after), ChangeFieldTypeChanged, "User", "email")
+	if change == nil || !change.Destructive {
+		t.Fatalf("String → Int should be a destructive type change, got %v", change)
+	}
+	if change.Before != "String" || change.After != "Int" {
+		t.Errorf("unexpected before/after: %s → %s", change.Before, change.After)
+	}
+}
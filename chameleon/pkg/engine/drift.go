@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+)
+
+// DriftIssue is a table or column the schema declares but the connected
+// database does not have, typically after a hand-made change to the
+// database or a migration that was never applied
+type DriftIssue struct {
+	Entity string
+	Field  string // "" when the whole table is missing
+	Table  string
+}
+
+func (d DriftIssue) String() string {
+	if d.Field == "" {
+		return fmt.Sprintf("table %s of %s does not exist", d.Table, d.Entity)
+	}
+	return fmt.Sprintf("column %s.%s of %s does not exist", d.Table, d.Field, d.Entity)
+}
+
+// DriftReporter receives the result of the drift check Connect runs:
+// the missing tables and columns, or the error that stopped the check
+type DriftReporter func(issues []DriftIssue, err error)
+
+// WithDriftCheck makes Connect compare the schema with the database and
+// pass what it finds to report. The check never fails Connect. It is off
+// by default because it adds a catalog query to startup; pass nil to turn
+// it off again, or call CheckDrift to run it on demand.
+func (e *Engine) WithDriftCheck(report DriftReporter) *Engine {
+	e.driftReporter = report
+	return e
+}
+
+// CheckDrift compares the loaded schema with the connected database and
+// returns the tables and columns the database lacks. Extra tables and
+// columns in the database are not reported.
+func (e *Engine) CheckDrift(ctx context.Context) ([]DriftIssue, error) {
+	if e.schema == nil {
+		return nil, fmt.Errorf("schema not loaded")
+	}
+	if err := e.connectionErr(); err != nil {
+		return nil, err
+	}
+	factory := e.mutationFactory()
+	if factory == nil {
+		return nil, fmt.Errorf("no mutation factory registered")
+	}
+	pool := e.connector.Pool()
+	if pool == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	done, err := e.connector.BeginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	var currentSchema string
+	if err := pool.QueryRow(ctx, "SELECT current_schema()").Scan(&currentSchema); err != nil {
+		return nil, fmt.Errorf("drift check: %w", err)
+	}
+
+	tables := make([]string, 0, len(e.schema.Entities))
+	for _, entity := range e.schema.Entities {
+		tables = append(tables, factory.TableName(entity.Name))
+	}
+	rows, err := pool.Query(ctx, `
+		SELECT table_schema, table_name, column_name
+		FROM information_schema.columns
+		WHERE table_name = ANY($1)`, tables)
+	if err != nil {
+		return nil, fmt.Errorf("drift check: %w", err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]map[string]bool)
+	for rows.Next() {
+		var schemaName, table, column string
+		if err := rows.Scan(&schemaName, &table, &column); err != nil {
+			return nil, fmt.Errorf("drift check: %w", err)
+		}
+		key := schemaName + "." + table
+		if columns[key] == nil {
+			columns[key] = make(map[string]bool)
+		}
+		columns[key][column] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("drift check: %w", err)
+	}
+
+	return findDrift(e.schema, factory.TableName, currentSchema, columns), nil
+}
+
+// findDrift lists the schema's tables and columns missing from columns,
+// the database's columns keyed by "schema.table". Entities without a
+// @schema namespace are looked up in currentSchema.
+func findDrift(schema *Schema, tableName func(string) string, currentSchema string, columns map[string]map[string]bool) []DriftIssue {
+	var issues []DriftIssue
+	for _, entity := range schema.Entities {
+		namespace := entity.Schema
+		if namespace == "" {
+			namespace = currentSchema
+		}
+		table := tableName(entity.Name)
+
+		existing, ok := columns[namespace+"."+table]
+		if !ok {
+			issues = append(issues, DriftIssue{Entity: entity.Name, Table: table})
+			continue
+		}
+		for _, field := range entity.FieldNames() {
+			if !existing[field] {
+				issues = append(issues, DriftIssue{Entity: entity.Name, Field: field, Table: table})
+			}
+		}
+	}
+	return issues
+}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/chameleon-db/chameleondb/chameleon/internal/config"
@@ -19,13 +22,49 @@ const defaultMergedSchemaPath = ".chameleon/state/schema.merged.cham"
 type Engine struct {
 	schema    *Schema
 	connector *Connector
-	executor  *Executor
+	executor  QueryExecutor
 	ffiHandle unsafe.Pointer
 	vault     *vault.Vault
 
+	// targets are the named databases registered with ConnectTarget;
+	// targetErr is set on a view from On whose target does not exist
+	targets   *targetSet
+	targetErr error
+
 	schemaSourcePath    string
 	allowSchemaOverride bool
 
+	// authorizer is the optional policy hook (see WithAuthorizer)
+	authorizer Authorizer
+
+	// journal records runtime operations when set (see WithJournal)
+	journal JournalLogger
+
+	// validatorConfig overrides DefaultValidatorConfig for every mutation
+	// when set (see SetValidatorConfig)
+	validatorConfig *ValidatorConfig
+
+	// maxIncludeDepth limits nested includes (0 = DefaultMaxIncludeDepth, <0 = unlimited)
+	maxIncludeDepth int
+
+	// limits sets the default and maximum query Limit (see WithLimits)
+	limits LimitConfig
+
+	// timeouts bound operations whose context has no deadline (see
+	// WithTimeouts)
+	timeouts TimeoutConfig
+
+	// driftReporter receives the drift check Connect runs (see
+	// WithDriftCheck); nil skips the check
+	driftReporter DriftReporter
+
+	// dryRun stops mutations before the database (see WithDryRun)
+	dryRun bool
+
+	// backend replaces PostgreSQL and the registered mutation factory
+	// when set (see WithBackend)
+	backend Backend
+
 	// Debug context
 	Debug *DebugContext
 }
@@ -54,6 +93,28 @@ func NewEngine() (*Engine, error) {
 		return nil, err
 	}
 
+	return newEngine(workDir, schemaSourcePath)
+}
+
+// NewEngineWithSchemaPath is NewEngine with the merged schema read from
+// path instead of schema.merged_output or the default location, e.g. to
+// try a candidate schema or a snapshot. The vault must still exist and
+// pass its integrity check.
+func NewEngineWithSchemaPath(path string) (*Engine, error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve working directory: %w", err)
+	}
+
+	schemaSourcePath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve schema path: %w", err)
+	}
+
+	return newEngine(workDir, schemaSourcePath)
+}
+
+func newEngine(workDir, schemaSourcePath string) (*Engine, error) {
 	eng := &Engine{
 		Debug:            DefaultDebugContext(),
 		vault:            vault.NewVault(workDir),
@@ -71,6 +132,11 @@ func NewEngine() (*Engine, error) {
 		return nil, fmt.Errorf("integrity check failed")
 	}
 
+	// Refuse schemas registered by a newer chameleon before parsing them
+	if err := eng.vault.CheckFormat(); err != nil {
+		return nil, err
+	}
+
 	// Load ONLY from vault
 	if _, err := eng.loadSchemaFromVault(eng.schemaSourcePath); err != nil {
 		return nil, err
@@ -79,6 +145,48 @@ func NewEngine() (*Engine, error) {
 	return eng, nil
 }
 
+// NewEngineFromSchemaFile creates an engine for applications that embed
+// ChameleonDB and manage their schema themselves: the .cham file at path
+// is parsed and validated, and no vault is required or checked. The
+// schema cannot be replaced afterwards.
+func NewEngineFromSchemaFile(path string) (*Engine, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	eng := &Engine{Debug: DefaultDebugContext()}
+	schema, err := eng.loadSchemaFromString(string(content))
+	if err != nil {
+		return nil, err
+	}
+	return eng.withEmbeddedSchema(schema)
+}
+
+// NewEngineFromSchemaJSON is NewEngineFromSchemaFile for a schema already
+// in JSON form, e.g. produced by Schema.ToJSON at build time. It needs no
+// parsing by the core.
+func NewEngineFromSchemaJSON(data []byte) (*Engine, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to deserialize schema: %w", err)
+	}
+	if schema.FormatVersion > vault.SchemaFormat {
+		return nil, &vault.FormatError{Format: schema.FormatVersion, Supported: vault.SchemaFormat}
+	}
+	return (&Engine{Debug: DefaultDebugContext()}).withEmbeddedSchema(&schema)
+}
+
+// withEmbeddedSchema validates schema the way vault loading does and
+// installs it
+func (e *Engine) withEmbeddedSchema(schema *Schema) (*Engine, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	e.schema = schema
+	return e, nil
+}
+
 // CLI-only bypass
 func NewEngineForCLI() *Engine {
 	return &Engine{
@@ -103,6 +211,94 @@ func (e *Engine) WithDebug(level DebugLevel) *Engine {
 	return e
 }
 
+// DefaultMaxIncludeDepth is the include depth allowed when none is configured
+const DefaultMaxIncludeDepth = 5
+
+// WithMaxIncludeDepth sets how many levels an Include path may have
+// ("orders.items" has depth 2). Each level costs one extra query.
+// A negative value disables the limit.
+func (e *Engine) WithMaxIncludeDepth(depth int) *Engine {
+	e.maxIncludeDepth = depth
+	return e
+}
+
+// MaxIncludeDepth returns the effective include depth limit (<0 = unlimited)
+func (e *Engine) MaxIncludeDepth() int {
+	if e.maxIncludeDepth == 0 {
+		return DefaultMaxIncludeDepth
+	}
+	return e.maxIncludeDepth
+}
+
+// LimitConfig protects the database from unbounded reads through the
+// query builder. The zero value applies no default and no cap.
+type LimitConfig struct {
+	// Default is the Limit used when a query sets none (0 = no limit)
+	Default uint64
+
+	// Max caps any Limit a query asks for (0 = no cap)
+	Max uint64
+
+	// Clamp lowers a Limit above Max to Max instead of failing with a
+	// *LimitError
+	Clamp bool
+}
+
+// WithLimits sets the default and maximum Limit for every query the
+// engine runs. Count ignores both, since it has no Limit.
+//
+// Example:
+//
+//	eng.WithLimits(engine.LimitConfig{Default: 100, Max: 1000})
+func (e *Engine) WithLimits(cfg LimitConfig) *Engine {
+	e.limits = cfg
+	return e
+}
+
+// TimeoutConfig bounds queries and mutations whose context has no
+// deadline. The zero value applies no timeout.
+type TimeoutConfig struct {
+	// Query bounds Execute, Count and Paginate (0 = no timeout)
+	Query time.Duration
+
+	// Mutation bounds Insert, Update, Delete, Truncate and BulkLoad
+	// (0 = no timeout)
+	Mutation time.Duration
+}
+
+// WithTimeouts sets default timeouts for queries and mutations. They only
+// apply when the caller's context has no deadline; an explicit deadline,
+// shorter or longer, always wins. Such an operation runs in a
+// transaction that sets statement_timeout with SET LOCAL, so the server
+// stops a runaway statement even if the client never cancels it.
+//
+// Example:
+//
+//	eng.WithTimeouts(engine.TimeoutConfig{Query: 5 * time.Second, Mutation: 10 * time.Second})
+func (e *Engine) WithTimeouts(cfg TimeoutConfig) *Engine {
+	e.timeouts = cfg
+	return e
+}
+
+// WithDefaultTimeout returns ctx bounded by timeout when ctx has no
+// deadline of its own and timeout is positive, and ctx unchanged
+// otherwise. The cancel function must always be called.
+func WithDefaultTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// WithMaxLimit caps the Limit a query may ask for, so a bad page size
+// can't pull a whole table. ToSQL/Execute reject larger limits with a
+// *LimitError unless LimitConfig.Clamp is set. 0 removes the cap.
+// Queries without a Limit are not affected; see WithLimits for a default.
+func (e *Engine) WithMaxLimit(max uint64) *Engine {
+	e.limits.Max = max
+	return e
+}
+
 // ─────────────────────────────────────────────────────────────
 // Schema handling
 // ─────────────────────────────────────────────────────────────
@@ -119,6 +315,10 @@ func (e *Engine) loadSchemaFromString(input string) (*Schema, error) {
 	if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
 		return nil, fmt.Errorf("failed to deserialize schema: %w", err)
 	}
+	// A core library newer than this engine may emit fields it would drop
+	if schema.FormatVersion > vault.SchemaFormat {
+		return nil, &vault.FormatError{Format: schema.FormatVersion, Supported: vault.SchemaFormat}
+	}
 	e.schema = &schema
 	return &schema, nil
 }
@@ -142,13 +342,68 @@ func (e *Engine) LoadSchemaFromFile(filepath string) (*Schema, error) {
 	return e.loadSchemaFromString(string(content))
 } */
 
-// LoadSchemaFromVault loads the merged schema (vault)
+// LoadSchemaFromVault loads the merged schema (vault). The integrity hash
+// only proves the file is unchanged, so the schema is validated as well.
 func (e *Engine) loadSchemaFromVault(filepath string) (*Schema, error) {
 	content, err := os.ReadFile(filepath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read schema file: %w", err)
 	}
-	return e.loadSchemaFromString(string(content))
+	schema, err := e.loadSchemaFromString(string(content))
+	if err != nil {
+		return nil, err
+	}
+	if err := schema.Validate(); err != nil {
+		e.schema = nil
+		return nil, err
+	}
+	return schema, nil
+}
+
+// LoadVersionSchema parses the schema stored for a vault version, for
+// diffing or inspecting past schemas:
+//
+//	before, err := engine.LoadVersionSchema(v, "v003")
+//
+// Snapshots hold the registered .cham source, which is parsed by the core;
+// snapshots stored as schema JSON (see vault.SerializeSchema) are decoded
+// directly.
+func LoadVersionSchema(v *vault.Vault, version string) (*Schema, error) {
+	entry, err := v.GetVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	if entry.FormatVersion > vault.SchemaFormat {
+		return nil, &vault.FormatError{Format: entry.FormatVersion, Requires: entry.FormatRequires, Supported: vault.SchemaFormat}
+	}
+	content, err := v.GetVersionContent(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSchemaJSON(content) {
+		schema, err := NewEngineForCLI().LoadSchemaFromString(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse version %s: %w", version, err)
+		}
+		return schema, nil
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return nil, fmt.Errorf("failed to decode version %s: %w", version, err)
+	}
+	if err := schema.Validate(); err != nil {
+		return nil, fmt.Errorf("version %s: %w", version, err)
+	}
+	return &schema, nil
+}
+
+// isSchemaJSON reports whether a snapshot holds schema JSON rather than
+// .cham source, which never starts with '{'
+func isSchemaJSON(content []byte) bool {
+	trimmed := strings.TrimSpace(string(content))
+	return strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed))
 }
 
 func resolveSchemaSourcePath(workDir string) (string, error) {
@@ -199,13 +454,38 @@ func (e *Engine) Connect(ctx context.Context, config ConnectorConfig) error {
 	}
 	e.executor = NewExecutor(e.connector)
 
+	if e.driftReporter != nil && e.schema != nil {
+		e.driftReporter(e.CheckDrift(ctx))
+	}
 	return nil
 }
 
-// Close closes the database connection
+// ConnectURL parses a connection string, either a postgresql:// URL or a
+// keyword DSN ("host=... dbname=..."), and connects with it. Pool settings
+// use DefaultConfig.
+//
+//	err := eng.ConnectURL(ctx, os.Getenv("DATABASE_URL"))
+func (e *Engine) ConnectURL(ctx context.Context, connStr string) error {
+	config, err := ParseConnectionString(connStr)
+	if err != nil {
+		return err
+	}
+	return e.Connect(ctx, config)
+}
+
+// Shutdown stops accepting new queries and mutations, waits for in-flight
+// ones to finish (or for ctx to expire), then closes the pools of the
+// default connection and every target. The pools are closed either way;
+// the error reports whether draining timed out.
+func (e *Engine) Shutdown(ctx context.Context) error {
+	return shutdownAll(ctx, e.connectors())
+}
+
+// Close closes the database connections immediately, cancelling in-flight
+// queries. Prefer Shutdown in long-running services.
 func (e *Engine) Close() {
-	if e.connector != nil {
-		e.connector.Close()
+	for _, c := range e.connectors() {
+		c.Close()
 	}
 }
 
@@ -236,6 +516,9 @@ func (e *Engine) GenerateMigration() (string, error) {
 	if e.schema == nil {
 		return "", fmt.Errorf("no schema loaded")
 	}
+	if err := e.schema.CheckMigrationTypes(); err != nil {
+		return "", err
+	}
 
 	schemaJSON, err := json.Marshal(e.schema)
 	if err != nil {
@@ -245,6 +528,137 @@ func (e *Engine) GenerateMigration() (string, error) {
 	return ffi.GenerateMigration(string(schemaJSON))
 }
 
+// Migration statement kinds
+const (
+	StatementCreateSchema = "create_schema"
+	StatementDropTable    = "drop_table"
+	StatementCreateTable  = "create_table"
+	StatementCreateIndex  = "create_index"
+	StatementComment      = "comment" // COMMENT ON for /// descriptions
+)
+
+// MigrationStatement is one DDL statement of a migration plan.
+// Concurrent statements (CREATE INDEX CONCURRENTLY) cannot run inside a
+// transaction; plans list them last. If one fails, Cleanup removes the
+// INVALID index it leaves behind so the migration can be retried.
+type MigrationStatement struct {
+	Kind        string `json:"kind"`
+	Entity      string `json:"entity,omitempty"` // Empty for namespace creation
+	Description string `json:"description"`      // e.g. "Create table users"
+	SQL         string `json:"sql"`
+	Concurrent  bool   `json:"concurrent,omitempty"`
+	Cleanup     string `json:"cleanup,omitempty"`
+}
+
+// GenerateMigrationPlan generates the same DDL as GenerateMigration, split
+// into statements in execution order so they can be reviewed and applied
+// one at a time
+func (e *Engine) GenerateMigrationPlan() ([]MigrationStatement, error) {
+	if e.schema == nil {
+		return nil, fmt.Errorf("no schema loaded")
+	}
+	if err := e.schema.CheckMigrationTypes(); err != nil {
+		return nil, err
+	}
+
+	schemaJSON, err := json.Marshal(e.schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize schema: %w", err)
+	}
+
+	planJSON, err := ffi.GenerateMigrationPlan(string(schemaJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []MigrationStatement
+	if err := json.Unmarshal([]byte(planJSON), &plan); err != nil {
+		return nil, fmt.Errorf("failed to deserialize migration plan: %w", err)
+	}
+	return plan, nil
+}
+
+// MigrationSQL joins a plan back into a single script, identical to the
+// output of GenerateMigration
+func MigrationSQL(plan []MigrationStatement) string {
+	parts := make([]string, len(plan))
+	for i, stmt := range plan {
+		parts[i] = stmt.SQL
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ifNotExistsClauses match the DDL that fails when its object already
+// exists; IdempotentPlan adds IF NOT EXISTS right after each match
+var ifNotExistsClauses = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^\s*CREATE\s+TABLE\s+`),
+	regexp.MustCompile(`(?i)^\s*CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?`),
+}
+
+// addColumnClause is rewritten only in ALTER TABLE statements, where
+// each of its (comma-separated) actions may add a column
+var (
+	alterTableStatement = regexp.MustCompile(`(?i)^\s*ALTER\s+TABLE\s+`)
+	addColumnClause     = regexp.MustCompile(`(?i)\bADD\s+COLUMN\s+`)
+)
+
+var ifNotExistsPrefix = regexp.MustCompile(`(?i)^IF\s+NOT\s+EXISTS\b`)
+
+// IdempotentPlan returns a copy of plan whose CREATE TABLE, CREATE INDEX
+// and ALTER TABLE ... ADD COLUMN statements use IF NOT EXISTS, so a plan
+// that partially ran before can be retried. Existing objects are skipped
+// without being compared to the definition, which can hide a real
+// conflict: an older table with other columns, or an INVALID index left
+// by a failed concurrent build (run its Cleanup first).
+func IdempotentPlan(plan []MigrationStatement) []MigrationStatement {
+	result := make([]MigrationStatement, len(plan))
+	for i, stmt := range plan {
+		for _, clause := range ifNotExistsClauses {
+			stmt.SQL = addIfNotExists(stmt.SQL, clause)
+		}
+		if alterTableStatement.MatchString(stmt.SQL) {
+			stmt.SQL = addIfNotExists(stmt.SQL, addColumnClause)
+		}
+		result[i] = stmt
+	}
+	return result
+}
+
+// addIfNotExists inserts IF NOT EXISTS after each match of clause outside
+// quoted literals and identifiers
+func addIfNotExists(sql string, clause *regexp.Regexp) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range clause.FindAllStringIndex(sql, -1) {
+		if inQuotes(sql, loc[0]) {
+			continue
+		}
+		b.WriteString(sql[last:loc[1]])
+		if !ifNotExistsPrefix.MatchString(sql[loc[1]:]) {
+			b.WriteString("IF NOT EXISTS ")
+		}
+		last = loc[1]
+	}
+	b.WriteString(sql[last:])
+	return b.String()
+}
+
+// inQuotes reports whether position pos of sql lies inside a
+// '...' literal or a "..." identifier. Doubled quotes inside them toggle
+// twice, so they need no special case.
+func inQuotes(sql string, pos int) bool {
+	var quote byte
+	for i := 0; i < pos; i++ {
+		switch c := sql[i]; {
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+		case c == quote:
+			quote = 0
+		}
+	}
+	return quote != 0
+}
+
 // ─────────────────────────────────────────────────────────────
 // Mutation API (uses registry pattern)
 // ─────────────────────────────────────────────────────────────
@@ -254,15 +668,15 @@ func (e *Engine) Insert(entity string) InsertMutation {
 	if e.schema == nil {
 		return newInvalidInsertMutation(fmt.Errorf("schema not loaded"))
 	}
-	if e.connector == nil {
-		return newInvalidInsertMutation(fmt.Errorf("not connected - call Connect() first"))
+	if err := e.mutationConnErr(); err != nil {
+		return newInvalidInsertMutation(err)
 	}
 
-	factory := getMutationFactory()
+	factory := e.mutationFactory()
 	if factory == nil {
 		return newInvalidInsertMutation(fmt.Errorf("no mutation factory registered"))
 	}
-	return factory.NewInsert(entity, e.schema, e.connector)
+	return factory.NewInsert(entity, e.schema, e.connector, e.mutationOptions())
 }
 
 // Update starts a new UPDATE mutation
@@ -270,15 +684,15 @@ func (e *Engine) Update(entity string) UpdateMutation {
 	if e.schema == nil {
 		return newInvalidUpdateMutation(fmt.Errorf("schema not loaded"))
 	}
-	if e.connector == nil {
-		return newInvalidUpdateMutation(fmt.Errorf("not connected - call Connect() first"))
+	if err := e.mutationConnErr(); err != nil {
+		return newInvalidUpdateMutation(err)
 	}
 
-	factory := getMutationFactory()
+	factory := e.mutationFactory()
 	if factory == nil {
 		return newInvalidUpdateMutation(fmt.Errorf("no mutation factory registered"))
 	}
-	return factory.NewUpdate(entity, e.schema, e.connector)
+	return factory.NewUpdate(entity, e.schema, e.connector, e.mutationOptions())
 }
 
 // Delete starts a new DELETE mutation
@@ -286,15 +700,124 @@ func (e *Engine) Delete(entity string) DeleteMutation {
 	if e.schema == nil {
 		return newInvalidDeleteMutation(fmt.Errorf("schema not loaded"))
 	}
-	if e.connector == nil {
-		return newInvalidDeleteMutation(fmt.Errorf("not connected - call Connect() first"))
+	if err := e.mutationConnErr(); err != nil {
+		return newInvalidDeleteMutation(err)
 	}
 
-	factory := getMutationFactory()
+	factory := e.mutationFactory()
 	if factory == nil {
 		return newInvalidDeleteMutation(fmt.Errorf("no mutation factory registered"))
 	}
-	return factory.NewDelete(entity, e.schema, e.connector)
+	return factory.NewDelete(entity, e.schema, e.connector, e.mutationOptions())
+}
+
+// Truncate starts a TRUNCATE of the entities' tables, restarting their
+// identity sequences and cascading to tables that reference them. It is
+// much faster than DELETE for clearing test data, and just as final, so
+// it must be confirmed with Force():
+//
+//	_, err := eng.Truncate("Order", "User").Force().Execute(ctx)
+func (e *Engine) Truncate(entities ...string) TruncateMutation {
+	if e.schema == nil {
+		return newInvalidTruncateMutation(fmt.Errorf("schema not loaded"))
+	}
+	if err := e.mutationConnErr(); err != nil {
+		return newInvalidTruncateMutation(err)
+	}
+
+	factory := e.mutationFactory()
+	if factory == nil {
+		return newInvalidTruncateMutation(fmt.Errorf("no mutation factory registered"))
+	}
+	return factory.NewTruncate(entities, e.schema, e.connector, e.mutationOptions())
+}
+
+// BulkLoad copies rows into the entity's table using COPY, which is much
+// faster than INSERT for seeding and ETL. Every row is validated first;
+// if any fails, a *BulkLoadError lists them and nothing is loaded.
+// Columns are copied in declared order. A row may omit a column other
+// rows set only if the column is nullable without a default, since COPY
+// writes NULL rather than the default.
+func (e *Engine) BulkLoad(ctx context.Context, entity string, rows []map[string]interface{}) (*BulkLoadResult, error) {
+	if e.schema == nil {
+		return nil, fmt.Errorf("schema not loaded")
+	}
+	if err := e.mutationConnErr(); err != nil {
+		return nil, err
+	}
+
+	factory := e.mutationFactory()
+	if factory == nil {
+		return nil, fmt.Errorf("no mutation factory registered")
+	}
+	return factory.BulkLoad(ctx, entity, rows, e.schema, e.connector, e.mutationOptions())
+}
+
+// SetValidatorConfig sets the validation settings for every mutation the
+// engine creates, including BulkLoad. A builder's WithValidatorConfig
+// still takes precedence for that one mutation.
+//
+// Example:
+//
+//	cfg := engine.DefaultValidatorConfig()
+//	cfg.ValidateFK = false
+//	eng.SetValidatorConfig(cfg)
+func (e *Engine) SetValidatorConfig(cfg ValidatorConfig) *Engine {
+	e.validatorConfig = &cfg
+	return e
+}
+
+// WithDryRun puts mutations in dry-run mode: Execute runs authorization,
+// tenant scoping, validation and SQL generation, prints Debug output, and
+// returns a result whose DryRun field holds the statement instead of
+// running it. No connection is needed and nothing is journaled, so test
+// harnesses can exercise the whole mutation pipeline without a database.
+//
+//	res, err := eng.WithDryRun(true).Insert("User").Set("email", email).Execute(ctx)
+//	// err: validation errors as usual; res.DryRun.SQL: "INSERT INTO users ..."
+func (e *Engine) WithDryRun(enabled bool) *Engine {
+	e.dryRun = enabled
+	return e
+}
+
+// WithBackend runs the engine's queries and mutations through b instead
+// of PostgreSQL, so no Connect is needed. It is meant for test doubles
+// such as the in-memory store in package enginetest:
+//
+//	store := enginetest.New(eng) // calls eng.WithBackend(store)
+func (e *Engine) WithBackend(b Backend) *Engine {
+	e.backend = b
+	e.executor = b
+	return e
+}
+
+// mutationConnErr is connectionErr for mutations, which need no
+// connection in dry-run mode or with a backend
+func (e *Engine) mutationConnErr() error {
+	if (e.dryRun || e.backend != nil) && e.targetErr == nil {
+		return nil
+	}
+	return e.connectionErr()
+}
+
+// mutationFactory returns the backend set with WithBackend, or the
+// registered factory
+func (e *Engine) mutationFactory() MutationFactory {
+	if e.backend != nil {
+		return e.backend
+	}
+	return getMutationFactory()
+}
+
+// mutationOptions collects the engine-level settings passed to mutation builders
+func (e *Engine) mutationOptions() MutationOptions {
+	return MutationOptions{
+		Authorizer: e.authorizer,
+		Journal:    e.journal,
+		Validator:  e.validatorConfig,
+		DryRun:     e.dryRun,
+		Timeout:    e.timeouts.Mutation,
+	}
 }
 
 // ─────────────────────────────────────────────────────────────
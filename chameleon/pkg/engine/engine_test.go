@@ -2,8 +2,15 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/vault"
 )
 
 func TestEngineInsertWithoutSchemaReturnsError(t *testing.T) {
@@ -124,3 +131,240 @@ func TestInvalidSchema(t *testing.T) {
 
 	t.Logf("Got expected error: %v", err)
 }
+
+func TestLoadVersionSchemaJSONSnapshot(t *testing.T) {
+	root := t.TempDir()
+	v := vault.NewVault(root)
+
+	stored := &Schema{Entities: []*Entity{{
+		Name:   "User",
+		Fields: map[string]*Field{"id": {Name: "id", Type: FieldTypeUUID, PrimaryKey: true}},
+	}}}
+	data, err := vault.SerializeSchema(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaPath := filepath.Join(root, "schema.json")
+	if err := os.WriteFile(schemaPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.RegisterVersion(schemaPath, "test", "Initial schema"); err != nil {
+		t.Fatalf("RegisterVersion() error = %v", err)
+	}
+
+	schema, err := LoadVersionSchema(v, "v001")
+	if err != nil {
+		t.Fatalf("LoadVersionSchema() error = %v", err)
+	}
+	user := schema.GetEntity("User")
+	if user == nil || user.Fields["id"] == nil || !user.Fields["id"].PrimaryKey || user.Fields["id"].Type != FieldTypeUUID {
+		t.Errorf("unexpected schema: %+v", schema.Entities)
+	}
+
+	if _, err := LoadVersionSchema(v, "v009"); err == nil || !strings.Contains(err.Error(), "version v009 not found") {
+		t.Errorf("expected an unknown version error, got %v", err)
+	}
+}
+
+func TestIsSchemaJSON(t *testing.T) {
+	tests := map[string]bool{
+		`{"entities": []}`:                      true,
+		"\n  {\"entities\": []}\n":              true,
+		"entity User {\n  id: uuid primary,\n}": false,
+		"{ not json":                            false,
+	}
+	for content, want := range tests {
+		if got := isSchemaJSON([]byte(content)); got != want {
+			t.Errorf("isSchemaJSON(%q) = %v, want %v", content, got, want)
+		}
+	}
+}
+
+func TestFindDrift(t *testing.T) {
+	schema := &Schema{Entities: []*Entity{
+		{Name: "User", Fields: map[string]*Field{
+			"id":    {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+			"email": {Name: "email", Type: FieldTypeString},
+			"bio":   {Name: "bio", Type: FieldTypeString, Nullable: true},
+		}, FieldOrder: []string{"id", "email", "bio"}},
+		{Name: "Invoice", Schema: "billing", Fields: map[string]*Field{
+			"id": {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+		}},
+		{Name: "Tag", Fields: map[string]*Field{
+			"id": {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+		}},
+	}}
+	columns := map[string]map[string]bool{
+		"public.users":     {"id": true, "email": true, "legacy": true},
+		"billing.invoices": {"id": true},
+		"other.tags":       {"id": true}, // wrong namespace
+	}
+	tableName := func(entity string) string { return strings.ToLower(entity) + "s" }
+
+	issues := findDrift(schema, tableName, "public", columns)
+	want := []DriftIssue{
+		{Entity: "User", Field: "bio", Table: "users"},
+		{Entity: "Tag", Table: "tags"},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Fatalf("findDrift() = %+v, want %+v", issues, want)
+	}
+	if got := issues[0].String(); got != "column users.bio of User does not exist" {
+		t.Errorf("unexpected issue text %q", got)
+	}
+}
+
+func TestCheckDriftNotConnected(t *testing.T) {
+	eng := NewEngineWithoutSchema()
+	if _, err := eng.CheckDrift(context.Background()); err == nil || err.Error() != "schema not loaded" {
+		t.Errorf("expected a schema error, got %v", err)
+	}
+	eng.schema = &Schema{}
+	if _, err := eng.CheckDrift(context.Background()); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected a connection error, got %v", err)
+	}
+}
+
+func TestDescribeSchema(t *testing.T) {
+	userID := "user_id"
+	schema := &Schema{Entities: []*Entity{
+		{Name: "User", Fields: map[string]*Field{
+			"id":        {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+			"email":     {Name: "email", Type: FieldTypeString, Unique: true},
+			"embedding": {Name: "embedding", Type: FieldType{Kind: "Vector", Param: 3.0}, Nullable: true},
+		}, FieldOrder: []string{"id", "email", "embedding"}, Relations: map[string]*Relation{
+			"orders": {Name: "orders", Kind: RelationHasMany, TargetEntity: "Order", ForeignKey: &userID},
+		}},
+		{Name: "Invoice", Schema: "billing", ReadOnly: true, Fields: map[string]*Field{
+			"id": {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+		}},
+	}}
+	tableName := func(entity string) string { return strings.ToLower(entity) + "s" }
+
+	desc := describeSchema(schema, tableName)
+	want := &SchemaDescription{Entities: []EntityDescription{
+		{
+			Name:  "User",
+			Table: "users",
+			Fields: []FieldDescription{
+				{Name: "id", Type: "UUID", PrimaryKey: true},
+				{Name: "email", Type: "String", Unique: true},
+				{Name: "embedding", Type: "Vector(3)", Nullable: true},
+			},
+			Relations: []RelationDescription{
+				{Name: "orders", Kind: "HasMany", Target: "Order", ForeignKey: "user_id"},
+			},
+		},
+		{
+			Name:      "Invoice",
+			Table:     "invoices",
+			Schema:    "billing",
+			ReadOnly:  true,
+			Fields:    []FieldDescription{{Name: "id", Type: "UUID", PrimaryKey: true}},
+			Relations: []RelationDescription{},
+		},
+	}}
+	if !reflect.DeepEqual(desc, want) {
+		t.Fatalf("describeSchema() = %+v, want %+v", desc, want)
+	}
+
+	if _, err := NewEngineWithoutSchema().DescribeSchema(); err == nil || err.Error() != "schema not loaded" {
+		t.Errorf("expected a schema error, got %v", err)
+	}
+}
+
+func TestListenRequiresConnectionAndChannel(t *testing.T) {
+	eng := NewEngineWithoutSchema()
+	if _, err := eng.Listen(context.Background(), "events"); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected a connection error, got %v", err)
+	}
+
+	eng.connector = NewConnector(DefaultConfig())
+	if _, err := eng.Listen(context.Background(), ""); err == nil || !strings.Contains(err.Error(), "channel name is required") {
+		t.Errorf("expected a channel error, got %v", err)
+	}
+}
+
+func TestRelistenReportsFailedAttempts(t *testing.T) {
+	config := DefaultConfig()
+	config.Host = "127.0.0.1"
+	config.Port = 1 // nothing listens here
+	config.ConnectTimeout = time.Second
+	connector := NewConnector(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), listenRetryMin+listenRetryMin/2)
+	defer cancel()
+
+	var reported []error
+	if conn := connector.relisten(ctx, "events", func(err error) { reported = append(reported, err) }); conn != nil {
+		t.Fatal("expected no connection")
+	}
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "listen events") {
+		t.Errorf("expected one failed attempt reported, got %v", reported)
+	}
+}
+
+func TestNewEngineFromSchemaJSON(t *testing.T) {
+	schemaJSON, err := reflectionSchema().ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eng, err := NewEngineFromSchemaJSON([]byte(schemaJSON))
+	if err != nil {
+		t.Fatalf("NewEngineFromSchemaJSON() error = %v", err)
+	}
+	if eng.Schema().GetEntity("Order") == nil {
+		t.Fatal("schema not loaded")
+	}
+	if _, err := eng.LoadSchemaFromString("entity Other {}"); err == nil {
+		t.Error("an embedded schema should not be replaceable")
+	}
+
+	// Validated like a vault schema
+	invalid := reflectionSchema()
+	invalid.Entities[0].Relations["ghosts"] = &Relation{Name: "ghosts", Kind: RelationBelongsTo, TargetEntity: "Ghost"}
+	invalidJSON, _ := invalid.ToJSON()
+	var schemaErr *SchemaError
+	if _, err := NewEngineFromSchemaJSON([]byte(invalidJSON)); !errors.As(err, &schemaErr) {
+		t.Errorf("expected *SchemaError, got %v", err)
+	}
+
+	var formatErr *vault.FormatError
+	if _, err := NewEngineFromSchemaJSON([]byte(`{"entities": [], "format_version": 999}`)); !errors.As(err, &formatErr) {
+		t.Errorf("expected *vault.FormatError, got %v", err)
+	}
+
+	if _, err := NewEngineFromSchemaFile(filepath.Join(t.TempDir(), "missing.cham")); err == nil {
+		t.Error("expected an error for a missing schema file")
+	}
+}
+
+func TestWithTimeouts(t *testing.T) {
+	e := NewEngineWithoutSchema().WithTimeouts(TimeoutConfig{Query: 5 * time.Second, Mutation: 10 * time.Second})
+
+	if got := e.mutationOptions().Timeout; got != 10*time.Second {
+		t.Errorf("mutation timeout = %v, want 10s", got)
+	}
+
+	ctx, cancel := WithDefaultTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > 5*time.Second {
+		t.Errorf("expected a 5s deadline, got %v (%v)", deadline, ok)
+	}
+
+	// The caller's deadline wins, even when it is longer
+	parent, parentCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer parentCancel()
+	ctx, cancel = WithDefaultTimeout(parent, 5*time.Second)
+	defer cancel()
+	if ctx != parent {
+		t.Error("a context with a deadline should be returned unchanged")
+	}
+
+	ctx, cancel = WithDefaultTimeout(context.Background(), 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("no timeout should leave the context without a deadline")
+	}
+}
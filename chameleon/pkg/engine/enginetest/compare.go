@@ -0,0 +1,190 @@
+package enginetest
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+)
+
+// Stored and filter values come in whatever Go type the caller used
+// (int, int64, "19.99", time.Time, a date string, ...). They are compared
+// the way PostgreSQL compares the column: numbers by value, timestamps as
+// instants, everything else as text.
+
+// normalize converts v to nil, float64, time.Time, bool or string
+func normalize(v interface{}) interface{} {
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case bool, time.Time, string:
+		return x
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", x[0:4], x[4:6], x[6:8], x[8:10], x[10:16])
+	case []byte:
+		return string(x)
+	case *big.Float:
+		f, _ := x.Float64()
+		return f
+	}
+	if f, ok := toFloat(v); ok {
+		return f
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// toFloat converts Go numbers to float64
+func toFloat(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case int:
+		return float64(x), true
+	case int8:
+		return float64(x), true
+	case int16:
+		return float64(x), true
+	case int32:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case uint:
+		return float64(x), true
+	case uint8:
+		return float64(x), true
+	case uint16:
+		return float64(x), true
+	case uint32:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case float32:
+		return float64(x), true
+	case float64:
+		return x, true
+	}
+	return 0, false
+}
+
+// compareValues orders a and b: -1, 0 or 1. ok is false when either is
+// NULL or they cannot be compared, which like SQL matches nothing.
+func compareValues(a, b interface{}) (result int, ok bool) {
+	a, b = normalize(a), normalize(b)
+	if a == nil || b == nil {
+		return 0, false
+	}
+
+	// Decimals are stored as text; compare them with numbers by value
+	if s, isString := a.(string); isString {
+		if _, isNumber := b.(float64); isNumber {
+			if f, err := parseFloat(s); err == nil {
+				a = f
+			}
+		}
+		if _, isTime := b.(time.Time); isTime {
+			if t, parsed := engine.ParseTimestamp(s); parsed {
+				a = t
+			}
+		}
+	}
+	if s, isString := b.(string); isString {
+		if _, isNumber := a.(float64); isNumber {
+			if f, err := parseFloat(s); err == nil {
+				b = f
+			}
+		}
+		if _, isTime := a.(time.Time); isTime {
+			if t, parsed := engine.ParseTimestamp(s); parsed {
+				b = t
+			}
+		}
+	}
+
+	switch x := a.(type) {
+	case float64:
+		y, same := b.(float64)
+		if !same {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case time.Time:
+		y, same := b.(time.Time)
+		if !same {
+			return 0, false
+		}
+		return x.Compare(y), true
+	case bool:
+		y, same := b.(bool)
+		if !same {
+			return 0, false
+		}
+		switch {
+		case x == y:
+			return 0, true
+		case !x:
+			return -1, true
+		}
+		return 1, true
+	case string:
+		return strings.Compare(x, fmt.Sprintf("%v", b)), true
+	}
+	return 0, false
+}
+
+// equalValues reports whether a and b hold the same value. Unlike SQL
+// equality, two NULLs are equal, which is what key lookups need.
+func equalValues(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	c, ok := compareValues(a, b)
+	return ok && c == 0
+}
+
+func parseFloat(s string) (float64, error) {
+	f, _, err := big.ParseFloat(strings.TrimSpace(s), 10, 64, big.ToNearestEven)
+	if err != nil {
+		return 0, err
+	}
+	v, _ := f.Float64()
+	return v, nil
+}
+
+// likeMatch reports whether s matches a LIKE pattern: % is any run of
+// characters, _ any single one and \ escapes the next character
+func likeMatch(s, pattern string, caseInsensitive bool) bool {
+	var expr strings.Builder
+	if caseInsensitive {
+		expr.WriteString("(?is)")
+	} else {
+		expr.WriteString("(?s)")
+	}
+	expr.WriteString("^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			expr.WriteString(".*")
+		case r == '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	return err == nil && re.MatchString(s)
+}
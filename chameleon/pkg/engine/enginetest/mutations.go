@@ -0,0 +1,895 @@
+package enginetest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+)
+
+// Each builder wraps the production builder in dry-run mode: Execute runs
+// it first for validation, authorization and tenant checks (and its Debug
+// output and ToSQL), then applies the change to the store.
+
+// dryRunOptions are the options for the wrapped production builder. It
+// never journals; the store records the mutation once it is applied.
+func dryRunOptions(opts engine.MutationOptions) engine.MutationOptions {
+	opts.DryRun = true
+	opts.Journal = nil
+	return opts
+}
+
+// journalFor returns the journal the store records to; dry runs change
+// nothing, so they are not journaled
+func journalFor(opts engine.MutationOptions) engine.JournalLogger {
+	if opts.DryRun {
+		return nil
+	}
+	return opts.Journal
+}
+
+func validatorFor(schema *engine.Schema, opts engine.MutationOptions) *engine.Validator {
+	if opts.Validator != nil {
+		return engine.NewValidator(schema, *opts.Validator)
+	}
+	return engine.NewValidator(schema, engine.DefaultValidatorConfig())
+}
+
+// condition is one mutation filter
+type condition struct {
+	field string
+	op    string
+	value interface{}
+}
+
+// matchConditions reports whether row satisfies every condition, with
+// values coerced like the production builders bind them
+func matchConditions(validator *engine.Validator, ent *engine.Entity, row engine.Row, conditions []condition) (bool, error) {
+	for _, cond := range conditions {
+		op := strings.ToLower(cond.op)
+		value := cond.value
+		switch op {
+		case "ilike_any":
+			patterns, err := engine.ContainsPatterns(cond.field, value)
+			if err != nil {
+				return false, err
+			}
+			value = patterns
+		case "isnull", "notnull":
+			value = nil
+		case "between":
+			from, to, err := engine.BetweenBounds(cond.field, value)
+			if err != nil {
+				return false, err
+			}
+			if from, err = validator.CoerceValue(ent.Fields[cond.field], cond.field, from); err != nil {
+				return false, err
+			}
+			if to, err = validator.CoerceValue(ent.Fields[cond.field], cond.field, to); err != nil {
+				return false, err
+			}
+			value = []interface{}{from, to}
+		default:
+			coerced, err := validator.CoerceValue(ent.Fields[cond.field], cond.field, value)
+			if err != nil {
+				return false, err
+			}
+			value = coerced
+		}
+		if !matchOp(row[cond.field], op, value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchOp applies a mutation filter operator to a stored value
+func matchOp(stored interface{}, op string, value interface{}) bool {
+	switch op {
+	case "eq":
+		c, ok := compareValues(stored, value)
+		return ok && c == 0
+	case "neq", "ne":
+		c, ok := compareValues(stored, value)
+		return ok && c != 0
+	case "gt":
+		c, ok := compareValues(stored, value)
+		return ok && c > 0
+	case "gte":
+		c, ok := compareValues(stored, value)
+		return ok && c >= 0
+	case "lt":
+		c, ok := compareValues(stored, value)
+		return ok && c < 0
+	case "lte":
+		c, ok := compareValues(stored, value)
+		return ok && c <= 0
+	case "like", "ilike":
+		s, ok := normalize(stored).(string)
+		pattern, _ := value.(string)
+		return ok && likeMatch(s, pattern, op == "ilike")
+	case "in":
+		for _, item := range sliceValues(value) {
+			if c, ok := compareValues(stored, item); ok && c == 0 {
+				return true
+			}
+		}
+		return false
+	case "nin":
+		// Like != ALL: an empty list excludes nothing, NULL matches nothing else
+		items := sliceValues(value)
+		if len(items) == 0 {
+			return true
+		}
+		if stored == nil {
+			return false
+		}
+		for _, item := range items {
+			if c, ok := compareValues(stored, item); ok && c == 0 {
+				return false
+			}
+		}
+		return true
+	case "isnull":
+		return stored == nil
+	case "notnull":
+		return stored != nil
+	case "between":
+		bounds := sliceValues(value)
+		if len(bounds) != 2 {
+			return false
+		}
+		low, lowOK := compareValues(stored, bounds[0])
+		high, highOK := compareValues(stored, bounds[1])
+		return lowOK && highOK && low >= 0 && high <= 0
+	case "ilike_any":
+		s, ok := normalize(stored).(string)
+		if !ok {
+			return false
+		}
+		for _, pattern := range sliceValues(value) {
+			if p, isString := pattern.(string); isString && likeMatch(s, p, true) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sliceValues returns the elements of a slice or array value
+func sliceValues(value interface{}) []interface{} {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items
+}
+
+// ============================================================
+// INSERT
+// ============================================================
+
+// NewInsert implements engine.MutationFactory
+func (s *Store) NewInsert(entity string, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) engine.InsertMutation {
+	return &insertMutation{
+		store:  s,
+		schema: schema,
+		entity: entity,
+		opts:   opts,
+		real:   s.real.NewInsert(entity, schema, connector, dryRunOptions(opts)),
+		values: make(map[string]interface{}),
+	}
+}
+
+type insertMutation struct {
+	store  *Store
+	schema *engine.Schema
+	entity string
+	opts   engine.MutationOptions
+	real   engine.InsertMutation
+
+	values     map[string]interface{}
+	rows       []map[string]interface{}
+	upsert     bool
+	conflict   []string
+	allTenants bool
+}
+
+func (im *insertMutation) Set(field string, value interface{}) engine.InsertMutation {
+	im.real.Set(field, value)
+	im.values[field] = value
+	return im
+}
+
+func (im *insertMutation) Rows(rows ...map[string]interface{}) engine.InsertMutation {
+	im.real.Rows(rows...)
+	im.rows = append(im.rows, rows...)
+	return im
+}
+
+func (im *insertMutation) Upsert(conflictFields ...string) engine.InsertMutation {
+	im.real.Upsert(conflictFields...)
+	im.upsert = true
+	im.conflict = conflictFields
+	return im
+}
+
+func (im *insertMutation) AllTenants() engine.InsertMutation {
+	im.real.AllTenants()
+	im.allTenants = true
+	return im
+}
+
+func (im *insertMutation) WithValidatorConfig(cfg engine.ValidatorConfig) engine.InsertMutation {
+	im.real.WithValidatorConfig(cfg)
+	im.opts.Validator = &cfg
+	return im
+}
+
+func (im *insertMutation) Debug() engine.InsertMutation {
+	im.real.Debug()
+	return im
+}
+
+func (im *insertMutation) ToSQL() (string, []interface{}, error) {
+	return im.real.ToSQL()
+}
+
+func (im *insertMutation) Execute(ctx context.Context) (*engine.InsertResult, error) {
+	start := time.Now()
+	result, err := im.execute(ctx)
+
+	affected := 0
+	if result != nil {
+		affected = result.Affected
+	}
+	engine.RecordOperation(journalFor(im.opts), engine.OperationInsert, im.entity, affected, time.Since(start), err)
+
+	return result, err
+}
+
+func (im *insertMutation) execute(ctx context.Context) (*engine.InsertResult, error) {
+	plan, err := im.real.Execute(ctx)
+	if err != nil || im.opts.DryRun {
+		return plan, err
+	}
+
+	ent := im.schema.GetEntity(im.entity)
+	scope, err := engine.ResolveTenantScope(ctx, ent, engine.OperationInsert, im.allTenants)
+	if err != nil {
+		return nil, err
+	}
+
+	input := []map[string]interface{}{im.values}
+	if len(im.rows) > 0 {
+		input = make([]map[string]interface{}, len(im.rows))
+		for i, row := range im.rows {
+			input[i] = merge(im.values, row)
+		}
+	}
+	for i, row := range input {
+		if scope != nil {
+			if _, ok := row[scope.Column]; !ok {
+				row = merge(row, map[string]interface{}{scope.Column: scope.Value})
+			}
+		}
+		input[i] = row
+	}
+
+	im.store.mu.Lock()
+	defer im.store.mu.Unlock()
+
+	conflict := im.conflict
+	if im.upsert && len(conflict) == 0 {
+		for _, key := range ent.PrimaryKeys() {
+			conflict = append(conflict, key.Name)
+		}
+	}
+
+	records, err := im.store.insert(ent, input, validatorFor(im.schema, im.opts), im.upsert, conflict, scope)
+	if err != nil {
+		if len(im.rows) > 0 {
+			return nil, err
+		}
+		return nil, unwrapRow(err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("INSERT executed but returned no rows (check required fields)")
+	}
+	return insertResult(ent, records), nil
+}
+
+// insert stores the rows, all or none. An upsert updates the row that
+// has the same conflict fields instead; a conflicting row of another
+// tenant is left alone and returns nothing, as in PostgreSQL.
+func (s *Store) insert(ent *engine.Entity, input []map[string]interface{}, validator *engine.Validator, upsert bool, conflict []string, scope *engine.TenantScope) ([]map[string]interface{}, error) {
+	now := time.Now().UTC()
+	rows := copyRows(s.rows[ent.Name])
+	serials := make(map[string]int64, len(s.serials))
+	for k, v := range s.serials {
+		serials[k] = v
+	}
+
+	var records []map[string]interface{}
+	for i, values := range input {
+		coerced := make(map[string]interface{}, len(values))
+		for field, value := range values {
+			v, err := validator.CoerceValue(ent.Fields[field], field, value)
+			if err != nil {
+				s.serials = serials
+				return nil, rowError(i, err)
+			}
+			coerced[field] = v
+		}
+
+		if upsert {
+			if at := indexOf(rows, coerced, conflict); at >= 0 {
+				existing := rows[at]
+				if scope != nil && !equalValues(existing[scope.Column], scope.Value) {
+					continue
+				}
+				updated := copyRow(existing)
+				for field, value := range coerced {
+					updated[field] = value
+				}
+				if ent.IsTimestampField(engine.UpdatedAtField) {
+					if _, ok := coerced[engine.UpdatedAtField]; !ok {
+						updated[engine.UpdatedAtField] = now
+					}
+				}
+				if err := s.checkRow(ent, rows, updated, at); err != nil {
+					s.serials = serials
+					return nil, rowError(i, err)
+				}
+				rows[at] = updated
+				records = append(records, copyRow(updated))
+				continue
+			}
+		}
+
+		record := s.newRecord(ent, coerced, now)
+		if err := s.checkRow(ent, rows, record, -1); err != nil {
+			s.serials = serials
+			return nil, rowError(i, err)
+		}
+		s.noteSerial(ent, record)
+		rows = append(rows, record)
+		records = append(records, copyRow(record))
+	}
+
+	s.rows[ent.Name] = rows
+	return records, nil
+}
+
+// checkRow enforces NOT NULL, unique and foreign key constraints on a
+// row about to be stored at position skip of rows (-1 = appended)
+func (s *Store) checkRow(ent *engine.Entity, rows []engine.Row, record engine.Row, skip int) error {
+	for _, name := range ent.FieldNames() {
+		field := ent.Fields[name]
+		if record[name] == nil && !field.Nullable && field.GeneratedExpression() == "" {
+			return &engine.NotNullError{
+				Field:      name,
+				Suggestion: fmt.Sprintf("Provide a value for %s", name),
+			}
+		}
+	}
+	if err := s.checkUnique(ent, rows, record, skip); err != nil {
+		return err
+	}
+	return s.checkReferences(ent.Name, record)
+}
+
+// indexOf returns the position of the row with the same values for
+// fields as values, or -1
+func indexOf(rows []engine.Row, values map[string]interface{}, fields []string) int {
+	if len(fields) == 0 {
+		return -1
+	}
+	for i, row := range rows {
+		match := true
+		for _, field := range fields {
+			value, ok := values[field]
+			if !ok || !equalValues(row[field], value) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// rowError names the input row an error comes from, like the production
+// builder does for multi-row inserts
+type rowErr struct {
+	row int
+	err error
+}
+
+func (e *rowErr) Error() string { return fmt.Sprintf("row %d: %v", e.row, e.err) }
+func (e *rowErr) Unwrap() error { return e.err }
+
+func rowError(i int, err error) error {
+	return &rowErr{row: i, err: err}
+}
+
+// unwrapRow drops the row number from errors of single-row inserts
+func unwrapRow(err error) error {
+	if re, ok := err.(*rowErr); ok {
+		return re.err
+	}
+	return err
+}
+
+func merge(base, over map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(over))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range over {
+		merged[k] = v
+	}
+	return merged
+}
+
+// insertResult mirrors the production result: ID and Record describe the
+// first row
+func insertResult(ent *engine.Entity, records []map[string]interface{}) *engine.InsertResult {
+	ids := make([]interface{}, len(records))
+	for i, record := range records {
+		ids[i] = recordID(ent, record)
+	}
+	return &engine.InsertResult{
+		ID:       ids[0],
+		Record:   records[0],
+		Affected: len(records),
+		IDs:      ids,
+		Records:  records,
+	}
+}
+
+// recordID returns the primary key of record: the bare value for a
+// single-column key, a map of column to value for a composite one
+func recordID(ent *engine.Entity, record map[string]interface{}) interface{} {
+	keys := ent.PrimaryKeys()
+	switch len(keys) {
+	case 0:
+		return record["id"]
+	case 1:
+		return record[keys[0].Name]
+	}
+	id := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		id[key.Name] = record[key.Name]
+	}
+	return id
+}
+
+// ============================================================
+// UPDATE
+// ============================================================
+
+// NewUpdate implements engine.MutationFactory
+func (s *Store) NewUpdate(entity string, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) engine.UpdateMutation {
+	return &updateMutation{
+		store:   s,
+		schema:  schema,
+		entity:  entity,
+		opts:    opts,
+		real:    s.real.NewUpdate(entity, schema, connector, dryRunOptions(opts)),
+		updates: make(map[string]interface{}),
+	}
+}
+
+type updateMutation struct {
+	store  *Store
+	schema *engine.Schema
+	entity string
+	opts   engine.MutationOptions
+	real   engine.UpdateMutation
+
+	filters    []condition
+	updates    map[string]interface{}
+	allTenants bool
+}
+
+func (um *updateMutation) Set(field string, value interface{}) engine.UpdateMutation {
+	um.real.Set(field, value)
+	um.updates[field] = value
+	return um
+}
+
+func (um *updateMutation) Filter(field string, op string, value interface{}) engine.UpdateMutation {
+	um.real.Filter(field, op, value)
+	um.filters = setCondition(um.filters, condition{field: field, op: op, value: value})
+	return um
+}
+
+func (um *updateMutation) AllTenants() engine.UpdateMutation {
+	um.real.AllTenants()
+	um.allTenants = true
+	return um
+}
+
+func (um *updateMutation) WithValidatorConfig(cfg engine.ValidatorConfig) engine.UpdateMutation {
+	um.real.WithValidatorConfig(cfg)
+	um.opts.Validator = &cfg
+	return um
+}
+
+func (um *updateMutation) Debug() engine.UpdateMutation {
+	um.real.Debug()
+	return um
+}
+
+func (um *updateMutation) ToSQL() (string, []interface{}, error) {
+	return um.real.ToSQL()
+}
+
+func (um *updateMutation) Execute(ctx context.Context) (*engine.UpdateResult, error) {
+	start := time.Now()
+	result, err := um.execute(ctx)
+
+	affected := 0
+	if result != nil {
+		affected = result.Affected
+	}
+	engine.RecordOperation(journalFor(um.opts), engine.OperationUpdate, um.entity, affected, time.Since(start), err)
+
+	return result, err
+}
+
+func (um *updateMutation) execute(ctx context.Context) (*engine.UpdateResult, error) {
+	plan, err := um.real.Execute(ctx)
+	if err != nil || um.opts.DryRun {
+		return plan, err
+	}
+
+	ent := um.schema.GetEntity(um.entity)
+	scope, err := engine.ResolveTenantScope(ctx, ent, engine.OperationUpdate, um.allTenants)
+	if err != nil {
+		return nil, err
+	}
+	filters := withTenantCondition(um.filters, scope)
+	validator := validatorFor(um.schema, um.opts)
+
+	updates := make(map[string]interface{}, len(um.updates))
+	for field, value := range um.updates {
+		v, err := validator.CoerceValue(ent.Fields[field], field, value)
+		if err != nil {
+			return nil, err
+		}
+		updates[field] = v
+	}
+	if ent.IsTimestampField(engine.UpdatedAtField) {
+		if _, ok := updates[engine.UpdatedAtField]; !ok {
+			updates[engine.UpdatedAtField] = time.Now().UTC()
+		}
+	}
+
+	um.store.mu.Lock()
+	defer um.store.mu.Unlock()
+
+	rows := copyRows(um.store.rows[um.entity])
+	var records []map[string]interface{}
+	for i, row := range rows {
+		match, err := matchConditions(validator, ent, row, filters)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+		for field, value := range updates {
+			row[field] = value
+		}
+		if err := um.store.checkRow(ent, rows, row, i); err != nil {
+			return nil, err
+		}
+		records = append(records, copyRow(row))
+	}
+
+	um.store.rows[um.entity] = rows
+	return &engine.UpdateResult{Records: records, Affected: len(records)}, nil
+}
+
+// setCondition adds cond, replacing an earlier filter on the same field
+// and operator as the production builders do
+func setCondition(conditions []condition, cond condition) []condition {
+	for i, existing := range conditions {
+		if existing.field == cond.field && existing.op == cond.op {
+			conditions[i] = cond
+			return conditions
+		}
+	}
+	return append(conditions, cond)
+}
+
+// withTenantCondition limits conditions to the tenant in scope
+func withTenantCondition(conditions []condition, scope *engine.TenantScope) []condition {
+	if scope == nil {
+		return conditions
+	}
+	scoped := append([]condition(nil), conditions...)
+	return append(scoped, condition{field: scope.Column, op: "eq", value: scope.Value})
+}
+
+// ============================================================
+// DELETE
+// ============================================================
+
+// NewDelete implements engine.MutationFactory
+func (s *Store) NewDelete(entity string, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) engine.DeleteMutation {
+	return &deleteMutation{
+		store:  s,
+		schema: schema,
+		entity: entity,
+		opts:   opts,
+		real:   s.real.NewDelete(entity, schema, connector, dryRunOptions(opts)),
+	}
+}
+
+type deleteMutation struct {
+	store  *Store
+	schema *engine.Schema
+	entity string
+	opts   engine.MutationOptions
+	real   engine.DeleteMutation
+
+	filters    []condition
+	allTenants bool
+	batchSize  int
+	onBatch    func(records []map[string]interface{}) error
+}
+
+func (dm *deleteMutation) Filter(field string, op string, value interface{}) engine.DeleteMutation {
+	dm.real.Filter(field, op, value)
+	dm.filters = setCondition(dm.filters, condition{field: field, op: op, value: value})
+	return dm
+}
+
+func (dm *deleteMutation) AllTenants() engine.DeleteMutation {
+	dm.real.AllTenants()
+	dm.allTenants = true
+	return dm
+}
+
+func (dm *deleteMutation) WithValidatorConfig(cfg engine.ValidatorConfig) engine.DeleteMutation {
+	dm.real.WithValidatorConfig(cfg)
+	dm.opts.Validator = &cfg
+	return dm
+}
+
+func (dm *deleteMutation) Batch(size int) engine.DeleteMutation {
+	dm.real.Batch(size)
+	dm.batchSize = size
+	return dm
+}
+
+func (dm *deleteMutation) OnBatch(fn func(records []map[string]interface{}) error) engine.DeleteMutation {
+	dm.real.OnBatch(fn)
+	dm.onBatch = fn
+	return dm
+}
+
+func (dm *deleteMutation) Debug() engine.DeleteMutation {
+	dm.real.Debug()
+	return dm
+}
+
+func (dm *deleteMutation) ToSQL() (string, []interface{}, error) {
+	return dm.real.ToSQL()
+}
+
+func (dm *deleteMutation) Execute(ctx context.Context) (*engine.DeleteResult, error) {
+	start := time.Now()
+	result, err := dm.execute(ctx)
+
+	affected := 0
+	if result != nil {
+		affected = result.Affected
+	}
+	engine.RecordOperation(journalFor(dm.opts), engine.OperationDelete, dm.entity, affected, time.Since(start), err)
+
+	return result, err
+}
+
+func (dm *deleteMutation) execute(ctx context.Context) (*engine.DeleteResult, error) {
+	plan, err := dm.real.Execute(ctx)
+	if err != nil || dm.opts.DryRun {
+		return plan, err
+	}
+
+	ent := dm.schema.GetEntity(dm.entity)
+	scope, err := engine.ResolveTenantScope(ctx, ent, engine.OperationDelete, dm.allTenants)
+	if err != nil {
+		return nil, err
+	}
+	filters := withTenantCondition(dm.filters, scope)
+	validator := validatorFor(dm.schema, dm.opts)
+
+	dm.store.mu.Lock()
+	defer dm.store.mu.Unlock()
+
+	var kept []engine.Row
+	var removed []map[string]interface{}
+	for _, row := range dm.store.rows[dm.entity] {
+		match, err := matchConditions(validator, ent, row, filters)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			kept = append(kept, row)
+			continue
+		}
+		if err := dm.store.checkDependents(dm.entity, row); err != nil {
+			return nil, err
+		}
+		removed = append(removed, map[string]interface{}(row))
+	}
+	dm.store.rows[dm.entity] = kept
+
+	result := &engine.DeleteResult{Affected: len(removed)}
+	if dm.batchSize > 0 {
+		// Batches run until one deletes fewer rows than the batch size
+		result.Batches = len(removed)/dm.batchSize + 1
+		if dm.onBatch != nil {
+			for i := 0; i < len(removed); i += dm.batchSize {
+				if err := dm.onBatch(removed[i:min(i+dm.batchSize, len(removed))]); err != nil {
+					return result, fmt.Errorf("delete batch %d: %w", i/dm.batchSize+1, err)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// ============================================================
+// TRUNCATE
+// ============================================================
+
+// NewTruncate implements engine.MutationFactory
+func (s *Store) NewTruncate(entities []string, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) engine.TruncateMutation {
+	return &truncateMutation{
+		store:    s,
+		schema:   schema,
+		entities: entities,
+		opts:     opts,
+		real:     s.real.NewTruncate(entities, schema, connector, dryRunOptions(opts)),
+	}
+}
+
+type truncateMutation struct {
+	store    *Store
+	schema   *engine.Schema
+	entities []string
+	opts     engine.MutationOptions
+	real     engine.TruncateMutation
+}
+
+func (tm *truncateMutation) Force() engine.TruncateMutation {
+	tm.real.Force()
+	return tm
+}
+
+func (tm *truncateMutation) AllTenants() engine.TruncateMutation {
+	tm.real.AllTenants()
+	return tm
+}
+
+func (tm *truncateMutation) Debug() engine.TruncateMutation {
+	tm.real.Debug()
+	return tm
+}
+
+func (tm *truncateMutation) ToSQL() (string, []interface{}, error) {
+	return tm.real.ToSQL()
+}
+
+func (tm *truncateMutation) Execute(ctx context.Context) (*engine.TruncateResult, error) {
+	start := time.Now()
+	result, err := tm.execute(ctx)
+
+	for _, entity := range tm.entities {
+		engine.RecordOperation(journalFor(tm.opts), engine.OperationTruncate, entity, 0, time.Since(start), err)
+	}
+
+	return result, err
+}
+
+// execute empties the tables, cascading to the entities that reference
+// them, and restarts their auto-numbering
+func (tm *truncateMutation) execute(ctx context.Context) (*engine.TruncateResult, error) {
+	plan, err := tm.real.Execute(ctx)
+	if err != nil || tm.opts.DryRun {
+		return plan, err
+	}
+
+	tm.store.mu.Lock()
+	defer tm.store.mu.Unlock()
+
+	pending := append([]string(nil), tm.entities...)
+	done := make(map[string]bool)
+	for len(pending) > 0 {
+		entity := pending[0]
+		pending = pending[1:]
+		if done[entity] {
+			continue
+		}
+		done[entity] = true
+
+		delete(tm.store.rows, entity)
+		for key := range tm.store.serials {
+			if strings.HasPrefix(key, entity+".") {
+				delete(tm.store.serials, key)
+			}
+		}
+		for _, other := range tm.schema.Entities {
+			for _, fk := range tm.schema.ForeignKeys(other.Name) {
+				if fk.ReferencedEntity == entity {
+					pending = append(pending, other.Name)
+				}
+			}
+		}
+	}
+
+	return &engine.TruncateResult{Tables: plan.Tables}, nil
+}
+
+// ============================================================
+// BULK LOAD
+// ============================================================
+
+// BulkLoad implements engine.MutationFactory. Rows are validated by the
+// production BulkLoad and then stored like a multi-row insert.
+func (s *Store) BulkLoad(ctx context.Context, entity string, rows []map[string]interface{}, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) (*engine.BulkLoadResult, error) {
+	start := time.Now()
+	result, err := s.bulkLoad(ctx, entity, rows, schema, connector, opts)
+
+	loaded := 0
+	if result != nil {
+		loaded = int(result.Loaded)
+	}
+	engine.RecordOperation(journalFor(opts), engine.OperationInsert, entity, loaded, time.Since(start), err)
+
+	return result, err
+}
+
+func (s *Store) bulkLoad(ctx context.Context, entity string, rows []map[string]interface{}, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) (*engine.BulkLoadResult, error) {
+	plan, err := s.real.BulkLoad(ctx, entity, rows, schema, connector, dryRunOptions(opts))
+	if err != nil || opts.DryRun || len(rows) == 0 {
+		return plan, err
+	}
+
+	ent := schema.GetEntity(entity)
+	scope, err := engine.ResolveTenantScope(ctx, ent, engine.OperationInsert, false)
+	if err != nil {
+		return nil, err
+	}
+	input := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		input[i] = row
+		if scope != nil {
+			if _, ok := row[scope.Column]; !ok {
+				input[i] = merge(row, map[string]interface{}{scope.Column: scope.Value})
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.insert(ent, input, validatorFor(schema, opts), false, nil, nil); err != nil {
+		return nil, err
+	}
+	return &engine.BulkLoadResult{Loaded: int64(len(rows)), Columns: plan.Columns}, nil
+}
@@ -0,0 +1,520 @@
+package enginetest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+)
+
+// Execute implements engine.QueryExecutor
+func (s *Store) Execute(ctx context.Context, qb *engine.QueryBuilder) (*engine.QueryResult, error) {
+	if err := qb.Authorize(ctx); err != nil {
+		return nil, err
+	}
+	query := qb.Query()
+	ent := s.schema.GetEntity(query.Entity)
+	if ent == nil {
+		return nil, &engine.UnknownEntityError{Entity: query.Entity, Available: s.schema.EntityNames()}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.match(ent, query.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relation counts are columns, so they can be ordered by
+	for _, count := range query.Counts {
+		for _, row := range rows {
+			related, err := s.relatedMatching(ent, count.Relation, row, count.Filters)
+			if err != nil {
+				return nil, err
+			}
+			row[count.Relation+"_count"] = int64(len(related))
+		}
+	}
+
+	if err := s.sortRows(ent, rows, query.OrderBy); err != nil {
+		return nil, err
+	}
+	if query.Distinct {
+		rows = distinct(rows, selectColumns(ent, query))
+	}
+	rows = window(rows, query.Offset, query.Limit)
+
+	relations, err := s.includes(ent, rows, query.Includes, qb.IncludeScope)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := selectColumns(ent, query)
+	result := &engine.QueryResult{
+		Entity:    query.Entity,
+		Rows:      project(rows, columns),
+		Relations: relations,
+	}
+	for _, name := range columns {
+		nullable := true
+		if field := ent.Fields[name]; field != nil {
+			nullable = field.Nullable
+		}
+		result.Columns = append(result.Columns, engine.ColumnMeta{Name: name, Nullable: nullable})
+	}
+	return result, nil
+}
+
+// Count implements engine.QueryExecutor
+func (s *Store) Count(ctx context.Context, qb *engine.QueryBuilder) (int64, error) {
+	if err := qb.Authorize(ctx); err != nil {
+		return 0, err
+	}
+	query := qb.Query()
+	ent := s.schema.GetEntity(query.Entity)
+	if ent == nil {
+		return 0, &engine.UnknownEntityError{Entity: query.Entity, Available: s.schema.EntityNames()}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.match(ent, query.Filters)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(rows)), nil
+}
+
+// match returns copies of the rows of ent that pass every filter
+func (s *Store) match(ent *engine.Entity, filters []engine.FilterExpr) ([]engine.Row, error) {
+	var rows []engine.Row
+	for _, row := range s.rows[ent.Name] {
+		ok, err := s.matchAll(ent, row, filters)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, copyRow(row))
+		}
+	}
+	return rows, nil
+}
+
+func (s *Store) matchAll(ent *engine.Entity, row engine.Row, filters []engine.FilterExpr) (bool, error) {
+	for _, filter := range filters {
+		ok, err := s.matchFilter(ent, row, filter)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (s *Store) matchFilter(ent *engine.Entity, row engine.Row, filter engine.FilterExpr) (bool, error) {
+	switch {
+	case filter.Condition != nil:
+		return s.matchPath(ent, row, filter.Condition.Field.Segments, filter.Condition)
+
+	case filter.Binary != nil:
+		left, err := s.matchFilter(ent, row, filter.Binary.Left)
+		if err != nil {
+			return false, err
+		}
+		if filter.Binary.Op == "Or" && left {
+			return true, nil
+		}
+		if filter.Binary.Op == "And" && !left {
+			return false, nil
+		}
+		return s.matchFilter(ent, row, filter.Binary.Right)
+
+	case filter.Exists != nil:
+		related, err := s.relatedMatching(ent, filter.Exists.Relation, row, filter.Exists.Filters)
+		if err != nil {
+			return false, err
+		}
+		return (len(related) > 0) != filter.Exists.Negated, nil
+	}
+	return true, nil
+}
+
+// matchPath applies a condition to a field of row, or for a path through
+// relations ("orders.total"), to any related row, like the SQL join
+func (s *Store) matchPath(ent *engine.Entity, row engine.Row, path []string, cond *engine.FilterCondition) (bool, error) {
+	if len(path) == 0 {
+		return false, fmt.Errorf("filter without a field")
+	}
+	if len(path) == 1 {
+		return matchCondition(row[path[0]], cond)
+	}
+
+	target, related, err := s.related(ent, path[0], row)
+	if err != nil {
+		return false, err
+	}
+	for _, child := range related {
+		ok, err := s.matchPath(target, child, path[1:], cond)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// matchCondition applies a query operator with SQL NULL semantics
+func matchCondition(stored interface{}, cond *engine.FilterCondition) (bool, error) {
+	value := filterValue(cond.Value)
+
+	switch cond.Op {
+	case "Is":
+		if value == nil {
+			return stored == nil, nil
+		}
+		want, _ := value.(bool)
+		got, ok := stored.(bool)
+		return ok && got == want, nil
+	case "Eq":
+		if value == nil {
+			return stored == nil, nil
+		}
+		return matchOp(stored, "eq", value), nil
+	case "Neq":
+		if value == nil {
+			return stored != nil, nil
+		}
+		return matchOp(stored, "neq", value), nil
+	case "Gt", "Gte", "Lt", "Lte", "Like", "In":
+		return matchOp(stored, strings.ToLower(cond.Op), value), nil
+	case "NotIn":
+		return matchOp(stored, "nin", value), nil
+	case "Between":
+		return matchOp(stored, "between", value), nil
+	case "IsNull":
+		return stored == nil, nil
+	case "IsNotNull":
+		return stored != nil, nil
+	case "IlikeAny":
+		return matchOp(stored, "ilike_any", value), nil
+	}
+	return false, fmt.Errorf("enginetest: unsupported filter operator %s", cond.Op)
+}
+
+// filterValue decodes a FilterValue ({"String": "x"}, {"List": [...]}, ...)
+func filterValue(fv engine.FilterValue) interface{} {
+	for kind, value := range fv {
+		switch kind {
+		case "Null":
+			return nil
+		case "List":
+			list, ok := value.([]engine.FilterValue)
+			if !ok {
+				return sliceValues(value)
+			}
+			items := make([]interface{}, len(list))
+			for i, item := range list {
+				items[i] = filterValue(item)
+			}
+			return items
+		}
+		return value
+	}
+	return nil
+}
+
+// related returns the target entity of relation and the rows of it that
+// belong to row
+func (s *Store) related(ent *engine.Entity, relation string, row engine.Row) (*engine.Entity, []engine.Row, error) {
+	rel := ent.RelationByName(relation)
+	if rel == nil {
+		return nil, nil, &engine.UnknownFieldError{Entity: ent.Name, Field: relation, Available: ent.RelationNames()}
+	}
+	target := s.schema.GetEntity(rel.TargetEntity)
+	if target == nil {
+		return nil, nil, &engine.UnknownEntityError{Entity: rel.TargetEntity, Available: s.schema.EntityNames()}
+	}
+
+	parentField, childField, err := s.relationKeys(ent, rel)
+	if err != nil {
+		return nil, nil, err
+	}
+	key := row[parentField]
+	if key == nil {
+		return target, nil, nil
+	}
+
+	var related []engine.Row
+	for _, child := range s.rows[target.Name] {
+		if equalValues(child[childField], key) {
+			related = append(related, child)
+		}
+	}
+	return target, related, nil
+}
+
+// relatedMatching is related filtered by filters on the target entity
+func (s *Store) relatedMatching(ent *engine.Entity, relation string, row engine.Row, filters []engine.FilterExpr) ([]engine.Row, error) {
+	target, related, err := s.related(ent, relation, row)
+	if err != nil {
+		return nil, err
+	}
+	var matched []engine.Row
+	for _, child := range related {
+		ok, err := s.matchAll(target, child, filters)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, child)
+		}
+	}
+	return matched, nil
+}
+
+// relationKeys returns the parent and child columns a relation joins on.
+// BelongsTo keeps the key on the parent; the other kinds on the child.
+func (s *Store) relationKeys(ent *engine.Entity, rel *engine.Relation) (parentField, childField string, err error) {
+	switch rel.Kind {
+	case engine.RelationHasMany, engine.RelationHasOne:
+		if rel.ForeignKey == nil {
+			return "", "", fmt.Errorf("enginetest: relation %s.%s has no foreign key", ent.Name, rel.Name)
+		}
+		return "id", *rel.ForeignKey, nil
+
+	case engine.RelationBelongsTo:
+		if rel.ForeignKey != nil {
+			return *rel.ForeignKey, "id", nil
+		}
+		// Declared on the other side: "orders: [Order] via user_id"
+		for _, fk := range s.schema.ForeignKeys(ent.Name) {
+			if fk.ReferencedEntity == rel.TargetEntity {
+				return fk.Field, fk.ReferencedField, nil
+			}
+		}
+		return rel.Name + "_id", "id", nil
+	}
+	return "", "", fmt.Errorf("enginetest: %s relation %s.%s is not supported", rel.Kind, ent.Name, rel.Name)
+}
+
+// sortRows orders rows like ORDER BY: NULLs last ascending and first
+// descending, as PostgreSQL does, except for relation aggregates of rows
+// without related rows, which always sort last
+func (s *Store) sortRows(ent *engine.Entity, rows []engine.Row, orderBy []engine.OrderByClause) error {
+	if len(orderBy) == 0 {
+		return nil
+	}
+
+	keys := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		keys[i] = make([]interface{}, len(orderBy))
+		for j, order := range orderBy {
+			if order.Relation == "" {
+				keys[i][j] = row[order.Field]
+				continue
+			}
+			value, err := s.aggregate(ent, row, order)
+			if err != nil {
+				return err
+			}
+			keys[i][j] = value
+		}
+	}
+
+	index := make([]int, len(rows))
+	for i := range index {
+		index[i] = i
+	}
+	sort.SliceStable(index, func(a, b int) bool {
+		for j, order := range orderBy {
+			x, y := keys[index[a]][j], keys[index[b]][j]
+			desc := order.Direction == "Desc"
+			nullsLast := !desc || order.Relation != ""
+			switch {
+			case x == nil && y == nil:
+				continue
+			case x == nil:
+				return !nullsLast
+			case y == nil:
+				return nullsLast
+			}
+			c, _ := compareValues(x, y)
+			if c == 0 {
+				continue
+			}
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	sorted := make([]engine.Row, len(rows))
+	for i, at := range index {
+		sorted[i] = rows[at]
+	}
+	copy(rows, sorted)
+	return nil
+}
+
+// aggregate computes an OrderByRelation value for row: 0 for count and
+// sum over no rows, NULL for the others
+func (s *Store) aggregate(ent *engine.Entity, row engine.Row, order engine.OrderByClause) (interface{}, error) {
+	related, err := s.relatedMatching(ent, order.Relation, row, order.Filters)
+	if err != nil {
+		return nil, err
+	}
+	if order.Aggregate == "Count" {
+		return float64(len(related)), nil
+	}
+
+	var result interface{}
+	sum, n := 0.0, 0
+	for _, child := range related {
+		value := normalize(child[order.Field])
+		if s, ok := value.(string); ok {
+			if f, err := parseFloat(s); err == nil {
+				value = f
+			}
+		}
+		if value == nil {
+			continue
+		}
+		if f, ok := value.(float64); ok {
+			sum += f
+		}
+		n++
+		switch order.Aggregate {
+		case "Min":
+			if c, ok := compareValues(value, result); result == nil || ok && c < 0 {
+				result = value
+			}
+		case "Max":
+			if c, ok := compareValues(value, result); result == nil || ok && c > 0 {
+				result = value
+			}
+		}
+	}
+
+	switch order.Aggregate {
+	case "Sum":
+		return sum, nil
+	case "Avg":
+		if n == 0 {
+			return nil, nil
+		}
+		return sum / float64(n), nil
+	}
+	return result, nil
+}
+
+// includes loads each include path level by level. Rows are stored under
+// the full path ("orders.items") and also under the leaf name when no
+// other relation holds it, as the executor does. Rows outside the tenant
+// scope of their include are left out.
+func (s *Store) includes(ent *engine.Entity, rows []engine.Row, includes []engine.IncludePath, scopeOf func(path string) *engine.TenantScope) (map[string][]engine.Row, error) {
+	relations := make(map[string][]engine.Row)
+	var paths []string
+	for _, include := range includes {
+		parent, parents := ent, rows
+		for depth, name := range include.Path {
+			path := strings.Join(include.Path[:depth+1], ".")
+			rel := parent.RelationByName(name)
+			if rel == nil {
+				return nil, &engine.UnknownFieldError{Entity: parent.Name, Field: name, Available: parent.RelationNames()}
+			}
+
+			loaded, done := relations[path]
+			if !done {
+				seen := make(map[interface{}]bool)
+				scope := scopeOf(path)
+				for _, row := range parents {
+					_, related, err := s.related(parent, name, row)
+					if err != nil {
+						return nil, err
+					}
+					for _, child := range related {
+						if scope != nil && !scope.Matches(child[scope.Column]) {
+							continue
+						}
+						key := normalize(child["id"])
+						if key != nil && seen[key] {
+							continue
+						}
+						seen[key] = true
+						loaded = append(loaded, copyRow(child))
+					}
+				}
+				relations[path] = loaded
+				paths = append(paths, path)
+			}
+
+			parent = s.schema.GetEntity(rel.TargetEntity)
+			parents = loaded
+		}
+	}
+
+	for _, path := range paths {
+		leaf := path[strings.LastIndex(path, ".")+1:]
+		if _, exists := relations[leaf]; !exists {
+			relations[leaf] = relations[path]
+		}
+	}
+	return relations, nil
+}
+
+// selectColumns lists the result columns: the selected fields (or every
+// field in declared order) followed by the relation counts
+func selectColumns(ent *engine.Entity, query engine.QueryJSON) []string {
+	columns := query.SelectFields
+	if len(columns) == 0 {
+		columns = ent.FieldNames()
+	}
+	columns = append([]string(nil), columns...)
+	for _, count := range query.Counts {
+		columns = append(columns, count.Relation+"_count")
+	}
+	return columns
+}
+
+func project(rows []engine.Row, columns []string) []engine.Row {
+	projected := make([]engine.Row, len(rows))
+	for i, row := range rows {
+		projected[i] = make(engine.Row, len(columns))
+		for _, column := range columns {
+			projected[i][column] = row[column]
+		}
+	}
+	return projected
+}
+
+// distinct drops rows whose columns repeat an earlier row's
+func distinct(rows []engine.Row, columns []string) []engine.Row {
+	seen := make(map[string]bool)
+	var kept []engine.Row
+	for _, row := range rows {
+		key := fmt.Sprintf("%v", valuesOf(row, columns))
+		if !seen[key] {
+			seen[key] = true
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+// window applies OFFSET and LIMIT
+func window(rows []engine.Row, offset, limit *uint64) []engine.Row {
+	if offset != nil {
+		if *offset >= uint64(len(rows)) {
+			return nil
+		}
+		rows = rows[*offset:]
+	}
+	if limit != nil && *limit < uint64(len(rows)) {
+		rows = rows[:*limit]
+	}
+	return rows
+}
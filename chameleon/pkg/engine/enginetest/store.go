@@ -0,0 +1,323 @@
+// Package enginetest provides an in-memory backend for engine.Engine, so
+// application code that reads and writes through the engine can be unit
+// tested without PostgreSQL or Docker.
+//
+//	eng, err := engine.NewEngineFromSchemaFile("schema.cham")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	store := enginetest.New(eng)
+//
+//	_, err = eng.Insert("User").Set("email", "ana@mail.com").Set("name", "Ana").Execute(ctx)
+//	users, err := eng.Query("User").Filter("email", "eq", "ana@mail.com").Execute(ctx)
+//
+// Mutations first run through the real builders in dry-run mode, so
+// validation, authorization, @tenant and @readonly errors are the ones
+// production returns; the rows are then kept in maps. Primary keys,
+// defaults, @timestamps, unique constraints and foreign keys are enforced
+// the way the generated migrations enforce them.
+//
+// Queries are validated and rendered to SQL as usual, which needs the
+// core library, and then evaluated against the stored rows. Many-to-many
+// relations are not supported.
+package enginetest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine/mutation"
+	"github.com/google/uuid"
+)
+
+// Store holds the rows of every entity in memory. It implements
+// engine.Backend; all methods are safe for concurrent use.
+type Store struct {
+	schema *engine.Schema
+
+	mu sync.RWMutex
+	// rows are the stored rows of each entity, in insertion order
+	rows map[string][]engine.Row
+	// serials are the last values handed out to auto-numbered columns,
+	// keyed by entity and field
+	serials map[string]int64
+
+	// real builds the production builders used to validate mutations
+	real *mutation.Factory
+}
+
+var _ engine.Backend = (*Store)(nil)
+
+// New returns an empty Store for eng's schema and makes it eng's backend
+// (see Engine.WithBackend)
+func New(eng *engine.Engine) *Store {
+	store := NewStore(eng.Schema())
+	eng.WithBackend(store)
+	return store
+}
+
+// NewStore returns an empty Store for schema
+func NewStore(schema *engine.Schema) *Store {
+	return &Store{
+		schema:  schema,
+		rows:    make(map[string][]engine.Row),
+		serials: make(map[string]int64),
+		real:    mutation.NewFactory(),
+	}
+}
+
+// Rows returns a copy of the stored rows of entity, in insertion order
+func (s *Store) Rows(entity string) []engine.Row {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyRows(s.rows[entity])
+}
+
+// Reset removes every stored row and restarts auto-numbering
+func (s *Store) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows = make(map[string][]engine.Row)
+	s.serials = make(map[string]int64)
+}
+
+// TableName implements engine.MutationFactory
+func (s *Store) TableName(entity string) string {
+	return s.real.TableName(entity)
+}
+
+// newRecord returns a row of ent with every column set: values from
+// input, then defaults and @timestamps the database would fill in, and
+// NULL for the rest. A primary key without a default stays NULL, so
+// checkRow rejects it like the NOT NULL constraint would.
+func (s *Store) newRecord(ent *engine.Entity, input map[string]interface{}, now time.Time) engine.Row {
+	record := make(engine.Row, len(ent.Fields))
+	for _, name := range ent.FieldNames() {
+		if value, ok := input[name]; ok {
+			record[name] = value
+			continue
+		}
+		record[name] = s.defaultValue(ent, ent.Fields[name], now)
+	}
+	return record
+}
+
+// defaultValue is the value a column left out of an INSERT gets
+func (s *Store) defaultValue(ent *engine.Entity, field *engine.Field, now time.Time) interface{} {
+	if ent.IsTimestampField(field.Name) {
+		return now
+	}
+	if field.Default == nil {
+		return nil
+	}
+
+	switch def := (*field.Default).(type) {
+	case string:
+		switch def {
+		case "Now":
+			return now
+		case "UUIDv4":
+			return uuid.NewString()
+		case "AutoIncrement":
+			return s.nextSerial(ent.Name, field.Name)
+		}
+	case map[string]interface{}:
+		if literal, ok := def["Literal"].(string); ok {
+			return literalValue(field, literal)
+		}
+	}
+	// generated(...) columns are computed by PostgreSQL and stay NULL here
+	return nil
+}
+
+// nextSerial returns the next value of an auto-numbered column
+func (s *Store) nextSerial(entity, field string) int64 {
+	key := entity + "." + field
+	s.serials[key]++
+	return s.serials[key]
+}
+
+// noteSerial keeps auto-numbering ahead of explicitly inserted values, so
+// a later generated key does not collide with them
+func (s *Store) noteSerial(ent *engine.Entity, record engine.Row) {
+	for _, key := range ent.PrimaryKeys() {
+		if key.Type.Kind != engine.FieldTypeInt.Kind {
+			continue
+		}
+		if n, ok := toFloat(record[key.Name]); ok && int64(n) > s.serials[ent.Name+"."+key.Name] {
+			s.serials[ent.Name+"."+key.Name] = int64(n)
+		}
+	}
+}
+
+// checkUnique rejects record when another row of ent (other than the one
+// at skip) already holds one of its unique values. NULLs never conflict,
+// and partial unique indexes are not checked.
+func (s *Store) checkUnique(ent *engine.Entity, rows []engine.Row, record engine.Row, skip int) error {
+	for _, fields := range uniqueKeys(ent) {
+		if hasNull(record, fields) {
+			continue
+		}
+		for i, other := range rows {
+			if i == skip || !sameValues(record, other, fields) {
+				continue
+			}
+			field := fields[0]
+			var value interface{} = record[field]
+			if len(fields) > 1 {
+				field = "(" + strings.Join(fields, ", ") + ")"
+				value = valuesOf(record, fields)
+			}
+			return &engine.UniqueConstraintError{
+				Field:          field,
+				Value:          value,
+				ConflictingRow: copyRow(other),
+				Table:          ent.Name,
+				Suggestion:     fmt.Sprintf("Use a different value for %s, or update the existing record", field),
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueKeys lists the column sets of ent that must be unique: the
+// primary key, unique fields and non-partial unique indexes
+func uniqueKeys(ent *engine.Entity) [][]string {
+	var keys [][]string
+	if pk := ent.PrimaryKeys(); len(pk) > 0 {
+		fields := make([]string, len(pk))
+		for i, key := range pk {
+			fields[i] = key.Name
+		}
+		keys = append(keys, fields)
+	}
+	for _, name := range ent.FieldNames() {
+		if field := ent.Fields[name]; field.Unique && !field.PrimaryKey {
+			keys = append(keys, []string{name})
+		}
+	}
+	for _, index := range ent.UniqueIndexes {
+		if index.Predicate == nil {
+			keys = append(keys, index.Fields)
+		}
+	}
+	return keys
+}
+
+// checkReferences rejects record when one of its foreign keys points to
+// a row that does not exist
+func (s *Store) checkReferences(entity string, record engine.Row) error {
+	for _, fk := range s.schema.ForeignKeys(entity) {
+		value := record[fk.Field]
+		if value == nil {
+			continue
+		}
+		if s.find(fk.ReferencedEntity, fk.ReferencedField, value) != nil {
+			continue
+		}
+		return &engine.ForeignKeyError{
+			Field:            fk.Field,
+			Value:            value,
+			ReferencedTable:  s.TableName(fk.ReferencedEntity),
+			ReferencedField:  fk.ReferencedField,
+			ReferencedEntity: fk.ReferencedEntity,
+			Suggestion:       fmt.Sprintf("Create the %s first, or check the %s value", fk.ReferencedEntity, fk.Field),
+		}
+	}
+	return nil
+}
+
+// checkDependents rejects deleting record while rows of other entities
+// still reference it, as the migrations' foreign keys do
+func (s *Store) checkDependents(entity string, record engine.Row) error {
+	for _, other := range s.schema.Entities {
+		for _, fk := range s.schema.ForeignKeys(other.Name) {
+			if fk.ReferencedEntity != entity {
+				continue
+			}
+			count := 0
+			for _, row := range s.rows[other.Name] {
+				if equalValues(row[fk.Field], record[fk.ReferencedField]) {
+					count++
+				}
+			}
+			if count > 0 {
+				return &engine.ForeignKeyConstraintError{
+					Entity:         entity,
+					ID:             record[fk.ReferencedField],
+					DependentTable: other.Name,
+					DependentCount: count,
+					Suggestion:     fmt.Sprintf("Delete the %s rows first", other.Name),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// find returns the first row of entity whose field equals value
+func (s *Store) find(entity, field string, value interface{}) engine.Row {
+	for _, row := range s.rows[entity] {
+		if equalValues(row[field], value) {
+			return row
+		}
+	}
+	return nil
+}
+
+// literalValue converts a default("...") literal to the field's type
+func literalValue(field *engine.Field, literal string) interface{} {
+	validator := engine.NewValidator(&engine.Schema{}, engine.ValidatorConfig{CoerceStrings: true})
+	if value, err := validator.CoerceValue(field, field.Name, literal); err == nil {
+		return value
+	}
+	return literal
+}
+
+func hasNull(record engine.Row, fields []string) bool {
+	for _, field := range fields {
+		if record[field] == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func sameValues(a, b engine.Row, fields []string) bool {
+	for _, field := range fields {
+		if !equalValues(a[field], b[field]) {
+			return false
+		}
+	}
+	return true
+}
+
+func valuesOf(record engine.Row, fields []string) []interface{} {
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		values[i] = record[field]
+	}
+	return values
+}
+
+func copyRow(row engine.Row) engine.Row {
+	if row == nil {
+		return nil
+	}
+	copied := make(engine.Row, len(row))
+	for k, v := range row {
+		copied[k] = v
+	}
+	return copied
+}
+
+func copyRows(rows []engine.Row) []engine.Row {
+	copied := make([]engine.Row, len(rows))
+	for i, row := range rows {
+		copied[i] = copyRow(row)
+	}
+	return copied
+}
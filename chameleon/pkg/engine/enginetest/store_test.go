@@ -0,0 +1,334 @@
+package enginetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+)
+
+func testSchema() *engine.Schema {
+	userID := "user_id"
+	var auto interface{} = "AutoIncrement"
+	var now interface{} = "Now"
+	var uuidv4 interface{} = "UUIDv4"
+
+	return &engine.Schema{
+		Entities: []*engine.Entity{
+			{
+				Name: "User",
+				Fields: map[string]*engine.Field{
+					"id":    {Name: "id", Type: engine.FieldTypeUUID, PrimaryKey: true, Default: &uuidv4},
+					"email": {Name: "email", Type: engine.FieldTypeString, Unique: true},
+					"name":  {Name: "name", Type: engine.FieldTypeString},
+					"age":   {Name: "age", Type: engine.FieldTypeInt, Nullable: true},
+				},
+				FieldOrder: []string{"id", "email", "name", "age"},
+				Relations: map[string]*engine.Relation{
+					"orders": {Name: "orders", Kind: engine.RelationHasMany, TargetEntity: "Order", ForeignKey: &userID},
+				},
+			},
+			{
+				Name: "Order",
+				Fields: map[string]*engine.Field{
+					"id":         {Name: "id", Type: engine.FieldTypeInt, PrimaryKey: true, Default: &auto},
+					"total":      {Name: "total", Type: engine.FieldTypeDecimal},
+					"user_id":    {Name: "user_id", Type: engine.FieldTypeUUID},
+					"created_at": {Name: "created_at", Type: engine.FieldTypeTimestamp, Default: &now},
+					"updated_at": {Name: "updated_at", Type: engine.FieldTypeTimestamp, Default: &now},
+				},
+				FieldOrder: []string{"id", "total", "user_id", "created_at", "updated_at"},
+				Relations: map[string]*engine.Relation{
+					"user": {Name: "user", Kind: engine.RelationBelongsTo, TargetEntity: "User"},
+				},
+				Timestamps: true,
+			},
+		},
+	}
+}
+
+// setup returns an engine backed by a fresh store
+func setup(t *testing.T) (*engine.Engine, *Store) {
+	t.Helper()
+
+	schemaJSON, err := testSchema().ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	eng, err := engine.NewEngineFromSchemaJSON([]byte(schemaJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eng, New(eng)
+}
+
+func insertUser(t *testing.T, eng *engine.Engine, email, name string) string {
+	t.Helper()
+	res, err := eng.Insert("User").Set("email", email).Set("name", name).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("insert %s: %v", email, err)
+	}
+	return res.ID.(string)
+}
+
+func insertOrder(t *testing.T, eng *engine.Engine, userID, total string) {
+	t.Helper()
+	if _, err := eng.Insert("Order").Set("user_id", userID).Set("total", total).Execute(context.Background()); err != nil {
+		t.Fatalf("insert order: %v", err)
+	}
+}
+
+func TestInsertFillsGeneratedColumns(t *testing.T) {
+	eng, store := setup(t)
+	ctx := context.Background()
+
+	userID := insertUser(t, eng, "ana@mail.com", "Ana")
+	if userID == "" {
+		t.Fatal("expected a generated uuid primary key")
+	}
+
+	res, err := eng.Insert("Order").Rows(
+		map[string]interface{}{"user_id": userID, "total": "10.00"},
+		map[string]interface{}{"user_id": userID, "total": "20.00"},
+	).Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Affected != 2 || res.IDs[0] != int64(1) || res.IDs[1] != int64(2) {
+		t.Errorf("expected serial ids 1 and 2, got %+v", res.IDs)
+	}
+	if res.Record["created_at"] == nil || res.Record["updated_at"] == nil {
+		t.Errorf("@timestamps columns not filled: %+v", res.Record)
+	}
+
+	if got := len(store.Rows("Order")); got != 2 {
+		t.Errorf("expected 2 stored orders, got %d", got)
+	}
+}
+
+func TestMutationsEnforceConstraints(t *testing.T) {
+	eng, _ := setup(t)
+	ctx := context.Background()
+	userID := insertUser(t, eng, "ana@mail.com", "Ana")
+
+	// Validation comes from the production builders
+	var notNull *engine.NotNullError
+	if _, err := eng.Insert("User").Set("email", "bob@mail.com").Execute(ctx); !errors.As(err, &notNull) {
+		t.Errorf("expected NotNullError for a missing name, got %v", err)
+	}
+
+	var unique *engine.UniqueConstraintError
+	if _, err := eng.Insert("User").Set("email", "ana@mail.com").Set("name", "Other").Execute(ctx); !errors.As(err, &unique) {
+		t.Fatalf("expected UniqueConstraintError, got %v", err)
+	}
+	if unique.Field != "email" || unique.ConflictingRow["id"] != userID {
+		t.Errorf("unexpected unique error: %+v", unique)
+	}
+
+	var fk *engine.ForeignKeyError
+	if _, err := eng.Insert("Order").Set("user_id", "00000000-0000-0000-0000-000000000000").Set("total", "1").Execute(ctx); !errors.As(err, &fk) {
+		t.Errorf("expected ForeignKeyError, got %v", err)
+	}
+
+	insertOrder(t, eng, userID, "5.00")
+	var dependents *engine.ForeignKeyConstraintError
+	if _, err := eng.Delete("User").Filter("id", "eq", userID).Execute(ctx); !errors.As(err, &dependents) {
+		t.Errorf("expected ForeignKeyConstraintError, got %v", err)
+	}
+
+	var safety *engine.SafetyError
+	if _, err := eng.Delete("Order").Execute(ctx); !errors.As(err, &safety) {
+		t.Errorf("expected SafetyError for a delete without filters, got %v", err)
+	}
+}
+
+func TestPrimaryKeyWithoutDefaultIsRequired(t *testing.T) {
+	schema := testSchema()
+	schema.Entities[0].Fields["id"].Default = nil
+	schemaJSON, err := schema.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	eng, err := engine.NewEngineFromSchemaJSON([]byte(schemaJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := New(eng)
+	ctx := context.Background()
+
+	// Like PostgreSQL, the store does not make up a key the schema does not generate
+	var notNull *engine.NotNullError
+	if _, err := eng.Insert("User").Set("email", "ana@mail.com").Set("name", "Ana").Execute(ctx); !errors.As(err, &notNull) || notNull.Field != "id" {
+		t.Errorf("expected NotNullError for id, got %v", err)
+	}
+
+	id := "7b0e3c1a-5d2f-4c8e-9a1b-2c3d4e5f6a7b"
+	if _, err := eng.Insert("User").Set("id", id).Set("email", "ana@mail.com").Set("name", "Ana").Execute(ctx); err != nil {
+		t.Fatalf("insert with an explicit id: %v", err)
+	}
+	if rows := store.Rows("User"); len(rows) != 1 || rows[0]["id"] != id {
+		t.Errorf("expected the explicit id to be stored, got %+v", rows)
+	}
+}
+
+func TestUpdateAndDelete(t *testing.T) {
+	eng, store := setup(t)
+	ctx := context.Background()
+	insertUser(t, eng, "ana@mail.com", "Ana")
+	insertUser(t, eng, "bob@mail.com", "Bob")
+
+	updated, err := eng.Update("User").Filter("email", "eq", "bob@mail.com").Set("age", 30).Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Affected != 1 || updated.Records[0]["age"] != 30 {
+		t.Errorf("unexpected update result: %+v", updated)
+	}
+
+	updated, err = eng.Update("User").Filter("age", "isnull", nil).Set("age", 20).Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Affected != 1 || updated.Records[0]["email"] != "ana@mail.com" {
+		t.Errorf("expected only ana to have no age, got %+v", updated)
+	}
+
+	var batches [][]map[string]interface{}
+	deleted, err := eng.Delete("User").Filter("email", "like", "%@mail.com").Batch(1).
+		OnBatch(func(records []map[string]interface{}) error {
+			batches = append(batches, records)
+			return nil
+		}).
+		Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted.Affected != 2 || deleted.Batches != 3 || len(batches) != 2 || len(batches[0]) != 1 {
+		t.Errorf("unexpected delete result: %+v, batches %v", deleted, batches)
+	}
+	if len(store.Rows("User")) != 0 {
+		t.Errorf("rows left after delete: %v", store.Rows("User"))
+	}
+}
+
+func TestUpsertUpdatesExistingRow(t *testing.T) {
+	eng, store := setup(t)
+	ctx := context.Background()
+	userID := insertUser(t, eng, "ana@mail.com", "Ana")
+
+	res, err := eng.Insert("User").Set("email", "ana@mail.com").Set("name", "Ana María").Upsert("email").Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ID != userID {
+		t.Errorf("upsert should return the existing row, got id %v", res.ID)
+	}
+	if rows := store.Rows("User"); len(rows) != 1 || rows[0]["name"] != "Ana María" {
+		t.Errorf("unexpected rows after upsert: %v", rows)
+	}
+}
+
+func TestDryRunStoresNothing(t *testing.T) {
+	eng, store := setup(t)
+	eng.WithDryRun(true)
+
+	res, err := eng.Insert("User").Set("email", "ana@mail.com").Set("name", "Ana").Execute(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.DryRun == nil {
+		t.Error("expected a dry-run plan")
+	}
+	if len(store.Rows("User")) != 0 {
+		t.Error("dry run should not store rows")
+	}
+}
+
+func TestTruncateCascades(t *testing.T) {
+	eng, store := setup(t)
+	ctx := context.Background()
+	insertOrder(t, eng, insertUser(t, eng, "ana@mail.com", "Ana"), "1")
+
+	if _, err := eng.Truncate("User").Execute(ctx); err == nil {
+		t.Error("truncate without Force should fail")
+	}
+	if _, err := eng.Truncate("User").Force().Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Rows("User")) != 0 || len(store.Rows("Order")) != 0 {
+		t.Error("truncate should empty the table and the tables referencing it")
+	}
+
+	// Identity restarts
+	insertOrder(t, eng, insertUser(t, eng, "ana@mail.com", "Ana"), "1")
+	if id := store.Rows("Order")[0]["id"]; id != int64(1) {
+		t.Errorf("expected order id 1 after truncate, got %v", id)
+	}
+}
+
+// Queries are evaluated by Store.Execute; going through QueryBuilder.Execute
+// also renders the SQL, which needs the core library
+func TestExecuteFiltersOrdersAndIncludes(t *testing.T) {
+	eng, store := setup(t)
+	ctx := context.Background()
+
+	ana := insertUser(t, eng, "ana@mail.com", "Ana")
+	bob := insertUser(t, eng, "bob@mail.com", "Bob")
+	insertUser(t, eng, "carl@mail.com", "Carl")
+	insertOrder(t, eng, ana, "10.50")
+	insertOrder(t, eng, bob, "100")
+	insertOrder(t, eng, bob, "5")
+
+	result, err := store.Execute(ctx, eng.Query("User").Filter("orders.total", "gt", 50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 1 || result.Rows[0]["email"] != "bob@mail.com" {
+		t.Errorf("expected only bob, got %v", result.Rows)
+	}
+
+	result, err = store.Execute(ctx, eng.Query("User").
+		WhereHas("orders", nil).
+		OrderByRelation("orders", "sum", "total", "desc").
+		Include("orders").
+		WithCount("orders", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 2 || result.Rows[0]["email"] != "bob@mail.com" {
+		t.Fatalf("expected bob then ana, got %v", result.Rows)
+	}
+	if got := result.Rows[0].RelationCount("orders"); got != 2 {
+		t.Errorf("expected 2 orders for bob, got %d", got)
+	}
+	if got := len(result.Relations["orders"]); got != 3 {
+		t.Errorf("expected 3 included orders, got %d", got)
+	}
+
+	page, err := store.Execute(ctx, eng.Query("User").Select("email").OrderBy("email", "desc").Offset(1).Limit(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Rows) != 1 || page.Rows[0]["email"] != "bob@mail.com" || len(page.Rows[0]) != 1 {
+		t.Errorf("unexpected page: %v", page.Rows)
+	}
+
+	others, err := store.Execute(ctx, eng.Query("User").Filter("email", "nin", []string{"ana@mail.com", "bob@mail.com"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(others.Rows) != 1 || others.Rows[0]["email"] != "carl@mail.com" {
+		t.Errorf("expected only carl, got %v", others.Rows)
+	}
+
+	total, err := store.Count(ctx, eng.Query("Order").Filter("total", "between", []int{5, 20}))
+	if err != nil || total != 2 {
+		t.Errorf("Count() between = %d, %v; want 2", total, err)
+	}
+
+	total, err = store.Count(ctx, eng.Query("Order").Filter("user_id", "eq", bob))
+	if err != nil || total != 2 {
+		t.Errorf("Count() = %d, %v; want 2", total, err)
+	}
+}
@@ -1,6 +1,11 @@
 package engine
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ============================================================
 // VALIDATION ERRORS (Before SQL generation)
@@ -28,6 +33,39 @@ func (e *ValidationError) Error() string {
 func (e *ValidationError) Code() string     { return "VALIDATION_ERROR" }
 func (e *ValidationError) IsMutationError() {}
 
+// RowError is a validation failure for one row of a bulk operation
+type RowError struct {
+	Row int // 0-based index into the input rows
+	Err error
+}
+
+// BulkLoadError: Rows rejected before a bulk load started; nothing was copied
+type BulkLoadError struct {
+	Entity string
+	Rows   []RowError
+}
+
+func (e *BulkLoadError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "BulkLoadError: %d row(s) of %s failed validation, nothing was loaded", len(e.Rows), e.Entity)
+	for _, row := range e.Rows {
+		fmt.Fprintf(&sb, "\n  row %d: %s", row.Row, strings.ReplaceAll(row.Err.Error(), "\n", "\n    "))
+	}
+	return sb.String()
+}
+
+func (e *BulkLoadError) Code() string     { return "BULK_LOAD_VALIDATION" }
+func (e *BulkLoadError) IsMutationError() {}
+
+// Unwrap exposes the row errors to errors.Is / errors.As
+func (e *BulkLoadError) Unwrap() []error {
+	errs := make([]error, len(e.Rows))
+	for i, row := range e.Rows {
+		errs[i] = row.Err
+	}
+	return errs
+}
+
 // ============================================================
 // TYPE ERRORS
 // ============================================================
@@ -117,7 +155,7 @@ type MutationError interface {
 
 // ConstraintError: Generic constraint violation
 type ConstraintError struct {
-	Type       string // "unique", "not_null", "check", "foreign_key"
+	Type       string // "unique", "not_null", "check", "foreign_key", "generated"
 	Field      string
 	Value      interface{}
 	Suggestion string
@@ -142,16 +180,21 @@ type UniqueConstraintError struct {
 	Value          interface{}
 	ConflictingRow map[string]interface{} // The existing row
 	Table          string
+	Condition      string // Predicate of a partial unique index; empty = always unique
 	Suggestion     string
 }
 
 func (e *UniqueConstraintError) Error() string {
+	scope := ""
+	if e.Condition != "" {
+		scope = fmt.Sprintf(" where %s", e.Condition)
+	}
 	return fmt.Sprintf(
-		"UniqueConstraintError: Field '%s' must be unique\n"+
+		"UniqueConstraintError: Field '%s' must be unique%s\n"+
 			"  Value: %v\n"+
 			"  Conflict: %s(id=%v) already has this value\n"+
 			"  Suggestion: %s",
-		e.Field, e.Value,
+		e.Field, scope, e.Value,
 		e.Table, e.ConflictingRow["id"],
 		e.Suggestion,
 	)
@@ -343,11 +386,12 @@ func (e *SafetyError) Error() string {
 func (e *SafetyError) Code() string     { return "SAFETY_VIOLATION" }
 func (e *SafetyError) IsMutationError() {}
 
-// AuthorizationError: User not authorized (v0.2)
+// AuthorizationError: Operation not allowed on entity (e.g. @readonly)
 type AuthorizationError struct {
 	Operation string
 	Entity    string
 	Message   string
+	Err       error // Policy error returned by the Authorizer, if any
 }
 
 func (e *AuthorizationError) Error() string {
@@ -361,6 +405,66 @@ func (e *AuthorizationError) Error() string {
 
 func (e *AuthorizationError) Code() string     { return "AUTHORIZATION_DENIED" }
 func (e *AuthorizationError) IsMutationError() {}
+func (e *AuthorizationError) Unwrap() error    { return e.Err }
+
+// ============================================================
+// CONTEXT ERRORS
+// ============================================================
+
+// QueryCancelledError: ctx was cancelled while the statement was running
+type QueryCancelledError struct {
+	Operation string // "SELECT", "INSERT", "UPDATE", "DELETE"
+	Entity    string
+	Err       error
+}
+
+func (e *QueryCancelledError) Error() string {
+	return fmt.Sprintf(
+		"QueryCancelledError: %s on %s was cancelled\n"+
+			"  Cause: %v",
+		e.Operation, e.Entity, e.Err,
+	)
+}
+
+func (e *QueryCancelledError) Code() string     { return "QUERY_CANCELLED" }
+func (e *QueryCancelledError) IsMutationError() {}
+func (e *QueryCancelledError) Unwrap() error    { return e.Err }
+
+// QueryTimeoutError: ctx deadline expired while the statement was running
+type QueryTimeoutError struct {
+	Operation string
+	Entity    string
+	Err       error
+}
+
+func (e *QueryTimeoutError) Error() string {
+	return fmt.Sprintf(
+		"QueryTimeoutError: %s on %s exceeded its deadline\n"+
+			"  Cause: %v",
+		e.Operation, e.Entity, e.Err,
+	)
+}
+
+func (e *QueryTimeoutError) Code() string     { return "QUERY_TIMEOUT" }
+func (e *QueryTimeoutError) IsMutationError() {}
+func (e *QueryTimeoutError) Unwrap() error    { return e.Err }
+
+// ContextError converts a driver error caused by context cancellation or
+// deadline into QueryCancelledError / QueryTimeoutError.
+// Returns nil when err is not context-related.
+func ContextError(err error, operation, entity string) error {
+	switch {
+	case err == nil:
+		return nil
+	case IsQueryCancelledError(err) || IsQueryTimeoutError(err):
+		return err
+	case errors.Is(err, context.DeadlineExceeded):
+		return &QueryTimeoutError{Operation: operation, Entity: entity, Err: err}
+	case errors.Is(err, context.Canceled):
+		return &QueryCancelledError{Operation: operation, Entity: entity, Err: err}
+	}
+	return nil
+}
 
 // ============================================================
 // HELPER FUNCTIONS
@@ -386,6 +490,24 @@ func IsSafetyError(err error) bool {
 	return ok
 }
 
+// IsAuthorizationError checks if error is an authorization denial
+func IsAuthorizationError(err error) bool {
+	_, ok := err.(*AuthorizationError)
+	return ok
+}
+
+// IsQueryCancelledError checks if error is a context cancellation
+func IsQueryCancelledError(err error) bool {
+	_, ok := err.(*QueryCancelledError)
+	return ok
+}
+
+// IsQueryTimeoutError checks if error is a context deadline expiry
+func IsQueryTimeoutError(err error) bool {
+	_, ok := err.(*QueryTimeoutError)
+	return ok
+}
+
 // IsConstraintError checks if error is constraint-related
 func IsConstraintError(err error) bool {
 	switch err.(type) {
@@ -1,6 +1,9 @@
 package engine
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -418,3 +421,56 @@ func TestAuthorizationError(t *testing.T) {
 
 	var _ MutationError = err
 }
+
+func TestAuthorizerCheck(t *testing.T) {
+	var nilAuth Authorizer
+	if err := nilAuth.Check(context.Background(), OperationSelect, "User"); err != nil {
+		t.Fatalf("nil authorizer should allow, got %v", err)
+	}
+
+	policyErr := errors.New("read access revoked")
+	deny := Authorizer(func(op, entity string, ctx context.Context) error {
+		if op == OperationSelect {
+			return policyErr
+		}
+		return nil
+	})
+
+	if err := deny.Check(context.Background(), OperationInsert, "User"); err != nil {
+		t.Fatalf("expected INSERT to be allowed, got %v", err)
+	}
+
+	err := deny.Check(context.Background(), OperationSelect, "User")
+	if !IsAuthorizationError(err) {
+		t.Fatalf("expected AuthorizationError, got %v", err)
+	}
+	if !errors.Is(err, policyErr) {
+		t.Error("AuthorizationError should wrap the policy error")
+	}
+}
+
+func TestContextError(t *testing.T) {
+	if err := ContextError(errors.New("syntax error"), OperationSelect, "User"); err != nil {
+		t.Errorf("non-context error should map to nil, got %v", err)
+	}
+
+	wrapped := fmt.Errorf("timeout: %w", context.DeadlineExceeded)
+	err := ContextError(wrapped, OperationUpdate, "User")
+	if !IsQueryTimeoutError(err) {
+		t.Fatalf("expected QueryTimeoutError, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("QueryTimeoutError should wrap the original error")
+	}
+	if ErrorCode(err) != "QUERY_TIMEOUT" {
+		t.Errorf("unexpected code %s", ErrorCode(err))
+	}
+
+	err = ContextError(context.Canceled, OperationSelect, "User")
+	if !IsQueryCancelledError(err) {
+		t.Fatalf("expected QueryCancelledError, got %v", err)
+	}
+	if again := ContextError(err, OperationSelect, "User"); again != err {
+		t.Error("typed context errors should pass through unchanged")
+	}
+}
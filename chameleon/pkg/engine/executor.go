@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // Executor runs queries against PostgreSQL
@@ -19,37 +22,108 @@ func NewExecutor(connector *Connector) *Executor {
 	return &Executor{connector: connector}
 }
 
-// Execute runs a QueryBuilder against the database
+// Execute runs a QueryBuilder against the database, applying the
+// engine's default and maximum Limit (see Engine.WithLimits) and its
+// query timeout (see Engine.WithTimeouts)
 func (ex *Executor) Execute(ctx context.Context, qb *QueryBuilder) (*QueryResult, error) {
+	start := time.Now()
+	qb = qb.withLimits()
+	result, err := ex.execute(ctx, qb)
+
+	affected := 0
+	if result != nil {
+		affected = len(result.Rows)
+	}
+	RecordOperation(qb.engine.journal, OperationSelect, qb.query.Entity, affected, time.Since(start), err)
+
+	return result, err
+}
+
+func (ex *Executor) execute(ctx context.Context, qb *QueryBuilder) (*QueryResult, error) {
 	if !ex.connector.IsConnected() {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
+	done, err := ex.connector.BeginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	if err := qb.Authorize(ctx); err != nil {
+		return nil, err
+	}
+	qb, err = qb.withTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate SQL
 	generated, err := qb.ToSQL()
 	if err != nil {
 		return nil, fmt.Errorf("SQL generation failed: %w", err)
 	}
 
+	eagerQueries, err := scopeEagerQueries(generated.EagerQueries, qb.IncludeScope)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create identity map for this query.
 	identityMap := NewIdentityMap()
 
-	// Execute main query
-	mainRows, err := ex.executeQuery(ctx, generated.MainQuery)
+	// The main and eager queries share the timeout
+	var mainRows []Row
+	var columns []ColumnMeta
+	var relations map[string][]Row
+	err = ex.connector.RunWithTimeout(ctx, qb.engine.timeouts.Query, func(ctx context.Context) error {
+		// Execute main query
+		var err error
+		mainRows, columns, err = ex.executeQuery(ctx, qb.query.Entity, generated.MainQuery, generated.Args()...)
+		if err != nil {
+			if ctxErr := ContextError(err, OperationSelect, qb.query.Entity); ctxErr != nil {
+				return ctxErr
+			}
+			return fmt.Errorf("main query failed: %w", err)
+		}
+
+		// Deduplicate main rows.
+		mainRows = identityMap.Deduplicate(qb.query.Entity, mainRows)
+
+		// Execute eager queries
+		fetch := func(entity, sql string) ([]Row, error) {
+			rows, _, err := ex.executeQuery(ctx, entity, sql)
+			return rows, err
+		}
+		relations, err = loadRelations(eagerQueries, mainRows, identityMap, fetch)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("main query failed: %w", err)
+		return nil, contextOr(ctx, err, qb.query.Entity)
 	}
 
-	// Deduplicate main rows.
-	mainRows = identityMap.Deduplicate(qb.query.Entity, mainRows)
+	return &QueryResult{
+		Entity:    qb.query.Entity,
+		Rows:      mainRows,
+		Relations: relations,
+		Columns:   markNullable(columns, qb.engine.schema.GetEntity(qb.query.Entity)),
+		schema:    qb.engine.schema,
+	}, nil
+}
 
-	// Execute eager queries
+// loadRelations runs the eager queries and returns the rows of each
+// relation path, also stored under the leaf name when no other relation
+// holds it. An include reached through several paths often renders the
+// same SQL; each distinct statement runs once per Execute and the paths
+// share its rows.
+func loadRelations(eagerQueries [][]string, mainRows []Row, identityMap *IdentityMap, fetch func(entity, sql string) ([]Row, error)) (map[string][]Row, error) {
 	relations := make(map[string][]Row)
 	relationIDs := map[string][]interface{}{
 		"": extractIDs(mainRows, "id"),
 	}
+	fetched := make(map[string][]Row)
 
-	for _, eager := range generated.EagerQueries {
+	for _, eager := range eagerQueries {
 		if len(eager) < 2 {
 			return nil, fmt.Errorf("invalid eager query format")
 		}
@@ -70,15 +144,22 @@ func (ex *Executor) Execute(ctx context.Context, qb *QueryBuilder) (*QueryResult
 			return nil, fmt.Errorf("eager query '%s' failed: %w", relName, err)
 		}
 
-		eagerRows, err := ex.executeQuery(ctx, sql)
-		if err != nil {
-			return nil, fmt.Errorf("eager query '%s' failed: %w", relName, err)
+		eagerRows, done := fetched[sql]
+		if !done {
+			// Deduplicate eager rows.
+			entityName := inferEntityNameFromRelation(relName)
+
+			eagerRows, err = fetch(entityName, sql)
+			if err != nil {
+				if ctxErr := ContextError(err, OperationSelect, entityName); ctxErr != nil {
+					return nil, ctxErr
+				}
+				return nil, fmt.Errorf("eager query '%s' failed: %w", relName, err)
+			}
+			eagerRows = identityMap.Deduplicate(entityName, eagerRows)
+			fetched[sql] = eagerRows
 		}
 
-		// Deduplicate eager rows.
-		entityName := inferEntityNameFromRelation(relName)
-		eagerRows = identityMap.Deduplicate(entityName, eagerRows)
-
 		relations[relName] = eagerRows
 		if leaf := relationLeafName(relName); leaf != relName {
 			if _, exists := relations[leaf]; !exists {
@@ -88,11 +169,29 @@ func (ex *Executor) Execute(ctx context.Context, qb *QueryBuilder) (*QueryResult
 		relationIDs[relName] = extractIDs(eagerRows, "id")
 	}
 
-	return &QueryResult{
-		Entity:    qb.query.Entity,
-		Rows:      mainRows,
-		Relations: relations,
-	}, nil
+	return relations, nil
+}
+
+// scopeEagerQueries limits the eager queries of @tenant includes to the
+// tenant in scope (see QueryBuilder.IncludeScope)
+func scopeEagerQueries(eagerQueries [][]string, scopeOf func(path string) *TenantScope) ([][]string, error) {
+	scoped := make([][]string, len(eagerQueries))
+	for i, eager := range eagerQueries {
+		scoped[i] = eager
+		if len(eager) < 2 {
+			continue
+		}
+		scope := scopeOf(eager[0])
+		if scope == nil {
+			continue
+		}
+		literal, err := sqlLiteral(scope.Value)
+		if err != nil {
+			return nil, fmt.Errorf("eager query '%s' failed: tenant %w", eager[0], err)
+		}
+		scoped[i] = []string{eager[0], fmt.Sprintf("%s\nAND %s = %s", eager[1], scope.Column, literal)}
+	}
+	return scoped, nil
 }
 
 // inferEntityNameFromRelation infers entity name from relation name.
@@ -137,26 +236,49 @@ func relationLeafName(relName string) string {
 	return relName[idx+1:]
 }
 
-// executeQuery runs a single SQL query and returns rows.
-func (ex *Executor) executeQuery(ctx context.Context, sql string) ([]Row, error) {
-	rows, err := ex.connector.Pool().Query(ctx, sql)
+// executeQuery runs a single SQL query and returns rows and columns.
+// Context cancellation and deadlines are reported as typed errors.
+func (ex *Executor) executeQuery(ctx context.Context, entity string, sql string, args ...interface{}) ([]Row, []ColumnMeta, error) {
+	rows, err := ex.connector.Querier(ctx).Query(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, contextOr(ctx, err, entity)
 	}
 	defer rows.Close()
 
-	return scanRows(rows)
+	result, columns, err := scanRows(rows)
+	if err != nil {
+		return nil, nil, contextOr(ctx, err, entity)
+	}
+	return result, columns, nil
+}
+
+// contextOr returns a typed context error when ctx ended, err otherwise.
+// pgx does not always wrap ctx.Err() into the errors it returns.
+func contextOr(ctx context.Context, err error, entity string) error {
+	if ctxErr := ContextError(err, OperationSelect, entity); ctxErr != nil {
+		return ctxErr
+	}
+	if ctxErr := ContextError(ctx.Err(), OperationSelect, entity); ctxErr != nil {
+		return ctxErr
+	}
+	return err
 }
 
-// scanRows converts pgx rows into Row.
-func scanRows(rows pgx.Rows) ([]Row, error) {
+// scanRows converts pgx rows into Row and describes their columns.
+func scanRows(rows pgx.Rows) ([]Row, []ColumnMeta, error) {
 	var result []Row
 	columns := rows.FieldDescriptions()
 
+	var types *pgtype.Map
+	if conn := rows.Conn(); conn != nil {
+		types = conn.TypeMap()
+	}
+	meta := columnMeta(columns, types)
+
 	for rows.Next() {
 		values, err := rows.Values()
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
 		row := make(Row)
@@ -167,10 +289,46 @@ func scanRows(rows pgx.Rows) ([]Row, error) {
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return result, nil
+	return result, meta, nil
+}
+
+// columnMeta describes result columns in SELECT order. Type names come
+// from the connection's type map (nil = names unknown). Every column
+// starts out nullable; see markNullable.
+func columnMeta(fields []pgconn.FieldDescription, types *pgtype.Map) []ColumnMeta {
+	columns := make([]ColumnMeta, len(fields))
+	for i, field := range fields {
+		columns[i] = ColumnMeta{
+			Name:     field.Name,
+			TypeOID:  field.DataTypeOID,
+			Nullable: true,
+		}
+		if types == nil {
+			continue
+		}
+		if t, ok := types.TypeForOID(field.DataTypeOID); ok {
+			columns[i].TypeName = t.Name
+		}
+	}
+	return columns
+}
+
+// markNullable takes nullability from the entity's fields. PostgreSQL
+// does not report it for result columns, so columns without a schema
+// field (such as relation counts) stay nullable.
+func markNullable(columns []ColumnMeta, ent *Entity) []ColumnMeta {
+	if ent == nil {
+		return columns
+	}
+	for i := range columns {
+		if field, ok := ent.Fields[columns[i].Name]; ok {
+			columns[i].Nullable = field.Nullable && !field.PrimaryKey
+		}
+	}
+	return columns
 }
 
 // extractIDs pulls a field from all rows and converts UUID values to string.
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ToCSV writes the main rows as CSV: a header line with the column names,
+// then one line per row. Columns follow Columns (SELECT order); NULL is an
+// empty field. Values are formatted as described in exportValue.
+func (qr *QueryResult) ToCSV(w io.Writer) error {
+	columns := qr.exportColumns()
+	out := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for _, row := range qr.Rows {
+		for i, col := range columns {
+			text, err := csvField(exportValue(row[col.Name], col))
+			if err != nil {
+				return fmt.Errorf("column %s: %w", col.Name, err)
+			}
+			record[i] = text
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// ToNDJSON writes the main rows as newline-delimited JSON, one object per
+// row with keys in column order. Values are formatted as described in
+// exportValue; decimals are written as exact JSON numbers.
+func (qr *QueryResult) ToNDJSON(w io.Writer) error {
+	columns := qr.exportColumns()
+	out := bufio.NewWriter(w)
+
+	for _, row := range qr.Rows {
+		out.WriteByte('{')
+		for i, col := range columns {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			key, _ := json.Marshal(col.Name)
+			value, err := json.Marshal(exportValue(row[col.Name], col))
+			if err != nil {
+				return fmt.Errorf("column %s: %w", col.Name, err)
+			}
+			out.Write(key)
+			out.WriteByte(':')
+			out.Write(value)
+		}
+		out.WriteString("}\n")
+	}
+
+	return out.Flush()
+}
+
+// exportColumns returns the columns to export. Results built without
+// column metadata fall back to every field seen in the rows, sorted.
+func (qr *QueryResult) exportColumns() []ColumnMeta {
+	if len(qr.Columns) > 0 {
+		return qr.Columns
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, row := range qr.Rows {
+		for name := range row {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	columns := make([]ColumnMeta, len(names))
+	for i, name := range names {
+		columns[i] = ColumnMeta{Name: name, Nullable: true}
+	}
+	return columns
+}
+
+// exportValue normalizes a scanned value so CSV and NDJSON agree:
+// UUIDs as canonical strings, timestamps as RFC 3339 in UTC (dates as
+// YYYY-MM-DD), decimals as exact json.Number, bytea as \x hex. JSON
+// columns are left as decoded.
+func exportValue(v interface{}, col ColumnMeta) interface{} {
+	switch value := v.(type) {
+	case nil, string, bool, int64, int32, int16, float64, float32:
+		return value
+	case [16]byte:
+		return uuidToString(value)
+	case []byte:
+		return `\x` + hex.EncodeToString(value)
+	case time.Time:
+		if col.TypeName == "date" {
+			return value.Format("2006-01-02")
+		}
+		return value.UTC().Format(time.RFC3339Nano)
+	case pgtype.Numeric:
+		if !value.Valid {
+			return nil
+		}
+		if value.NaN || value.InfinityModifier != pgtype.Finite {
+			text, _ := value.Value()
+			return text
+		}
+		text, err := value.MarshalJSON()
+		if err != nil {
+			return nil
+		}
+		return json.Number(text)
+	case map[string]interface{}, []interface{}:
+		return value
+	case driver.Valuer:
+		// Other pgtype values (intervals, ranges, ...) use their text form
+		text, err := value.Value()
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return exportValue(text, col)
+	case fmt.Stringer:
+		return value.String()
+	}
+	return v
+}
+
+// csvField renders a normalized value as one CSV field
+func csvField(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(value), 'g', -1, 32), nil
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	}
+	return fmt.Sprint(v), nil
+}
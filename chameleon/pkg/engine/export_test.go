@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func exportTestResult() *QueryResult {
+	created := time.Date(2024, 3, 1, 14, 30, 0, 0, time.FixedZone("ART", -3*3600))
+	return &QueryResult{
+		Entity: "User",
+		Columns: []ColumnMeta{
+			{Name: "id", TypeName: "uuid"},
+			{Name: "name", TypeName: "text"},
+			{Name: "balance", TypeName: "numeric", Nullable: true},
+			{Name: "created_at", TypeName: "timestamptz"},
+			{Name: "birthday", TypeName: "date", Nullable: true},
+		},
+		Rows: []Row{
+			{
+				"id":         [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00},
+				"name":       "Ana, \"the admin\"",
+				"balance":    pgtype.Numeric{Int: big.NewInt(1250), Exp: -2, Valid: true},
+				"created_at": created,
+				"birthday":   time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				"id":         [16]byte{},
+				"name":       "Bob",
+				"balance":    nil,
+				"created_at": created,
+				"birthday":   nil,
+			},
+		},
+	}
+}
+
+func TestQueryResult_ToCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := exportTestResult().ToCSV(&buf); err != nil {
+		t.Fatalf("ToCSV failed: %v", err)
+	}
+
+	want := "id,name,balance,created_at,birthday\n" +
+		"550e8400-e29b-41d4-a716-446655440000,\"Ana, \"\"the admin\"\"\",12.50,2024-03-01T17:30:00Z,1990-05-17\n" +
+		"00000000-0000-0000-0000-000000000000,Bob,,2024-03-01T17:30:00Z,\n"
+	if buf.String() != want {
+		t.Errorf("unexpected CSV:\n got: %q\nwant: %q", buf.String(), want)
+	}
+}
+
+func TestQueryResult_ToNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := exportTestResult().ToNDJSON(&buf); err != nil {
+		t.Fatalf("ToNDJSON failed: %v", err)
+	}
+
+	// Keys keep column order and decimals stay exact
+	want := `{"id":"550e8400-e29b-41d4-a716-446655440000","name":"Ana, \"the admin\"","balance":12.50,"created_at":"2024-03-01T17:30:00Z","birthday":"1990-05-17"}` + "\n" +
+		`{"id":"00000000-0000-0000-0000-000000000000","name":"Bob","balance":null,"created_at":"2024-03-01T17:30:00Z","birthday":null}` + "\n"
+	if buf.String() != want {
+		t.Errorf("unexpected NDJSON:\n got: %s\nwant: %s", buf.String(), want)
+	}
+}
+
+func TestQueryResult_ExportWithoutColumns(t *testing.T) {
+	result := &QueryResult{Rows: []Row{
+		{"name": "Ana", "age": int64(30)},
+		{"name": "Bob", "tags": []interface{}{"a", "b"}},
+	}}
+
+	var buf bytes.Buffer
+	if err := result.ToCSV(&buf); err != nil {
+		t.Fatalf("ToCSV failed: %v", err)
+	}
+
+	// Without column metadata every field seen is exported, sorted
+	want := "age,name,tags\n30,Ana,\n,Bob,\"[\"\"a\"\",\"\"b\"\"]\"\n"
+	if buf.String() != want {
+		t.Errorf("unexpected CSV:\n got: %q\nwant: %q", buf.String(), want)
+	}
+}
@@ -7,20 +7,74 @@ import (
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine/mutation"
 )
 
+// GenerateOptions configures schema generation
+type GenerateOptions struct {
+	// TypeMapping overrides the default database-to-ChameleonDB type rules
+	TypeMapping TypeMapping
+}
+
 // GenerateChameleonSchema converts introspected tables to .cham format
 func GenerateChameleonSchema(tables []TableInfo) (string, error) {
+	return GenerateChameleonSchemaWithOptions(tables, GenerateOptions{})
+}
+
+// GenerateChameleonSchemaWithOptions converts introspected tables to .cham
+// format. Columns of unmapped types are generated as string fields with a
+// comment pointing them out; see UnmappedColumns.
+func GenerateChameleonSchemaWithOptions(tables []TableInfo, opts GenerateOptions) (string, error) {
+	if err := opts.TypeMapping.Validate(); err != nil {
+		return "", err
+	}
+
 	var sb strings.Builder
 
 	sb.WriteString("// Auto-generated by: chameleon introspect\n")
 	sb.WriteString("// Review and adjust relations manually\n\n")
 
+	// Entity names are unqualified, so tables sharing a name across schemas collide
+	seen := make(map[string]string)
+	for _, table := range tables {
+		entityName := toEntityName(table.Name)
+		qualified := qualifiedName(table)
+		if prev, ok := seen[entityName]; ok {
+			return "", fmt.Errorf("tables %s and %s both map to entity %s; exclude one of them", prev, qualified, entityName)
+		}
+		seen[entityName] = qualified
+	}
+
 	// First pass: entities and fields
 	for _, table := range tables {
 		entityName := toEntityName(table.Name)
-		sb.WriteString(fmt.Sprintf("entity %s {\n", entityName))
+		writeDocComment(&sb, "", table.Comment)
+		sb.WriteString(fmt.Sprintf("entity %s", entityName))
+		if table.IsView {
+			sb.WriteString(" @view")
+		}
+		if table.Schema != "" && table.Schema != DefaultSchema {
+			sb.WriteString(fmt.Sprintf(" @schema(%s)", quoteString(table.Schema)))
+		}
+		sb.WriteString(" {\n")
+
+		// Single-column checks become field modifiers, the rest stay table-level
+		columnChecks := make(map[string][]string)
+		var tableChecks []string
+		for _, check := range table.Checks {
+			if len(check.Columns) == 1 {
+				columnChecks[check.Columns[0]] = append(columnChecks[check.Columns[0]], check.Expression)
+			} else {
+				tableChecks = append(tableChecks, check.Expression)
+			}
+		}
 
 		for _, col := range table.Columns {
-			fieldType := mapColumnType(col.Type)
+			fieldType, mapped := mapColumnType(col, opts.TypeMapping)
+			writeDocComment(&sb, "    ", col.Comment)
+			if !mapped {
+				sb.WriteString(fmt.Sprintf("    // Unmapped type %s, generated as %s\n", col.Type, fieldType))
+			}
+			if isBigSerial(col, fieldType) {
+				sb.WriteString("    // bigserial, generated as int auto: migrations create a 32-bit SERIAL\n")
+			}
 			sb.WriteString(fmt.Sprintf("    %s: %s", col.Name, fieldType))
 
 			// Add constraints
@@ -33,10 +87,23 @@ func GenerateChameleonSchema(tables []TableInfo) (string, error) {
 			if col.Nullable {
 				sb.WriteString(" nullable")
 			}
+			if fieldType == "int" && isSequenceDefault(col.DefaultVal) {
+				sb.WriteString(" auto")
+			}
+			if col.Generated != "" {
+				sb.WriteString(fmt.Sprintf(" generated(%s)", quoteString(col.Generated)))
+			}
+			for _, expr := range columnChecks[col.Name] {
+				sb.WriteString(fmt.Sprintf(" check(%s)", quoteString(expr)))
+			}
 
 			sb.WriteString(",\n")
 		}
 
+		for _, expr := range tableChecks {
+			sb.WriteString(fmt.Sprintf("    check(%s),\n", quoteString(expr)))
+		}
+
 		sb.WriteString("}\n\n")
 	}
 
@@ -65,33 +132,91 @@ func GenerateChameleonSchema(tables []TableInfo) (string, error) {
 	return sb.String(), nil
 }
 
-// mapColumnType converts SQL type to ChameleonDB type
-func mapColumnType(sqlType string) string {
-	// PostgreSQL types mapping
-	typeMap := map[string]string{
-		"uuid":                     "uuid",
-		"text":                     "string",
-		"varchar":                  "string",
-		"character varying":        "string",
-		"integer":                  "int",
-		"bigint":                   "int",
-		"smallint":                 "int",
-		"decimal":                  "decimal",
-		"numeric":                  "decimal",
-		"real":                     "float",
-		"double precision":         "float",
-		"boolean":                  "bool",
-		"timestamp":                "timestamp",
-		"timestamp with time zone": "timestamp",
-		"date":                     "timestamp",
+// qualifiedName returns schema.table, or just the table name for public tables
+func qualifiedName(table TableInfo) string {
+	if table.Schema == "" || table.Schema == DefaultSchema {
+		return table.Name
 	}
+	return table.Schema + "." + table.Name
+}
+
+// isSequenceDefault reports whether a column default draws from a
+// sequence, as serial and bigserial columns do
+func isSequenceDefault(defaultVal *string) bool {
+	return defaultVal != nil && strings.HasPrefix(*defaultVal, "nextval(")
+}
+
+// isBigSerial reports whether col is a bigserial column generated as
+// `int auto`. The schema has no 64-bit integer type, so migrations would
+// recreate it as a 32-bit SERIAL.
+func isBigSerial(col ColumnInfo, fieldType string) bool {
+	switch strings.ToLower(col.Type) {
+	case "bigint", "int8":
+		return fieldType == "int" && isSequenceDefault(col.DefaultVal)
+	}
+	return false
+}
+
+// SerialColumn is a bigserial column that is generated as `int auto`
+type SerialColumn struct {
+	Table  string // Qualified table name
+	Column string
+}
+
+// BigSerialColumns lists the bigserial columns of tables, which lose
+// their 64-bit range when the generated schema is migrated
+func BigSerialColumns(tables []TableInfo, overrides TypeMapping) []SerialColumn {
+	var columns []SerialColumn
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if fieldType, _ := mapColumnType(col, overrides); isBigSerial(col, fieldType) {
+				columns = append(columns, SerialColumn{Table: qualifiedName(table), Column: col.Name})
+			}
+		}
+	}
+	return columns
+}
 
-	if mapped, ok := typeMap[sqlType]; ok {
-		return mapped
+// writeDocComment writes a database comment as `///` lines
+func writeDocComment(sb *strings.Builder, indent, comment string) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			sb.WriteString(indent + "///\n")
+		} else {
+			sb.WriteString(indent + "/// " + line + "\n")
+		}
 	}
+}
 
-	// Default fallback
-	return "string"
+// quoteString renders a value as a .cham string literal, with the rules
+// of engine.chamString: the parser keeps escape sequences verbatim, so
+// only bare double quotes need escaping
+func quoteString(value string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	if escaped {
+		// A trailing lone backslash would escape the closing quote
+		sb.WriteByte('\\')
+	}
+	sb.WriteByte('"')
+	return sb.String()
 }
 
 // toEntityName converts table name to entity name
@@ -1,6 +1,9 @@
 package introspect
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestToEntityName(t *testing.T) {
 	tests := []struct {
@@ -25,3 +28,259 @@ func TestToEntityName(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateChameleonSchemaChecks(t *testing.T) {
+	tables := []TableInfo{
+		{
+			Name: "products",
+			Columns: []ColumnInfo{
+				{Name: "id", Type: "uuid", PrimaryKey: true},
+				{Name: "price", Type: "numeric"},
+				{Name: "cost", Type: "numeric"},
+			},
+			Checks: []CheckInfo{
+				{Name: "products_price_check", Expression: "(price > (0)::numeric)", Columns: []string{"price"}},
+				{Name: "products_margin_check", Expression: "(price >= cost)", Columns: []string{"price", "cost"}},
+			},
+		},
+	}
+
+	got, err := GenerateChameleonSchema(tables)
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchema() error = %v", err)
+	}
+
+	wantLines := []string{
+		`    price: decimal check("(price > (0)::numeric)"),`,
+		`    check("(price >= cost)"),`,
+	}
+	for _, want := range wantLines {
+		if !strings.Contains(got, want) {
+			t.Fatalf("generated schema missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestCheckExpression(t *testing.T) {
+	tests := []struct {
+		definition string
+		want       string
+	}{
+		{definition: "CHECK ((price > (0)::numeric))", want: "(price > (0)::numeric)"},
+		{definition: "CHECK ((price >= cost)) NOT VALID", want: "(price >= cost)"},
+	}
+
+	for _, tt := range tests {
+		if got := checkExpression(tt.definition); got != tt.want {
+			t.Fatalf("checkExpression(%q) = %q, want %q", tt.definition, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	// Same rules as engine.chamString
+	tests := map[string]string{
+		`plain`:                `"plain"`,
+		`status IN ("a", "b")`: `"status IN (\"a\", \"b\")"`,
+		`already \"`:           `"already \""`,
+		`trailing \`:           `"trailing \\"`,
+		`email ~ '^\S+@\S+$'`:  `"email ~ '^\S+@\S+$'"`,
+	}
+	for input, want := range tests {
+		if got := quoteString(input); got != want {
+			t.Errorf("quoteString(%q) = %s, want %s", input, got, want)
+		}
+	}
+}
+
+func TestGenerateChameleonSchemaViews(t *testing.T) {
+	tables := []TableInfo{
+		{
+			Name:    "active_users",
+			Columns: []ColumnInfo{{Name: "id", Type: "uuid"}},
+			IsView:  true,
+		},
+	}
+
+	got, err := GenerateChameleonSchema(tables)
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchema() error = %v", err)
+	}
+
+	if !strings.Contains(got, "entity ActiveUser @view {") {
+		t.Fatalf("view should be generated as @view entity\n%s", got)
+	}
+}
+
+func TestGenerateChameleonSchemaSchemas(t *testing.T) {
+	tables := []TableInfo{
+		{Name: "users", Schema: "public", Columns: []ColumnInfo{{Name: "id", Type: "uuid"}}},
+		{Name: "invoices", Schema: "billing", Columns: []ColumnInfo{{Name: "id", Type: "uuid"}}},
+	}
+
+	got, err := GenerateChameleonSchema(tables)
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchema() error = %v", err)
+	}
+
+	if !strings.Contains(got, "entity User {") {
+		t.Fatalf("public table should not get @schema\n%s", got)
+	}
+	if !strings.Contains(got, `entity Invoice @schema("billing") {`) {
+		t.Fatalf("non-public table should get @schema\n%s", got)
+	}
+
+	tables = append(tables, TableInfo{Name: "users", Schema: "audit", Columns: []ColumnInfo{{Name: "id", Type: "uuid"}}})
+	if _, err := GenerateChameleonSchema(tables); err == nil {
+		t.Fatal("expected error for entity name collision across schemas")
+	}
+}
+
+func TestGenerateChameleonSchemaComments(t *testing.T) {
+	tables := []TableInfo{
+		{
+			Name:    "users",
+			Comment: "Registered accounts\n\nOne per login",
+			Columns: []ColumnInfo{
+				{Name: "id", Type: "uuid", PrimaryKey: true},
+				{Name: "email", Type: "text", Comment: "Used to sign in"},
+			},
+		},
+	}
+
+	got, err := GenerateChameleonSchema(tables)
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchema() error = %v", err)
+	}
+
+	want := "/// Registered accounts\n///\n/// One per login\nentity User {\n" +
+		"    id: uuid primary,\n" +
+		"    /// Used to sign in\n    email: string,\n"
+	if !strings.Contains(got, want) {
+		t.Fatalf("generated schema missing doc comments\n%s", got)
+	}
+}
+
+func TestGenerateChameleonSchemaSerial(t *testing.T) {
+	serial := "nextval('tags_id_seq'::regclass)"
+	literal := "'draft'::character varying"
+	tables := []TableInfo{
+		{
+			Name: "tags",
+			Columns: []ColumnInfo{
+				{Name: "id", Type: "bigint", PrimaryKey: true, DefaultVal: &serial},
+				{Name: "status", Type: "character varying", DefaultVal: &literal},
+			},
+		},
+	}
+
+	got, err := GenerateChameleonSchema(tables)
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchema() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"    // bigserial, generated as int auto: migrations create a 32-bit SERIAL\n    id: int primary auto,\n",
+		"    status: string,\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("generated schema missing %q\n%s", want, got)
+		}
+	}
+
+	serials := BigSerialColumns(tables, nil)
+	if len(serials) != 1 || serials[0] != (SerialColumn{Table: "tags", Column: "id"}) {
+		t.Fatalf("BigSerialColumns() = %+v, want only tags.id", serials)
+	}
+
+	tables[0].Columns[0].Type = "integer"
+	got, err = GenerateChameleonSchema(tables)
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchema() error = %v", err)
+	}
+	if strings.Contains(got, "bigserial") {
+		t.Fatalf("serial column flagged as bigserial\n%s", got)
+	}
+}
+
+func TestGenerateChameleonSchemaGenerated(t *testing.T) {
+	tables := []TableInfo{
+		{
+			Name: "order_items",
+			Columns: []ColumnInfo{
+				{Name: "id", Type: "uuid", PrimaryKey: true},
+				{Name: "price", Type: "numeric"},
+				{Name: "quantity", Type: "integer"},
+				{Name: "total", Type: "numeric", Generated: "(price * (quantity)::numeric)"},
+			},
+		},
+	}
+
+	got, err := GenerateChameleonSchema(tables)
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchema() error = %v", err)
+	}
+
+	want := `    total: decimal generated("(price * (quantity)::numeric)"),` + "\n"
+	if !strings.Contains(got, want) {
+		t.Fatalf("generated schema missing %q\n%s", want, got)
+	}
+}
+
+func TestGenerateChameleonSchemaTypeMapping(t *testing.T) {
+	tables := []TableInfo{
+		{
+			Name: "accounts",
+			Columns: []ColumnInfo{
+				{Name: "id", Type: "uuid", PrimaryKey: true},
+				{Name: "email", Type: "citext"},
+				{Name: "path", Type: "ltree"},
+				{Name: "status", Type: "account_status"},
+				{Name: "balance", Type: "numeric", Domain: "money_amount"},
+			},
+		},
+	}
+	typeMap := TypeMapping{"CITEXT": "String", "account_status": "string", "money_amount": "int"}
+
+	got, err := GenerateChameleonSchemaWithOptions(tables, GenerateOptions{TypeMapping: typeMap})
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchemaWithOptions() error = %v", err)
+	}
+
+	wantLines := []string{
+		"    email: string,\n",
+		"    // Unmapped type ltree, generated as string\n    path: string,\n",
+		"    status: string,\n",
+		"    balance: int,\n",
+	}
+	for _, want := range wantLines {
+		if !strings.Contains(got, want) {
+			t.Fatalf("generated schema missing %q\n%s", want, got)
+		}
+	}
+
+	unmapped := UnmappedColumns(tables, typeMap)
+	if len(unmapped) != 1 || unmapped[0] != (UnmappedColumn{Table: "accounts", Column: "path", Type: "ltree"}) {
+		t.Fatalf("UnmappedColumns() = %+v, want only accounts.path", unmapped)
+	}
+
+	if _, err := GenerateChameleonSchemaWithOptions(tables, GenerateOptions{TypeMapping: TypeMapping{"ltree": "enum"}}); err == nil {
+		t.Fatal("expected error for a mapping to an unknown ChameleonDB type")
+	}
+}
+
+func TestParseTypeMapping(t *testing.T) {
+	got, err := ParseTypeMapping([]string{"citext=string", " embedding = vector(3) ", "tags=[string]"})
+	if err != nil {
+		t.Fatalf("ParseTypeMapping() error = %v", err)
+	}
+	if got["citext"] != "string" || got["embedding"] != "vector(3)" || got["tags"] != "[string]" {
+		t.Fatalf("ParseTypeMapping() = %v", got)
+	}
+
+	for _, pairs := range [][]string{{"citext"}, {"=string"}, {"citext=text"}, {"v=vector(0)"}} {
+		if _, err := ParseTypeMapping(pairs); err == nil {
+			t.Fatalf("ParseTypeMapping(%q) should fail", pairs)
+		}
+	}
+}
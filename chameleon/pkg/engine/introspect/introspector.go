@@ -3,6 +3,7 @@ package introspect
 import (
 	"context"
 	"fmt"
+	"path"
 	"strings"
 )
 
@@ -19,25 +20,40 @@ const (
 // ColumnInfo represents a column
 type ColumnInfo struct {
 	Name       string
-	Type       string // DB-specific type (e.g., "varchar", "integer")
+	Type       string // DB-specific type (e.g., "varchar", "integer", "citext")
+	Domain     string // Domain the column is declared with, "" if none
 	Nullable   bool
 	PrimaryKey bool
 	Unique     bool
 	DefaultVal *string
 	ForeignKey *ForeignKeyInfo
+	Comment    string // COMMENT ON COLUMN, "" if none
+	Generated  string // GENERATED ALWAYS AS expression, "" for ordinary columns
 }
 
 // ForeignKeyInfo represents a foreign key constraint
 type ForeignKeyInfo struct {
+	ReferencedSchema string
 	ReferencedTable  string
 	ReferencedColumn string
 	ConstraintName   string
 }
 
+// CheckInfo represents a CHECK constraint
+type CheckInfo struct {
+	Name       string
+	Expression string   // Boolean expression without the CHECK keyword
+	Columns    []string // Columns referenced by the expression
+}
+
 // TableInfo represents a table structure
 type TableInfo struct {
 	Name    string
+	Schema  string // PostgreSQL namespace ("public", "billing", ...)
 	Columns []ColumnInfo
+	Checks  []CheckInfo
+	IsView  bool
+	Comment string // COMMENT ON TABLE, "" if none
 }
 
 // Introspector is the interface all DB engines must implement
@@ -54,10 +70,89 @@ type Introspector interface {
 	// GetAllTables returns complete schema
 	GetAllTables(ctx context.Context) ([]TableInfo, error)
 
+	// ListViews returns all user-defined views
+	ListViews(ctx context.Context) ([]string, error)
+
+	// GetAllViews returns the structure of every view (IsView is set)
+	GetAllViews(ctx context.Context) ([]TableInfo, error)
+
+	// SetFilter restricts ListTables/ListViews (and the GetAll* calls) to matching names
+	SetFilter(filter TableFilter)
+
+	// SetSchemas selects the namespaces to introspect (default: "public").
+	// AllSchemas selects every non-system namespace. When anything other than
+	// "public" alone is selected, listed names are schema-qualified ("billing.invoices").
+	SetSchemas(schemas ...string)
+
 	// Close closes the connection
 	Close() error
 }
 
+// AllSchemas passed to SetSchemas introspects every non-system namespace
+const AllSchemas = "*"
+
+// DefaultSchema is the namespace introspected when none is selected
+const DefaultSchema = "public"
+
+// splitQualifiedName splits "billing.invoices" into ("billing", "invoices").
+// Unqualified names get defaultSchema.
+func splitQualifiedName(name, defaultSchema string) (string, string) {
+	if idx := strings.Index(name, "."); idx > 0 {
+		return name[:idx], name[idx+1:]
+	}
+	return defaultSchema, name
+}
+
+// TableFilter selects which tables and views are introspected.
+// Patterns use path.Match glob syntax ("audit_*", "tmp_?"); plain names
+// match exactly, so an explicit table list is just a list of Include names.
+type TableFilter struct {
+	Include []string // Empty = everything
+	Exclude []string // Applied after Include
+}
+
+// Validate reports the first malformed pattern
+func (f TableFilter) Validate() error {
+	for _, pattern := range append(append([]string{}, f.Include...), f.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid table pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Match reports whether a table name passes the filter
+func (f TableFilter) Match(name string) bool {
+	if len(f.Include) > 0 && !matchAny(f.Include, name) {
+		return false
+	}
+	return !matchAny(f.Exclude, name)
+}
+
+// Apply returns the names that pass the filter, preserving order
+func (f TableFilter) Apply(names []string) []string {
+	if len(f.Include) == 0 && len(f.Exclude) == 0 {
+		return names
+	}
+
+	var result []string
+	for _, name := range names {
+		if f.Match(name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // NewIntrospector creates the right introspector for a connection string
 func NewIntrospector(ctx context.Context, connStr string) (Introspector, error) {
 	normalizedConn := strings.TrimSpace(connStr)
@@ -2,6 +2,7 @@ package introspect
 
 import (
 	"context"
+	"strings"
 	"testing"
 )
 
@@ -41,3 +42,31 @@ func TestNewIntrospectorRejectsInvalidConnectionString(t *testing.T) {
 		t.Fatal("expected error for unsupported connection scheme")
 	}
 }
+
+func TestTableFilter(t *testing.T) {
+	tables := []string{"users", "orders", "audit_log", "audit_events", "tmp_import"}
+
+	tests := []struct {
+		name   string
+		filter TableFilter
+		want   []string
+	}{
+		{name: "no filter", filter: TableFilter{}, want: tables},
+		{name: "explicit list", filter: TableFilter{Include: []string{"users", "orders"}}, want: []string{"users", "orders"}},
+		{name: "exclude globs", filter: TableFilter{Exclude: []string{"audit_*", "tmp_*"}}, want: []string{"users", "orders"}},
+		{name: "include then exclude", filter: TableFilter{Include: []string{"audit_*"}, Exclude: []string{"audit_events"}}, want: []string{"audit_log"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.Apply(tables)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := (TableFilter{Exclude: []string{"audit_["}}).Validate(); err == nil {
+		t.Fatal("expected error for malformed pattern")
+	}
+}
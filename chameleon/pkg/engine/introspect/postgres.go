@@ -3,12 +3,15 @@ package introspect
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 )
 
 type postgresIntrospector struct {
-	conn *pgx.Conn
+	conn    *pgx.Conn
+	filter  TableFilter
+	schemas []string
 }
 
 func newPostgresIntrospector(ctx context.Context, connStr string) (Introspector, error) {
@@ -17,7 +20,7 @@ func newPostgresIntrospector(ctx context.Context, connStr string) (Introspector,
 		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
 
-	return &postgresIntrospector{conn: conn}, nil
+	return &postgresIntrospector{conn: conn, schemas: []string{DefaultSchema}}, nil
 }
 
 func (pi *postgresIntrospector) Detect(ctx context.Context) (bool, error) {
@@ -27,35 +30,94 @@ func (pi *postgresIntrospector) Detect(ctx context.Context) (bool, error) {
 }
 
 func (pi *postgresIntrospector) ListTables(ctx context.Context) ([]string, error) {
+	return pi.listRelations(ctx, "BASE TABLE")
+}
+
+func (pi *postgresIntrospector) ListViews(ctx context.Context) ([]string, error) {
+	return pi.listRelations(ctx, "VIEW")
+}
+
+func (pi *postgresIntrospector) SetFilter(filter TableFilter) {
+	pi.filter = filter
+}
+
+func (pi *postgresIntrospector) SetSchemas(schemas ...string) {
+	if len(schemas) == 0 {
+		schemas = []string{DefaultSchema}
+	}
+	pi.schemas = schemas
+}
+
+// allSchemas reports whether every non-system namespace is selected
+func (pi *postgresIntrospector) allSchemas() bool {
+	for _, schema := range pi.schemas {
+		if schema == AllSchemas {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifyNames reports whether listed names carry their schema
+func (pi *postgresIntrospector) qualifyNames() bool {
+	return len(pi.schemas) != 1 || pi.schemas[0] != DefaultSchema
+}
+
+// defaultSchema is the namespace used for unqualified table names
+func (pi *postgresIntrospector) defaultSchema() string {
+	if len(pi.schemas) == 1 && pi.schemas[0] != AllSchemas {
+		return pi.schemas[0]
+	}
+	return DefaultSchema
+}
+
+// listRelations returns the names of relations of the given table_type
+// in the selected schemas
+func (pi *postgresIntrospector) listRelations(ctx context.Context, tableType string) ([]string, error) {
 	rows, err := pi.conn.Query(ctx, `
-		SELECT table_name 
-		FROM information_schema.tables 
-		WHERE table_schema = 'public' 
-		AND table_type = 'BASE TABLE'
-		ORDER BY table_name
-	`)
+		SELECT table_schema, table_name
+		FROM information_schema.tables
+		WHERE table_type = $1
+		AND (
+			table_schema = ANY($2)
+			OR ($3 AND table_schema NOT IN ('pg_catalog', 'information_schema')
+				AND table_schema NOT LIKE 'pg\_%')
+		)
+		ORDER BY table_schema, table_name
+	`, tableType, pi.schemas, pi.allSchemas())
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	qualify := pi.qualifyNames()
 	var tables []string
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var schema, name string
+		if err := rows.Scan(&schema, &name); err != nil {
 			return nil, err
 		}
+		if qualify {
+			name = schema + "." + name
+		}
 		tables = append(tables, name)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return tables, rows.Err()
+	return pi.filter.Apply(tables), nil
 }
 
-func (pi *postgresIntrospector) InspectTable(ctx context.Context, tableName string) (*TableInfo, error) {
+// InspectTable accepts plain ("users") or schema-qualified ("billing.invoices") names
+func (pi *postgresIntrospector) InspectTable(ctx context.Context, qualifiedName string) (*TableInfo, error) {
+	schemaName, tableName := splitQualifiedName(qualifiedName, pi.defaultSchema())
+
 	rows, err := pi.conn.Query(ctx, `
 		SELECT
 			c.column_name,
-			c.data_type,
+			CASE WHEN c.data_type = 'USER-DEFINED' THEN c.udt_name ELSE c.data_type END,
+			COALESCE(c.domain_name, ''),
 			c.is_nullable,
 			EXISTS (
 				SELECT 1
@@ -79,12 +141,17 @@ func (pi *postgresIntrospector) InspectTable(ctx context.Context, tableName stri
 					AND tc.constraint_type = 'UNIQUE'
 					AND kcu.column_name = c.column_name
 			) AS is_unique,
-			c.column_default
+			c.column_default,
+			COALESCE(col_description(
+				format('%I.%I', c.table_schema, c.table_name)::regclass,
+				c.ordinal_position
+			), ''),
+			CASE WHEN c.is_generated = 'ALWAYS' THEN COALESCE(c.generation_expression, '') ELSE '' END
 		FROM information_schema.columns c
-		WHERE c.table_schema = 'public'
+		WHERE c.table_schema = $2
 			AND c.table_name = $1
 		ORDER BY c.ordinal_position
-	`, tableName)
+	`, tableName, schemaName)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +159,7 @@ func (pi *postgresIntrospector) InspectTable(ctx context.Context, tableName stri
 
 	table := &TableInfo{
 		Name:    tableName,
+		Schema:  schemaName,
 		Columns: []ColumnInfo{},
 	}
 
@@ -104,10 +172,13 @@ func (pi *postgresIntrospector) InspectTable(ctx context.Context, tableName stri
 		if err := rows.Scan(
 			&col.Name,
 			&col.Type,
+			&col.Domain,
 			&nullable,
 			&isPrimary,
 			&isUnique,
 			&defaultVal,
+			&col.Comment,
+			&col.Generated,
 		); err != nil {
 			return nil, err
 		}
@@ -118,7 +189,7 @@ func (pi *postgresIntrospector) InspectTable(ctx context.Context, tableName stri
 		col.Unique = isUnique
 
 		fkRows, err := pi.conn.Query(ctx, `
-			SELECT ccu.table_name, ccu.column_name, tc.constraint_name
+			SELECT ccu.table_schema, ccu.table_name, ccu.column_name, tc.constraint_name
 			FROM information_schema.table_constraints tc
 			JOIN information_schema.key_column_usage kcu
 				ON tc.constraint_name = kcu.constraint_name
@@ -129,12 +200,14 @@ func (pi *postgresIntrospector) InspectTable(ctx context.Context, tableName stri
 			WHERE tc.constraint_type = 'FOREIGN KEY'
 			AND tc.table_name = $1
 			AND kcu.column_name = $2
-		`, tableName, col.Name)
+			AND tc.table_schema = $3
+		`, tableName, col.Name, schemaName)
 		if err == nil {
 			for fkRows.Next() {
-				var refTable, refCol, fkName string
-				if err := fkRows.Scan(&refTable, &refCol, &fkName); err == nil {
+				var refSchema, refTable, refCol, fkName string
+				if err := fkRows.Scan(&refSchema, &refTable, &refCol, &fkName); err == nil {
 					col.ForeignKey = &ForeignKeyInfo{
+						ReferencedSchema: refSchema,
 						ReferencedTable:  refTable,
 						ReferencedColumn: refCol,
 						ConstraintName:   fkName,
@@ -147,11 +220,85 @@ func (pi *postgresIntrospector) InspectTable(ctx context.Context, tableName stri
 		table.Columns = append(table.Columns, col)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
 	if len(table.Columns) == 0 {
-		return nil, fmt.Errorf("table %s not found or has no columns", tableName)
+		return nil, fmt.Errorf("table %s not found or has no columns", qualifiedName)
+	}
+
+	checks, err := pi.inspectChecks(ctx, schemaName, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read check constraints: %w", err)
+	}
+	table.Checks = checks
+
+	err = pi.conn.QueryRow(ctx, `
+		SELECT COALESCE(obj_description(format('%I.%I', $2::text, $1::text)::regclass, 'pg_class'), '')
+	`, tableName, schemaName).Scan(&table.Comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table comment: %w", err)
+	}
+
+	return table, nil
+}
+
+// inspectChecks returns the CHECK constraints of a table with the
+// columns each one references.
+func (pi *postgresIntrospector) inspectChecks(ctx context.Context, schemaName, tableName string) ([]CheckInfo, error) {
+	rows, err := pi.conn.Query(ctx, `
+		SELECT
+			con.conname,
+			pg_get_constraintdef(con.oid),
+			COALESCE(
+				array_agg(att.attname::text ORDER BY att.attnum)
+					FILTER (WHERE att.attname IS NOT NULL),
+				'{}'
+			)
+		FROM pg_constraint con
+		JOIN pg_class rel ON rel.oid = con.conrelid
+		JOIN pg_namespace nsp ON nsp.oid = rel.relnamespace
+		LEFT JOIN pg_attribute att
+			ON att.attrelid = con.conrelid
+			AND att.attnum = ANY(con.conkey)
+		WHERE con.contype = 'c'
+			AND nsp.nspname = $2
+			AND rel.relname = $1
+		GROUP BY con.oid, con.conname
+		ORDER BY con.conname
+	`, tableName, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checks []CheckInfo
+	for rows.Next() {
+		var check CheckInfo
+		var definition string
+		if err := rows.Scan(&check.Name, &definition, &check.Columns); err != nil {
+			return nil, err
+		}
+		check.Expression = checkExpression(definition)
+		checks = append(checks, check)
 	}
 
-	return table, rows.Err()
+	return checks, rows.Err()
+}
+
+// checkExpression strips the CHECK wrapper from pg_get_constraintdef output
+// Input: "CHECK ((price > (0)::numeric)) NOT VALID"
+// Output: "(price > (0)::numeric)"
+func checkExpression(definition string) string {
+	expr := strings.TrimSpace(definition)
+	expr = strings.TrimSuffix(expr, " NOT VALID")
+	expr = strings.TrimPrefix(expr, "CHECK ")
+	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		expr = expr[1 : len(expr)-1]
+	}
+	return expr
 }
 
 func (pi *postgresIntrospector) GetAllTables(ctx context.Context) ([]TableInfo, error) {
@@ -172,6 +319,25 @@ func (pi *postgresIntrospector) GetAllTables(ctx context.Context) ([]TableInfo,
 	return result, nil
 }
 
+func (pi *postgresIntrospector) GetAllViews(ctx context.Context) ([]TableInfo, error) {
+	views, err := pi.ListViews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []TableInfo
+	for _, viewName := range views {
+		view, err := pi.InspectTable(ctx, viewName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect view %s: %w", viewName, err)
+		}
+		view.IsView = true
+		result = append(result, *view)
+	}
+
+	return result, nil
+}
+
 func (pi *postgresIntrospector) Close() error {
 	return pi.conn.Close(context.Background())
 }
@@ -0,0 +1,156 @@
+package introspect
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fallbackType is the field type of columns whose type is not mapped
+const fallbackType = "string"
+
+// defaultTypeMap maps PostgreSQL types (as information_schema reports
+// them) to ChameleonDB types
+var defaultTypeMap = map[string]string{
+	"uuid":                        "uuid",
+	"text":                        "string",
+	"varchar":                     "string",
+	"character varying":           "string",
+	"character":                   "string",
+	"integer":                     "int",
+	"bigint":                      "int",
+	"smallint":                    "int",
+	"decimal":                     "decimal",
+	"numeric":                     "decimal",
+	"real":                        "float",
+	"double precision":            "float",
+	"boolean":                     "bool",
+	"timestamp":                   "timestamp",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamp",
+	"date":                        "timestamp",
+}
+
+// TypeMapping maps database type names to ChameleonDB field types, for
+// domains, extension types and enums the default rules don't know:
+//
+//	introspect.TypeMapping{"citext": "string", "ltree": "string", "order_status": "string"}
+//
+// Names match case-insensitively, first against the column's domain and
+// then against its type, and field types may be written in any case
+// ("String"). Entries take precedence over the default rules.
+type TypeMapping map[string]string
+
+// ParseTypeMapping parses "db_type=cham_type" pairs, as given to
+// `chameleon introspect --type-map`
+func ParseTypeMapping(pairs []string) (TypeMapping, error) {
+	mapping := make(TypeMapping, len(pairs))
+	for _, pair := range pairs {
+		dbType, chamType, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(dbType) == "" {
+			return nil, fmt.Errorf("invalid type mapping %q: expected db_type=cham_type", pair)
+		}
+		mapping[strings.TrimSpace(dbType)] = strings.TrimSpace(chamType)
+	}
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// Validate checks that every entry maps to a valid ChameleonDB field type
+func (m TypeMapping) Validate() error {
+	dbTypes := make([]string, 0, len(m))
+	for dbType := range m {
+		dbTypes = append(dbTypes, dbType)
+	}
+	sort.Strings(dbTypes)
+
+	for _, dbType := range dbTypes {
+		if !isFieldType(normalizeFieldType(m[dbType])) {
+			return fmt.Errorf("type mapping for %s: %q is not a ChameleonDB type (uuid, string, int, decimal, bool, timestamp, float, vector(N) or [T])",
+				dbType, m[dbType])
+		}
+	}
+	return nil
+}
+
+// lookup returns the field type mapped to dbType
+func (m TypeMapping) lookup(dbType string) (string, bool) {
+	if dbType == "" {
+		return "", false
+	}
+	if mapped, ok := m[dbType]; ok {
+		return normalizeFieldType(mapped), true
+	}
+	for name, mapped := range m {
+		if strings.EqualFold(name, dbType) {
+			return normalizeFieldType(mapped), true
+		}
+	}
+	return "", false
+}
+
+// mapColumnType returns the ChameleonDB type of col. ok is false when
+// neither overrides nor the default rules know its type, and the column
+// falls back to string.
+func mapColumnType(col ColumnInfo, overrides TypeMapping) (fieldType string, ok bool) {
+	if mapped, found := overrides.lookup(col.Domain); found {
+		return mapped, true
+	}
+	if mapped, found := overrides.lookup(col.Type); found {
+		return mapped, true
+	}
+	if mapped, found := defaultTypeMap[strings.ToLower(col.Type)]; found {
+		return mapped, true
+	}
+	return fallbackType, false
+}
+
+// UnmappedColumn is a column whose database type has no mapping; it is
+// generated as a string field
+type UnmappedColumn struct {
+	Table  string // Qualified table name
+	Column string
+	Type   string
+}
+
+// UnmappedColumns lists the columns of tables that would fall back to
+// string with the given overrides
+func UnmappedColumns(tables []TableInfo, overrides TypeMapping) []UnmappedColumn {
+	var unmapped []UnmappedColumn
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if _, ok := mapColumnType(col, overrides); !ok {
+				unmapped = append(unmapped, UnmappedColumn{
+					Table:  qualifiedName(table),
+					Column: col.Name,
+					Type:   col.Type,
+				})
+			}
+		}
+	}
+	return unmapped
+}
+
+// normalizeFieldType writes a field type the way the schema parser expects
+func normalizeFieldType(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), ""))
+}
+
+// isFieldType reports whether s is a field type the schema parser accepts
+func isFieldType(s string) bool {
+	switch s {
+	case "uuid", "string", "int", "decimal", "bool", "timestamp", "float":
+		return true
+	}
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		return isFieldType(s[1 : len(s)-1])
+	}
+	if strings.HasPrefix(s, "vector(") && strings.HasSuffix(s, ")") {
+		n, err := strconv.Atoi(s[len("vector(") : len(s)-1])
+		return err == nil && n > 0
+	}
+	return false
+}
@@ -16,6 +16,22 @@ func (m *invalidInsertMutation) Set(field string, value interface{}) InsertMutat
 	return m
 }
 
+func (m *invalidInsertMutation) Rows(rows ...map[string]interface{}) InsertMutation {
+	return m
+}
+
+func (m *invalidInsertMutation) Upsert(conflictFields ...string) InsertMutation {
+	return m
+}
+
+func (m *invalidInsertMutation) WithValidatorConfig(cfg ValidatorConfig) InsertMutation {
+	return m
+}
+
+func (m *invalidInsertMutation) AllTenants() InsertMutation {
+	return m
+}
+
 func (m *invalidInsertMutation) Debug() InsertMutation {
 	return m
 }
@@ -24,6 +40,10 @@ func (m *invalidInsertMutation) Execute(ctx context.Context) (*InsertResult, err
 	return nil, m.err
 }
 
+func (m *invalidInsertMutation) ToSQL() (string, []interface{}, error) {
+	return "", nil, m.err
+}
+
 type invalidUpdateMutation struct {
 	err error
 }
@@ -40,6 +60,14 @@ func (m *invalidUpdateMutation) Filter(field string, operator string, value inte
 	return m
 }
 
+func (m *invalidUpdateMutation) WithValidatorConfig(cfg ValidatorConfig) UpdateMutation {
+	return m
+}
+
+func (m *invalidUpdateMutation) AllTenants() UpdateMutation {
+	return m
+}
+
 func (m *invalidUpdateMutation) Debug() UpdateMutation {
 	return m
 }
@@ -48,6 +76,10 @@ func (m *invalidUpdateMutation) Execute(ctx context.Context) (*UpdateResult, err
 	return nil, m.err
 }
 
+func (m *invalidUpdateMutation) ToSQL() (string, []interface{}, error) {
+	return "", nil, m.err
+}
+
 type invalidDeleteMutation struct {
 	err error
 }
@@ -60,6 +92,22 @@ func (m *invalidDeleteMutation) Filter(field string, operator string, value inte
 	return m
 }
 
+func (m *invalidDeleteMutation) WithValidatorConfig(cfg ValidatorConfig) DeleteMutation {
+	return m
+}
+
+func (m *invalidDeleteMutation) AllTenants() DeleteMutation {
+	return m
+}
+
+func (m *invalidDeleteMutation) Batch(size int) DeleteMutation {
+	return m
+}
+
+func (m *invalidDeleteMutation) OnBatch(fn func(records []map[string]interface{}) error) DeleteMutation {
+	return m
+}
+
 func (m *invalidDeleteMutation) Debug() DeleteMutation {
 	return m
 }
@@ -67,3 +115,35 @@ func (m *invalidDeleteMutation) Debug() DeleteMutation {
 func (m *invalidDeleteMutation) Execute(ctx context.Context) (*DeleteResult, error) {
 	return nil, m.err
 }
+
+func (m *invalidDeleteMutation) ToSQL() (string, []interface{}, error) {
+	return "", nil, m.err
+}
+
+type invalidTruncateMutation struct {
+	err error
+}
+
+func newInvalidTruncateMutation(err error) TruncateMutation {
+	return &invalidTruncateMutation{err: err}
+}
+
+func (m *invalidTruncateMutation) Force() TruncateMutation {
+	return m
+}
+
+func (m *invalidTruncateMutation) AllTenants() TruncateMutation {
+	return m
+}
+
+func (m *invalidTruncateMutation) Debug() TruncateMutation {
+	return m
+}
+
+func (m *invalidTruncateMutation) Execute(ctx context.Context) (*TruncateResult, error) {
+	return nil, m.err
+}
+
+func (m *invalidTruncateMutation) ToSQL() (string, []interface{}, error) {
+	return "", nil, m.err
+}
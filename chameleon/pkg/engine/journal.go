@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/internal/journal"
+)
+
+// JournalLogger receives runtime journal entries. The logger returned by
+// OpenJournal (the same journal `chameleon journal` reads) satisfies it.
+type JournalLogger interface {
+	Log(action, status string, details map[string]interface{}, err error) error
+}
+
+// OpenJournal opens the project journal in workDir/.chameleon/journal
+func OpenJournal(workDir string) (JournalLogger, error) {
+	logger, err := journal.NewLogger(filepath.Join(workDir, ".chameleon", "journal"))
+	if err != nil {
+		return nil, err
+	}
+	return logger, nil
+}
+
+// WithJournal records every query and mutation executed through the engine
+// (entity, operation, affected rows, duration). Runtime journaling is off
+// by default; pass nil to turn it off again.
+//
+// Example:
+//
+//	logger, err := engine.OpenJournal(".")
+//	if err != nil {
+//		return err
+//	}
+//	eng.WithJournal(logger)
+func (e *Engine) WithJournal(logger JournalLogger) *Engine {
+	e.journal = logger
+	return e
+}
+
+// RecordOperation appends a runtime entry for one query or mutation.
+// A nil logger records nothing, and journal write failures never fail
+// the operation itself.
+func RecordOperation(logger JournalLogger, operation string, entity string, affected int, duration time.Duration, err error) {
+	if logger == nil {
+		return
+	}
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+
+	_ = logger.Log(strings.ToLower(operation), status, map[string]interface{}{
+		"entity":      entity,
+		"operation":   operation,
+		"affected":    affected,
+		"duration_ms": duration.Milliseconds(),
+	}, err)
+}
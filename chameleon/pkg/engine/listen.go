@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Notification is a NOTIFY received through Listen
+type Notification struct {
+	Channel string
+	Payload string
+	PID     uint32 // Backend process that sent it
+}
+
+// Backoff between reconnection attempts of a dropped listener
+const (
+	listenRetryMin = time.Second
+	listenRetryMax = 30 * time.Second
+)
+
+// listenBuffer is how many notifications wait for the reader before
+// the listener stops reading from the connection
+const listenBuffer = 16
+
+// ListenOptions configures ListenWithOptions
+type ListenOptions struct {
+	// OnError receives the error that dropped the connection, then the
+	// error of each failed reconnection attempt. The listener keeps
+	// retrying either way. Nil ignores them.
+	OnError func(err error)
+}
+
+// Listen subscribes to a NOTIFY channel on a dedicated connection,
+// outside the pool, and streams the notifications it receives. If the
+// connection drops, Listen reconnects with backoff and LISTENs again;
+// notifications sent while it was down are lost. Cancel ctx to UNLISTEN,
+// release the connection and close the returned channel; Shutdown and
+// Close do the same for every listener.
+//
+// The first connection is made before Listen returns, so a bad channel
+// or an unreachable database fails here.
+func (e *Engine) Listen(ctx context.Context, channel string) (<-chan Notification, error) {
+	return e.ListenWithOptions(ctx, channel, ListenOptions{})
+}
+
+// ListenWithOptions is Listen with options, such as a callback for the
+// errors it reconnects after
+func (e *Engine) ListenWithOptions(ctx context.Context, channel string, opts ListenOptions) (<-chan Notification, error) {
+	if err := e.connectionErr(); err != nil {
+		return nil, err
+	}
+	if channel == "" {
+		return nil, fmt.Errorf("listen: channel name is required")
+	}
+
+	ctx, done, err := e.connector.trackListener(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := e.connector.listen(ctx, channel)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	out := make(chan Notification, listenBuffer)
+	onError := opts.OnError
+	if onError == nil {
+		onError = func(error) {}
+	}
+	go func() {
+		defer done()
+		e.connector.receive(ctx, conn, channel, out, onError)
+	}()
+	return out, nil
+}
+
+// listen opens a connection outside the pool and runs LISTEN on it
+func (c *Connector) listen(ctx context.Context, channel string) (*pgx.Conn, error) {
+	poolConfig, err := c.poolConfig()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := pgx.ConnectConfig(ctx, poolConfig.ConnConfig)
+	if err != nil {
+		return nil, fmt.Errorf("listen %s: %w", channel, err)
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		conn.Close(context.Background())
+		return nil, fmt.Errorf("listen %s: %w", channel, err)
+	}
+	return conn, nil
+}
+
+// receive forwards notifications from conn to out until ctx is done,
+// replacing conn whenever it drops
+func (c *Connector) receive(ctx context.Context, conn *pgx.Conn, channel string, out chan<- Notification, onError func(error)) {
+	defer close(out)
+	defer func() {
+		if conn != nil {
+			unlisten(conn, channel)
+		}
+	}()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err == nil {
+			select {
+			case out <- Notification{Channel: n.Channel, Payload: n.Payload, PID: n.PID}:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		onError(fmt.Errorf("listen %s: connection lost: %w", channel, err))
+		conn.Close(context.Background())
+		if conn = c.relisten(ctx, channel, onError); conn == nil {
+			return
+		}
+	}
+}
+
+// relisten reconnects with exponential backoff. It returns nil once ctx
+// is done, which Drain and Close also cause.
+func (c *Connector) relisten(ctx context.Context, channel string, onError func(error)) *pgx.Conn {
+	wait := listenRetryMin
+	for {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil
+		}
+		conn, err := c.listen(ctx, channel)
+		if err == nil {
+			return conn
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		onError(err)
+		wait = min(wait*2, listenRetryMax)
+	}
+}
+
+// unlisten stops the subscription and closes conn. ctx is already done
+// at this point, so the statement gets a short deadline of its own.
+func unlisten(conn *pgx.Conn, channel string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn.Exec(ctx, "UNLISTEN "+pgx.Identifier{channel}.Sanitize())
+	conn.Close(ctx)
+}
@@ -0,0 +1,187 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// MergeConflict is a part of the schema both sides of a merge changed in
+// different ways
+type MergeConflict struct {
+	Entity string `json:"entity"`
+	Field  string `json:"field,omitempty"` // Field or relation; "" for the entity itself
+	Ours   string `json:"ours"`
+	Theirs string `json:"theirs"`
+}
+
+// String renders the conflict for terminal output
+func (c MergeConflict) String() string {
+	target := c.Entity
+	if c.Field != "" {
+		target += "." + c.Field
+	}
+	return fmt.Sprintf("%s: ours %s, theirs %s", target, c.Ours, c.Theirs)
+}
+
+// MergeSchemas reconciles ours and theirs, two schemas that diverged from
+// base, taking every change only one side made. Entities, fields and
+// relations changed on both sides in different ways are reported as
+// conflicts and keep their ours version in the result. base may be nil
+// when the lineages share no version.
+func MergeSchemas(base, ours, theirs *Schema) (*Schema, []MergeConflict) {
+	baseEntities := entitiesByName(base)
+	ourEntities := entitiesByName(ours)
+	theirEntities := entitiesByName(theirs)
+
+	merged := &Schema{}
+	if ours != nil {
+		merged.FormatVersion = ours.FormatVersion
+	}
+	if theirs != nil && theirs.FormatVersion > merged.FormatVersion {
+		merged.FormatVersion = theirs.FormatVersion
+	}
+
+	var conflicts []MergeConflict
+	for _, name := range mergeOrder(ours, theirs) {
+		b, o, t := baseEntities[name], ourEntities[name], theirEntities[name]
+
+		if entity, ok := pickMerged(b, o, t); ok {
+			if entity != nil {
+				merged.Entities = append(merged.Entities, entity)
+			}
+			continue
+		}
+		if o == nil || t == nil {
+			// Removed on one side, changed on the other
+			conflicts = append(conflicts, MergeConflict{Entity: name, Ours: entityState(o), Theirs: entityState(t)})
+			if o != nil {
+				merged.Entities = append(merged.Entities, o)
+			}
+			continue
+		}
+
+		entity, entityConflicts := mergeEntity(b, o, t)
+		merged.Entities = append(merged.Entities, entity)
+		conflicts = append(conflicts, entityConflicts...)
+	}
+	return merged, conflicts
+}
+
+// mergeEntity merges an entity both sides changed, member by member
+func mergeEntity(base, ours, theirs *Entity) (*Entity, []MergeConflict) {
+	if base == nil {
+		base = &Entity{Name: ours.Name}
+	}
+	var conflicts []MergeConflict
+
+	entity := *ours
+	if attrs, ok := pickMerged(entityAttributes(base), entityAttributes(ours), entityAttributes(theirs)); ok {
+		entity = *attrs
+	} else {
+		conflicts = append(conflicts, MergeConflict{Entity: ours.Name, Ours: "changed annotations", Theirs: "changed annotations"})
+	}
+
+	entity.Fields = make(map[string]*Field)
+	for _, name := range unionKeys(ours.Fields, theirs.Fields) {
+		b, o, t := base.Fields[name], ours.Fields[name], theirs.Fields[name]
+		field, ok := pickMerged(b, o, t)
+		if !ok {
+			conflicts = append(conflicts, MergeConflict{Entity: ours.Name, Field: name, Ours: describeField(o), Theirs: describeField(t)})
+			field = o
+		}
+		if field != nil {
+			entity.Fields[name] = field
+		}
+	}
+
+	entity.Relations = make(map[string]*Relation)
+	for _, name := range unionKeys(ours.Relations, theirs.Relations) {
+		b, o, t := base.Relations[name], ours.Relations[name], theirs.Relations[name]
+		rel, ok := pickMerged(b, o, t)
+		if !ok {
+			conflicts = append(conflicts, MergeConflict{Entity: ours.Name, Field: name, Ours: relationState(o), Theirs: relationState(t)})
+			rel = o
+		}
+		if rel != nil {
+			entity.Relations[name] = rel
+		}
+	}
+
+	// Our declaration order, then the fields only theirs declares
+	entity.FieldOrder = nil
+	for _, order := range [][]string{ours.FieldOrder, theirs.FieldOrder} {
+		for _, name := range order {
+			if entity.Fields[name] != nil && !slices.Contains(entity.FieldOrder, name) {
+				entity.FieldOrder = append(entity.FieldOrder, name)
+			}
+		}
+	}
+	return &entity, conflicts
+}
+
+// pickMerged is the three-way rule: a side that left base untouched takes
+// the other side's version. ok is false when both sides changed it
+// differently.
+func pickMerged[T any](base, ours, theirs *T) (*T, bool) {
+	switch {
+	case reflect.DeepEqual(ours, theirs):
+		return ours, true
+	case reflect.DeepEqual(base, ours):
+		return theirs, true
+	case reflect.DeepEqual(base, theirs):
+		return ours, true
+	}
+	return nil, false
+}
+
+// entityAttributes returns a copy of the entity without its members, to
+// compare annotations, checks and indexes on their own
+func entityAttributes(entity *Entity) *Entity {
+	attrs := *entity
+	attrs.Fields = nil
+	attrs.Relations = nil
+	attrs.FieldOrder = nil
+	return &attrs
+}
+
+// mergeOrder lists entity names in ours order, then those only theirs has
+func mergeOrder(ours, theirs *Schema) []string {
+	var names []string
+	for _, schema := range []*Schema{ours, theirs} {
+		if schema == nil {
+			continue
+		}
+		for _, entity := range schema.Entities {
+			if !slices.Contains(names, entity.Name) {
+				names = append(names, entity.Name)
+			}
+		}
+	}
+	return names
+}
+
+func entityState(entity *Entity) string {
+	if entity == nil {
+		return "removed"
+	}
+	return "changed"
+}
+
+func describeField(field *Field) string {
+	if field == nil {
+		return "removed"
+	}
+	definition, err := chamFieldDefinition(field, nil)
+	if err != nil {
+		return field.Type.String()
+	}
+	return definition
+}
+
+func relationState(rel *Relation) string {
+	if rel == nil {
+		return "removed"
+	}
+	return describeRelation(rel)
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeSchemas_Clean(t *testing.T) {
+	base := diffTestSchema()
+	ours := diffTestSchema()
+	theirs := diffTestSchema()
+
+	// Ours adds a field and an entity; theirs drops one and changes another
+	ours.GetEntity("User").Fields["bio"] = &Field{Name: "bio", Type: FieldTypeString, Nullable: true}
+	ours.Entities = append(ours.Entities, &Entity{Name: "Tag", Fields: map[string]*Field{}})
+	delete(theirs.GetEntity("User").Fields, "age")
+	theirs.GetEntity("Post").Fields["user_id"] = &Field{Name: "user_id", Type: FieldTypeUUID, Nullable: true}
+
+	merged, conflicts := MergeSchemas(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("expected a clean merge, got %v", conflicts)
+	}
+
+	user := merged.GetEntity("User")
+	if user.Fields["bio"] == nil || user.Fields["age"] != nil || user.Fields["email"] == nil {
+		t.Errorf("unexpected User fields %v", user.FieldNames())
+	}
+	if !merged.GetEntity("Post").Fields["user_id"].Nullable {
+		t.Error("theirs change to Post.user_id should be kept")
+	}
+	if got := merged.EntityNames(); !reflect.DeepEqual(got, []string{"User", "Post", "Tag"}) {
+		t.Errorf("unexpected entities %v", got)
+	}
+}
+
+func TestMergeSchemas_Conflicts(t *testing.T) {
+	base := diffTestSchema()
+	ours := diffTestSchema()
+	theirs := diffTestSchema()
+
+	ours.GetEntity("User").Fields["age"] = &Field{Name: "age", Type: FieldTypeFloat, Nullable: true}
+	theirs.GetEntity("User").Fields["age"] = &Field{Name: "age", Type: FieldTypeString, Nullable: true}
+	// Both add the same field identically: not a conflict
+	ours.GetEntity("User").Fields["bio"] = &Field{Name: "bio", Type: FieldTypeString}
+	theirs.GetEntity("User").Fields["bio"] = &Field{Name: "bio", Type: FieldTypeString}
+	// Removed on one side, changed on the other
+	ours.Entities = ours.Entities[:1]
+	theirs.GetEntity("Post").Fields["title"] = &Field{Name: "title", Type: FieldTypeString}
+
+	merged, conflicts := MergeSchemas(base, ours, theirs)
+
+	want := []MergeConflict{
+		{Entity: "User", Field: "age", Ours: "float nullable", Theirs: "string nullable"},
+		{Entity: "Post", Ours: "removed", Theirs: "changed"},
+	}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Fatalf("conflicts = %v, want %v", conflicts, want)
+	}
+	if got := conflicts[0].String(); got != "User.age: ours float nullable, theirs string nullable" {
+		t.Errorf("unexpected conflict text %q", got)
+	}
+	if merged.GetEntity("User").Fields["age"].Type != FieldTypeFloat || merged.GetEntity("User").Fields["bio"] == nil {
+		t.Error("conflicting members should keep the ours version")
+	}
+}
+
+func TestMergeSchemas_FieldOrder(t *testing.T) {
+	entity := func(order ...string) *Schema {
+		fields := make(map[string]*Field)
+		for _, name := range order {
+			fields[name] = &Field{Name: name, Type: FieldTypeString}
+		}
+		return &Schema{Entities: []*Entity{{Name: "User", Fields: fields, FieldOrder: order}}}
+	}
+
+	merged, conflicts := MergeSchemas(entity("id", "email"), entity("id", "email", "name"), entity("id", "email", "plan"))
+	if len(conflicts) != 0 {
+		t.Fatalf("unexpected conflicts %v", conflicts)
+	}
+	if got := merged.GetEntity("User").FieldOrder; !reflect.DeepEqual(got, []string{"id", "email", "name", "plan"}) {
+		t.Errorf("FieldOrder = %v", got)
+	}
+}
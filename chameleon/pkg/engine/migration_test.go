@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -43,3 +44,77 @@ func TestGenerateMigrationNoSchema(t *testing.T) {
 		t.Fatal("Expected error when no schema loaded")
 	}
 }
+
+func TestGenerateMigrationPlan(t *testing.T) {
+	eng := NewEngineForCLI()
+	_, err := eng.LoadSchemaFromString(`
+		entity User {
+			id: uuid primary,
+			email: string,
+			deleted_at: timestamp nullable,
+			@unique(email) where deleted_at is null,
+		}
+	`)
+	if err != nil {
+		t.Fatalf("Failed to load schema: %v", err)
+	}
+
+	plan, err := eng.GenerateMigrationPlan()
+	if err != nil {
+		t.Fatalf("GenerateMigrationPlan failed: %v", err)
+	}
+
+	kinds := make([]string, len(plan))
+	for i, stmt := range plan {
+		kinds[i] = stmt.Kind
+	}
+	want := []string{StatementDropTable, StatementCreateTable, StatementCreateIndex}
+	if strings.Join(kinds, ",") != strings.Join(want, ",") {
+		t.Errorf("kinds = %v, want %v", kinds, want)
+	}
+	if plan[1].Description != "Create table users" || plan[1].Entity != "User" {
+		t.Errorf("unexpected create statement: %+v", plan[1])
+	}
+
+	sql, err := eng.GenerateMigration()
+	if err != nil {
+		t.Fatalf("GenerateMigration failed: %v", err)
+	}
+	if MigrationSQL(plan) != sql {
+		t.Errorf("plan does not join back into the migration script")
+	}
+}
+
+func TestIdempotentPlan(t *testing.T) {
+	plan := []MigrationStatement{
+		{Kind: StatementCreateSchema, SQL: `CREATE SCHEMA IF NOT EXISTS "billing";`},
+		{Kind: StatementDropTable, SQL: "DROP TABLE IF EXISTS users CASCADE;"},
+		{Kind: StatementCreateTable, SQL: "CREATE TABLE users (\nid UUID PRIMARY KEY\n);"},
+		{Kind: StatementCreateIndex, SQL: `CREATE UNIQUE INDEX CONCURRENTLY "users_email_key" ON users (email);`, Concurrent: true},
+		{Kind: StatementCreateIndex, SQL: `CREATE INDEX IF NOT EXISTS "users_name_idx" ON users (name);`},
+		{SQL: "ALTER TABLE users ADD COLUMN age INTEGER, ADD COLUMN bio TEXT;"},
+		// ADD COLUMN is only a clause of ALTER TABLE, and never inside quotes
+		{SQL: "COMMENT ON TABLE users IS 'ADD COLUMN age later';"},
+		{SQL: "ALTER TABLE users ADD COLUMN note TEXT DEFAULT 'add column ', ADD COLUMN \"add column x\" TEXT;"},
+	}
+
+	got := IdempotentPlan(plan)
+	want := []string{
+		`CREATE SCHEMA IF NOT EXISTS "billing";`,
+		"DROP TABLE IF EXISTS users CASCADE;",
+		"CREATE TABLE IF NOT EXISTS users (\nid UUID PRIMARY KEY\n);",
+		`CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS "users_email_key" ON users (email);`,
+		`CREATE INDEX IF NOT EXISTS "users_name_idx" ON users (name);`,
+		"ALTER TABLE users ADD COLUMN IF NOT EXISTS age INTEGER, ADD COLUMN IF NOT EXISTS bio TEXT;",
+		"COMMENT ON TABLE users IS 'ADD COLUMN age later';",
+		"ALTER TABLE users ADD COLUMN IF NOT EXISTS note TEXT DEFAULT 'add column ', ADD COLUMN IF NOT EXISTS \"add column x\" TEXT;",
+	}
+	for i := range want {
+		if got[i].SQL != want[i] {
+			t.Errorf("statement %d:\n got %s\nwant %s", i+1, got[i].SQL, want[i])
+		}
+	}
+	if !got[3].Concurrent || plan[2].SQL != "CREATE TABLE users (\nid UUID PRIMARY KEY\n);" {
+		t.Error("IdempotentPlan should keep the other fields and leave the input plan unchanged")
+	}
+}
@@ -3,11 +3,13 @@ package mutation
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 // ============================================================
@@ -21,8 +23,31 @@ type InsertBuilder struct {
 	values    map[string]interface{}
 	config    engine.ValidatorConfig
 
+	// rows are additional rows for a multi-row insert (see Rows)
+	rows []map[string]interface{}
+
+	// upsert turns the insert into INSERT ... ON CONFLICT DO UPDATE;
+	// conflict holds the target fields (empty = primary key).
+	upsert   bool
+	conflict []string
+
+	// allTenants opts out of @tenant scoping (see AllTenants); tenant is
+	// the scope resolved from the context when the insert runs.
+	allTenants bool
+	tenant     *engine.TenantScope
+
+	// authorizer is the engine policy hook (nil = allow all).
+	authorizer engine.Authorizer
+	// journal records the finished mutation (nil = not journaled).
+	journal engine.JournalLogger
+
 	// debugLevel controls mutation debug verbosity.
 	debugLevel *engine.DebugLevel
+
+	// dryRun stops Execute before the database (engine WithDryRun).
+	dryRun bool
+	// timeout bounds Execute when ctx has no deadline (engine WithTimeouts).
+	timeout time.Duration
 }
 
 func NewInsertBuilder(schema *engine.Schema, connector *engine.Connector, entity string) *InsertBuilder {
@@ -41,6 +66,62 @@ func (ib *InsertBuilder) Set(field string, value interface{}) engine.InsertMutat
 	return ib
 }
 
+// Rows implements engine.InsertMutation
+func (ib *InsertBuilder) Rows(rows ...map[string]interface{}) engine.InsertMutation {
+	ib.rows = append(ib.rows, rows...)
+	return ib
+}
+
+// inputRows returns the rows to insert: the fields from Set alone, or
+// each Rows entry on top of the fields from Set
+func (ib *InsertBuilder) inputRows() []map[string]interface{} {
+	if len(ib.rows) == 0 {
+		return []map[string]interface{}{ib.values}
+	}
+
+	merged := make([]map[string]interface{}, len(ib.rows))
+	for i, row := range ib.rows {
+		values := make(map[string]interface{}, len(ib.values)+len(row))
+		for field, value := range ib.values {
+			values[field] = value
+		}
+		for field, value := range row {
+			values[field] = value
+		}
+		merged[i] = values
+	}
+	return merged
+}
+
+// scopedRows returns the input rows with the tenant column filled in for
+// rows that leave it out
+func (ib *InsertBuilder) scopedRows() []map[string]interface{} {
+	rows := ib.inputRows()
+	for i, row := range rows {
+		rows[i] = withTenantColumn(ib.tenant, row)
+	}
+	return rows
+}
+
+// Upsert implements engine.InsertMutation
+func (ib *InsertBuilder) Upsert(conflictFields ...string) engine.InsertMutation {
+	ib.upsert = true
+	ib.conflict = conflictFields
+	return ib
+}
+
+// AllTenants implements engine.InsertMutation
+func (ib *InsertBuilder) AllTenants() engine.InsertMutation {
+	ib.allTenants = true
+	return ib
+}
+
+// WithValidatorConfig implements engine.InsertMutation
+func (ib *InsertBuilder) WithValidatorConfig(cfg engine.ValidatorConfig) engine.InsertMutation {
+	ib.config = cfg
+	return ib
+}
+
 // Debug implements engine.InsertMutation
 func (ib *InsertBuilder) Debug() engine.InsertMutation {
 	level := engine.DebugSQL
@@ -51,65 +132,173 @@ func (ib *InsertBuilder) Debug() engine.InsertMutation {
 // Execute implements engine.InsertMutation
 func (ib *InsertBuilder) Execute(ctx context.Context) (*engine.InsertResult, error) {
 	start := time.Now()
+	result, err := ib.execute(ctx, start)
+
+	affected := 0
+	if result != nil {
+		affected = result.Affected
+	}
+	engine.RecordOperation(ib.journal, engine.OperationInsert, ib.entity, affected, time.Since(start), err)
+
+	return result, err
+}
 
-	// Validate
+// ToSQL validates the mutation and returns the INSERT statement and its
+// ordered arguments without executing it. For an insert split into
+// several statements (see maxBindParams) it returns the first.
+func (ib *InsertBuilder) ToSQL() (string, []interface{}, error) {
+	if err := checkWritable(ib.schema, ib.entity, engine.OperationInsert); err != nil {
+		return "", nil, err
+	}
+	statements, err := ib.build()
+	if err != nil {
+		return "", nil, err
+	}
+	return statements[0].SQL, statements[0].Args, nil
+}
+
+// build validates the input and generates the statements. Errors in a
+// multi-row insert name the row they come from.
+func (ib *InsertBuilder) build() ([]engine.MutationPlan, error) {
 	validator := engine.NewValidator(ib.schema, ib.config)
-	if err := validator.ValidateInsertInput(ib.entity, ib.values); err != nil {
-		return nil, err
+	rows := ib.scopedRows()
+	for i, row := range rows {
+		err := checkTenantValue(ib.tenant, engine.OperationInsert, ib.entity, row)
+		if err == nil {
+			err = validator.ValidateInsertInput(ib.entity, row)
+		}
+		if err == nil && ib.upsert {
+			err = ib.checkConflictFields(row)
+		}
+		if err != nil {
+			if len(ib.rows) > 0 {
+				return nil, fmt.Errorf("row %d: %w", i, err)
+			}
+			return nil, err
+		}
 	}
 
-	// Generate SQL
-	sql, orderedValues := ib.generateSQL()
+	return ib.generateStatements()
+}
 
-	if ib.shouldDebug() {
-		fmt.Printf("[ENTITY] INSERT INTO %s\n", ib.entity)
-		fmt.Printf("[SQL] %s\n", sql)
-		fmt.Printf("[VALUES] %v\n\n", orderedValues)
+// conflictFields returns the ON CONFLICT target: the fields given to
+// Upsert, or the entity's primary key
+func (ib *InsertBuilder) conflictFields(ent *engine.Entity) []string {
+	if len(ib.conflict) > 0 {
+		return ib.conflict
 	}
+	var fields []string
+	for _, key := range ent.PrimaryKeys() {
+		fields = append(fields, key.Name)
+	}
+	return fields
+}
 
-	// Execute via pgx
-	rows, err := ib.connector.Pool().Query(ctx, sql, orderedValues...)
+// checkConflictFields rejects an upsert whose conflict target is unknown
+// or not set, since PostgreSQL could never match it against an existing row
+func (ib *InsertBuilder) checkConflictFields(row map[string]interface{}) error {
+	ent := ib.schema.GetEntity(ib.entity)
+	fields := ib.conflictFields(ent)
+	if len(fields) == 0 {
+		return &engine.ValidationError{
+			Field:    ib.entity,
+			Type:     "missing_conflict_target",
+			Expected: "conflict fields or a primary key",
+			Message:  fmt.Sprintf("entity '%s' has no primary key; pass the conflict fields to Upsert", ib.entity),
+		}
+	}
+
+	for _, field := range fields {
+		if ent.Fields[field] == nil {
+			return &engine.UnknownFieldError{Entity: ib.entity, Field: field, Available: ent.FieldNames()}
+		}
+		if _, ok := row[field]; !ok {
+			return &engine.ValidationError{
+				Field:    field,
+				Type:     "missing_conflict_field",
+				Expected: "a value",
+				Message:  fmt.Sprintf("upsert conflicts on '%s', so it must be set", field),
+			}
+		}
+	}
+	return nil
+}
+
+func (ib *InsertBuilder) execute(ctx context.Context, start time.Time) (*engine.InsertResult, error) {
+	if err := checkWritable(ib.schema, ib.entity, engine.OperationInsert); err != nil {
+		return nil, err
+	}
+	if err := ib.authorizer.Check(ctx, engine.OperationInsert, ib.entity); err != nil {
+		return nil, err
+	}
+	scope, err := engine.ResolveTenantScope(ctx, ib.schema.GetEntity(ib.entity), engine.OperationInsert, ib.allTenants)
 	if err != nil {
-		return nil, mapDatabaseError(err, ib.entity, "INSERT", ib.values)
+		return nil, err
 	}
-	defer rows.Close()
+	ib.tenant = scope
 
-	// Parse RETURNING *.
-	if !rows.Next() {
-		if err := rows.Err(); err != nil {
-			return nil, mapDatabaseError(err, ib.entity, "INSERT", ib.values)
+	statements, err := ib.build()
+	if err != nil {
+		return nil, err
+	}
+
+	if ib.shouldDebug() {
+		fmt.Printf("[ENTITY] INSERT INTO %s\n", ib.entity)
+		for _, stmt := range statements {
+			fmt.Printf("[SQL] %s\n", stmt.SQL)
+			fmt.Printf("[VALUES] %v\n\n", stmt.Args)
 		}
-		return nil, fmt.Errorf("INSERT executed but returned no rows (check required fields)")
+	}
+	if ib.dryRun {
+		plan := statements[0]
+		if len(statements) > 1 {
+			plan.Statements = statements
+		}
+		return &engine.InsertResult{DryRun: &plan}, nil
 	}
 
-	values, err := rows.Values()
+	done, err := ib.connector.BeginOperation()
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan result: %w", err)
+		return nil, err
 	}
+	defer done()
 
-	record := make(map[string]interface{})
-	columns := rows.FieldDescriptions()
-	for i, col := range columns {
-		record[col.Name] = values[i]
+	// Unique violations are mapped against the input values, which only
+	// identify the conflicting row for a single-row insert
+	var mapValues map[string]interface{}
+	if len(ib.rows) == 0 {
+		mapValues = ib.values
 	}
 
-	var id interface{}
-	if len(values) > 0 {
-		id = values[0]
-		for i, col := range columns {
-			if col.Name == "id" {
-				id = values[i]
-				break
+	// Execute via pgx; the statements of a split insert share a transaction
+	var records []map[string]interface{}
+	run := func(ctx context.Context) error {
+		for _, stmt := range statements {
+			returned, err := ib.insertRows(ctx, stmt)
+			if err != nil {
+				return err
 			}
+			records = append(records, returned...)
 		}
+		return nil
 	}
-
-	result := &engine.InsertResult{
-		ID:       id,
-		Record:   record,
-		Affected: 1,
+	if len(statements) > 1 {
+		err = ib.connector.RunWithTimeout(ctx, ib.timeout, func(ctx context.Context) error {
+			return ib.connector.RunInTx(ctx, run)
+		})
+	} else {
+		err = ib.connector.RunWithTimeout(ctx, ib.timeout, run)
+	}
+	if err != nil {
+		return nil, mapDatabaseError(ctx, ib.connector.Pool(), err, ib.schema, ib.entity, "INSERT", mapValues)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("INSERT executed but returned no rows (check required fields)")
 	}
 
+	ent := ib.schema.GetEntity(ib.entity)
+	result := insertResult(ent, orderRecords(ent, ib.scopedRows(), records))
+
 	duration := time.Since(start)
 
 	if ib.shouldTrace() {
@@ -119,6 +308,30 @@ func (ib *InsertBuilder) Execute(ctx context.Context) (*engine.InsertResult, err
 	return result, nil
 }
 
+// insertRows runs one INSERT statement and returns its RETURNING * records
+func (ib *InsertBuilder) insertRows(ctx context.Context, stmt engine.MutationPlan) ([]map[string]interface{}, error) {
+	rows, err := ib.connector.Querier(ctx).Query(ctx, stmt.SQL, stmt.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []map[string]interface{}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan result: %w", err)
+		}
+
+		record := make(map[string]interface{})
+		for i, col := range rows.FieldDescriptions() {
+			record[col.Name] = values[i]
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
 func (ib *InsertBuilder) shouldDebug() bool {
 	if ib.debugLevel != nil {
 		return *ib.debugLevel >= engine.DebugSQL
@@ -133,70 +346,290 @@ func (ib *InsertBuilder) shouldTrace() bool {
 	return false
 }
 
-func (ib *InsertBuilder) generateSQL() (string, []interface{}) {
-	// Get entity definition for table name
+// maxBindParams is the most bind parameters PostgreSQL accepts in one
+// statement. A multi-row insert needing more is split into several
+// statements, each below the limit.
+const maxBindParams = 65535
+
+// generateSQL returns the first statement of generateStatements
+func (ib *InsertBuilder) generateSQL() (string, []interface{}, error) {
+	statements, err := ib.generateStatements()
+	if err != nil {
+		return "", nil, err
+	}
+	return statements[0].SQL, statements[0].Args, nil
+}
+
+func (ib *InsertBuilder) generateStatements() ([]engine.MutationPlan, error) {
+	// Validation normally rejects unknown entities first; guessing a
+	// table name here would only hide typos until the database fails
 	ent := ib.schema.GetEntity(ib.entity)
 	if ent == nil {
-		// Fallback: simple lowercase + "s"
-		return ib.generateSQLFallback()
+		return nil, &engine.UnknownEntityError{Entity: ib.entity, Available: ib.schema.EntityNames()}
 	}
 
+	validator := engine.NewValidator(ib.schema, ib.config)
+
 	// Use entity table name (handles pluralization correctly)
-	tableName := entityToTableName(ib.entity)
+	tableName := qualifiedTableName(ib.schema, ib.entity)
+
+	rows := ib.scopedRows()
 
+	// Columns follow the schema's declared field order, so the SQL reads
+	// like the schema and identical inserts produce identical statements.
+	// A multi-row insert uses every field set by any row.
+	seen := make(map[string]bool)
 	var fields []string
-	var placeholders []string
+	for _, row := range rows {
+		for field := range row {
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+	// @timestamps columns no row sets are filled with now(); created_at
+	// then keeps its value when an upsert updates the existing row
+	for _, field := range []string{engine.CreatedAtField, engine.UpdatedAtField} {
+		if ent.IsTimestampField(field) && !seen[field] {
+			fields = append(fields, field)
+		}
+	}
+	fields = ent.OrderFields(fields)
+
+	// Rows that set nothing still need a column to insert DEFAULT into,
+	// e.g. an entity whose only required column is an auto primary key
+	if len(fields) == 0 {
+		for _, key := range ent.PrimaryKeys() {
+			fields = append(fields, key.Name)
+		}
+	}
+
+	suffix := ""
+	if ib.upsert {
+		updated := fields
+		if ent.IsTimestampField(engine.CreatedAtField) && !seen[engine.CreatedAtField] {
+			updated = removeField(fields, engine.CreatedAtField)
+		}
+		suffix += onConflictClause(ib.conflictFields(ent), updated)
+		// A conflicting row of another tenant is left alone, so the
+		// upsert returns no row instead of taking it over
+		if ib.tenant != nil {
+			suffix += fmt.Sprintf(" WHERE %s.%s = EXCLUDED.%s", tableName, ib.tenant.Column, ib.tenant.Column)
+		}
+	}
+	suffix += " RETURNING *"
+
+	// Build placeholders and values in column order; a row that leaves a
+	// column out gets the column default. A row that would take the
+	// statement past maxBindParams starts the next one.
+	var statements []engine.MutationPlan
+	var tuples []string
 	var values []interface{}
+	flush := func() {
+		sql := fmt.Sprintf(
+			"INSERT INTO %s (%s) VALUES %s",
+			tableName,
+			strings.Join(fields, ", "),
+			strings.Join(tuples, ", "),
+		)
+		statements = append(statements, engine.MutationPlan{SQL: sql + suffix, Args: values})
+		tuples, values = nil, nil
+	}
+	for _, row := range rows {
+		placeholders := make([]string, len(fields))
+		var rowValues []interface{}
+		for i, field := range fields {
+			value, ok := row[field]
+			if !ok && ent.IsTimestampField(field) {
+				placeholders[i] = "now()"
+				continue
+			}
+			if !ok {
+				placeholders[i] = "DEFAULT"
+				continue
+			}
+			value, err := coerceValue(validator, ent, field, value)
+			if err != nil {
+				return nil, err
+			}
+			rowValues = append(rowValues, value)
+		}
+
+		if len(tuples) > 0 && len(values)+len(rowValues) > maxBindParams {
+			flush()
+		}
+		next := 0
+		for i := range placeholders {
+			if placeholders[i] == "" {
+				values = append(values, rowValues[next])
+				next++
+				placeholders[i] = fmt.Sprintf("$%d", len(values))
+			}
+		}
+		tuples = append(tuples, "("+strings.Join(placeholders, ", ")+")")
+	}
+	flush()
+
+	return statements, nil
+}
 
-	// Get field names and sort them for consistent order
-	for field := range ib.values {
-		fields = append(fields, field)
+// onConflictClause builds ON CONFLICT ... DO UPDATE for an upsert: every
+// inserted column outside the conflict target takes the new value. When
+// there is nothing else to update, the target is reassigned to itself so
+// RETURNING still yields the existing row (DO NOTHING would return none).
+func onConflictClause(conflict, fields []string) string {
+	isTarget := make(map[string]bool, len(conflict))
+	for _, field := range conflict {
+		isTarget[field] = true
 	}
-	sort.Strings(fields)
 
-	// Build placeholders and values in sorted order
-	for i, field := range fields {
-		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
-		values = append(values, ib.values[field])
+	var assignments []string
+	for _, field := range fields {
+		if !isTarget[field] {
+			assignments = append(assignments, fmt.Sprintf("%s = EXCLUDED.%s", field, field))
+		}
+	}
+	if len(assignments) == 0 {
+		assignments = append(assignments, fmt.Sprintf("%s = EXCLUDED.%s", conflict[0], conflict[0]))
 	}
 
-	sql := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s) RETURNING *",
-		tableName,
-		strings.Join(fields, ", "),
-		strings.Join(placeholders, ", "),
-	)
+	return fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(conflict, ", "), strings.Join(assignments, ", "))
+}
 
-	return sql, values
+// removeField returns fields without name
+func removeField(fields []string, name string) []string {
+	var kept []string
+	for _, field := range fields {
+		if field != name {
+			kept = append(kept, field)
+		}
+	}
+	return kept
 }
 
-func (ib *InsertBuilder) generateSQLFallback() (string, []interface{}) {
-	tableName := strings.ToLower(ib.entity) + "s"
+// orderRecords puts the RETURNING records of an insert in the order of
+// rows. PostgreSQL does not promise that RETURNING follows VALUES, so
+// records are matched to rows by a key every row sets: the primary key,
+// else a unique field. Without such a key, or when the records do not
+// match the rows one to one (an upsert that skipped a row), the returned
+// order is kept.
+func orderRecords(ent *engine.Entity, rows, records []map[string]interface{}) []map[string]interface{} {
+	if ent == nil || len(rows) < 2 || len(rows) != len(records) {
+		return records
+	}
 
-	var fields []string
-	var placeholders []string
-	var values []interface{}
+	candidates := [][]*engine.Field{ent.PrimaryKeys()}
+	for _, name := range ent.FieldNames() {
+		if field := ent.Fields[name]; field.Unique && !field.PrimaryKey {
+			candidates = append(candidates, []*engine.Field{field})
+		}
+	}
 
-	// Get field names and sort them for consistent order
-	for field := range ib.values {
-		fields = append(fields, field)
+	for _, key := range candidates {
+		if ordered := matchRecords(key, rows, records); ordered != nil {
+			return ordered
+		}
 	}
-	sort.Strings(fields)
+	return records
+}
 
-	// Build placeholders and values in sorted order
-	for i, field := range fields {
-		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
-		values = append(values, ib.values[field])
+// matchRecords orders records like rows by the values of key, or returns
+// nil when some row does not set key or some record matches no row
+func matchRecords(key []*engine.Field, rows, records []map[string]interface{}) []map[string]interface{} {
+	if len(key) == 0 {
+		return nil
 	}
 
-	sql := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s) RETURNING *",
-		tableName,
-		strings.Join(fields, ", "),
-		strings.Join(placeholders, ", "),
-	)
+	byKey := make(map[string]map[string]interface{}, len(records))
+	for _, record := range records {
+		byKey[keyString(key, record)] = record
+	}
+	if len(byKey) != len(records) {
+		return nil
+	}
+
+	ordered := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		for _, field := range key {
+			if row[field.Name] == nil {
+				return nil
+			}
+		}
+		record, ok := byKey[keyString(key, row)]
+		if !ok {
+			return nil
+		}
+		ordered[i] = record
+		delete(byKey, keyString(key, row))
+	}
+	return ordered
+}
+
+// keyString renders the key values of a row or record so that a value
+// compares equal whether it was given as input or scanned back: UUIDs as
+// lowercase text, times in UTC
+func keyString(key []*engine.Field, values map[string]interface{}) string {
+	parts := make([]string, len(key))
+	for i, field := range key {
+		value, err := engine.CoerceFieldValue(field, field.Name, values[field.Name])
+		if err != nil {
+			value = values[field.Name]
+		}
+		switch v := value.(type) {
+		case [16]byte:
+			parts[i] = fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+		case time.Time:
+			parts[i] = v.UTC().Format(time.RFC3339Nano)
+		default:
+			parts[i] = fmt.Sprint(v)
+		}
+		if field.Type.Kind == engine.FieldTypeUUID.Kind {
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// insertResult builds the result of an INSERT from its records, in input
+// order (see orderRecords). ID and Record describe the first row, as they
+// did before multi-row inserts.
+func insertResult(ent *engine.Entity, records []map[string]interface{}) *engine.InsertResult {
+	ids := make([]interface{}, len(records))
+	for i, record := range records {
+		ids[i] = insertedID(ent, record)
+	}
+
+	return &engine.InsertResult{
+		ID:       ids[0],
+		Record:   records[0],
+		Affected: len(records),
+		IDs:      ids,
+		Records:  records,
+	}
+}
+
+// insertedID extracts the primary key from a RETURNING * record: the bare
+// value for a single-column key, or a map of column to value for a
+// composite key. Entities without a declared key fall back to "id".
+func insertedID(ent *engine.Entity, record map[string]interface{}) interface{} {
+	var keys []*engine.Field
+	if ent != nil {
+		keys = ent.PrimaryKeys()
+	}
 
-	return sql, values
+	switch len(keys) {
+	case 0:
+		return record["id"]
+	case 1:
+		return record[keys[0].Name]
+	}
+
+	id := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		id[key.Name] = record[key.Name]
+	}
+	return id
 }
 
 // ============================================================
@@ -211,9 +644,24 @@ type UpdateBuilder struct {
 	updates   map[string]interface{}
 	config    engine.ValidatorConfig
 
+	// authorizer is the engine policy hook (nil = allow all).
+	authorizer engine.Authorizer
+	// journal records the finished mutation (nil = not journaled).
+	journal engine.JournalLogger
+
+	// allTenants opts out of @tenant scoping (see AllTenants); tenant is
+	// the scope resolved from the context when the update runs.
+	allTenants bool
+	tenant     *engine.TenantScope
+
 	// debugLevel controls mutation debug verbosity.
 	debugLevel *engine.DebugLevel
 	forceAll   bool
+
+	// dryRun stops Execute before the database (engine WithDryRun).
+	dryRun bool
+	// timeout bounds Execute when ctx has no deadline (engine WithTimeouts).
+	timeout time.Duration
 }
 
 func NewUpdateBuilder(schema *engine.Schema, connector *engine.Connector, entity string) *UpdateBuilder {
@@ -240,6 +688,18 @@ func (ub *UpdateBuilder) Set(field string, value interface{}) engine.UpdateMutat
 	return ub
 }
 
+// AllTenants implements engine.UpdateMutation
+func (ub *UpdateBuilder) AllTenants() engine.UpdateMutation {
+	ub.allTenants = true
+	return ub
+}
+
+// WithValidatorConfig implements engine.UpdateMutation
+func (ub *UpdateBuilder) WithValidatorConfig(cfg engine.ValidatorConfig) engine.UpdateMutation {
+	ub.config = cfg
+	return ub
+}
+
 // Debug implements engine.UpdateMutation
 func (ub *UpdateBuilder) Debug() engine.UpdateMutation {
 	level := engine.DebugSQL
@@ -250,19 +710,57 @@ func (ub *UpdateBuilder) Debug() engine.UpdateMutation {
 // Execute implements engine.UpdateMutation
 func (ub *UpdateBuilder) Execute(ctx context.Context) (*engine.UpdateResult, error) {
 	start := time.Now()
+	result, err := ub.execute(ctx, start)
 
-	// Validate
+	affected := 0
+	if result != nil {
+		affected = result.Affected
+	}
+	engine.RecordOperation(ub.journal, engine.OperationUpdate, ub.entity, affected, time.Since(start), err)
+
+	return result, err
+}
+
+// ToSQL validates the mutation and returns the UPDATE statement and its
+// ordered arguments without executing it
+func (ub *UpdateBuilder) ToSQL() (string, []interface{}, error) {
+	if err := checkWritable(ub.schema, ub.entity, engine.OperationUpdate); err != nil {
+		return "", nil, err
+	}
+	return ub.build()
+}
+
+// build validates the input and generates SQL
+func (ub *UpdateBuilder) build() (string, []interface{}, error) {
 	validator := engine.NewValidator(ub.schema, ub.config)
 	if err := validator.ValidateUpdateInput(
 		ub.entity,
 		ub.parseFilters(),
 		ub.updates,
 	); err != nil {
+		return "", nil, err
+	}
+	if err := checkTenantValue(ub.tenant, engine.OperationUpdate, ub.entity, ub.updates); err != nil {
+		return "", nil, err
+	}
+
+	return ub.generateSQL()
+}
+
+func (ub *UpdateBuilder) execute(ctx context.Context, start time.Time) (*engine.UpdateResult, error) {
+	if err := checkWritable(ub.schema, ub.entity, engine.OperationUpdate); err != nil {
 		return nil, err
 	}
+	if err := ub.authorizer.Check(ctx, engine.OperationUpdate, ub.entity); err != nil {
+		return nil, err
+	}
+	scope, err := engine.ResolveTenantScope(ctx, ub.schema.GetEntity(ub.entity), engine.OperationUpdate, ub.allTenants)
+	if err != nil {
+		return nil, err
+	}
+	ub.tenant = scope
 
-	// Generate SQL
-	sql, orderedValues, err := ub.generateSQL()
+	sql, orderedValues, err := ub.build()
 	if err != nil {
 		return nil, err
 	}
@@ -271,33 +769,48 @@ func (ub *UpdateBuilder) Execute(ctx context.Context) (*engine.UpdateResult, err
 		fmt.Printf("\n[SQL] UPDATE %s\n%s\n", ub.entity, sql)
 		fmt.Printf("[VALUES] %v\n\n", orderedValues)
 	}
+	if ub.dryRun {
+		return &engine.UpdateResult{DryRun: &engine.MutationPlan{SQL: sql, Args: orderedValues}}, nil
+	}
 
-	// Execute via pgx
-	rows, err := ub.connector.Pool().Query(ctx, sql, orderedValues...)
+	done, err := ub.connector.BeginOperation()
 	if err != nil {
-		return nil, mapDatabaseError(err, ub.entity, "UPDATE", ub.updates)
+		return nil, err
 	}
-	defer rows.Close()
+	defer done()
 
-	// Parse RETURNING * (all updated rows)
+	// Execute via pgx
 	var records []map[string]interface{}
-	columns := rows.FieldDescriptions()
-
-	for rows.Next() {
-		values, err := rows.Values()
+	err = ub.connector.RunWithTimeout(ctx, ub.timeout, func(ctx context.Context) error {
+		rows, err := ub.connector.Querier(ctx).Query(ctx, sql, orderedValues...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan result: %w", err)
+			return err
 		}
+		defer rows.Close()
 
-		record := make(map[string]interface{})
-		for i, col := range columns {
-			record[col.Name] = values[i]
+		// Parse RETURNING * (all updated rows)
+		columns := rows.FieldDescriptions()
+
+		for rows.Next() {
+			values, err := rows.Values()
+			if err != nil {
+				return fmt.Errorf("failed to scan result: %w", err)
+			}
+
+			record := make(map[string]interface{})
+			for i, col := range columns {
+				record[col.Name] = values[i]
+			}
+			records = append(records, record)
 		}
-		records = append(records, record)
-	}
 
-	if err := rows.Err(); err != nil {
-		return nil, mapDatabaseError(err, ub.entity, "UPDATE", ub.updates)
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, mapDatabaseError(ctx, ub.connector.Pool(), err, ub.schema, ub.entity, "UPDATE", ub.updates)
 	}
 
 	duration := time.Since(start)
@@ -327,7 +840,7 @@ func (ub *UpdateBuilder) shouldTrace() bool {
 }
 
 func (ub *UpdateBuilder) generateSQL() (string, []interface{}, error) {
-	tableName := entityToTableName(ub.entity)
+	tableName := qualifiedTableName(ub.schema, ub.entity)
 
 	var setClauses []string
 	var values []interface{}
@@ -340,9 +853,15 @@ func (ub *UpdateBuilder) generateSQL() (string, []interface{}, error) {
 	}
 	sort.Strings(updateFields)
 
+	ent := ub.schema.GetEntity(ub.entity)
+	validator := engine.NewValidator(ub.schema, ub.config)
 	for _, field := range updateFields {
+		value, err := coerceValue(validator, ent, field, ub.updates[field])
+		if err != nil {
+			return "", nil, err
+		}
 		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", field, paramIndex))
-		values = append(values, ub.updates[field])
+		values = append(values, value)
 		paramIndex++
 	}
 
@@ -350,6 +869,13 @@ func (ub *UpdateBuilder) generateSQL() (string, []interface{}, error) {
 		return "", nil, fmt.Errorf("UPDATE requires at least one field to set")
 	}
 
+	// @timestamps: every update bumps updated_at unless it is set explicitly
+	if ent != nil && ent.IsTimestampField(engine.UpdatedAtField) {
+		if _, ok := ub.updates[engine.UpdatedAtField]; !ok {
+			setClauses = append(setClauses, engine.UpdatedAtField+" = now()")
+		}
+	}
+
 	// WHERE clauses - sort filters for consistent order
 	var whereFields []string
 	for filterKey := range ub.filters {
@@ -366,19 +892,27 @@ func (ub *UpdateBuilder) generateSQL() (string, []interface{}, error) {
 			op = parts[1]
 		}
 
-		sqlOp, err := mutationOperatorToSQL(op)
+		value, err := filterValue(validator, ent, field, op, ub.filters[filterKey])
+		if err != nil {
+			return "", nil, err
+		}
+		clause, args, err := filterClause(field, op, value, paramIndex)
 		if err != nil {
 			return "", nil, err
 		}
 
-		whereClauses = append(whereClauses, fmt.Sprintf("%s %s $%d", field, sqlOp, paramIndex))
-		values = append(values, ub.filters[filterKey])
-		paramIndex++
+		whereClauses = append(whereClauses, clause)
+		values = append(values, args...)
+		paramIndex += len(args)
 	}
 
 	if len(whereClauses) == 0 {
 		return "", nil, fmt.Errorf("UPDATE without filters is blocked")
 	}
+	if ub.tenant != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = $%d", ub.tenant.Column, paramIndex))
+		values = append(values, ub.tenant.Value)
+	}
 
 	sql := fmt.Sprintf(
 		"UPDATE %s SET %s WHERE %s RETURNING *",
@@ -413,8 +947,29 @@ type DeleteBuilder struct {
 	config         engine.ValidatorConfig
 	forceDeleteAll bool
 
+	// allTenants opts out of @tenant scoping (see AllTenants); tenant is
+	// the scope resolved from the context when the delete runs.
+	allTenants bool
+	tenant     *engine.TenantScope
+
+	// authorizer is the engine policy hook (nil = allow all).
+	authorizer engine.Authorizer
+	// journal records the finished mutation (nil = not journaled).
+	journal engine.JournalLogger
+
 	// debugLevel controls mutation debug verbosity.
 	debugLevel *engine.DebugLevel
+
+	// dryRun stops Execute before the database (engine WithDryRun).
+	dryRun bool
+	// timeout bounds Execute when ctx has no deadline (engine WithTimeouts).
+	timeout time.Duration
+
+	// batchSize is the chunk size set by Batch (batched = Batch was called).
+	batched   bool
+	batchSize int
+	// onBatch receives the rows of each chunk (OnBatch); nil = counts only.
+	onBatch func(records []map[string]interface{}) error
 }
 
 func NewDeleteBuilder(schema *engine.Schema, connector *engine.Connector, entity string) *DeleteBuilder {
@@ -434,6 +989,31 @@ func (db *DeleteBuilder) Filter(field string, op string, value interface{}) engi
 	return db
 }
 
+// AllTenants implements engine.DeleteMutation
+func (db *DeleteBuilder) AllTenants() engine.DeleteMutation {
+	db.allTenants = true
+	return db
+}
+
+// WithValidatorConfig implements engine.DeleteMutation
+func (db *DeleteBuilder) WithValidatorConfig(cfg engine.ValidatorConfig) engine.DeleteMutation {
+	db.config = cfg
+	return db
+}
+
+// Batch implements engine.DeleteMutation
+func (db *DeleteBuilder) Batch(size int) engine.DeleteMutation {
+	db.batched = true
+	db.batchSize = size
+	return db
+}
+
+// OnBatch implements engine.DeleteMutation
+func (db *DeleteBuilder) OnBatch(fn func(records []map[string]interface{}) error) engine.DeleteMutation {
+	db.onBatch = fn
+	return db
+}
+
 // Debug implements engine.DeleteMutation
 func (db *DeleteBuilder) Debug() engine.DeleteMutation {
 	level := engine.DebugSQL
@@ -444,19 +1024,54 @@ func (db *DeleteBuilder) Debug() engine.DeleteMutation {
 // Execute implements engine.DeleteMutation
 func (db *DeleteBuilder) Execute(ctx context.Context) (*engine.DeleteResult, error) {
 	start := time.Now()
+	result, err := db.execute(ctx, start)
+
+	affected := 0
+	if result != nil {
+		affected = result.Affected
+	}
+	engine.RecordOperation(db.journal, engine.OperationDelete, db.entity, affected, time.Since(start), err)
+
+	return result, err
+}
 
-	// Validate
+// ToSQL validates the mutation and returns the DELETE statement and its
+// ordered arguments without executing it
+func (db *DeleteBuilder) ToSQL() (string, []interface{}, error) {
+	if err := checkWritable(db.schema, db.entity, engine.OperationDelete); err != nil {
+		return "", nil, err
+	}
+	return db.build()
+}
+
+// build validates the input and generates SQL
+func (db *DeleteBuilder) build() (string, []interface{}, error) {
 	validator := engine.NewValidator(db.schema, db.config)
 	if err := validator.ValidateDeleteInput(
 		db.entity,
 		db.parseFilters(),
 		db.forceDeleteAll,
 	); err != nil {
+		return "", nil, err
+	}
+
+	return db.generateSQL()
+}
+
+func (db *DeleteBuilder) execute(ctx context.Context, start time.Time) (*engine.DeleteResult, error) {
+	if err := checkWritable(db.schema, db.entity, engine.OperationDelete); err != nil {
+		return nil, err
+	}
+	if err := db.authorizer.Check(ctx, engine.OperationDelete, db.entity); err != nil {
+		return nil, err
+	}
+	scope, err := engine.ResolveTenantScope(ctx, db.schema.GetEntity(db.entity), engine.OperationDelete, db.allTenants)
+	if err != nil {
 		return nil, err
 	}
+	db.tenant = scope
 
-	// Generate SQL
-	sql, orderedValues, err := db.generateSQL()
+	sql, orderedValues, err := db.build()
 	if err != nil {
 		return nil, err
 	}
@@ -465,11 +1080,28 @@ func (db *DeleteBuilder) Execute(ctx context.Context) (*engine.DeleteResult, err
 		fmt.Printf("\n[SQL] DELETE FROM %s\n%s\n", db.entity, sql)
 		fmt.Printf("[VALUES] %v\n\n", orderedValues)
 	}
+	if db.dryRun {
+		return &engine.DeleteResult{DryRun: &engine.MutationPlan{SQL: sql, Args: orderedValues}}, nil
+	}
+
+	done, err := db.connector.BeginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	if db.batched {
+		return db.executeBatches(ctx, start, sql, orderedValues)
+	}
 
 	// Execute via pgx
-	commandTag, err := db.connector.Pool().Exec(ctx, sql, orderedValues...)
+	var commandTag pgconn.CommandTag
+	err = db.connector.RunWithTimeout(ctx, db.timeout, func(ctx context.Context) error {
+		commandTag, err = db.connector.Querier(ctx).Exec(ctx, sql, orderedValues...)
+		return err
+	})
 	if err != nil {
-		return nil, mapDatabaseError(err, db.entity, "DELETE", nil)
+		return nil, mapDatabaseError(ctx, db.connector.Pool(), err, db.schema, db.entity, "DELETE", nil)
 	}
 
 	affected := int(commandTag.RowsAffected())
@@ -485,6 +1117,83 @@ func (db *DeleteBuilder) Execute(ctx context.Context) (*engine.DeleteResult, err
 	}, nil
 }
 
+// executeBatches runs the batch statement until a batch deletes fewer
+// rows than the batch size. Each batch commits on its own, so locks are
+// held briefly, and gets what is left of the timeout; on error the result
+// still reports the rows already deleted. Only OnBatch sees the rows.
+func (db *DeleteBuilder) executeBatches(ctx context.Context, start time.Time, sql string, values []interface{}) (*engine.DeleteResult, error) {
+	result := &engine.DeleteResult{}
+	for {
+		var deleted int
+		var records []map[string]interface{}
+		remaining := db.timeout - time.Since(start)
+		err := context.DeadlineExceeded
+		if db.timeout <= 0 || remaining > 0 {
+			err = db.connector.RunWithTimeout(ctx, remaining, func(ctx context.Context) error {
+				if db.onBatch == nil {
+					commandTag, err := db.connector.Querier(ctx).Exec(ctx, sql, values...)
+					deleted = int(commandTag.RowsAffected())
+					return err
+				}
+				var err error
+				records, err = db.deleteBatch(ctx, sql, values)
+				deleted = len(records)
+				return err
+			})
+		}
+		if err != nil {
+			return result, mapDatabaseError(ctx, db.connector.Pool(), err, db.schema, db.entity, "DELETE", nil)
+		}
+
+		result.Affected += deleted
+		result.Batches++
+
+		if db.shouldTrace() {
+			fmt.Printf("[TRACE] DELETE batch %d on %s: %d rows\n", result.Batches, db.entity, deleted)
+		}
+		if db.onBatch != nil && deleted > 0 {
+			if err := db.onBatch(records); err != nil {
+				return result, fmt.Errorf("delete batch %d: %w", result.Batches, err)
+			}
+		}
+		if deleted < db.batchSize {
+			break
+		}
+	}
+
+	if db.shouldTrace() {
+		fmt.Printf("[TRACE] DELETE on %s: %v, %d rows in %d batches\n", db.entity, time.Since(start), result.Affected, result.Batches)
+	}
+	return result, nil
+}
+
+// deleteBatch runs one batch statement and returns the rows it deleted
+func (db *DeleteBuilder) deleteBatch(ctx context.Context, sql string, values []interface{}) ([]map[string]interface{}, error) {
+	rows, err := db.connector.Querier(ctx).Query(ctx, sql, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Parse RETURNING * (the rows of this batch)
+	var records []map[string]interface{}
+	columns := rows.FieldDescriptions()
+
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan result: %w", err)
+		}
+
+		record := make(map[string]interface{})
+		for i, col := range columns {
+			record[col.Name] = values[i]
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
 func (db *DeleteBuilder) shouldDebug() bool {
 	if db.debugLevel != nil {
 		return *db.debugLevel >= engine.DebugSQL
@@ -500,13 +1209,22 @@ func (db *DeleteBuilder) shouldTrace() bool {
 }
 
 func (db *DeleteBuilder) generateSQL() (string, []interface{}, error) {
-	tableName := entityToTableName(db.entity)
+	tableName := qualifiedTableName(db.schema, db.entity)
+	ent := db.schema.GetEntity(db.entity)
+	validator := engine.NewValidator(db.schema, db.config)
 
 	var whereClauses []string
 	var values []interface{}
 	paramIndex := 1
 
-	for filterKey, value := range db.filters {
+	// Sort filters so the same filters always produce the same statement
+	var whereFields []string
+	for filterKey := range db.filters {
+		whereFields = append(whereFields, filterKey)
+	}
+	sort.Strings(whereFields)
+
+	for _, filterKey := range whereFields {
 		parts := strings.SplitN(filterKey, ":", 2)
 		field := parts[0]
 		op := "eq"
@@ -514,19 +1232,35 @@ func (db *DeleteBuilder) generateSQL() (string, []interface{}, error) {
 			op = parts[1]
 		}
 
-		sqlOp, err := mutationOperatorToSQL(op)
+		value, err := filterValue(validator, ent, field, op, db.filters[filterKey])
+		if err != nil {
+			return "", nil, err
+		}
+		clause, args, err := filterClause(field, op, value, paramIndex)
 		if err != nil {
 			return "", nil, err
 		}
 
-		whereClauses = append(whereClauses, fmt.Sprintf("%s %s $%d", field, sqlOp, paramIndex))
-		values = append(values, value)
-		paramIndex++
+		whereClauses = append(whereClauses, clause)
+		values = append(values, args...)
+		paramIndex += len(args)
 	}
 
 	if len(whereClauses) == 0 {
 		return "", nil, fmt.Errorf("DELETE without filters is blocked")
 	}
+	if db.tenant != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = $%d", db.tenant.Column, paramIndex))
+		values = append(values, db.tenant.Value)
+	}
+
+	if db.onBatch != nil && !db.batched {
+		return "", nil, fmt.Errorf("OnBatch requires Batch")
+	}
+	if db.batched {
+		sql, err := db.batchSQL(tableName, ent, strings.Join(whereClauses, " AND "))
+		return sql, values, err
+	}
 
 	sql := fmt.Sprintf(
 		"DELETE FROM %s WHERE %s",
@@ -537,6 +1271,34 @@ func (db *DeleteBuilder) generateSQL() (string, []interface{}, error) {
 	return sql, values, nil
 }
 
+// batchSQL builds the statement of one Batch chunk: the rows are picked
+// by primary key in a LIMITed subquery, since DELETE has no LIMIT. They
+// are returned only for OnBatch.
+func (db *DeleteBuilder) batchSQL(tableName string, ent *engine.Entity, where string) (string, error) {
+	if db.batchSize < 1 {
+		return "", fmt.Errorf("delete batch size must be positive, got %d", db.batchSize)
+	}
+	keys := ent.PrimaryKeys()
+	if len(keys) == 0 {
+		return "", fmt.Errorf("batched DELETE on %s needs a primary key", db.entity)
+	}
+
+	columns := make([]string, len(keys))
+	for i, key := range keys {
+		columns[i] = key.Name
+	}
+	keyList := strings.Join(columns, ", ")
+
+	sql := fmt.Sprintf(
+		"DELETE FROM %s WHERE (%s) IN (SELECT %s FROM %s WHERE %s LIMIT %d)",
+		tableName, keyList, keyList, tableName, where, db.batchSize,
+	)
+	if db.onBatch != nil {
+		sql += " RETURNING *"
+	}
+	return sql, nil
+}
+
 func (db *DeleteBuilder) parseFilters() map[string]interface{} {
 	result := make(map[string]interface{})
 	for key, value := range db.filters {
@@ -585,6 +1347,114 @@ func entityToTableName(entity string) string {
 	return name
 }
 
+// qualifiedTableName prefixes the table name with the entity's
+// @schema namespace when it has one ("billing"."invoices").
+// Entities without a schema keep the bare name and rely on search_path.
+func qualifiedTableName(schema *engine.Schema, entity string) string {
+	tableName := entityToTableName(entity)
+	if schema == nil {
+		return tableName
+	}
+	if ent := schema.GetEntity(entity); ent != nil && ent.Schema != "" {
+		return quoteIdent(ent.Schema) + "." + quoteIdent(tableName)
+	}
+	return tableName
+}
+
+// quoteIdent quotes a PostgreSQL identifier, doubling embedded quotes
+func quoteIdent(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+// checkWritable rejects mutations against @readonly entities and views
+// before any SQL is generated.
+func checkWritable(schema *engine.Schema, entity string, operation string) error {
+	ent := schema.GetEntity(entity)
+	if ent == nil || !ent.IsReadOnly() {
+		return nil
+	}
+
+	message := fmt.Sprintf("Entity '%s' is marked @readonly", entity)
+	if ent.View {
+		message = fmt.Sprintf("Entity '%s' is a database view and cannot be modified", entity)
+	}
+
+	return &engine.AuthorizationError{
+		Operation: operation,
+		Entity:    entity,
+		Message:   message,
+	}
+}
+
+// coerceValue prepares a value bound to field (see Validator.CoerceValue)
+func coerceValue(validator *engine.Validator, ent *engine.Entity, field string, value interface{}) (interface{}, error) {
+	if ent == nil {
+		return value, nil
+	}
+	return validator.CoerceValue(ent.Fields[field], field, value)
+}
+
+// filterValue prepares the value bound for a filter: the escaped
+// patterns of "ilike_any" terms, both coerced bounds of "between",
+// nothing for "isnull" and "notnull", the coerced value otherwise
+func filterValue(validator *engine.Validator, ent *engine.Entity, field, op string, value interface{}) (interface{}, error) {
+	switch strings.ToLower(op) {
+	case "isnull", "notnull":
+		return nil, nil
+	case "ilike_any":
+		return engine.ContainsPatterns(field, value)
+	case "between":
+		from, to, err := engine.BetweenBounds(field, value)
+		if err != nil {
+			return nil, err
+		}
+		if from, err = coerceValue(validator, ent, field, from); err != nil {
+			return nil, err
+		}
+		if to, err = coerceValue(validator, ent, field, to); err != nil {
+			return nil, err
+		}
+		return []interface{}{from, to}, nil
+	}
+	return coerceValue(validator, ent, field, value)
+}
+
+// filterClause renders one WHERE condition starting at $paramIndex and
+// returns the arguments it binds. "in", "nin" and "ilike_any" bind the
+// whole slice as a single array parameter (field = ANY($n), field !=
+// ALL($n)), so lists of any length share one prepared statement;
+// "between" binds its two bounds, "isnull" and "notnull" none.
+func filterClause(field, op string, value interface{}, paramIndex int) (string, []interface{}, error) {
+	switch strings.ToLower(op) {
+	case "in", "nin":
+		if kind := reflect.ValueOf(value).Kind(); kind != reflect.Slice && kind != reflect.Array {
+			return "", nil, fmt.Errorf("filter %s %s: value must be a slice, got %T", field, strings.ToLower(op), value)
+		}
+		if strings.EqualFold(op, "nin") {
+			return fmt.Sprintf("%s != ALL($%d)", field, paramIndex), []interface{}{value}, nil
+		}
+		return fmt.Sprintf("%s = ANY($%d)", field, paramIndex), []interface{}{value}, nil
+	case "ilike_any":
+		return fmt.Sprintf("%s ILIKE ANY($%d)", field, paramIndex), []interface{}{value}, nil
+	case "between":
+		from, to, err := engine.BetweenBounds(field, value)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s BETWEEN $%d AND $%d", field, paramIndex, paramIndex+1), []interface{}{from, to}, nil
+	case "isnull":
+		return fmt.Sprintf("%s IS NULL", field), nil, nil
+	case "notnull":
+		return fmt.Sprintf("%s IS NOT NULL", field), nil, nil
+	}
+
+	sqlOp, err := mutationOperatorToSQL(op)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("%s %s $%d", field, sqlOp, paramIndex), []interface{}{value}, nil
+}
+
 func mutationOperatorToSQL(op string) (string, error) {
 	switch strings.ToLower(op) {
 	case "eq":
@@ -1,9 +1,17 @@
 package mutation
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 // ============================================================
@@ -355,17 +363,17 @@ func TestFactory_Integration(t *testing.T) {
 	factory := NewFactory()
 
 	// Test that factory creates working builders
-	insert := factory.NewInsert("User", schema, connector)
+	insert := factory.NewInsert("User", schema, connector, engine.MutationOptions{})
 	if insert == nil {
 		t.Error("Factory should create InsertMutation")
 	}
 
-	update := factory.NewUpdate("User", schema, connector)
+	update := factory.NewUpdate("User", schema, connector, engine.MutationOptions{})
 	if update == nil {
 		t.Error("Factory should create UpdateMutation")
 	}
 
-	delete := factory.NewDelete("User", schema, connector)
+	delete := factory.NewDelete("User", schema, connector, engine.MutationOptions{})
 	if delete == nil {
 		t.Error("Factory should create DeleteMutation")
 	}
@@ -385,7 +393,10 @@ func TestInsertBuilder_GenerateSQL(t *testing.T) {
 	builder := NewInsertBuilder(schema, mockConnector(), "User")
 	builder.Set("email", "ana@mail.com").Set("name", "Ana")
 
-	sql, values := builder.generateSQL()
+	sql, values, err := builder.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
 
 	// Verify SQL structure
 	if sql == "" {
@@ -410,6 +421,94 @@ func TestInsertBuilder_GenerateSQL(t *testing.T) {
 	}
 }
 
+func TestInsertBuilder_GenerateSQL_DeclaredFieldOrder(t *testing.T) {
+	schema := testSchema()
+	schema.GetEntity("User").FieldOrder = []string{"id", "name", "email"}
+
+	builder := NewInsertBuilder(schema, mockConnector(), "User")
+	builder.Set("email", "ana@mail.com").Set("name", "Ana").Set("id", "u1").Set("nickname", "ana")
+
+	sql, values, err := builder.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+
+	// Declared fields first in schema order, unknown fields after, sorted
+	expected := "INSERT INTO users (id, name, email, nickname) VALUES ($1, $2, $3, $4) RETURNING *"
+	if sql != expected {
+		t.Errorf("unexpected SQL:\n got: %s\nwant: %s", sql, expected)
+	}
+	if len(values) != 4 || values[0] != "u1" || values[1] != "Ana" || values[2] != "ana@mail.com" || values[3] != "ana" {
+		t.Errorf("values not in column order: %v", values)
+	}
+}
+
+func TestInsertBuilder_GenerateSQL_AutoIncrement(t *testing.T) {
+	var auto interface{} = "AutoIncrement"
+	schema := &engine.Schema{Entities: []*engine.Entity{{
+		Name: "Tag",
+		Fields: map[string]*engine.Field{
+			"id":    {Name: "id", Type: engine.FieldTypeInt, PrimaryKey: true, Default: &auto},
+			"label": {Name: "label", Type: engine.FieldTypeString, Nullable: true},
+		},
+	}}}
+
+	// The auto key is left to the database and read back through RETURNING
+	sql, _, err := NewInsertBuilder(schema, mockConnector(), "Tag").Set("label", "go").ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL should not fail: %v", err)
+	}
+	if want := "INSERT INTO tags (label) VALUES ($1) RETURNING *"; sql != want {
+		t.Errorf("unexpected SQL:\n got: %s\nwant: %s", sql, want)
+	}
+
+	// A row that sets nothing inserts DEFAULT into the key
+	sql, args, err := NewInsertBuilder(schema, mockConnector(), "Tag").
+		Rows(map[string]interface{}{}, map[string]interface{}{}).ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL should not fail: %v", err)
+	}
+	if want := "INSERT INTO tags (id) VALUES (DEFAULT), (DEFAULT) RETURNING *"; sql != want || len(args) != 0 {
+		t.Errorf("unexpected SQL:\n got: %s %v\nwant: %s", sql, args, want)
+	}
+}
+
+func TestInsertedID(t *testing.T) {
+	record := map[string]interface{}{
+		"id":         "row-1",
+		"sku":        "ABC-1",
+		"order_id":   "o1",
+		"product_id": "p1",
+	}
+
+	product := &engine.Entity{
+		Name: "Product",
+		Fields: map[string]*engine.Field{
+			"sku": {Name: "sku", Type: engine.FieldType{Kind: "String"}, PrimaryKey: true},
+		},
+	}
+	if id := insertedID(product, record); id != "ABC-1" {
+		t.Errorf("non-id primary key: got %v, want ABC-1", id)
+	}
+
+	orderItem := &engine.Entity{
+		Name: "OrderItem",
+		Fields: map[string]*engine.Field{
+			"order_id":   {Name: "order_id", Type: engine.FieldType{Kind: "UUID"}, PrimaryKey: true},
+			"product_id": {Name: "product_id", Type: engine.FieldType{Kind: "UUID"}, PrimaryKey: true},
+		},
+	}
+	id, ok := insertedID(orderItem, record).(map[string]interface{})
+	if !ok || len(id) != 2 || id["order_id"] != "o1" || id["product_id"] != "p1" {
+		t.Errorf("composite primary key: got %v", insertedID(orderItem, record))
+	}
+
+	keyless := &engine.Entity{Name: "Log", Fields: map[string]*engine.Field{}}
+	if id := insertedID(keyless, record); id != "row-1" {
+		t.Errorf("entity without primary key should fall back to id, got %v", id)
+	}
+}
+
 func TestUpdateBuilder_GenerateSQL(t *testing.T) {
 	schema := testSchema()
 	builder := NewUpdateBuilder(schema, mockConnector(), "User")
@@ -468,6 +567,520 @@ func TestDeleteBuilder_GenerateSQL(t *testing.T) {
 	}
 }
 
+func TestMutations_SchemaQualifiedTable(t *testing.T) {
+	schema := testSchema()
+	schema.Entities[0].Schema = "accounts"
+
+	insert := NewInsertBuilder(schema, mockConnector(), "User")
+	insert.Set("email", "ana@mail.com")
+	insertSQL, _, err := insert.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !contains(insertSQL, `INSERT INTO "accounts"."users"`) {
+		t.Errorf("INSERT should target the qualified table, got %s", insertSQL)
+	}
+
+	update := NewUpdateBuilder(schema, mockConnector(), "User")
+	update.Filter("id", "eq", "uuid-123").Set("name", "Ana")
+	updateSQL, _, err := update.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !contains(updateSQL, `UPDATE "accounts"."users"`) {
+		t.Errorf("UPDATE should target the qualified table, got %s", updateSQL)
+	}
+
+	del := NewDeleteBuilder(schema, mockConnector(), "User")
+	del.Filter("id", "eq", "uuid-123")
+	deleteSQL, _, err := del.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !contains(deleteSQL, `DELETE FROM "accounts"."users"`) {
+		t.Errorf("DELETE should target the qualified table, got %s", deleteSQL)
+	}
+}
+
+func TestInsertBuilder_GenerateSQL_UnknownEntity(t *testing.T) {
+	schema := testSchema()
+	builder := NewInsertBuilder(schema, mockConnector(), "Usr")
+	builder.Set("email", "ana@mail.com")
+
+	_, _, err := builder.generateSQL()
+	var unknown *engine.UnknownEntityError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected UnknownEntityError, got %v", err)
+	}
+	if unknown.Entity != "Usr" {
+		t.Errorf("expected entity Usr, got %s", unknown.Entity)
+	}
+}
+
+func TestMutations_ToSQL(t *testing.T) {
+	schema := testSchema()
+
+	var insert engine.InsertMutation = NewInsertBuilder(schema, mockConnector(), "User")
+	sql, args, err := insert.Set("email", "ana@mail.com").Set("name", "Ana").ToSQL()
+	if err != nil {
+		t.Fatalf("insert ToSQL should not fail: %v", err)
+	}
+	if sql != "INSERT INTO users (email, name) VALUES ($1, $2) RETURNING *" || len(args) != 2 || args[0] != "ana@mail.com" {
+		t.Errorf("unexpected insert SQL %q args %v", sql, args)
+	}
+
+	var update engine.UpdateMutation = NewUpdateBuilder(schema, mockConnector(), "User")
+	sql, args, err = update.Filter("id", "eq", "uuid-123").Set("name", "Ana").ToSQL()
+	if err != nil {
+		t.Fatalf("update ToSQL should not fail: %v", err)
+	}
+	if !contains(sql, "UPDATE users SET name = $1") || len(args) != 2 {
+		t.Errorf("unexpected update SQL %q args %v", sql, args)
+	}
+
+	var del engine.DeleteMutation = NewDeleteBuilder(schema, mockConnector(), "User")
+	sql, args, err = del.Filter("id", "eq", "uuid-123").ToSQL()
+	if err != nil {
+		t.Fatalf("delete ToSQL should not fail: %v", err)
+	}
+	if !contains(sql, "DELETE FROM users") || len(args) != 1 {
+		t.Errorf("unexpected delete SQL %q args %v", sql, args)
+	}
+}
+
+func TestMutations_TimestampValues(t *testing.T) {
+	schema := &engine.Schema{Entities: []*engine.Entity{
+		{Name: "Event", Fields: map[string]*engine.Field{
+			"id":        {Name: "id", Type: engine.FieldTypeInt, PrimaryKey: true},
+			"starts_at": {Name: "starts_at", Type: engine.FieldTypeTimestamp},
+		}},
+	}}
+	day := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	_, args, err := NewInsertBuilder(schema, mockConnector(), "Event").Set("starts_at", "2024-01-31").ToSQL()
+	if err != nil || len(args) != 1 || args[0] != day {
+		t.Errorf("insert should bind a time.Time, got %v (%v)", args, err)
+	}
+
+	_, args, err = NewUpdateBuilder(schema, mockConnector(), "Event").
+		Filter("starts_at", "lt", "2024-01-31").Set("starts_at", day).ToSQL()
+	if err != nil || len(args) != 2 || args[1] != day {
+		t.Errorf("update filter should bind a time.Time, got %v (%v)", args, err)
+	}
+
+	_, _, err = NewDeleteBuilder(schema, mockConnector(), "Event").Filter("starts_at", "lt", "soon").ToSQL()
+	var format *engine.FieldFormatError
+	if !errors.As(err, &format) {
+		t.Errorf("expected a FieldFormatError, got %v", err)
+	}
+}
+
+func TestMutations_ToSQLValidates(t *testing.T) {
+	schema := testSchema()
+
+	_, _, err := NewInsertBuilder(schema, mockConnector(), "User").Set("nickname", "ana").ToSQL()
+	var unknownField *engine.UnknownFieldError
+	if !errors.As(err, &unknownField) {
+		t.Errorf("expected UnknownFieldError, got %v", err)
+	}
+
+	if _, _, err := NewDeleteBuilder(schema, mockConnector(), "User").ToSQL(); err == nil {
+		t.Error("delete without filters should fail validation")
+	}
+}
+
+func TestInsertBuilder_Upsert(t *testing.T) {
+	schema := testSchema()
+
+	sql, args, err := NewInsertBuilder(schema, mockConnector(), "User").
+		Set("id", "550e8400-e29b-41d4-a716-446655440000").Set("email", "ana@mail.com").Set("name", "Ana").
+		Upsert().ToSQL()
+	if err != nil {
+		t.Fatalf("upsert ToSQL should not fail: %v", err)
+	}
+	want := "INSERT INTO users (email, id, name) VALUES ($1, $2, $3) " +
+		"ON CONFLICT (id) DO UPDATE SET email = EXCLUDED.email, name = EXCLUDED.name RETURNING *"
+	if sql != want || len(args) != 3 {
+		t.Errorf("unexpected upsert SQL:\n got: %s\nwant: %s (args %v)", sql, want, args)
+	}
+
+	// Explicit conflict target
+	sql, _, err = NewInsertBuilder(schema, mockConnector(), "User").
+		Set("email", "ana@mail.com").Set("name", "Ana").
+		Upsert("email").ToSQL()
+	if err != nil {
+		t.Fatalf("upsert on email should not fail: %v", err)
+	}
+	if !strings.HasSuffix(sql, "ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name RETURNING *") {
+		t.Errorf("unexpected upsert SQL: %s", sql)
+	}
+
+	// Only the key is set: reassign it so RETURNING still yields the row
+	keyOnly := NewInsertBuilder(schema, mockConnector(), "User")
+	keyOnly.Set("email", "ana@mail.com").Upsert("email")
+	sql, _, err = keyOnly.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !strings.HasSuffix(sql, "ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email RETURNING *") {
+		t.Errorf("unexpected key-only upsert SQL: %s", sql)
+	}
+}
+
+func TestInsertBuilder_UpsertConflictFields(t *testing.T) {
+	schema := testSchema()
+
+	// The default target is the primary key, which must be set
+	_, _, err := NewInsertBuilder(schema, mockConnector(), "User").
+		Set("email", "ana@mail.com").Set("name", "Ana").
+		Upsert().ToSQL()
+	var validation *engine.ValidationError
+	if !errors.As(err, &validation) || validation.Field != "id" || validation.Type != "missing_conflict_field" {
+		t.Errorf("expected missing_conflict_field on id, got %v", err)
+	}
+
+	_, _, err = NewInsertBuilder(schema, mockConnector(), "User").
+		Set("email", "ana@mail.com").Set("name", "Ana").
+		Upsert("mail").ToSQL()
+	var unknown *engine.UnknownFieldError
+	if !errors.As(err, &unknown) || unknown.Field != "mail" {
+		t.Errorf("expected UnknownFieldError for mail, got %v", err)
+	}
+}
+
+func TestInsertBuilder_Rows(t *testing.T) {
+	schema := testSchema()
+
+	sql, args, err := NewInsertBuilder(schema, mockConnector(), "User").
+		Set("name", "Guest").
+		Rows(
+			map[string]interface{}{"email": "ana@mail.com", "name": "Ana"},
+			map[string]interface{}{"email": "bob@mail.com"},
+			map[string]interface{}{"email": "eve@mail.com", "age": 30},
+		).ToSQL()
+	if err != nil {
+		t.Fatalf("multi-row ToSQL should not fail: %v", err)
+	}
+
+	// One tuple per row, in input order; a column a row leaves out is DEFAULT
+	want := "INSERT INTO users (age, email, name) VALUES " +
+		"(DEFAULT, $1, $2), (DEFAULT, $3, $4), ($5, $6, $7) RETURNING *"
+	if sql != want {
+		t.Errorf("unexpected multi-row SQL:\n got: %s\nwant: %s", sql, want)
+	}
+	wantArgs := []interface{}{"ana@mail.com", "Ana", "bob@mail.com", "Guest", 30, "eve@mail.com", "Guest"}
+	if len(args) != len(wantArgs) {
+		t.Fatalf("expected %d args, got %v", len(wantArgs), args)
+	}
+	for i := range wantArgs {
+		if args[i] != wantArgs[i] {
+			t.Errorf("arg %d: got %v, want %v", i, args[i], wantArgs[i])
+		}
+	}
+
+	// Validation errors name the offending row
+	_, _, err = NewInsertBuilder(schema, mockConnector(), "User").
+		Rows(
+			map[string]interface{}{"email": "ana@mail.com", "name": "Ana"},
+			map[string]interface{}{"email": "bob@mail.com", "name": "Bob", "nickname": "bob"},
+		).ToSQL()
+	var unknown *engine.UnknownFieldError
+	if !errors.As(err, &unknown) || !strings.HasPrefix(err.Error(), "row 1: ") {
+		t.Errorf("expected UnknownFieldError for row 1, got %v", err)
+	}
+}
+
+func TestInsertResult(t *testing.T) {
+	ent := testSchema().GetEntity("User")
+	records := []map[string]interface{}{
+		{"id": "u3", "email": "c@mail.com"},
+		{"id": "u1", "email": "a@mail.com"},
+		{"id": "u2", "email": "b@mail.com"},
+	}
+
+	result := insertResult(ent, records)
+	if result.Affected != 3 || len(result.IDs) != 3 || len(result.Records) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	for i, want := range []string{"u3", "u1", "u2"} {
+		if result.IDs[i] != want || result.Records[i]["id"] != want {
+			t.Errorf("row %d: got id %v, want %s (ids must keep input order)", i, result.IDs[i], want)
+		}
+	}
+
+	// ID and Record still describe the first row
+	if result.ID != "u3" || result.Record["email"] != "c@mail.com" {
+		t.Errorf("ID/Record should describe the first row, got %v %v", result.ID, result.Record)
+	}
+
+	single := insertResult(ent, records[:1])
+	if single.ID != "u3" || single.Affected != 1 || len(single.IDs) != 1 {
+		t.Errorf("unexpected single-row result: %+v", single)
+	}
+}
+
+func TestOrderRecords(t *testing.T) {
+	ent := testSchema().GetEntity("User")
+	ana := [16]byte{0xa1}
+	bob := [16]byte{0xb2}
+
+	// Matched on the primary key, however the UUID was written
+	rows := []map[string]interface{}{
+		{"id": "A1000000-0000-0000-0000-000000000000", "email": "ana@mail.com"},
+		{"id": "b2000000-0000-0000-0000-000000000000", "email": "bob@mail.com"},
+	}
+	records := []map[string]interface{}{
+		{"id": bob, "email": "bob@mail.com"},
+		{"id": ana, "email": "ana@mail.com"},
+	}
+	ordered := orderRecords(ent, rows, records)
+	if ordered[0]["id"] != ana || ordered[1]["id"] != bob {
+		t.Errorf("records not matched by primary key: %v", ordered)
+	}
+
+	// A generated key falls back to a unique field the rows set
+	rows = []map[string]interface{}{{"email": "ana@mail.com"}, {"email": "bob@mail.com"}}
+	ordered = orderRecords(ent, rows, records)
+	if ordered[0]["email"] != "ana@mail.com" || ordered[1]["email"] != "bob@mail.com" {
+		t.Errorf("records not matched by the unique field: %v", ordered)
+	}
+
+	// Nothing to match on: the returned order is kept
+	rows = []map[string]interface{}{{"name": "Ana"}, {"name": "Bob"}}
+	ordered = orderRecords(ent, rows, records)
+	if ordered[0]["id"] != bob {
+		t.Errorf("unmatched records should keep their order: %v", ordered)
+	}
+
+	// So is it when a row was skipped (an upsert that left a row alone)
+	rows = []map[string]interface{}{{"email": "ana@mail.com"}, {"email": "bob@mail.com"}, {"email": "eve@mail.com"}}
+	ordered = orderRecords(ent, rows, records)
+	if len(ordered) != 2 || ordered[0]["id"] != bob {
+		t.Errorf("records of a partial upsert should keep their order: %v", ordered)
+	}
+}
+
+func TestInsertBuilder_SplitsAtBindParamLimit(t *testing.T) {
+	schema := testSchema()
+
+	// Two parameters per row: one row more than fits in one statement
+	rows := make([]map[string]interface{}, maxBindParams/2+1)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"email": fmt.Sprintf("user%d@mail.com", i), "name": "User"}
+	}
+	builder := NewInsertBuilder(schema, mockConnector(), "User")
+	builder.Rows(rows...)
+	statements, err := builder.build()
+	if err != nil {
+		t.Fatalf("build should not fail: %v", err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(statements))
+	}
+	if got := len(statements[0].Args); got != maxBindParams-1 {
+		t.Errorf("first statement has %d args, want %d", got, maxBindParams-1)
+	}
+	want := "INSERT INTO users (email, name) VALUES ($1, $2) RETURNING *"
+	if statements[1].SQL != want || len(statements[1].Args) != 2 || statements[1].Args[0] != rows[len(rows)-1]["email"] {
+		t.Errorf("unexpected second statement: %s %v", statements[1].SQL, statements[1].Args)
+	}
+
+	// Dry runs list every statement
+	builder.dryRun = true
+	result, err := builder.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("dry run should not fail: %v", err)
+	}
+	if len(result.DryRun.Statements) != 2 || result.DryRun.SQL != statements[0].SQL {
+		t.Errorf("dry run should report both statements, got %d", len(result.DryRun.Statements))
+	}
+}
+
+func TestMutations_WithValidatorConfig(t *testing.T) {
+	schema := testSchema()
+	relaxed := engine.DefaultValidatorConfig()
+	relaxed.StrictTypes = false
+
+	_, _, err := NewInsertBuilder(schema, mockConnector(), "User").
+		Set("email", "ana@mail.com").Set("name", 42).
+		ToSQL()
+	var mismatch *engine.TypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("default config should reject a non-string name, got %v", err)
+	}
+
+	if _, _, err := NewInsertBuilder(schema, mockConnector(), "User").
+		Set("email", "ana@mail.com").Set("name", 42).
+		WithValidatorConfig(relaxed).ToSQL(); err != nil {
+		t.Errorf("relaxed insert should pass validation, got %v", err)
+	}
+
+	if _, _, err := NewUpdateBuilder(schema, mockConnector(), "User").
+		Filter("id", "eq", "uuid-123").Set("name", 42).
+		WithValidatorConfig(relaxed).ToSQL(); err != nil {
+		t.Errorf("relaxed update should pass validation, got %v", err)
+	}
+
+	// Safety guards are not part of the config
+	if _, _, err := NewDeleteBuilder(schema, mockConnector(), "User").
+		WithValidatorConfig(relaxed).ToSQL(); err == nil {
+		t.Error("delete without filters should still fail validation")
+	}
+}
+
+func TestFactory_EngineValidatorConfig(t *testing.T) {
+	schema := testSchema()
+	relaxed := engine.DefaultValidatorConfig()
+	relaxed.StrictTypes = false
+	opts := engine.MutationOptions{Validator: &relaxed}
+
+	insert := NewFactory().NewInsert("User", schema, mockConnector(), opts)
+	if _, _, err := insert.Set("email", "ana@mail.com").Set("name", 42).ToSQL(); err != nil {
+		t.Errorf("engine-level config should relax validation, got %v", err)
+	}
+
+	// A per-mutation config takes precedence over the engine's
+	strict := NewFactory().NewInsert("User", schema, mockConnector(), opts)
+	_, _, err := strict.Set("email", "ana@mail.com").Set("name", 42).
+		WithValidatorConfig(engine.DefaultValidatorConfig()).ToSQL()
+	var mismatch *engine.TypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Errorf("per-mutation config should win, got %v", err)
+	}
+
+	if validatorConfig(engine.MutationOptions{}) != engine.DefaultValidatorConfig() {
+		t.Error("no engine-level config should mean the defaults")
+	}
+}
+
+func TestMutations_InFilterReusesStatement(t *testing.T) {
+	schema := testSchema()
+
+	short := NewDeleteBuilder(schema, mockConnector(), "User")
+	short.Filter("id", "in", []string{"uuid-1", "uuid-2"})
+	shortSQL, shortArgs, err := short.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+
+	long := NewDeleteBuilder(schema, mockConnector(), "User")
+	long.Filter("id", "in", []string{"uuid-1", "uuid-2", "uuid-3", "uuid-4"})
+	longSQL, longArgs, err := long.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+
+	// Same text means the same prepared statement, whatever the list length
+	if shortSQL != longSQL {
+		t.Errorf("IN lists of different sizes should share one statement:\n%s\n%s", shortSQL, longSQL)
+	}
+	if !contains(shortSQL, "id = ANY($1)") {
+		t.Errorf("expected = ANY($1), got %s", shortSQL)
+	}
+	if len(shortArgs) != 1 || len(longArgs) != 1 {
+		t.Errorf("the list should bind as a single parameter, got %v and %v", shortArgs, longArgs)
+	}
+
+	update := NewUpdateBuilder(schema, mockConnector(), "User")
+	update.Filter("id", "in", []string{"uuid-1"}).Set("name", "Ana")
+	updateSQL, _, err := update.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !contains(updateSQL, "WHERE id = ANY($2)") {
+		t.Errorf("expected = ANY($2) in UPDATE, got %s", updateSQL)
+	}
+}
+
+func TestMutations_BetweenFilter(t *testing.T) {
+	builder := NewDeleteBuilder(testSchema(), mockConnector(), "User")
+	builder.Filter("age", "between", []int{18, 65}).Filter("name", "eq", "Ana")
+	sql, args, err := builder.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !contains(sql, "WHERE age BETWEEN $1 AND $2 AND name = $3") {
+		t.Errorf("expected BETWEEN $1 AND $2 followed by $3, got %s", sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{18, 65, "Ana"}) {
+		t.Errorf("expected both bounds as separate parameters, got %v", args)
+	}
+
+	invalid := NewDeleteBuilder(testSchema(), mockConnector(), "User")
+	invalid.Filter("age", "between", []int{18})
+	var mismatch *engine.TypeMismatchError
+	if _, _, err := invalid.generateSQL(); !errors.As(err, &mismatch) {
+		t.Errorf("expected TypeMismatchError for a single bound, got %v", err)
+	}
+}
+
+func TestMutations_NullFilters(t *testing.T) {
+	update := NewUpdateBuilder(testSchema(), mockConnector(), "User")
+	update.Filter("age", "isnull", nil).Filter("name", "notnull", nil).Filter("email", "eq", "ana@mail.com").Set("name", "Ana")
+	sql, args, err := update.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !contains(sql, "WHERE age IS NULL AND email = $2 AND name IS NOT NULL") {
+		t.Errorf("expected IS NULL / IS NOT NULL without parameters, got %s", sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"Ana", "ana@mail.com"}) {
+		t.Errorf("null filters should bind nothing, got %v", args)
+	}
+}
+
+func TestMutations_IlikeAnyFilter(t *testing.T) {
+	builder := NewDeleteBuilder(testSchema(), mockConnector(), "User")
+	builder.Filter("name", "ilike_any", []string{"ana", "50%_off"})
+	sql, args, err := builder.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !contains(sql, "name ILIKE ANY($1)") {
+		t.Errorf("expected ILIKE ANY($1), got %s", sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{[]string{"%ana%", `%50\%\_off%`}}) {
+		t.Errorf("expected the escaped patterns as one parameter, got %v", args)
+	}
+
+	invalid := NewDeleteBuilder(testSchema(), mockConnector(), "User")
+	invalid.Filter("name", "ilike_any", "ana")
+	var mismatch *engine.TypeMismatchError
+	if _, _, err := invalid.generateSQL(); !errors.As(err, &mismatch) {
+		t.Errorf("expected TypeMismatchError for a single term, got %v", err)
+	}
+}
+
+func TestMutations_InFilterRequiresSlice(t *testing.T) {
+	builder := NewDeleteBuilder(testSchema(), mockConnector(), "User")
+	builder.Filter("id", "in", "uuid-1")
+
+	if _, _, err := builder.generateSQL(); err == nil {
+		t.Fatal("in with a scalar value should fail")
+	}
+
+	builder = NewDeleteBuilder(testSchema(), mockConnector(), "User")
+	builder.Filter("id", "nin", "uuid-1")
+	if _, _, err := builder.generateSQL(); err == nil {
+		t.Fatal("nin with a scalar value should fail")
+	}
+}
+
+func TestMutations_NotInFilter(t *testing.T) {
+	update := NewUpdateBuilder(testSchema(), mockConnector(), "User")
+	update.Filter("id", "nin", []string{"uuid-1", "uuid-2"}).Set("name", "Ana")
+	sql, args, err := update.generateSQL()
+	if err != nil {
+		t.Fatalf("generateSQL should not fail: %v", err)
+	}
+	if !contains(sql, "WHERE id != ALL($2)") {
+		t.Errorf("expected != ALL($2) in UPDATE, got %s", sql)
+	}
+	if len(args) != 2 || !reflect.DeepEqual(args[1], []string{"uuid-1", "uuid-2"}) {
+		t.Errorf("the list should bind as a single parameter, got %v", args)
+	}
+}
+
 func TestUpdateBuilder_GenerateSQL_UnsupportedOperator(t *testing.T) {
 	schema := testSchema()
 	builder := NewUpdateBuilder(schema, mockConnector(), "User")
@@ -489,6 +1102,347 @@ func TestDeleteBuilder_GenerateSQL_NoFilters(t *testing.T) {
 	}
 }
 
+func TestDeleteBuilder_Batch(t *testing.T) {
+	schema := testSchema()
+
+	sql, values, err := NewDeleteBuilder(schema, mockConnector(), "User").
+		Filter("age", "lt", 18).
+		Batch(500).
+		ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL should not fail: %v", err)
+	}
+	want := `DELETE FROM users WHERE (id) IN (SELECT id FROM users WHERE age < $1 LIMIT 500)`
+	if sql != want {
+		t.Errorf("unexpected batch SQL:\n got: %s\nwant: %s", sql, want)
+	}
+	if len(values) != 1 {
+		t.Errorf("Expected 1 value, got %d", len(values))
+	}
+
+	// Rows are returned only for OnBatch
+	onBatch := func([]map[string]interface{}) error { return nil }
+	sql, _, err = NewDeleteBuilder(schema, mockConnector(), "User").
+		Filter("age", "lt", 18).
+		Batch(500).
+		OnBatch(onBatch).
+		ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL should not fail: %v", err)
+	}
+	if sql != want+" RETURNING *" {
+		t.Errorf("expected RETURNING * with OnBatch, got: %s", sql)
+	}
+	if _, _, err := NewDeleteBuilder(schema, mockConnector(), "User").Filter("age", "lt", 18).OnBatch(onBatch).ToSQL(); err == nil {
+		t.Error("ToSQL should fail for OnBatch without Batch")
+	}
+
+	if _, _, err := NewDeleteBuilder(schema, mockConnector(), "User").Filter("age", "lt", 18).Batch(0).ToSQL(); err == nil {
+		t.Error("ToSQL should fail for a zero batch size")
+	}
+
+	// Batching does not lift the guard against unfiltered deletes
+	_, _, err = NewDeleteBuilder(schema, mockConnector(), "User").Batch(500).ToSQL()
+	var safety *engine.SafetyError
+	if !errors.As(err, &safety) {
+		t.Errorf("expected SafetyError for an unfiltered batched delete, got %v", err)
+	}
+}
+
+func TestMutations_RejectViews(t *testing.T) {
+	schema := testSchema()
+	schema.Entities = append(schema.Entities, &engine.Entity{
+		Name: "ActiveUser",
+		Fields: map[string]*engine.Field{
+			"id": {Name: "id", Type: engine.FieldType{Kind: "UUID"}},
+		},
+		Relations: map[string]*engine.Relation{},
+		View:      true,
+	})
+	ctx := context.Background()
+
+	_, err := NewInsertBuilder(schema, mockConnector(), "ActiveUser").
+		Set("id", "uuid-123").
+		Execute(ctx)
+	if !engine.IsAuthorizationError(err) {
+		t.Errorf("INSERT on view should fail with AuthorizationError, got %v", err)
+	}
+
+	_, err = NewUpdateBuilder(schema, mockConnector(), "ActiveUser").
+		Filter("id", "eq", "uuid-123").
+		Set("id", "uuid-456").
+		Execute(ctx)
+	if !engine.IsAuthorizationError(err) {
+		t.Errorf("UPDATE on view should fail with AuthorizationError, got %v", err)
+	}
+
+	_, err = NewDeleteBuilder(schema, mockConnector(), "ActiveUser").
+		Filter("id", "eq", "uuid-123").
+		Execute(ctx)
+	if !engine.IsAuthorizationError(err) {
+		t.Errorf("DELETE on view should fail with AuthorizationError, got %v", err)
+	}
+}
+
+func TestMutations_RejectReadOnly(t *testing.T) {
+	schema := testSchema()
+	schema.GetEntity("User").ReadOnly = true
+	ctx := context.Background()
+
+	_, err := NewInsertBuilder(schema, mockConnector(), "User").
+		Set("email", "ana@mail.com").
+		Set("name", "Ana").
+		Execute(ctx)
+	authErr, ok := err.(*engine.AuthorizationError)
+	if !ok {
+		t.Fatalf("INSERT on @readonly entity should fail with AuthorizationError, got %v", err)
+	}
+	if authErr.Operation != "INSERT" || authErr.Entity != "User" {
+		t.Errorf("unexpected AuthorizationError: %+v", authErr)
+	}
+
+	_, err = NewDeleteBuilder(schema, mockConnector(), "User").
+		Filter("id", "eq", "uuid-123").
+		Execute(ctx)
+	if !engine.IsAuthorizationError(err) {
+		t.Errorf("DELETE on @readonly entity should fail with AuthorizationError, got %v", err)
+	}
+}
+
+func TestMutations_AuthorizerDenies(t *testing.T) {
+	schema := testSchema()
+	policyErr := errors.New("users are managed by the identity service")
+
+	var calls []string
+	opts := engine.MutationOptions{
+		Authorizer: func(op, entity string, ctx context.Context) error {
+			calls = append(calls, op+" "+entity)
+			return policyErr
+		},
+	}
+	factory := NewFactory()
+	ctx := context.Background()
+
+	_, err := factory.NewUpdate("User", schema, mockConnector(), opts).
+		Filter("id", "eq", "uuid-123").
+		Set("name", "Ana").
+		Execute(ctx)
+
+	authErr, ok := err.(*engine.AuthorizationError)
+	if !ok {
+		t.Fatalf("expected AuthorizationError, got %v", err)
+	}
+	if authErr.Message != policyErr.Error() {
+		t.Errorf("expected policy message, got %q", authErr.Message)
+	}
+	if !errors.Is(err, policyErr) {
+		t.Error("AuthorizationError should wrap the policy error")
+	}
+	if len(calls) != 1 || calls[0] != "UPDATE User" {
+		t.Errorf("unexpected authorizer calls: %v", calls)
+	}
+}
+
+// recordingJournal captures runtime journal entries
+type recordingJournal struct {
+	entries []map[string]interface{}
+	status  []string
+}
+
+func (j *recordingJournal) Log(action, status string, details map[string]interface{}, err error) error {
+	j.entries = append(j.entries, details)
+	j.status = append(j.status, action+" "+status)
+	return nil
+}
+
+func TestMutations_JournalRecordsDeniedMutation(t *testing.T) {
+	schema := testSchema()
+	schema.GetEntity("User").ReadOnly = true
+	journal := &recordingJournal{}
+
+	_, err := NewFactory().NewDelete("User", schema, mockConnector(), engine.MutationOptions{Journal: journal}).
+		Filter("id", "eq", "uuid-123").
+		Execute(context.Background())
+	if !engine.IsAuthorizationError(err) {
+		t.Fatalf("expected AuthorizationError, got %v", err)
+	}
+
+	if len(journal.status) != 1 || journal.status[0] != "delete error" {
+		t.Fatalf("expected one 'delete error' entry, got %v", journal.status)
+	}
+	details := journal.entries[0]
+	if details["entity"] != "User" || details["operation"] != engine.OperationDelete || details["affected"] != 0 {
+		t.Errorf("unexpected journal details: %v", details)
+	}
+}
+
+func TestMutations_DryRun(t *testing.T) {
+	schema := testSchema()
+	journal := &recordingJournal{}
+	opts := engine.MutationOptions{DryRun: true, Journal: journal}
+	ctx := context.Background()
+
+	inserted, err := NewFactory().NewInsert("User", schema, mockConnector(), opts).
+		Set("email", "ana@mail.com").Set("name", "Ana").
+		Execute(ctx)
+	if err != nil {
+		t.Fatalf("dry-run insert should not need a connection, got %v", err)
+	}
+	if inserted.DryRun == nil || !strings.HasPrefix(inserted.DryRun.SQL, "INSERT INTO") || len(inserted.DryRun.Args) != 2 {
+		t.Errorf("expected the insert statement and its arguments, got %+v", inserted.DryRun)
+	}
+
+	updated, err := NewFactory().NewUpdate("User", schema, mockConnector(), opts).
+		Filter("id", "eq", "uuid-123").Set("name", "Ana").
+		Execute(ctx)
+	if err != nil || updated.DryRun == nil || !strings.HasPrefix(updated.DryRun.SQL, "UPDATE") {
+		t.Errorf("expected a dry-run update, got %+v, %v", updated, err)
+	}
+
+	deleted, err := NewFactory().NewDelete("User", schema, mockConnector(), opts).
+		Filter("id", "eq", "uuid-123").
+		Execute(ctx)
+	if err != nil || deleted.DryRun == nil || !strings.HasPrefix(deleted.DryRun.SQL, "DELETE") {
+		t.Errorf("expected a dry-run delete, got %+v, %v", deleted, err)
+	}
+
+	truncated, err := NewFactory().NewTruncate([]string{"User"}, schema, mockConnector(), opts).Force().Execute(ctx)
+	if err != nil || truncated.DryRun == nil || !strings.HasPrefix(truncated.DryRun.SQL, "TRUNCATE") {
+		t.Errorf("expected a dry-run truncate, got %+v, %v", truncated, err)
+	}
+
+	loaded, err := NewFactory().BulkLoad(ctx, "User", []map[string]interface{}{{"email": "bo@mail.com", "name": "Bo"}}, schema, mockConnector(), opts)
+	if err != nil || loaded.DryRun == nil || !strings.HasPrefix(loaded.DryRun.SQL, "COPY") {
+		t.Errorf("expected a dry-run bulk load, got %+v, %v", loaded, err)
+	}
+
+	// Validation still runs
+	_, err = NewFactory().NewInsert("User", schema, mockConnector(), opts).
+		Set("email", "ana@mail.com").Set("nickname", "ana").
+		Execute(ctx)
+	if err == nil {
+		t.Error("dry run should still report validation errors")
+	}
+
+	if len(journal.status) != 0 {
+		t.Errorf("dry runs should not be journaled, got %v", journal.status)
+	}
+}
+
+func TestMapDatabaseError_Context(t *testing.T) {
+	err := mapDatabaseError(context.Background(), nil, fmt.Errorf("conn closed: %w", context.Canceled), nil, "User", "UPDATE", nil)
+	if !engine.IsQueryCancelledError(err) {
+		t.Errorf("expected QueryCancelledError, got %v", err)
+	}
+
+	err = mapDatabaseError(context.Background(), nil, context.DeadlineExceeded, nil, "User", "DELETE", nil)
+	if !engine.IsQueryTimeoutError(err) {
+		t.Errorf("expected QueryTimeoutError, got %v", err)
+	}
+}
+
+func TestMapDatabaseError_UndefinedColumnListsFields(t *testing.T) {
+	pgErr := &pgconn.PgError{
+		Code:    "42703",
+		Message: `column "nickname" of relation "users" does not exist`,
+	}
+
+	err := mapDatabaseError(context.Background(), nil, pgErr, testSchema(), "User", "INSERT", nil)
+	unknown, ok := err.(*engine.UnknownFieldError)
+	if !ok {
+		t.Fatalf("expected UnknownFieldError, got %v", err)
+	}
+	if unknown.Field != "nickname" {
+		t.Errorf("expected field 'nickname', got %q", unknown.Field)
+	}
+	if len(unknown.Available) == 0 {
+		t.Fatal("expected available fields from schema")
+	}
+	for i := 1; i < len(unknown.Available); i++ {
+		if unknown.Available[i-1] > unknown.Available[i] {
+			t.Errorf("available fields should be sorted: %v", unknown.Available)
+			break
+		}
+	}
+}
+
+func TestMapDatabaseError_PartialUniqueIndex(t *testing.T) {
+	schema := testSchema()
+	predicate := "deleted_at IS NULL"
+	schema.GetEntity("User").UniqueIndexes = []*engine.UniqueIndex{
+		{Fields: []string{"email"}, Predicate: &predicate},
+	}
+
+	pgErr := &pgconn.PgError{
+		Code:           "23505",
+		Detail:         "Key (email)=(ana@mail.com) already exists.",
+		ConstraintName: "users_email_key",
+	}
+	values := map[string]interface{}{"email": "ana@mail.com"}
+
+	err := mapDatabaseError(context.Background(), nil, pgErr, schema, "User", "INSERT", values)
+	uniqueErr, ok := err.(*engine.UniqueConstraintError)
+	if !ok {
+		t.Fatalf("expected UniqueConstraintError, got %v", err)
+	}
+	if uniqueErr.Condition != predicate {
+		t.Errorf("expected condition %q, got %q", predicate, uniqueErr.Condition)
+	}
+	if !strings.Contains(uniqueErr.Error(), "must be unique where deleted_at IS NULL") {
+		t.Errorf("error should mention the predicate: %v", uniqueErr)
+	}
+
+	// A plain unique column keeps the unconditional message
+	err = mapDatabaseError(context.Background(), nil, pgErr, testSchema(), "User", "INSERT", values)
+	if uniqueErr := err.(*engine.UniqueConstraintError); uniqueErr.Condition != "" {
+		t.Errorf("expected no condition, got %q", uniqueErr.Condition)
+	}
+}
+
+// fakeCatalog answers foreign key lookups with a fixed row
+type fakeCatalog struct {
+	table, column string
+	err           error
+}
+
+func (f fakeCatalog) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return f
+}
+
+func (f fakeCatalog) Scan(dest ...any) error {
+	if f.err != nil {
+		return f.err
+	}
+	*dest[0].(*string) = f.table
+	*dest[1].(*string) = f.column
+	return nil
+}
+
+func TestMapDatabaseError_ForeignKeyUsesCatalog(t *testing.T) {
+	pgErr := &pgconn.PgError{
+		Code:           "23503",
+		Detail:         `Key (author_id)=(uuid-999) is not present in table "users".`,
+		ConstraintName: "posts_author_fkey",
+		TableName:      "posts",
+	}
+
+	err := mapDatabaseError(context.Background(), fakeCatalog{table: "accounts", column: "account_id"}, pgErr, nil, "Post", "INSERT", nil)
+	fkErr, ok := err.(*engine.ForeignKeyError)
+	if !ok {
+		t.Fatalf("expected ForeignKeyError, got %v", err)
+	}
+	if fkErr.ReferencedTable != "accounts" || fkErr.ReferencedField != "account_id" {
+		t.Errorf("expected catalog target accounts.account_id, got %s.%s", fkErr.ReferencedTable, fkErr.ReferencedField)
+	}
+
+	// Lookup failure falls back to the error detail
+	err = mapDatabaseError(context.Background(), fakeCatalog{err: pgx.ErrNoRows}, pgErr, nil, "Post", "INSERT", nil)
+	fkErr = err.(*engine.ForeignKeyError)
+	if fkErr.ReferencedTable != "users" || fkErr.ReferencedField != "id" {
+		t.Errorf("expected fallback users.id, got %s.%s", fkErr.ReferencedTable, fkErr.ReferencedField)
+	}
+}
+
 func TestEntityToTableName(t *testing.T) {
 	tests := []struct {
 		entity string
@@ -532,3 +1486,199 @@ func hasSubstring(s, substr string) bool {
 // - Chaining behavior
 // - SQL generation (without execution)
 // - Interface compliance
+
+func TestTruncateBuilder_ToSQL(t *testing.T) {
+	schema := testSchema()
+
+	sql, args, err := NewTruncateBuilder(schema, mockConnector(), []string{"User"}).Force().ToSQL()
+	if err != nil {
+		t.Fatalf("truncate ToSQL should not fail: %v", err)
+	}
+	if sql != "TRUNCATE TABLE users RESTART IDENTITY CASCADE" || len(args) != 0 {
+		t.Errorf("unexpected truncate SQL %q args %v", sql, args)
+	}
+
+	schema.Entities[0].Schema = "app"
+	sql, _, _ = NewTruncateBuilder(schema, mockConnector(), []string{"User"}).Force().ToSQL()
+	if !contains(sql, `TRUNCATE TABLE "app"."users"`) {
+		t.Errorf("expected schema-qualified table, got %q", sql)
+	}
+}
+
+func TestTruncateBuilder_Guards(t *testing.T) {
+	schema := testSchema()
+
+	_, _, err := NewTruncateBuilder(schema, mockConnector(), []string{"User"}).ToSQL()
+	var safety *engine.SafetyError
+	if !errors.As(err, &safety) || safety.Operation != "truncate_without_force" {
+		t.Errorf("expected SafetyError without Force(), got %v", err)
+	}
+
+	_, _, err = NewTruncateBuilder(schema, mockConnector(), []string{"Usr"}).Force().ToSQL()
+	var unknown *engine.UnknownEntityError
+	if !errors.As(err, &unknown) {
+		t.Errorf("expected UnknownEntityError, got %v", err)
+	}
+
+	if _, _, err := NewTruncateBuilder(schema, mockConnector(), nil).Force().ToSQL(); err == nil {
+		t.Error("truncate without entities should fail")
+	}
+
+	schema.GetEntity("User").View = true
+	_, _, err = NewTruncateBuilder(schema, mockConnector(), []string{"User"}).Force().ToSQL()
+	var authz *engine.AuthorizationError
+	if !errors.As(err, &authz) || authz.Operation != engine.OperationTruncate {
+		t.Errorf("expected AuthorizationError for a view, got %v", err)
+	}
+}
+
+// tenantSchema scopes User rows by an org_id column
+func tenantSchema() *engine.Schema {
+	schema := testSchema()
+	user := schema.GetEntity("User")
+	user.Tenant = "org_id"
+	user.Fields["org_id"] = &engine.Field{Name: "org_id", Type: engine.FieldType{Kind: "String"}}
+	return schema
+}
+
+func TestMutations_TenantScopeSQL(t *testing.T) {
+	schema := tenantSchema()
+	scope := &engine.TenantScope{Column: "org_id", Value: "acme"}
+
+	ib := NewInsertBuilder(schema, mockConnector(), "User")
+	ib.Set("email", "ana@mail.com").Set("name", "Ana")
+	ib.tenant = scope
+	sql, args, err := ib.ToSQL()
+	if err != nil {
+		t.Fatalf("insert ToSQL should not fail: %v", err)
+	}
+	if sql != "INSERT INTO users (email, name, org_id) VALUES ($1, $2, $3) RETURNING *" || args[2] != "acme" {
+		t.Errorf("tenant column should be filled in, got %s %v", sql, args)
+	}
+	if _, ok := ib.values["org_id"]; ok {
+		t.Error("the tenant column should not be added to the builder's own values")
+	}
+
+	ib.Upsert("email")
+	sql, _, _ = ib.ToSQL()
+	if !strings.HasSuffix(sql, "DO UPDATE SET name = EXCLUDED.name, org_id = EXCLUDED.org_id WHERE users.org_id = EXCLUDED.org_id RETURNING *") {
+		t.Errorf("upsert should not take over another tenant's row, got %s", sql)
+	}
+
+	ub := NewUpdateBuilder(schema, mockConnector(), "User")
+	ub.Filter("email", "eq", "ana@mail.com").Set("name", "Ana")
+	ub.tenant = scope
+	sql, args, err = ub.ToSQL()
+	if err != nil {
+		t.Fatalf("update ToSQL should not fail: %v", err)
+	}
+	if sql != "UPDATE users SET name = $1 WHERE email = $2 AND org_id = $3 RETURNING *" || args[2] != "acme" {
+		t.Errorf("update should be scoped to the tenant, got %s %v", sql, args)
+	}
+
+	db := NewDeleteBuilder(schema, mockConnector(), "User")
+	db.Filter("email", "eq", "ana@mail.com")
+	db.tenant = scope
+	sql, args, err = db.ToSQL()
+	if err != nil {
+		t.Fatalf("delete ToSQL should not fail: %v", err)
+	}
+	if sql != "DELETE FROM users WHERE email = $1 AND org_id = $2" || args[1] != "acme" {
+		t.Errorf("delete should be scoped to the tenant, got %s %v", sql, args)
+	}
+
+	// The tenant filter does not count as a filter of its own
+	empty := NewDeleteBuilder(schema, mockConnector(), "User")
+	empty.tenant = scope
+	if _, _, err := empty.generateSQL(); err == nil {
+		t.Error("a delete with only the tenant filter should still be blocked")
+	}
+}
+
+func TestMutations_TenantScopeErrors(t *testing.T) {
+	schema := tenantSchema()
+	factory := NewFactory()
+	ctx := context.Background()
+	var scopeErr *engine.TenantScopeError
+
+	_, err := factory.NewDelete("User", schema, mockConnector(), engine.MutationOptions{}).
+		Filter("email", "eq", "ana@mail.com").Execute(ctx)
+	if !errors.As(err, &scopeErr) || scopeErr.Operation != engine.OperationDelete || scopeErr.Column != "org_id" {
+		t.Errorf("expected TenantScopeError without a tenant, got %v", err)
+	}
+
+	// Writing another tenant's id is rejected
+	scope := &engine.TenantScope{Column: "org_id", Value: "acme"}
+	ib := NewInsertBuilder(schema, mockConnector(), "User")
+	ib.Set("email", "ana@mail.com").Set("name", "Ana").Set("org_id", "globex")
+	ib.tenant = scope
+	if _, _, err := ib.ToSQL(); !errors.As(err, &scopeErr) {
+		t.Errorf("expected TenantScopeError for another tenant's row, got %v", err)
+	}
+
+	ub := NewUpdateBuilder(schema, mockConnector(), "User")
+	ub.Filter("email", "eq", "ana@mail.com").Set("org_id", "globex")
+	ub.tenant = scope
+	if _, _, err := ub.ToSQL(); !errors.As(err, &scopeErr) {
+		t.Errorf("expected TenantScopeError moving a row to another tenant, got %v", err)
+	}
+
+	_, _, err = NewTruncateBuilder(schema, mockConnector(), []string{"User"}).Force().ToSQL()
+	if !errors.As(err, &scopeErr) || scopeErr.Operation != engine.OperationTruncate {
+		t.Errorf("expected TenantScopeError truncating a @tenant entity, got %v", err)
+	}
+	if _, _, err := NewTruncateBuilder(schema, mockConnector(), []string{"User"}).Force().AllTenants().ToSQL(); err != nil {
+		t.Errorf("AllTenants() should allow the truncate, got %v", err)
+	}
+}
+
+// timestampSchema declares User with @timestamps
+func timestampSchema() *engine.Schema {
+	schema := testSchema()
+	var now interface{} = "Now"
+	user := schema.GetEntity("User")
+	user.Timestamps = true
+	user.FieldOrder = []string{"id", "email", "name", "age", "created_at", "updated_at"}
+	for _, name := range []string{"created_at", "updated_at"} {
+		user.Fields[name] = &engine.Field{Name: name, Type: engine.FieldType{Kind: "Timestamp"}, Default: &now}
+	}
+	return schema
+}
+
+func TestMutations_Timestamps(t *testing.T) {
+	schema := timestampSchema()
+
+	sql, args, err := NewInsertBuilder(schema, mockConnector(), "User").
+		Set("email", "ana@mail.com").Set("name", "Ana").ToSQL()
+	if err != nil {
+		t.Fatalf("insert ToSQL should not fail: %v", err)
+	}
+	if sql != "INSERT INTO users (email, name, created_at, updated_at) VALUES ($1, $2, now(), now()) RETURNING *" || len(args) != 2 {
+		t.Errorf("timestamps should be set to now(), got %s %v", sql, args)
+	}
+
+	// An upsert never resets an omitted created_at
+	sql, _, err = NewInsertBuilder(schema, mockConnector(), "User").
+		Set("email", "ana@mail.com").Set("name", "Ana").Upsert("email").ToSQL()
+	if err != nil {
+		t.Fatalf("upsert ToSQL should not fail: %v", err)
+	}
+	if !strings.HasSuffix(sql, "DO UPDATE SET name = EXCLUDED.name, updated_at = EXCLUDED.updated_at RETURNING *") {
+		t.Errorf("upsert should keep created_at, got %s", sql)
+	}
+
+	sql, _, err = NewUpdateBuilder(schema, mockConnector(), "User").
+		Filter("email", "eq", "ana@mail.com").Set("name", "Ana").ToSQL()
+	if err != nil {
+		t.Fatalf("update ToSQL should not fail: %v", err)
+	}
+	if sql != "UPDATE users SET name = $1, updated_at = now() WHERE email = $2 RETURNING *" {
+		t.Errorf("update should bump updated_at, got %s", sql)
+	}
+
+	sql, args, _ = NewUpdateBuilder(schema, mockConnector(), "User").
+		Filter("email", "eq", "ana@mail.com").Set("updated_at", "2024-01-01T00:00:00Z").ToSQL()
+	if sql != "UPDATE users SET updated_at = $1 WHERE email = $2 RETURNING *" || len(args) != 2 {
+		t.Errorf("an explicit updated_at should win, got %s %v", sql, args)
+	}
+}
@@ -0,0 +1,172 @@
+package mutation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/jackc/pgx/v5"
+)
+
+// BulkLoad implements engine.MutationFactory
+func (f *Factory) BulkLoad(ctx context.Context, entity string, rows []map[string]interface{}, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) (*engine.BulkLoadResult, error) {
+	start := time.Now()
+	result, err := bulkLoad(ctx, entity, rows, schema, connector, opts)
+
+	loaded := 0
+	if result != nil {
+		loaded = int(result.Loaded)
+	}
+	engine.RecordOperation(journalFor(opts), engine.OperationInsert, entity, loaded, time.Since(start), err)
+
+	return result, err
+}
+
+func bulkLoad(ctx context.Context, entity string, rows []map[string]interface{}, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) (*engine.BulkLoadResult, error) {
+	if err := checkWritable(schema, entity, engine.OperationInsert); err != nil {
+		return nil, err
+	}
+	if err := opts.Authorizer.Check(ctx, engine.OperationInsert, entity); err != nil {
+		return nil, err
+	}
+	scope, err := engine.ResolveTenantScope(ctx, schema.GetEntity(entity), engine.OperationInsert, false)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, values, err := prepareBulkLoad(schema, entity, rows, scope, validatorConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		sql := fmt.Sprintf("COPY %s (%s) FROM STDIN", copyTableIdentifier(schema, entity).Sanitize(), strings.Join(columns, ", "))
+		return &engine.BulkLoadResult{Columns: columns, DryRun: &engine.MutationPlan{SQL: sql}}, nil
+	}
+	if len(values) == 0 {
+		return &engine.BulkLoadResult{Columns: columns}, nil
+	}
+
+	if !connector.IsConnected() {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	done, err := connector.BeginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	var loaded int64
+	err = connector.RunWithTimeout(ctx, opts.Timeout, func(ctx context.Context) error {
+		loaded, err = connector.Querier(ctx).CopyFrom(ctx, copyTableIdentifier(schema, entity), columns, pgx.CopyFromRows(values))
+		return err
+	})
+	if err != nil {
+		return nil, mapDatabaseError(ctx, connector.Pool(), err, schema, entity, "COPY", nil)
+	}
+
+	return &engine.BulkLoadResult{Loaded: loaded, Columns: columns}, nil
+}
+
+// prepareBulkLoad validates every row and lays the values out in column
+// order. Columns are the union of fields set by any row, in declared order;
+// a tenant scope fills in its column for rows that leave it out.
+// All failing rows are reported together so a load can be fixed in one pass.
+func prepareBulkLoad(schema *engine.Schema, entity string, rows []map[string]interface{}, scope *engine.TenantScope, config engine.ValidatorConfig) ([]string, [][]interface{}, error) {
+	ent := schema.GetEntity(entity)
+	if ent == nil {
+		return nil, nil, &engine.UnknownEntityError{Entity: entity, Available: schema.EntityNames()}
+	}
+
+	if scope != nil {
+		scoped := make([]map[string]interface{}, len(rows))
+		for i, row := range rows {
+			scoped[i] = withTenantColumn(scope, row)
+		}
+		rows = scoped
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, row := range rows {
+		for name := range row {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	columns := ent.OrderFields(names)
+
+	validator := engine.NewValidator(schema, config)
+	var rowErrors []engine.RowError
+	values := make([][]interface{}, 0, len(rows))
+	for i, row := range rows {
+		if err := checkTenantValue(scope, engine.OperationInsert, entity, row); err != nil {
+			rowErrors = append(rowErrors, engine.RowError{Row: i, Err: err})
+			continue
+		}
+		if err := validator.ValidateInsertInput(entity, row); err != nil {
+			rowErrors = append(rowErrors, engine.RowError{Row: i, Err: err})
+			continue
+		}
+		if err := checkOmittedColumns(ent, columns, row); err != nil {
+			rowErrors = append(rowErrors, engine.RowError{Row: i, Err: err})
+			continue
+		}
+
+		record := make([]interface{}, len(columns))
+		var err error
+		for j, column := range columns {
+			if record[j], err = coerceValue(validator, ent, column, row[column]); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, engine.RowError{Row: i, Err: err})
+			continue
+		}
+		values = append(values, record)
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, nil, &engine.BulkLoadError{Entity: entity, Rows: rowErrors}
+	}
+	return columns, values, nil
+}
+
+// checkOmittedColumns rejects a row that leaves out a column other rows
+// set, unless NULL is what an INSERT would have stored: COPY writes NULL
+// for the missing value instead of applying the column default.
+func checkOmittedColumns(ent *engine.Entity, columns []string, row map[string]interface{}) error {
+	for _, column := range columns {
+		if _, ok := row[column]; ok {
+			continue
+		}
+		field := ent.Fields[column]
+		if field == nil || (field.Nullable && field.Default == nil) {
+			continue // Unknown columns are reported on the row that sets them
+		}
+		return &engine.ValidationError{
+			Field:    column,
+			Type:     "missing_column",
+			Value:    nil,
+			Expected: "a value (other rows set this column)",
+			Message:  fmt.Sprintf("COPY cannot apply the default for '%s' to a single row; set it explicitly", column),
+		}
+	}
+	return nil
+}
+
+// copyTableIdentifier names the entity's table for CopyFrom, including
+// its @schema namespace when it has one
+func copyTableIdentifier(schema *engine.Schema, entity string) pgx.Identifier {
+	table := entityToTableName(entity)
+	if ent := schema.GetEntity(entity); ent != nil && ent.Schema != "" {
+		return pgx.Identifier{ent.Schema, table}
+	}
+	return pgx.Identifier{table}
+}
@@ -0,0 +1,149 @@
+package mutation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestPrepareBulkLoad_DeclaredColumnOrder(t *testing.T) {
+	schema := testSchema()
+	schema.GetEntity("User").FieldOrder = []string{"id", "email", "name", "age"}
+
+	rows := []map[string]interface{}{
+		{"name": "Ana", "email": "ana@mail.com", "id": "550e8400-e29b-41d4-a716-446655440000", "age": 30},
+		{"email": "bo@mail.com", "name": "Bo", "id": "550e8400-e29b-41d4-a716-446655440001"},
+	}
+
+	columns, values, err := prepareBulkLoad(schema, "User", rows, nil, engine.DefaultValidatorConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"id", "email", "name", "age"}; !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns = %v, want %v", columns, want)
+	}
+	want := [][]interface{}{
+		{"550e8400-e29b-41d4-a716-446655440000", "ana@mail.com", "Ana", 30},
+		{"550e8400-e29b-41d4-a716-446655440001", "bo@mail.com", "Bo", nil}, // age is nullable without default
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("values = %v, want %v", values, want)
+	}
+}
+
+func TestPrepareBulkLoad_CoerceStrings(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"email": "ana@mail.com", "name": "Ana", "age": "30"},
+		{"email": "bo@mail.com", "name": "Bo", "age": "thirty"},
+	}
+	cfg := engine.DefaultValidatorConfig()
+	cfg.CoerceStrings = true
+
+	_, _, err := prepareBulkLoad(testSchema(), "User", rows, nil, cfg)
+	var loadErr *engine.BulkLoadError
+	if !errors.As(err, &loadErr) || len(loadErr.Rows) != 1 || loadErr.Rows[0].Row != 1 {
+		t.Fatalf("expected only row 1 to fail, got %v", err)
+	}
+
+	_, values, err := prepareBulkLoad(testSchema(), "User", rows[:1], nil, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(values[0], interface{}(int64(30))) {
+		t.Errorf("age should be bound as an integer, got %v", values[0])
+	}
+}
+
+func TestPrepareBulkLoad_ReportsEveryInvalidRow(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"email": "ana@mail.com", "name": "Ana"},
+		{"email": "bo@mail.com"},             // missing required name
+		{"email": "cy@mail.com", "name": 42}, // wrong type
+	}
+
+	_, _, err := prepareBulkLoad(testSchema(), "User", rows, nil, engine.DefaultValidatorConfig())
+
+	var bulkErr *engine.BulkLoadError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("expected BulkLoadError, got %v", err)
+	}
+	if len(bulkErr.Rows) != 2 || bulkErr.Rows[0].Row != 1 || bulkErr.Rows[1].Row != 2 {
+		t.Fatalf("expected rows 1 and 2 to fail, got %+v", bulkErr.Rows)
+	}
+
+	var notNull *engine.NotNullError
+	if !errors.As(err, &notNull) || notNull.Field != "name" {
+		t.Errorf("expected NotNullError for name through Unwrap, got %v", notNull)
+	}
+}
+
+func TestPrepareBulkLoad_OmittedColumnWithDefault(t *testing.T) {
+	schema := testSchema()
+	var literal interface{} = map[string]interface{}{"Literal": "guest"}
+	schema.GetEntity("User").Fields["name"].Default = &literal
+
+	rows := []map[string]interface{}{
+		{"email": "ana@mail.com", "name": "Ana"},
+		{"email": "bo@mail.com"}, // COPY would store NULL, not the default
+	}
+
+	_, _, err := prepareBulkLoad(schema, "User", rows, nil, engine.DefaultValidatorConfig())
+
+	var bulkErr *engine.BulkLoadError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Rows) != 1 || bulkErr.Rows[0].Row != 1 {
+		t.Fatalf("expected row 1 to be rejected, got %v", err)
+	}
+}
+
+func TestPrepareBulkLoad_TenantScope(t *testing.T) {
+	scope := &engine.TenantScope{Column: "org_id", Value: "acme"}
+	rows := []map[string]interface{}{
+		{"email": "ana@mail.com", "name": "Ana"},
+		{"email": "bo@mail.com", "name": "Bo", "org_id": "globex"},
+	}
+
+	_, _, err := prepareBulkLoad(tenantSchema(), "User", rows, scope, engine.DefaultValidatorConfig())
+	var bulkErr *engine.BulkLoadError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Rows) != 1 || bulkErr.Rows[0].Row != 1 {
+		t.Fatalf("expected row 1 to be rejected, got %v", err)
+	}
+
+	columns, values, err := prepareBulkLoad(tenantSchema(), "User", rows[:1], scope, engine.DefaultValidatorConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(columns) != 3 || values[0][2] != "acme" {
+		t.Errorf("tenant column should be filled in, got %v %v", columns, values)
+	}
+	if _, ok := rows[0]["org_id"]; ok {
+		t.Error("input rows should not be modified")
+	}
+}
+
+func TestBulkLoad_RejectsReadOnlyEntity(t *testing.T) {
+	schema := testSchema()
+	schema.GetEntity("User").ReadOnly = true
+
+	_, err := NewFactory().BulkLoad(context.Background(), "User", []map[string]interface{}{{"name": "Ana"}}, schema, mockConnector(), engine.MutationOptions{})
+	if !engine.IsAuthorizationError(err) {
+		t.Errorf("expected AuthorizationError, got %v", err)
+	}
+}
+
+func TestCopyTableIdentifier(t *testing.T) {
+	schema := testSchema()
+	if got := copyTableIdentifier(schema, "User"); !reflect.DeepEqual(got, pgx.Identifier{"users"}) {
+		t.Errorf("got %v", got)
+	}
+
+	schema.GetEntity("User").Schema = "billing"
+	if got := copyTableIdentifier(schema, "User"); !reflect.DeepEqual(got, pgx.Identifier{"billing", "users"}) {
+		t.Errorf("got %v", got)
+	}
+}
@@ -1,21 +1,35 @@
 package mutation
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// catalogQuerier runs catalog lookups used to enrich errors (satisfied by *pgxpool.Pool)
+type catalogQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 // mapDatabaseError converts PostgreSQL errors to ChameleonDB error types
 // Returns the original error if it's not a PostgreSQL error or unknown type
-func mapDatabaseError(err error, entity string, operation string, values map[string]interface{}) error {
+// db and schema may be nil; they are only used to enrich error details.
+func mapDatabaseError(ctx context.Context, db catalogQuerier, err error, schema *engine.Schema, entity string, operation string, values map[string]interface{}) error {
 	if err == nil {
 		return nil
 	}
 
+	// Cancellation and deadlines are not operation failures; pass them through typed
+	if ctxErr := engine.ContextError(err, operation, entity); ctxErr != nil {
+		return ctxErr
+	}
+
 	// Try to extract PostgreSQL error
 	var pgErr *pgconn.PgError
 	if !errors.As(err, &pgErr) {
@@ -27,10 +41,10 @@ func mapDatabaseError(err error, entity string, operation string, values map[str
 	// See: https://www.postgresql.org/docs/current/errcodes-appendix.html
 	switch pgErr.Code {
 	case "23505": // unique_violation
-		return mapUniqueViolation(pgErr, entity, values)
+		return mapUniqueViolation(pgErr, schema, entity, values)
 
 	case "23503": // foreign_key_violation
-		return mapForeignKeyViolation(pgErr, entity, values)
+		return mapForeignKeyViolation(ctx, db, pgErr, entity, values)
 
 	case "23502": // not_null_violation
 		return mapNotNullViolation(pgErr, entity, values)
@@ -48,7 +62,7 @@ func mapDatabaseError(err error, entity string, operation string, values map[str
 		}
 
 	case "42703": // undefined_column
-		return mapUndefinedColumn(pgErr, entity, values)
+		return mapUndefinedColumn(pgErr, schema, entity)
 
 	default:
 		// Unknown PostgreSQL error, return with context
@@ -57,43 +71,108 @@ func mapDatabaseError(err error, entity string, operation string, values map[str
 }
 
 // mapUniqueViolation handles unique constraint violations
-func mapUniqueViolation(pgErr *pgconn.PgError, entity string, values map[string]interface{}) error {
+func mapUniqueViolation(pgErr *pgconn.PgError, schema *engine.Schema, entity string, values map[string]interface{}) error {
 	// Extract constraint name and field from error details
 	// Detail format: "Key (email)=(test@mail.com) already exists."
 	field := extractFieldFromDetail(pgErr.Detail)
 	value := extractValueForField(field, values)
 
-	return &engine.UniqueConstraintError{
+	uniqueErr := &engine.UniqueConstraintError{
 		Field:      field,
 		Value:      value,
 		Table:      entity,
 		Suggestion: fmt.Sprintf("Use a different value for %s, or update the existing record", field),
 	}
+
+	// Partial indexes only conflict with rows matching their predicate
+	if index := findUniqueIndex(schema, entity, pgErr.ConstraintName); index != nil && index.Predicate != nil {
+		uniqueErr.Condition = *index.Predicate
+		uniqueErr.Suggestion = fmt.Sprintf(
+			"Use a different value for %s, or update the existing record (uniqueness only applies where %s)",
+			field, *index.Predicate)
+	}
+	return uniqueErr
+}
+
+// findUniqueIndex returns the entity-level unique index behind a violated
+// constraint. Migrations name them <table>_<fields>_key.
+func findUniqueIndex(schema *engine.Schema, entity, constraintName string) *engine.UniqueIndex {
+	if schema == nil || constraintName == "" {
+		return nil
+	}
+	ent := schema.GetEntity(entity)
+	if ent == nil {
+		return nil
+	}
+	for _, index := range ent.UniqueIndexes {
+		if entityToTableName(entity)+"_"+strings.Join(index.Fields, "_")+"_key" == constraintName {
+			return index
+		}
+	}
+	return nil
 }
 
 // mapForeignKeyViolation handles foreign key constraint violations
-func mapForeignKeyViolation(pgErr *pgconn.PgError, entity string, values map[string]interface{}) error {
+func mapForeignKeyViolation(ctx context.Context, db catalogQuerier, pgErr *pgconn.PgError, entity string, values map[string]interface{}) error {
 	// Detail format: "Key (author_id)=(uuid-999) is not present in table "users"."
 	field := extractFieldFromDetail(pgErr.Detail)
 	value := extractValueForField(field, values)
 
-	// Try to extract referenced table from Detail first (more reliable)
-	referencedTable := extractReferencedTableFromDetail(pgErr.Detail)
-	if referencedTable == "" {
-		// Fallback to constraint name parsing
-		referencedTable = extractReferencedTable(pgErr.ConstraintName)
+	// Ask the catalog first; it is exact regardless of constraint naming
+	referencedTable, referencedField, err := lookupForeignKeyTarget(ctx, db, pgErr)
+	if err != nil {
+		referencedField = "id" // Usually the PK
+
+		// Detail only names the table when inserting into the child side
+		referencedTable = extractReferencedTableFromDetail(pgErr.Detail)
+		if referencedTable == "" {
+			// Last resort: constraint name parsing
+			referencedTable = extractReferencedTable(pgErr.ConstraintName)
+		}
 	}
 
 	return &engine.ForeignKeyError{
 		Field:            field,
 		Value:            value,
 		ReferencedTable:  referencedTable,
-		ReferencedField:  "id", // Usually the PK
+		ReferencedField:  referencedField,
 		ReferencedEntity: referencedTable,
 		Suggestion:       fmt.Sprintf("Ensure the referenced %s exists before creating this %s", referencedTable, entity),
 	}
 }
 
+// lookupForeignKeyTarget resolves the table and column referenced by the
+// violated constraint from pg_constraint.
+func lookupForeignKeyTarget(ctx context.Context, db catalogQuerier, pgErr *pgconn.PgError) (string, string, error) {
+	if db == nil || pgErr.ConstraintName == "" || pgErr.TableName == "" {
+		return "", "", fmt.Errorf("foreign key lookup unavailable")
+	}
+
+	schemaName := pgErr.SchemaName
+	if schemaName == "" {
+		schemaName = "public"
+	}
+
+	query := `
+		SELECT ref.relname, att.attname
+		FROM pg_constraint con
+		JOIN pg_class src ON src.oid = con.conrelid
+		JOIN pg_namespace ns ON ns.oid = src.relnamespace
+		JOIN pg_class ref ON ref.oid = con.confrelid
+		JOIN pg_attribute att ON att.attrelid = con.confrelid AND att.attnum = con.confkey[1]
+		WHERE con.contype = 'f'
+		  AND con.conname = $1
+		  AND src.relname = $2
+		  AND ns.nspname = $3
+	`
+
+	var table, column string
+	if err := db.QueryRow(ctx, query, pgErr.ConstraintName, pgErr.TableName, schemaName).Scan(&table, &column); err != nil {
+		return "", "", err
+	}
+	return table, column, nil
+}
+
 // mapNotNullViolation handles NOT NULL constraint violations
 func mapNotNullViolation(pgErr *pgconn.PgError, entity string, values map[string]interface{}) error {
 	// Column name is in pgErr.ColumnName
@@ -129,15 +208,33 @@ func mapCheckViolation(pgErr *pgconn.PgError, entity string, values map[string]i
 }
 
 // mapUndefinedColumn handles undefined column errors
-func mapUndefinedColumn(pgErr *pgconn.PgError, entity string, values map[string]interface{}) error {
+func mapUndefinedColumn(pgErr *pgconn.PgError, schema *engine.Schema, entity string) error {
 	// Message format: 'column "unknown_field" of relation "users" does not exist'
 	field := extractFieldFromMessage(pgErr.Message)
 
 	return &engine.UnknownFieldError{
 		Entity:    entity,
 		Field:     field,
-		Available: nil, // Could be populated from schema if needed
+		Available: schemaFieldNames(schema, entity),
+	}
+}
+
+// schemaFieldNames returns the sorted field names of entity, or nil if unknown
+func schemaFieldNames(schema *engine.Schema, entity string) []string {
+	if schema == nil {
+		return nil
+	}
+	ent := schema.GetEntity(entity)
+	if ent == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(ent.Fields))
+	for name := range ent.Fields {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
 }
 
 // ============================================================
@@ -11,16 +11,67 @@ func NewFactory() *Factory {
 }
 
 // NewInsert creates an insert builder with provided schema and connector
-func (f *Factory) NewInsert(entity string, schema *engine.Schema, connector *engine.Connector) engine.InsertMutation {
-	return NewInsertBuilder(schema, connector, entity)
+func (f *Factory) NewInsert(entity string, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) engine.InsertMutation {
+	ib := NewInsertBuilder(schema, connector, entity)
+	ib.authorizer = opts.Authorizer
+	ib.journal = journalFor(opts)
+	ib.dryRun = opts.DryRun
+	ib.timeout = opts.Timeout
+	ib.config = validatorConfig(opts)
+	return ib
 }
 
 // NewUpdate creates an update builder with provided schema and connector
-func (f *Factory) NewUpdate(entity string, schema *engine.Schema, connector *engine.Connector) engine.UpdateMutation {
-	return NewUpdateBuilder(schema, connector, entity)
+func (f *Factory) NewUpdate(entity string, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) engine.UpdateMutation {
+	ub := NewUpdateBuilder(schema, connector, entity)
+	ub.authorizer = opts.Authorizer
+	ub.journal = journalFor(opts)
+	ub.dryRun = opts.DryRun
+	ub.timeout = opts.Timeout
+	ub.config = validatorConfig(opts)
+	return ub
 }
 
 // NewDelete creates a delete builder with provided schema and connector
-func (f *Factory) NewDelete(entity string, schema *engine.Schema, connector *engine.Connector) engine.DeleteMutation {
-	return NewDeleteBuilder(schema, connector, entity)
+func (f *Factory) NewDelete(entity string, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) engine.DeleteMutation {
+	db := NewDeleteBuilder(schema, connector, entity)
+	db.authorizer = opts.Authorizer
+	db.journal = journalFor(opts)
+	db.dryRun = opts.DryRun
+	db.timeout = opts.Timeout
+	db.config = validatorConfig(opts)
+	return db
+}
+
+// NewTruncate creates a truncate builder with provided schema and connector
+func (f *Factory) NewTruncate(entities []string, schema *engine.Schema, connector *engine.Connector, opts engine.MutationOptions) engine.TruncateMutation {
+	tb := NewTruncateBuilder(schema, connector, entities)
+	tb.authorizer = opts.Authorizer
+	tb.journal = journalFor(opts)
+	tb.dryRun = opts.DryRun
+	tb.timeout = opts.Timeout
+	return tb
+}
+
+// TableName returns the table an entity is stored in
+func (f *Factory) TableName(entity string) string {
+	return entityToTableName(entity)
+}
+
+// validatorConfig returns the engine-level validation settings, or the
+// defaults when none were set
+func validatorConfig(opts engine.MutationOptions) engine.ValidatorConfig {
+	if opts.Validator != nil {
+		return *opts.Validator
+	}
+	return engine.DefaultValidatorConfig()
+}
+
+// journalFor returns the journal mutations record to; dry runs change
+// nothing, so they are not journaled
+func journalFor(opts engine.MutationOptions) engine.JournalLogger {
+	if opts.DryRun {
+		return nil
+	}
+	return opts.Journal
 }
@@ -0,0 +1,42 @@
+package mutation
+
+import (
+	"fmt"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+)
+
+// checkTenantValue rejects a write that sets the tenant column to another
+// tenant than the one the operation is scoped to
+func checkTenantValue(scope *engine.TenantScope, operation, entity string, values map[string]interface{}) error {
+	if scope == nil {
+		return nil
+	}
+	value, ok := values[scope.Column]
+	if !ok || scope.Matches(value) {
+		return nil
+	}
+	return &engine.TenantScopeError{
+		Operation: operation,
+		Entity:    entity,
+		Column:    scope.Column,
+		Message:   fmt.Sprintf("cannot write %s = %v outside the context tenant %v", scope.Column, value, scope.Value),
+	}
+}
+
+// withTenantColumn returns row with the tenant column set to the scoped
+// tenant when the row leaves it out. The row itself is not modified.
+func withTenantColumn(scope *engine.TenantScope, row map[string]interface{}) map[string]interface{} {
+	if scope == nil {
+		return row
+	}
+	if _, ok := row[scope.Column]; ok {
+		return row
+	}
+	scoped := make(map[string]interface{}, len(row)+1)
+	for field, value := range row {
+		scoped[field] = value
+	}
+	scoped[scope.Column] = scope.Value
+	return scoped
+}
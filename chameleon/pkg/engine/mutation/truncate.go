@@ -0,0 +1,179 @@
+package mutation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
+)
+
+// ============================================================
+// TRUNCATE BUILDER
+// ============================================================
+
+type TruncateBuilder struct {
+	schema    *engine.Schema
+	connector *engine.Connector
+	entities  []string
+	force     bool
+
+	// allTenants confirms truncating @tenant entities (see AllTenants).
+	allTenants bool
+
+	// authorizer is the engine policy hook (nil = allow all).
+	authorizer engine.Authorizer
+	// journal records the finished mutation (nil = not journaled).
+	journal engine.JournalLogger
+
+	// debugLevel controls mutation debug verbosity.
+	debugLevel *engine.DebugLevel
+
+	// dryRun stops Execute before the database (engine WithDryRun).
+	dryRun bool
+	// timeout bounds Execute when ctx has no deadline (engine WithTimeouts).
+	timeout time.Duration
+}
+
+func NewTruncateBuilder(schema *engine.Schema, connector *engine.Connector, entities []string) *TruncateBuilder {
+	return &TruncateBuilder{
+		schema:    schema,
+		connector: connector,
+		entities:  entities,
+	}
+}
+
+// Force implements engine.TruncateMutation
+func (tb *TruncateBuilder) Force() engine.TruncateMutation {
+	tb.force = true
+	return tb
+}
+
+// AllTenants implements engine.TruncateMutation
+func (tb *TruncateBuilder) AllTenants() engine.TruncateMutation {
+	tb.allTenants = true
+	return tb
+}
+
+// Debug implements engine.TruncateMutation
+func (tb *TruncateBuilder) Debug() engine.TruncateMutation {
+	level := engine.DebugSQL
+	tb.debugLevel = &level
+	return tb
+}
+
+// Execute implements engine.TruncateMutation. Each entity gets its own
+// journal entry; TRUNCATE does not report row counts, so affected is 0.
+func (tb *TruncateBuilder) Execute(ctx context.Context) (*engine.TruncateResult, error) {
+	start := time.Now()
+	result, err := tb.execute(ctx)
+
+	for _, entity := range tb.entities {
+		engine.RecordOperation(tb.journal, engine.OperationTruncate, entity, 0, time.Since(start), err)
+	}
+
+	return result, err
+}
+
+// ToSQL validates the mutation and returns the TRUNCATE statement
+// without executing it
+func (tb *TruncateBuilder) ToSQL() (string, []interface{}, error) {
+	if err := tb.validate(); err != nil {
+		return "", nil, err
+	}
+	return tb.generateSQL(), nil, nil
+}
+
+// validate rejects unknown and read-only entities, @tenant entities not
+// confirmed with AllTenants(), and any truncate that was not confirmed
+// with Force()
+func (tb *TruncateBuilder) validate() error {
+	if len(tb.entities) == 0 {
+		return fmt.Errorf("TRUNCATE needs at least one entity")
+	}
+
+	for _, entity := range tb.entities {
+		if tb.schema.GetEntity(entity) == nil {
+			return &engine.UnknownEntityError{Entity: entity, Available: tb.schema.EntityNames()}
+		}
+		if err := checkWritable(tb.schema, entity, engine.OperationTruncate); err != nil {
+			return err
+		}
+		// TRUNCATE cannot be scoped, so it always empties every tenant
+		if ent := tb.schema.GetEntity(entity); ent.Tenant != "" && !tb.allTenants {
+			return &engine.TenantScopeError{
+				Operation: engine.OperationTruncate,
+				Entity:    entity,
+				Column:    ent.Tenant,
+				Message:   "TRUNCATE removes the rows of every tenant; call AllTenants() to confirm",
+			}
+		}
+	}
+
+	if !tb.force {
+		return &engine.SafetyError{
+			Operation:  "truncate_without_force",
+			Message:    fmt.Sprintf("TRUNCATE removes every row from %s", strings.Join(tb.entities, ", ")),
+			Suggestion: "Call Force() to confirm",
+		}
+	}
+	return nil
+}
+
+func (tb *TruncateBuilder) execute(ctx context.Context) (*engine.TruncateResult, error) {
+	if err := tb.validate(); err != nil {
+		return nil, err
+	}
+	for _, entity := range tb.entities {
+		if err := tb.authorizer.Check(ctx, engine.OperationTruncate, entity); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := tb.generateSQL()
+	if tb.shouldDebug() {
+		fmt.Printf("\n[SQL] TRUNCATE %s\n%s\n\n", strings.Join(tb.entities, ", "), sql)
+	}
+	if tb.dryRun {
+		return &engine.TruncateResult{Tables: tb.tables(), DryRun: &engine.MutationPlan{SQL: sql}}, nil
+	}
+
+	done, err := tb.connector.BeginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	err = tb.connector.RunWithTimeout(ctx, tb.timeout, func(ctx context.Context) error {
+		_, err := tb.connector.Querier(ctx).Exec(ctx, sql)
+		return err
+	})
+	if err != nil {
+		return nil, mapDatabaseError(ctx, tb.connector.Pool(), err, tb.schema, tb.entities[0], "TRUNCATE", nil)
+	}
+
+	return &engine.TruncateResult{Tables: tb.tables()}, nil
+}
+
+func (tb *TruncateBuilder) shouldDebug() bool {
+	if tb.debugLevel != nil {
+		return *tb.debugLevel >= engine.DebugSQL
+	}
+	return false
+}
+
+// tables returns the table name of each entity, in the order given
+func (tb *TruncateBuilder) tables() []string {
+	tables := make([]string, len(tb.entities))
+	for i, entity := range tb.entities {
+		tables[i] = qualifiedTableName(tb.schema, entity)
+	}
+	return tables
+}
+
+// generateSQL truncates all tables in one statement, so rows referencing
+// each other across the listed tables are removed together
+func (tb *TruncateBuilder) generateSQL() string {
+	return fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tb.tables(), ", "))
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Page is one page of query results plus the metadata needed to render
+// pagination controls.
+type Page struct {
+	Rows []Row
+	// Eager-loaded relations for Rows: relation name → rows
+	Relations map[string][]Row
+
+	Total      int64 // Rows matching the query across all pages
+	Page       int   // 1-based page number
+	PerPage    int
+	TotalPages int
+	HasNext    bool
+	HasPrev    bool
+}
+
+// Paginate runs the query for one page (1-based) and counts all matching
+// rows. The data and count queries run concurrently.
+//
+// Example:
+//
+//	page, err := eng.Query("User").
+//		Filter("age", "gte", 18).
+//		OrderBy("email", "asc").
+//		Paginate(ctx, 2, 20)
+//
+// Limit and Offset set on the builder are replaced by the page window.
+func (qb *QueryBuilder) Paginate(ctx context.Context, page, perPage int) (*Page, error) {
+	if page < 1 {
+		return nil, fmt.Errorf("page must be >= 1, got %d", page)
+	}
+	if perPage < 1 {
+		return nil, fmt.Errorf("perPage must be >= 1, got %d", perPage)
+	}
+	if qb.err != nil {
+		return nil, qb.err
+	}
+	if qb.engine.targetErr != nil {
+		return nil, qb.engine.targetErr
+	}
+	if qb.engine.executor == nil {
+		return nil, fmt.Errorf("executor not initialized - call engine.Connect() first")
+	}
+	if limits := qb.engine.limits; limits.Clamp && limits.Max > 0 && uint64(perPage) > limits.Max {
+		// Keep the page metadata consistent with the rows returned
+		perPage = int(limits.Max)
+	}
+
+	// The count runs on the tenant-scoped query, as Execute does
+	counting, err := qb.withTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := *qb
+	data.Offset(uint64((page - 1) * perPage)).Limit(uint64(perPage))
+
+	var (
+		wg       sync.WaitGroup
+		result   *QueryResult
+		total    int64
+		dataErr  error
+		countErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		result, dataErr = data.Execute(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		total, countErr = qb.engine.executor.Count(ctx, counting)
+	}()
+	wg.Wait()
+
+	if dataErr != nil {
+		return nil, dataErr
+	}
+	if countErr != nil {
+		return nil, countErr
+	}
+
+	return newPage(result, total, page, perPage), nil
+}
+
+// newPage fills in the pagination metadata for one page of results
+func newPage(result *QueryResult, total int64, page, perPage int) *Page {
+	totalPages := int((total + int64(perPage) - 1) / int64(perPage))
+	return &Page{
+		Rows:       result.Rows,
+		Relations:  result.Relations,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+// Count returns how many rows the query matches, ignoring its limit,
+// offset, ordering, includes and relation counts. Like Execute, it is
+// scoped to the context's tenant for @tenant entities.
+func (ex *Executor) Count(ctx context.Context, qb *QueryBuilder) (int64, error) {
+	if !ex.connector.IsConnected() {
+		return 0, fmt.Errorf("not connected to database")
+	}
+	done, err := ex.connector.BeginOperation()
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+
+	if err := qb.Authorize(ctx); err != nil {
+		return 0, err
+	}
+	qb, err = qb.withTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	counting := *qb
+	counting.query.Limit = nil
+	counting.query.Offset = nil
+	counting.query.OrderBy = []OrderByClause{}
+	counting.query.Includes = []IncludePath{}
+	counting.query.Counts = []RelationCount{}
+
+	generated, err := counting.ToSQL()
+	if err != nil {
+		return 0, fmt.Errorf("SQL generation failed: %w", err)
+	}
+
+	var total int64
+	sql := fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS counted", generated.MainQuery)
+	err = ex.connector.RunWithTimeout(ctx, qb.engine.timeouts.Query, func(ctx context.Context) error {
+		return ex.connector.Querier(ctx).QueryRow(ctx, sql, generated.Args()...).Scan(&total)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count query failed: %w", contextOr(ctx, err, qb.query.Entity))
+	}
+	return total, nil
+}
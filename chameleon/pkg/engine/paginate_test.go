@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int64
+		page       int
+		perPage    int
+		totalPages int
+		hasNext    bool
+		hasPrev    bool
+	}{
+		{"empty", 0, 1, 20, 0, false, false},
+		{"single partial page", 5, 1, 20, 1, false, false},
+		{"first of many", 45, 1, 20, 3, true, false},
+		{"middle", 45, 2, 20, 3, true, true},
+		{"last exact", 40, 2, 20, 2, false, true},
+		{"past the end", 45, 5, 20, 3, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPage(&QueryResult{}, tt.total, tt.page, tt.perPage)
+			if p.TotalPages != tt.totalPages || p.HasNext != tt.hasNext || p.HasPrev != tt.hasPrev {
+				t.Errorf("newPage(total=%d, page=%d, perPage=%d) = %+v", tt.total, tt.page, tt.perPage, p)
+			}
+			if p.Total != tt.total || p.Page != tt.page || p.PerPage != tt.perPage {
+				t.Errorf("metadata not copied: %+v", p)
+			}
+		})
+	}
+}
+
+func TestPaginateRejectsInvalidArguments(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	if _, err := e.Query("User").Paginate(context.Background(), 0, 20); err == nil {
+		t.Error("page 0 should be rejected")
+	}
+	if _, err := e.Query("User").Paginate(context.Background(), 1, 0); err == nil {
+		t.Error("perPage 0 should be rejected")
+	}
+	if _, err := e.Query("User").Paginate(context.Background(), 1, 20); err == nil {
+		t.Error("Paginate without a connection should fail")
+	}
+}
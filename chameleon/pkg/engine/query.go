@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/chameleon-db/chameleondb/chameleon/internal/ffi"
@@ -32,6 +35,7 @@ type FieldPath struct {
 type FilterExpr struct {
 	Condition *FilterCondition `json:"Condition,omitempty"`
 	Binary    *BinaryExpr      `json:"Binary,omitempty"`
+	Exists    *ExistsExpr      `json:"Exists,omitempty"`
 }
 
 type BinaryExpr struct {
@@ -40,6 +44,14 @@ type BinaryExpr struct {
 	Right FilterExpr `json:"right"`
 }
 
+// ExistsExpr is a correlated EXISTS subquery on a relation.
+// Filters are relative to the related entity.
+type ExistsExpr struct {
+	Relation string       `json:"relation"`
+	Filters  []FilterExpr `json:"filters"`
+	Negated  bool         `json:"negated"` // NOT EXISTS
+}
+
 type IncludePath struct {
 	Path []string `json:"path"`
 }
@@ -47,6 +59,12 @@ type IncludePath struct {
 type OrderByClause struct {
 	Field     string `json:"field"`
 	Direction string `json:"direction"` // "Asc", "Desc"
+	// Relation and Aggregate sort by an aggregate of Field over the
+	// related rows (see OrderByRelation)
+	Relation  string `json:"relation,omitempty"`
+	Aggregate string `json:"aggregate,omitempty"` // "Count", "Sum", "Avg", "Min", "Max"
+	// Filters limit the related rows aggregated over
+	Filters []FilterExpr `json:"filters,omitempty"`
 }
 
 // QueryJSON is the serialization format matching Rust's Query
@@ -58,12 +76,32 @@ type QueryJSON struct {
 	Limit        *uint64         `json:"limit,omitempty"`
 	Offset       *uint64         `json:"offset,omitempty"`
 	SelectFields []string        `json:"select_fields"`
+	Counts       []RelationCount `json:"counts"`
+	Distinct     bool            `json:"distinct"`
+}
+
+// RelationCount adds a "<relation>_count" virtual column to the SELECT list.
+type RelationCount struct {
+	Relation string       `json:"relation"`
+	Filters  []FilterExpr `json:"filters"`
 }
 
 // GeneratedSQL mirrors Rust's GeneratedSQL
 type GeneratedSQL struct {
 	MainQuery    string     `json:"main_query"`
 	EagerQueries [][]string `json:"eager_queries"`
+	// Params are the FilterValues bound to the main query's $1, $2, ...
+	// placeholders, as the core serializes them; see Args
+	Params []interface{} `json:"params"`
+}
+
+// Args returns the values bound to the main query's placeholders
+func (g *GeneratedSQL) Args() []interface{} {
+	args := make([]interface{}, len(g.Params))
+	for i, param := range g.Params {
+		args[i] = filterParam(param)
+	}
+	return args
 }
 
 type EagerQuery struct {
@@ -81,6 +119,47 @@ type QueryBuilder struct {
 
 	// debugLevel overrides the engine debug level for this query.
 	debugLevel *DebugLevel
+
+	// err records the first build error; returned by ToSQL/Execute.
+	err error
+
+	// allTenants lifts the @tenant scope (see AllTenants); tenantScoped
+	// marks a copy that already carries the tenant filters, and
+	// includeScopes holds the scope of each @tenant include path
+	allTenants    bool
+	tenantScoped  bool
+	includeScopes map[string]*TenantScope
+}
+
+// IncludeDepthError is returned when an Include path is nested deeper
+// than the engine allows (see Engine.WithMaxIncludeDepth).
+type IncludeDepthError struct {
+	Path     string
+	Depth    int
+	MaxDepth int
+}
+
+func (e *IncludeDepthError) Error() string {
+	return fmt.Sprintf(
+		"include %q has depth %d, maximum allowed is %d (use WithMaxIncludeDepth to raise it)",
+		e.Path, e.Depth, e.MaxDepth,
+	)
+}
+
+// LimitError is returned when a query's Limit or Offset is out of range:
+// above PostgreSQL's bigint maximum, or a Limit above the engine's cap
+// (see Engine.WithMaxLimit).
+type LimitError struct {
+	Clause string // "LIMIT" or "OFFSET"
+	Value  uint64
+	Max    uint64
+}
+
+func (e *LimitError) Error() string {
+	if e.Max == math.MaxInt64 {
+		return fmt.Sprintf("%s %d exceeds the maximum PostgreSQL accepts (%d)", e.Clause, e.Value, e.Max)
+	}
+	return fmt.Sprintf("%s %d exceeds the engine maximum of %d (use WithMaxLimit to raise it)", e.Clause, e.Value, e.Max)
 }
 
 // Query starts a new query for the given entity
@@ -93,32 +172,384 @@ func (e *Engine) Query(entity string) *QueryBuilder {
 			Includes:     []IncludePath{},
 			OrderBy:      []OrderByClause{},
 			SelectFields: []string{},
+			Counts:       []RelationCount{},
 		},
 	}
 }
 
 // Filter adds a filter condition
 // field: "email" or "orders.total" (supports relation navigation)
-// op: "eq", "neq", "gt", "gte", "lt", "lte", "like"
-// value: string, int, float, or bool
+// op: "eq", "neq", "gt", "gte", "lt", "lte", "like", "is", "in", "nin",
+// "between", "ilike_any", "isnull", "notnull"
+// value: string, int, float, bool or time.Time; a slice for "in" and
+// "nin", the two bounds for "between", and a slice of strings for
+// "ilike_any"
+//
+// On Timestamp fields, strings such as "2024-01-31" or
+// "2024-01-31 14:30:00" are parsed into instants (UTC without a zone).
+// A string that is not a date fails the query with a *FieldFormatError.
+//
+// "ilike_any" keeps rows where field contains any of the terms, ignoring
+// case: field ILIKE ANY (ARRAY['%term%', ...]). % and _ in a term match
+// themselves.
+//
+// "between" takes a slice with the lower and upper bound and generates
+// field BETWEEN from AND to, both bounds included:
+//
+//	db.Query("Order").Filter("created_at", "between", []string{"2024-01-01", "2024-01-31"})
+//
+// "is" takes true, false or nil and generates IS TRUE / IS FALSE / IS NULL.
+// Unlike "= true", IS TRUE/IS FALSE never evaluate to NULL, so they stay
+// predictable for nullable columns.
+//
+// "isnull" and "notnull" generate IS NULL / IS NOT NULL; their value is
+// ignored, pass nil.
 func (qb *QueryBuilder) Filter(field string, op string, value interface{}) *QueryBuilder {
-	rustOp := goOpToRust(op)
+	qb.query.Filters = append(qb.query.Filters, qb.filterCondition(field, op, value))
+	return qb
+}
 
-	qb.query.Filters = append(qb.query.Filters, FilterExpr{
+// filterCondition builds the condition of a Filter call. Invalid values
+// are recorded in qb.err.
+func (qb *QueryBuilder) filterCondition(field string, op string, value interface{}) FilterExpr {
+	switch op {
+	case "is":
+		if err := qb.checkIsFilter(field, value); err != nil && qb.err == nil {
+			qb.err = err
+		}
+	case "like":
+		// Patterns stay text
+	case "isnull", "notnull":
+		value = nil
+	case "ilike_any":
+		patterns, err := ContainsPatterns(field, value)
+		if err != nil && qb.err == nil {
+			qb.err = err
+		}
+		value = patterns
+	case "between":
+		if _, _, err := BetweenBounds(field, value); err != nil {
+			if qb.err == nil {
+				qb.err = err
+			}
+			break
+		}
+		fallthrough
+	default:
+		// Date strings on Timestamp fields are compared as instants
+		coerced, err := CoerceFieldValue(qb.lookupField(field), field, value)
+		if err != nil && qb.err == nil {
+			qb.err = err
+		}
+		if err == nil {
+			value = coerced
+		}
+	}
+
+	return FilterExpr{
 		Condition: &FilterCondition{
 			Field: parseFieldPath(field),
-			Op:    rustOp,
+			Op:    goOpToRust(op),
 			Value: goValueToFilter(value),
 		},
+	}
+}
+
+// Condition is a filter for FilterGroup: a single Filter, or an And/Or
+// of other conditions
+type Condition struct {
+	field string
+	op    string
+	value interface{}
+
+	logic      string // "And", "Or"; "" for a single filter
+	conditions []Condition
+}
+
+// Filter returns a condition for FilterGroup; field, op and value are the
+// same as for QueryBuilder.Filter
+func Filter(field string, op string, value interface{}) Condition {
+	return Condition{field: field, op: op, value: value}
+}
+
+// And matches rows satisfying all conditions
+func And(conditions ...Condition) Condition {
+	return Condition{logic: "And", conditions: conditions}
+}
+
+// Or matches rows satisfying at least one of the conditions
+func Or(conditions ...Condition) Condition {
+	return Condition{logic: "Or", conditions: conditions}
+}
+
+// FilterGroup adds a condition combining several filters with And/Or.
+// Each group is parenthesized and ANDed with the other filters:
+//
+//	db.Query("User").
+//		Filter("active", "eq", true).
+//		FilterGroup(engine.Or(
+//			engine.Filter("age", "lt", 18),
+//			engine.Filter("age", "gt", 65),
+//		))
+//	// WHERE active = true AND (age < 18 OR age > 65)
+//
+// Groups nest: Or(And(...), Filter(...)). An And or Or without conditions
+// fails the query.
+func (qb *QueryBuilder) FilterGroup(condition Condition) *QueryBuilder {
+	expr, ok := qb.conditionExpr(condition)
+	if ok {
+		qb.query.Filters = append(qb.query.Filters, expr)
+	}
+	return qb
+}
+
+// conditionExpr converts a condition to a filter expression, folding
+// And/Or groups into nested binary expressions
+func (qb *QueryBuilder) conditionExpr(condition Condition) (FilterExpr, bool) {
+	if condition.logic == "" {
+		return qb.filterCondition(condition.field, condition.op, condition.value), true
+	}
+	if len(condition.conditions) == 0 {
+		if qb.err == nil {
+			qb.err = fmt.Errorf("empty %s group in FilterGroup: pass at least one condition", condition.logic)
+		}
+		return FilterExpr{}, false
+	}
+
+	expr, ok := qb.conditionExpr(condition.conditions[0])
+	if !ok {
+		return FilterExpr{}, false
+	}
+	for _, next := range condition.conditions[1:] {
+		right, ok := qb.conditionExpr(next)
+		if !ok {
+			return FilterExpr{}, false
+		}
+		left := expr
+		expr = FilterExpr{Binary: &BinaryExpr{Left: left, Op: condition.logic, Right: right}}
+	}
+	return expr, true
+}
+
+// Between keeps rows where field is within from and to, inclusive: it is
+// the "between" filter, SQL BETWEEN. Date strings are parsed for
+// Timestamp fields, see Filter.
+//
+//	db.Query("Order").Between("created_at", "2024-01-01", "2024-01-31 23:59:59")
+func (qb *QueryBuilder) Between(field string, from, to interface{}) *QueryBuilder {
+	return qb.Filter(field, "between", []interface{}{from, to})
+}
+
+// WhereTrue keeps rows where a Bool field IS TRUE (NULL rows excluded)
+func (qb *QueryBuilder) WhereTrue(field string) *QueryBuilder {
+	return qb.Filter(field, "is", true)
+}
+
+// WhereFalse keeps rows where a Bool field IS FALSE (NULL rows excluded)
+func (qb *QueryBuilder) WhereFalse(field string) *QueryBuilder {
+	return qb.Filter(field, "is", false)
+}
+
+// checkIsFilter validates an "is" filter: the value must be a bool or nil,
+// and a bool value requires a Bool field. Fields the schema doesn't know
+// are left for SQL generation to report.
+func (qb *QueryBuilder) checkIsFilter(field string, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	if _, ok := value.(bool); !ok {
+		return &TypeMismatchError{
+			Field:        field,
+			ExpectedType: "bool",
+			ReceivedType: fmt.Sprintf("%T", value),
+			Value:        value,
+			Suggestion:   `"is" accepts true, false or nil; use "eq" to compare other values`,
+		}
+	}
+
+	target := qb.lookupField(field)
+	if target == nil || target.Type.Kind == FieldTypeBool.Kind {
+		return nil
+	}
+	return &TypeMismatchError{
+		Field:        field,
+		ExpectedType: FieldTypeBool.String(),
+		ReceivedType: target.Type.String(),
+		Value:        value,
+		Suggestion:   fmt.Sprintf(`%s is not a Bool field; use Filter(%q, "eq", ...) instead`, field, field),
+	}
+}
+
+// ContainsPatterns converts the terms of an "ilike_any" filter into LIKE
+// patterns matching each term anywhere in the text. The wildcards % and _
+// and the escape character \ are escaped, so terms match literally.
+// value must be a slice of strings.
+func ContainsPatterns(field string, value interface{}) ([]string, error) {
+	var terms []string
+	switch v := value.(type) {
+	case []string:
+		terms = v
+	case []interface{}:
+		for _, item := range v {
+			term, ok := item.(string)
+			if !ok {
+				return nil, ilikeAnyTypeError(field, value)
+			}
+			terms = append(terms, term)
+		}
+	default:
+		return nil, ilikeAnyTypeError(field, value)
+	}
+
+	patterns := make([]string, len(terms))
+	for i, term := range terms {
+		patterns[i] = "%" + likeEscaper.Replace(term) + "%"
+	}
+	return patterns, nil
+}
+
+// BetweenBounds returns the lower and upper bound of a "between" filter.
+// value must be a slice or array of exactly two values.
+func BetweenBounds(field string, value interface{}) (from, to interface{}, err error) {
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() != 2 {
+		return nil, nil, &TypeMismatchError{
+			Field:        field,
+			ExpectedType: "[from, to]",
+			ReceivedType: fmt.Sprintf("%T", value),
+			Value:        value,
+			Suggestion:   `"between" takes the two bounds, e.g. []string{"2024-01-01", "2024-01-31"}`,
+		}
+	}
+	return rv.Index(0).Interface(), rv.Index(1).Interface(), nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func ilikeAnyTypeError(field string, value interface{}) error {
+	return &TypeMismatchError{
+		Field:        field,
+		ExpectedType: "[]string",
+		ReceivedType: fmt.Sprintf("%T", value),
+		Value:        value,
+		Suggestion:   `"ilike_any" takes a list of search terms, e.g. []string{"ana", "bob"}`,
+	}
+}
+
+// lookupField resolves "field" or "relation.field" against the schema.
+// Returns nil when the entity, relation or field is unknown.
+func (qb *QueryBuilder) lookupField(path string) *Field {
+	if qb.engine.schema == nil {
+		return nil
+	}
+	entity := qb.query.Entity
+	segments := splitPath(path)
+	if len(segments) == 2 {
+		entity = qb.relationTarget(segments[0])
+		segments = segments[1:]
+	}
+	if len(segments) != 1 {
+		return nil
+	}
+	ent := qb.engine.schema.GetEntity(entity)
+	if ent == nil {
+		return nil
+	}
+	return ent.Fields[segments[0]]
+}
+
+// WhereHas keeps only rows with at least one related row matching the
+// filters added in fn. Unlike a relation filter ("orders.total"), it
+// generates an EXISTS subquery, so rows are never duplicated.
+//
+// Example:
+//
+//	db.Query("User").WhereHas("orders", func(q *QueryBuilder) {
+//		q.Filter("total", "gt", 100)
+//	})
+//
+// fn may be nil to match any related row.
+func (qb *QueryBuilder) WhereHas(relation string, fn func(q *QueryBuilder)) *QueryBuilder {
+	return qb.addExists(relation, fn, false)
+}
+
+// WhereDoesntHave keeps only rows with no related row matching the
+// filters added in fn (NOT EXISTS). fn may be nil to match rows
+// without any related row.
+//
+// Example:
+//
+//	db.Query("User").WhereDoesntHave("orders", func(q *QueryBuilder) {
+//		q.Filter("status", "eq", "paid")
+//	})
+func (qb *QueryBuilder) WhereDoesntHave(relation string, fn func(q *QueryBuilder)) *QueryBuilder {
+	return qb.addExists(relation, fn, true)
+}
+
+// WithCount adds the number of related rows as a "<relation>_count"
+// column, computed with a correlated COUNT(*) subquery. Optional
+// callbacks add filters on the related entity.
+//
+// Example:
+//
+//	db.Query("User").
+//		WithCount("orders").
+//		WithCount("posts", func(q *QueryBuilder) { q.Filter("published", "eq", true) })
+//
+//	row.RelationCount("orders") // int64
+func (qb *QueryBuilder) WithCount(relation string, filters ...func(q *QueryBuilder)) *QueryBuilder {
+	sub := qb.engine.Query(qb.relationTarget(relation))
+	for _, fn := range filters {
+		if fn != nil {
+			fn(sub)
+		}
+	}
+	if sub.err != nil && qb.err == nil {
+		qb.err = sub.err
+	}
+
+	qb.query.Counts = append(qb.query.Counts, RelationCount{
+		Relation: relation,
+		Filters:  sub.query.Filters,
+	})
+	return qb
+}
+
+func (qb *QueryBuilder) addExists(relation string, fn func(q *QueryBuilder), negated bool) *QueryBuilder {
+	sub := qb.engine.Query(qb.relationTarget(relation))
+	if fn != nil {
+		fn(sub)
+	}
+	if sub.err != nil && qb.err == nil {
+		qb.err = sub.err
+	}
+
+	qb.query.Filters = append(qb.query.Filters, FilterExpr{
+		Exists: &ExistsExpr{
+			Relation: relation,
+			Filters:  sub.query.Filters,
+			Negated:  negated,
+		},
 	})
 	return qb
 }
 
 // Include adds eager loading for a relation
 // Supports nested paths: "orders", "orders.items"
+// Paths deeper than the engine's max include depth are rejected;
+// the error is returned by ToSQL/Execute.
 func (qb *QueryBuilder) Include(path string) *QueryBuilder {
+	segments := splitPath(path)
+
+	if limit := qb.engine.MaxIncludeDepth(); limit >= 0 && len(segments) > limit {
+		if qb.err == nil {
+			qb.err = &IncludeDepthError{Path: path, Depth: len(segments), MaxDepth: limit}
+		}
+		return qb
+	}
+
 	qb.query.Includes = append(qb.query.Includes, IncludePath{
-		Path: splitPath(path),
+		Path: segments,
 	})
 	return qb
 }
@@ -126,18 +557,60 @@ func (qb *QueryBuilder) Include(path string) *QueryBuilder {
 // OrderBy adds a sort clause
 // direction: "asc" or "desc"
 func (qb *QueryBuilder) OrderBy(field string, direction string) *QueryBuilder {
-	dir := "Asc"
-	if direction == "desc" {
-		dir = "Desc"
+	qb.query.OrderBy = append(qb.query.OrderBy, OrderByClause{
+		Field:     field,
+		Direction: sortDirection(direction),
+	})
+	return qb
+}
+
+// relationAggregates maps the aggregates OrderByRelation accepts to the
+// core's names
+var relationAggregates = map[string]string{
+	"count": "Count",
+	"sum":   "Sum",
+	"avg":   "Avg",
+	"min":   "Min",
+	"max":   "Max",
+}
+
+// OrderByRelation sorts by an aggregate of a field over a has-many
+// relation, computed with a correlated subquery. aggregate is "count",
+// "sum", "avg", "min" or "max"; field is ignored for count. Rows without
+// related rows sort as 0 for count and sum, and last for the others.
+//
+// Example:
+//
+//	// Users by total order value
+//	db.Query("User").OrderByRelation("orders", "sum", "total", "desc")
+func (qb *QueryBuilder) OrderByRelation(relation, aggregate, field, direction string) *QueryBuilder {
+	name, ok := relationAggregates[strings.ToLower(aggregate)]
+	if !ok {
+		if qb.err == nil {
+			qb.err = fmt.Errorf("OrderByRelation: unknown aggregate %q (use count, sum, avg, min or max)", aggregate)
+		}
+		return qb
 	}
+
 	qb.query.OrderBy = append(qb.query.OrderBy, OrderByClause{
 		Field:     field,
-		Direction: dir,
+		Direction: sortDirection(direction),
+		Relation:  relation,
+		Aggregate: name,
 	})
 	return qb
 }
 
-// Limit sets the maximum number of results
+// sortDirection converts "asc"/"desc" to the core's direction
+func sortDirection(direction string) string {
+	if direction == "desc" {
+		return "Desc"
+	}
+	return "Asc"
+}
+
+// Limit sets the maximum number of results. Limit(0) is a real LIMIT 0
+// and returns no rows; leave Limit unset for no limit.
 func (qb *QueryBuilder) Limit(n uint64) *QueryBuilder {
 	qb.query.Limit = &n
 	return qb
@@ -149,11 +622,71 @@ func (qb *QueryBuilder) Offset(n uint64) *QueryBuilder {
 	return qb
 }
 
+// Latest returns the n most recent rows: it orders by the entity's recency
+// column, newest first, and limits to n.
+//
+//	recent, err := eng.Query("Order").Filter("status", "eq", "paid").Latest(10).Execute(ctx)
+//
+// The recency column is created_at when the entity has one, otherwise an
+// integer primary key. Entities with neither (e.g. a UUID key and no
+// created_at) fail with an error; use OrderBy and Limit instead.
+func (qb *QueryBuilder) Latest(n uint64) *QueryBuilder {
+	return qb.byRecency("Latest", "desc", n)
+}
+
+// Oldest returns the n earliest rows, ordered by the same column as Latest
+func (qb *QueryBuilder) Oldest(n uint64) *QueryBuilder {
+	return qb.byRecency("Oldest", "asc", n)
+}
+
+func (qb *QueryBuilder) byRecency(method, direction string, n uint64) *QueryBuilder {
+	column, err := qb.recencyColumn()
+	if err != nil {
+		if qb.err == nil {
+			qb.err = fmt.Errorf("%s: %w", method, err)
+		}
+		return qb
+	}
+	return qb.OrderBy(column, direction).Limit(n)
+}
+
+// recencyColumn picks the column Latest and Oldest order by
+func (qb *QueryBuilder) recencyColumn() (string, error) {
+	var ent *Entity
+	if qb.engine.schema != nil {
+		ent = qb.engine.schema.GetEntity(qb.query.Entity)
+	}
+	if ent == nil {
+		return "", fmt.Errorf("unknown entity %s", qb.query.Entity)
+	}
+
+	if field := ent.Fields[CreatedAtField]; field != nil && field.Type.Kind == FieldTypeTimestamp.Kind {
+		return CreatedAtField, nil
+	}
+	if keys := ent.PrimaryKeys(); len(keys) == 1 {
+		switch keys[0].Type.Kind {
+		case FieldTypeInt.Kind, FieldTypeTimestamp.Kind:
+			return keys[0].Name, nil
+		}
+	}
+	return "", fmt.Errorf("%s has no created_at timestamp or integer primary key to order by (use OrderBy and Limit)", ent.Name)
+}
+
 // ToSQL generates SQL without executing.
 func (qb *QueryBuilder) ToSQL() (*GeneratedSQL, error) {
+	if qb.err != nil {
+		return nil, qb.err
+	}
+
 	if qb.engine.schema == nil {
 		return nil, fmt.Errorf("no schema loaded")
 	}
+	if err := qb.checkFields(); err != nil {
+		return nil, err
+	}
+	if err := qb.checkWindow(); err != nil {
+		return nil, err
+	}
 
 	queryJSON, err := json.Marshal(qb.query)
 	if err != nil {
@@ -170,8 +703,11 @@ func (qb *QueryBuilder) ToSQL() (*GeneratedSQL, error) {
 		return nil, fmt.Errorf("SQL generation failed: %w", err)
 	}
 
+	// Numbers stay json.Number so bound integers keep their precision
 	var result GeneratedSQL
-	if err := json.Unmarshal([]byte(resultJSON), &result); err != nil {
+	decoder := json.NewDecoder(strings.NewReader(resultJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to parse generated SQL: %w", err)
 	}
 
@@ -180,12 +716,20 @@ func (qb *QueryBuilder) ToSQL() (*GeneratedSQL, error) {
 
 // Execute generates SQL and runs it against the database
 func (qb *QueryBuilder) Execute(ctx context.Context) (*QueryResult, error) {
+	if qb.engine.targetErr != nil {
+		return nil, qb.engine.targetErr
+	}
 	if qb.engine.executor == nil {
 		return nil, fmt.Errorf("executor not initialized - call engine.Connect() first")
 	}
 
 	start := time.Now()
 
+	qb, err := qb.withTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qb = qb.withLimits()
 	generated, err := qb.ToSQL()
 	if err != nil {
 		return nil, err
@@ -198,6 +742,10 @@ func (qb *QueryBuilder) Execute(ctx context.Context) (*QueryResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	if result.schema == nil {
+		// Backends other than Executor cannot set it
+		result.schema = qb.engine.schema
+	}
 
 	duration := time.Since(start)
 	debugCtx.LogQuery(generated.MainQuery, duration, len(result.Rows))
@@ -205,6 +753,19 @@ func (qb *QueryBuilder) Execute(ctx context.Context) (*QueryResult, error) {
 	return result, nil
 }
 
+// Query returns the query as it is sent to the core. A QueryExecutor
+// receives builders whose query already carries the tenant filter and
+// the engine's limits.
+func (qb *QueryBuilder) Query() QueryJSON {
+	return qb.query
+}
+
+// Authorize runs the engine's authorizer for reading the queried entity.
+// QueryExecutor implementations call it before running the query.
+func (qb *QueryBuilder) Authorize(ctx context.Context) error {
+	return qb.engine.authorizer.Check(ctx, OperationSelect, qb.query.Entity)
+}
+
 // Select specifies which fields to retrieve
 // If not called, defaults to SELECT * (all fields)
 //
@@ -217,6 +778,18 @@ func (qb *QueryBuilder) Select(fields ...string) *QueryBuilder {
 	return qb
 }
 
+// Distinct returns only distinct rows (SELECT DISTINCT). Queries that
+// filter on a relation are already distinct; combine with Select to get
+// the distinct values of some columns.
+//
+// Example:
+//
+//	db.Query("User").Select("country").Distinct().Execute(ctx)
+func (qb *QueryBuilder) Distinct() *QueryBuilder {
+	qb.query.Distinct = true
+	return qb
+}
+
 // Debug enables debug mode for this query
 func (qb *QueryBuilder) Debug() *QueryBuilder {
 	level := DebugSQL
@@ -248,6 +821,344 @@ func (qb *QueryBuilder) getDebugContext() *DebugContext {
 }
 
 // --- Helpers ---
+
+// withLimits returns a copy of the query with the engine's default Limit
+// applied when it has none, and its Limit clamped to the maximum when
+// the engine clamps instead of failing
+func (qb *QueryBuilder) withLimits() *QueryBuilder {
+	limits := qb.engine.limits
+	limited := *qb
+
+	switch limit := qb.query.Limit; {
+	case limit == nil && limits.Default > 0:
+		n := limits.Default
+		if limits.Max > 0 && n > limits.Max {
+			n = limits.Max
+		}
+		limited.query.Limit = &n
+	case limit != nil && limits.Clamp && limits.Max > 0 && *limit > limits.Max:
+		n := limits.Max
+		limited.query.Limit = &n
+	}
+	return &limited
+}
+
+// AllTenants lifts the @tenant scope so the query reads the rows of every
+// tenant. Without it, queries on a @tenant entity are limited to the tenant
+// in the context and fail when there is none (see WithTenant).
+func (qb *QueryBuilder) AllTenants() *QueryBuilder {
+	qb.allTenants = true
+	return qb
+}
+
+// withTenant returns a copy limited to the context's tenant wherever it
+// reads a @tenant entity: the queried entity, relation filters, WhereHas
+// and WithCount subqueries, OrderByRelation aggregates and includes.
+// ToSQL has no context and is never scoped.
+func (qb *QueryBuilder) withTenant(ctx context.Context) (*QueryBuilder, error) {
+	if qb.tenantScoped || qb.engine.schema == nil {
+		return qb, nil
+	}
+	scoper := &tenantScoper{ctx: ctx, schema: qb.engine.schema, allTenants: qb.allTenants}
+	entity := qb.query.Entity
+
+	scoped := *qb
+	filters, err := scoper.filters(entity, qb.query.Filters)
+	if err != nil {
+		return qb, err
+	}
+	cond, err := scoper.condition(entity, nil)
+	if err != nil {
+		return qb, err
+	}
+	if cond != nil {
+		filters = append(filters, *cond)
+	}
+	scoped.query.Filters = filters
+
+	scoped.query.Counts = make([]RelationCount, len(qb.query.Counts))
+	for i, count := range qb.query.Counts {
+		if count.Filters, err = scoper.relationFilters(entity, count.Relation, count.Filters); err != nil {
+			return qb, err
+		}
+		scoped.query.Counts[i] = count
+	}
+
+	scoped.query.OrderBy = make([]OrderByClause, len(qb.query.OrderBy))
+	for i, order := range qb.query.OrderBy {
+		if order.Relation != "" {
+			if order.Filters, err = scoper.relationFilters(entity, order.Relation, order.Filters); err != nil {
+				return qb, err
+			}
+		}
+		scoped.query.OrderBy[i] = order
+	}
+
+	for _, include := range qb.query.Includes {
+		parent := entity
+		for depth, name := range include.Path {
+			parent = qb.engine.schema.relationTarget(parent, name)
+			scope, err := ResolveTenantScope(ctx, qb.engine.schema.GetEntity(parent), OperationSelect, qb.allTenants)
+			if err != nil {
+				return qb, err
+			}
+			if scope != nil {
+				if scoped.includeScopes == nil {
+					scoped.includeScopes = make(map[string]*TenantScope)
+				}
+				scoped.includeScopes[strings.Join(include.Path[:depth+1], ".")] = scope
+			}
+		}
+	}
+
+	scoped.tenantScoped = true
+	return &scoped, nil
+}
+
+// IncludeScope returns the tenant the rows loaded for an include path
+// ("orders.items") are limited to, or nil when they are not scoped.
+// QueryExecutor implementations apply it when eager loading.
+func (qb *QueryBuilder) IncludeScope(path string) *TenantScope {
+	return qb.includeScopes[path]
+}
+
+// tenantScoper adds the context's tenant to the filters of a query
+type tenantScoper struct {
+	ctx        context.Context
+	schema     *Schema
+	allTenants bool
+}
+
+// condition returns the filter limiting entity to the tenant, or nil when
+// it is not @tenant. relation prefixes the column for relation filters.
+func (s *tenantScoper) condition(entity string, relation []string) (*FilterExpr, error) {
+	scope, err := ResolveTenantScope(s.ctx, s.schema.GetEntity(entity), OperationSelect, s.allTenants)
+	if err != nil || scope == nil {
+		return nil, err
+	}
+	return &FilterExpr{
+		Condition: &FilterCondition{
+			Field: FieldPath{Segments: append(append([]string{}, relation...), scope.Column)},
+			Op:    goOpToRust("eq"),
+			Value: goValueToFilter(scope.Value),
+		},
+	}, nil
+}
+
+// filters scopes the relation filters and EXISTS subqueries of entity
+func (s *tenantScoper) filters(entity string, filters []FilterExpr) ([]FilterExpr, error) {
+	scoped := make([]FilterExpr, len(filters))
+	for i, filter := range filters {
+		expr, err := s.expr(entity, filter)
+		if err != nil {
+			return nil, err
+		}
+		scoped[i] = expr
+	}
+	return scoped, nil
+}
+
+func (s *tenantScoper) expr(entity string, expr FilterExpr) (FilterExpr, error) {
+	switch {
+	case expr.Condition != nil:
+		// "orders.total" joins orders; the tenant is checked on the same row
+		segments := expr.Condition.Field.Segments
+		if len(segments) < 2 {
+			return expr, nil
+		}
+		cond, err := s.condition(s.schema.relationTarget(entity, segments[0]), segments[:1])
+		if err != nil || cond == nil {
+			return expr, err
+		}
+		return FilterExpr{Binary: &BinaryExpr{Left: expr, Op: "And", Right: *cond}}, nil
+
+	case expr.Binary != nil:
+		left, err := s.expr(entity, expr.Binary.Left)
+		if err != nil {
+			return expr, err
+		}
+		right, err := s.expr(entity, expr.Binary.Right)
+		if err != nil {
+			return expr, err
+		}
+		return FilterExpr{Binary: &BinaryExpr{Left: left, Op: expr.Binary.Op, Right: right}}, nil
+
+	case expr.Exists != nil:
+		exists := *expr.Exists
+		filters, err := s.relationFilters(entity, exists.Relation, exists.Filters)
+		if err != nil {
+			return expr, err
+		}
+		exists.Filters = filters
+		return FilterExpr{Exists: &exists}, nil
+	}
+	return expr, nil
+}
+
+// relationFilters scopes the filters of a subquery over a relation of
+// entity, and limits the related rows to the tenant
+func (s *tenantScoper) relationFilters(entity, relation string, filters []FilterExpr) ([]FilterExpr, error) {
+	target := s.schema.relationTarget(entity, relation)
+	scoped, err := s.filters(target, filters)
+	if err != nil {
+		return nil, err
+	}
+	cond, err := s.condition(target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cond != nil {
+		scoped = append(scoped, *cond)
+	}
+	return scoped, nil
+}
+
+// checkWindow rejects a Limit or Offset PostgreSQL can't take as a
+// bigint, and a Limit above the engine cap
+func (qb *QueryBuilder) checkWindow() error {
+	if limit := qb.query.Limit; limit != nil {
+		if *limit > math.MaxInt64 {
+			return &LimitError{Clause: "LIMIT", Value: *limit, Max: math.MaxInt64}
+		}
+		if max := qb.engine.limits.Max; max > 0 && *limit > max && !qb.engine.limits.Clamp {
+			return &LimitError{Clause: "LIMIT", Value: *limit, Max: max}
+		}
+	}
+	if offset := qb.query.Offset; offset != nil && *offset > math.MaxInt64 {
+		return &LimitError{Clause: "OFFSET", Value: *offset, Max: math.MaxInt64}
+	}
+	return nil
+}
+
+// checkFields resolves every field the query names (filters, ORDER BY,
+// SELECT) against the schema. SQL generation interpolates these names
+// as-is, so anything the schema doesn't declare is rejected here.
+func (qb *QueryBuilder) checkFields() error {
+	schema := qb.engine.schema
+	if schema.GetEntity(qb.query.Entity) == nil {
+		// SQL generation reports unknown entities
+		return nil
+	}
+
+	if err := checkFilterFields(schema, qb.query.Entity, qb.query.Filters); err != nil {
+		return err
+	}
+	for _, count := range qb.query.Counts {
+		if target := schema.relationTarget(qb.query.Entity, count.Relation); target != "" {
+			if err := checkFilterFields(schema, target, count.Filters); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, field := range qb.query.SelectFields {
+		if err := checkFieldPath(schema, qb.query.Entity, []string{field}); err != nil {
+			return err
+		}
+	}
+
+	counted := make(map[string]bool, len(qb.query.Counts))
+	for _, count := range qb.query.Counts {
+		counted[count.Relation+"_count"] = true
+	}
+	for _, order := range qb.query.OrderBy {
+		if counted[order.Field] {
+			continue
+		}
+		if order.Relation != "" {
+			target := schema.relationTarget(qb.query.Entity, order.Relation)
+			if target == "" || order.Aggregate == "Count" {
+				// SQL generation reports unknown relations
+				continue
+			}
+			if err := checkFieldPath(schema, target, []string{order.Field}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := checkFieldPath(schema, qb.query.Entity, []string{order.Field}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFilterFields checks filter fields relative to entity. EXISTS
+// filters are relative to the relation's target entity.
+func checkFilterFields(schema *Schema, entity string, filters []FilterExpr) error {
+	for _, filter := range filters {
+		if err := checkFilterExpr(schema, entity, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkFilterExpr(schema *Schema, entity string, expr FilterExpr) error {
+	switch {
+	case expr.Condition != nil:
+		return checkFieldPath(schema, entity, expr.Condition.Field.Segments)
+	case expr.Binary != nil:
+		if err := checkFilterExpr(schema, entity, expr.Binary.Left); err != nil {
+			return err
+		}
+		return checkFilterExpr(schema, entity, expr.Binary.Right)
+	case expr.Exists != nil:
+		target := schema.relationTarget(entity, expr.Exists.Relation)
+		if target == "" {
+			// SQL generation reports unknown relations
+			return nil
+		}
+		return checkFilterFields(schema, target, expr.Exists.Filters)
+	}
+	return nil
+}
+
+// checkFieldPath resolves "field" or "relation[.relation].field" from
+// entity and returns an UnknownFieldError if any segment doesn't exist
+func checkFieldPath(schema *Schema, entity string, segments []string) error {
+	path := strings.Join(segments, ".")
+	ent := schema.GetEntity(entity)
+	if len(segments) == 0 {
+		return &UnknownFieldError{Entity: entity, Field: path, Available: ent.FieldNames()}
+	}
+
+	for _, relation := range segments[:len(segments)-1] {
+		rel := ent.RelationByName(relation)
+		if rel == nil || schema.GetEntity(rel.TargetEntity) == nil {
+			return &UnknownFieldError{Entity: ent.Name, Field: path, Available: ent.RelationNames()}
+		}
+		ent = schema.GetEntity(rel.TargetEntity)
+	}
+
+	if ent.Fields[segments[len(segments)-1]] == nil {
+		return &UnknownFieldError{Entity: ent.Name, Field: path, Available: ent.FieldNames()}
+	}
+	return nil
+}
+
+// relationTarget returns the entity a relation of entity points to, or ""
+func (s *Schema) relationTarget(entity, relation string) string {
+	ent := s.GetEntity(entity)
+	if ent == nil {
+		return ""
+	}
+	if rel := ent.RelationByName(relation); rel != nil {
+		return rel.TargetEntity
+	}
+	return ""
+}
+
+// relationTarget resolves the entity a relation of the queried entity points to.
+// Returns an empty string when the schema or relation is unknown; SQL
+// generation reports the error.
+func (qb *QueryBuilder) relationTarget(relation string) string {
+	if qb.engine.schema == nil {
+		return ""
+	}
+	return qb.engine.schema.relationTarget(qb.query.Entity, relation)
+}
+
 func parseFieldPath(path string) FieldPath {
 	return FieldPath{Segments: splitPath(path)}
 }
@@ -273,14 +1184,20 @@ func splitPath(path string) []string {
 
 func goOpToRust(op string) string {
 	ops := map[string]string{
-		"eq":   "Eq",
-		"neq":  "Neq",
-		"gt":   "Gt",
-		"gte":  "Gte",
-		"lt":   "Lt",
-		"lte":  "Lte",
-		"like": "Like",
-		"in":   "In",
+		"eq":        "Eq",
+		"neq":       "Neq",
+		"gt":        "Gt",
+		"gte":       "Gte",
+		"lt":        "Lt",
+		"lte":       "Lte",
+		"like":      "Like",
+		"in":        "In",
+		"nin":       "NotIn",
+		"between":   "Between",
+		"isnull":    "IsNull",
+		"notnull":   "IsNotNull",
+		"is":        "Is",
+		"ilike_any": "IlikeAny",
 	}
 	if rustOp, ok := ops[op]; ok {
 		return rustOp
@@ -300,9 +1217,98 @@ func goValueToFilter(value interface{}) FilterValue {
 		return FilterValue{"Float": v}
 	case bool:
 		return FilterValue{"Bool": v}
+	case time.Time:
+		return FilterValue{"String": v.Format(time.RFC3339Nano)}
+	case []byte:
+		return FilterValue{"String": string(v)}
 	case nil:
 		return FilterValue{"Null": nil}
-	default:
-		return FilterValue{"String": fmt.Sprintf("%v", v)}
 	}
+
+	// Slices (for "in", "nin" and "between") become a List of converted elements
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		items := make([]FilterValue, rv.Len())
+		for i := range items {
+			items[i] = goValueToFilter(rv.Index(i).Interface())
+		}
+		return FilterValue{"List": items}
+	}
+	return FilterValue{"String": fmt.Sprintf("%v", value)}
+}
+
+// filterParam converts a FilterValue serialized by the core back to the Go
+// value that is bound for it. Null is a bare "Null" string, every other
+// variant an object with a single key.
+func filterParam(value interface{}) interface{} {
+	tagged, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for variant, v := range tagged {
+		switch variant {
+		case "Int":
+			if n, ok := v.(json.Number); ok {
+				if i, err := n.Int64(); err == nil {
+					return i
+				}
+			}
+		case "Float":
+			if n, ok := v.(json.Number); ok {
+				if f, err := n.Float64(); err == nil {
+					return f
+				}
+			}
+		case "List":
+			items, _ := v.([]interface{})
+			return listParam(items)
+		}
+		return v
+	}
+	return nil
+}
+
+// listParam converts the items of a List. Lists of a single kind become
+// typed slices, which encode into any array type.
+func listParam(items []interface{}) interface{} {
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		values[i] = filterParam(item)
+	}
+	if len(values) == 0 {
+		return values
+	}
+
+	switch values[0].(type) {
+	case string:
+		typed := make([]string, len(values))
+		for i, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				return values
+			}
+			typed[i] = s
+		}
+		return typed
+	case int64:
+		typed := make([]int64, len(values))
+		for i, v := range values {
+			n, ok := v.(int64)
+			if !ok {
+				return values
+			}
+			typed[i] = n
+		}
+		return typed
+	case float64:
+		typed := make([]float64, len(values))
+		for i, v := range values {
+			f, ok := v.(float64)
+			if !ok {
+				return values
+			}
+			typed[i] = f
+		}
+		return typed
+	}
+	return values
 }
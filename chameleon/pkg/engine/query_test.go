@@ -1,6 +1,11 @@
 package engine
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -14,6 +19,7 @@ func setupTestEngine(t *testing.T) *Engine {
 			email: string unique,
 			name: string,
 			age: int nullable,
+			active: bool nullable,
 			orders: [Order] via user_id,
 		}
 
@@ -21,6 +27,7 @@ func setupTestEngine(t *testing.T) *Engine {
 			id: uuid primary,
 			total: decimal,
 			status: string,
+			paid: bool,
 			user_id: uuid,
 			user: User,
 			items: [OrderItem] via order_id,
@@ -172,6 +179,421 @@ func TestQueryBuilder_FullQuery(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_WhereHas(t *testing.T) {
+	e := setupTestEngine(t)
+
+	result, err := e.Query("User").
+		WhereHas("orders", func(q *QueryBuilder) {
+			q.Filter("total", "gt", 100)
+		}).
+		ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL failed: %v", err)
+	}
+
+	assertContains(t, result.MainQuery, "WHERE EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id AND orders.total > 100)")
+	if contains(result.MainQuery, "DISTINCT") {
+		t.Errorf("WhereHas should not use DISTINCT\n\nGot:\n%s", result.MainQuery)
+	}
+}
+
+func TestQueryBuilder_WhereDoesntHave(t *testing.T) {
+	e := setupTestEngine(t)
+
+	result, err := e.Query("User").
+		WhereDoesntHave("orders", func(q *QueryBuilder) {
+			q.Filter("status", "eq", "paid")
+		}).
+		ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL failed: %v", err)
+	}
+
+	assertContains(t, result.MainQuery, "WHERE NOT EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id AND orders.status = 'paid')")
+}
+
+func TestQueryBuilder_WhereHasPropagatesErrors(t *testing.T) {
+	e := NewEngineWithoutSchema()
+	invalid := func(q *QueryBuilder) { q.Filter("name", "ilike_any", 42) }
+
+	for _, qb := range []*QueryBuilder{
+		e.Query("User").WhereHas("orders", invalid),
+		e.Query("User").WhereDoesntHave("orders", invalid),
+	} {
+		var mismatch *TypeMismatchError
+		if _, err := qb.ToSQL(); !errors.As(err, &mismatch) || mismatch.Field != "name" {
+			t.Errorf("expected the callback's TypeMismatchError, got %v", err)
+		}
+	}
+}
+
+func TestQueryBuilder_WithCount(t *testing.T) {
+	e := setupTestEngine(t)
+
+	result, err := e.Query("User").
+		WithCount("orders", func(q *QueryBuilder) {
+			q.Filter("status", "eq", "paid")
+		}).
+		ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL failed: %v", err)
+	}
+
+	assertContains(t, result.MainQuery, "(SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id AND orders.status = 'paid') AS orders_count")
+}
+
+func TestQueryBuilder_WithCountPropagatesErrors(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	qb := e.Query("User").WithCount("orders", func(q *QueryBuilder) { q.Filter("paid", "is", "yes") })
+	var mismatch *TypeMismatchError
+	if _, err := qb.ToSQL(); !errors.As(err, &mismatch) || mismatch.Field != "paid" {
+		t.Errorf("expected the callback's TypeMismatchError, got %v", err)
+	}
+}
+
+func TestRow_RelationCount(t *testing.T) {
+	row := Row{"orders_count": int64(3)}
+	if got := row.RelationCount("orders"); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+	if got := row.RelationCount("posts"); got != 0 {
+		t.Errorf("expected 0 for missing count, got %d", got)
+	}
+}
+
+func TestQueryBuilder_OrderByRelation(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	qb := e.Query("User").OrderByRelation("orders", "SUM", "total", "desc")
+	if qb.err != nil {
+		t.Fatalf("unexpected error: %v", qb.err)
+	}
+	order := qb.query.OrderBy[0]
+	if order.Relation != "orders" || order.Aggregate != "Sum" || order.Field != "total" || order.Direction != "Desc" {
+		t.Errorf("unexpected order clause: %+v", order)
+	}
+
+	data, err := json.Marshal(e.Query("User").OrderBy("name", "asc").query.OrderBy[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "relation") || strings.Contains(string(data), "aggregate") {
+		t.Errorf("plain OrderBy should omit relation and aggregate, got %s", data)
+	}
+
+	qb = e.Query("User").OrderByRelation("orders", "median", "total", "desc")
+	if qb.err == nil || !strings.Contains(qb.err.Error(), `unknown aggregate "median"`) {
+		t.Errorf("expected unknown aggregate error, got %v", qb.err)
+	}
+	if len(qb.query.OrderBy) != 0 {
+		t.Errorf("rejected clause should not be added, got %+v", qb.query.OrderBy)
+	}
+}
+
+func TestQueryBuilder_FilterGroup(t *testing.T) {
+	e := setupTestEngine(t)
+
+	result, err := e.Query("User").
+		Filter("active", "eq", true).
+		FilterGroup(Or(
+			Filter("age", "lt", 18),
+			And(Filter("age", "gt", 65), Filter("name", "like", "A%")),
+		)).
+		ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL failed: %v", err)
+	}
+
+	assertContains(t, result.MainQuery, "WHERE active = true AND (age < 18 OR (age > 65 AND name LIKE 'A%'))")
+}
+
+func TestQueryBuilder_FilterGroupSerialization(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	qb := e.Query("User").FilterGroup(Or(
+		Filter("age", "lt", 18),
+		Filter("age", "gt", 65),
+		Filter("age", "eq", nil),
+	))
+	if len(qb.query.Filters) != 1 || qb.query.Filters[0].Binary == nil {
+		t.Fatalf("expected a single Binary filter, got %+v", qb.query.Filters)
+	}
+
+	// Groups fold left: ((a OR b) OR c)
+	outer := qb.query.Filters[0].Binary
+	if outer.Op != "Or" || outer.Right.Condition == nil || outer.Right.Condition.Op != "Eq" {
+		t.Fatalf("unexpected outer expression: %+v", outer)
+	}
+	inner := outer.Left.Binary
+	if inner == nil || inner.Op != "Or" || inner.Left.Condition.Op != "Lt" || inner.Right.Condition.Op != "Gt" {
+		t.Fatalf("unexpected inner expression: %+v", outer.Left)
+	}
+
+	// A single condition needs no Binary node
+	qb = e.Query("User").FilterGroup(And(Filter("age", "gte", 18)))
+	if qb.query.Filters[0].Condition == nil {
+		t.Errorf("expected a plain condition, got %+v", qb.query.Filters[0])
+	}
+
+	qb = e.Query("User").FilterGroup(Or())
+	if qb.err == nil || len(qb.query.Filters) != 0 {
+		t.Errorf("an empty group should fail the query, got err=%v filters=%+v", qb.err, qb.query.Filters)
+	}
+}
+
+func TestQueryBuilder_WhereHasSerialization(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	qb := e.Query("User").WhereHas("orders", func(q *QueryBuilder) {
+		q.Filter("total", "gt", 100).
+			WhereHas("items", nil)
+	})
+
+	if len(qb.query.Filters) != 1 || qb.query.Filters[0].Exists == nil {
+		t.Fatalf("expected a single Exists filter, got %+v", qb.query.Filters)
+	}
+	exists := qb.query.Filters[0].Exists
+	if exists.Relation != "orders" {
+		t.Errorf("expected relation 'orders', got %q", exists.Relation)
+	}
+	if len(exists.Filters) != 2 || exists.Filters[0].Condition == nil || exists.Filters[1].Exists == nil {
+		t.Fatalf("unexpected nested filters: %+v", exists.Filters)
+	}
+	if exists.Filters[1].Exists.Filters == nil {
+		t.Error("nested Exists filters should serialize as an empty list, not null")
+	}
+	if exists.Negated {
+		t.Error("WhereHas should not be negated")
+	}
+
+	qb = e.Query("User").WhereDoesntHave("orders", nil)
+	if !qb.query.Filters[0].Exists.Negated {
+		t.Error("WhereDoesntHave should be negated")
+	}
+}
+
+func TestQueryBuilder_DistinctSerialization(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	plain, err := json.Marshal(e.Query("User").Select("name").query)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	assertContains(t, string(plain), `"distinct":false`)
+
+	distinct, err := json.Marshal(e.Query("User").Select("name").Distinct().query)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	assertContains(t, string(distinct), `"distinct":true`)
+}
+
+func TestQueryBuilder_BoolShorthand(t *testing.T) {
+	e := setupTestEngine(t)
+
+	result, err := e.Query("User").
+		WhereTrue("active").
+		WhereFalse("orders.paid").
+		ToSQL()
+	if err != nil {
+		t.Fatalf("ToSQL failed: %v", err)
+	}
+
+	assertContains(t, result.MainQuery, "active IS TRUE")
+	assertContains(t, result.MainQuery, "orders.paid IS FALSE")
+}
+
+func TestQueryBuilder_IsFilterValidation(t *testing.T) {
+	e := NewEngineWithoutSchema()
+	e.schema = &Schema{Entities: []*Entity{
+		{
+			Name: "Post",
+			Fields: map[string]*Field{
+				"published": {Name: "published", Type: FieldTypeBool, Nullable: true},
+				"title":     {Name: "title", Type: FieldTypeString},
+			},
+		},
+	}}
+
+	if qb := e.Query("Post").WhereTrue("published"); qb.err != nil {
+		t.Errorf("WhereTrue on a Bool field should be accepted, got %v", qb.err)
+	}
+	if qb := e.Query("Post").Filter("title", "is", nil); qb.err != nil {
+		t.Errorf(`"is" nil should be accepted on any field, got %v`, qb.err)
+	}
+
+	qb := e.Query("Post").WhereFalse("title")
+	mismatch, ok := qb.err.(*TypeMismatchError)
+	if !ok {
+		t.Fatalf("expected TypeMismatchError for a String field, got %v", qb.err)
+	}
+	if mismatch.Field != "title" || mismatch.ReceivedType != "String" {
+		t.Errorf("unexpected mismatch: %+v", mismatch)
+	}
+
+	if qb := e.Query("Post").Filter("published", "is", "yes"); qb.err == nil {
+		t.Error(`"is" with a non-bool value should be rejected`)
+	}
+}
+
+func TestQueryBuilder_TimestampFilters(t *testing.T) {
+	e := NewEngineWithoutSchema()
+	e.schema = &Schema{Entities: []*Entity{
+		{Name: "Order", Fields: map[string]*Field{
+			"created_at": {Name: "created_at", Type: FieldTypeTimestamp},
+			"status":     {Name: "status", Type: FieldTypeString},
+		}},
+	}}
+
+	qb := e.Query("Order").Between("created_at", "2024-01-01", "2024-01-31 23:59:59").Filter("status", "eq", "2024")
+	if qb.err != nil {
+		t.Fatalf("unexpected error: %v", qb.err)
+	}
+	if len(qb.query.Filters) != 2 {
+		t.Fatalf("expected 2 filters, got %d", len(qb.query.Filters))
+	}
+
+	between := qb.query.Filters[0].Condition
+	bounds, _ := between.Value["List"].([]FilterValue)
+	if between.Op != "Between" || len(bounds) != 2 ||
+		bounds[0]["String"] != "2024-01-01T00:00:00Z" || bounds[1]["String"] != "2024-01-31T23:59:59Z" {
+		t.Errorf("Between = %s %v, want Between [2024-01-01T00:00:00Z 2024-01-31T23:59:59Z]", between.Op, between.Value)
+	}
+
+	// Strings on other fields stay as they are
+	status := qb.query.Filters[1].Condition
+	if status.Op != "Eq" || status.Value["String"] != "2024" {
+		t.Errorf("status filter = %s %v, want Eq 2024", status.Op, status.Value)
+	}
+
+	qb = e.Query("Order").Filter("created_at", "gt", "last tuesday")
+	if _, ok := qb.err.(*FieldFormatError); !ok {
+		t.Errorf("expected a FieldFormatError for an unparseable date, got %v", qb.err)
+	}
+}
+
+func TestQueryBuilder_IlikeAny(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	qb := e.Query("User").Filter("name", "ilike_any", []interface{}{"ana", "100%"})
+	if qb.err != nil {
+		t.Fatalf("unexpected error: %v", qb.err)
+	}
+	cond := qb.query.Filters[0].Condition
+	items, _ := cond.Value["List"].([]FilterValue)
+	if cond.Op != "IlikeAny" || len(items) != 2 || items[0]["String"] != "%ana%" || items[1]["String"] != `%100\%%` {
+		t.Errorf("unexpected ilike_any filter: %s %v", cond.Op, cond.Value)
+	}
+
+	qb = e.Query("User").Filter("name", "ilike_any", []int{1, 2})
+	if _, ok := qb.err.(*TypeMismatchError); !ok {
+		t.Errorf("expected a TypeMismatchError for non-string terms, got %v", qb.err)
+	}
+}
+
+func TestQueryBuilder_NotInSerialization(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	qb := e.Query("User").Filter("status", "nin", []string{"banned", "deleted"})
+	cond := qb.query.Filters[0].Condition
+	if cond == nil || cond.Op != "NotIn" {
+		t.Fatalf("expected a NotIn condition, got %+v", qb.query.Filters[0])
+	}
+	if list, ok := cond.Value["List"].([]FilterValue); !ok || len(list) != 2 {
+		t.Errorf("expected a two-item List value, got %+v", cond.Value)
+	}
+}
+
+func TestQueryBuilder_BetweenFilter(t *testing.T) {
+	e := NewEngineWithoutSchema()
+	e.schema = &Schema{Entities: []*Entity{
+		{Name: "Order", Fields: map[string]*Field{
+			"created_at": {Name: "created_at", Type: FieldTypeTimestamp},
+		}},
+	}}
+
+	qb := e.Query("Order").Filter("created_at", "between", []string{"2024-01-01", "2024-01-31"})
+	if qb.err != nil {
+		t.Fatalf("unexpected error: %v", qb.err)
+	}
+	cond := qb.query.Filters[0].Condition
+	list, _ := cond.Value["List"].([]FilterValue)
+	if cond.Op != "Between" || len(list) != 2 || list[0]["String"] != "2024-01-01T00:00:00Z" || list[1]["String"] != "2024-01-31T00:00:00Z" {
+		t.Errorf("unexpected between condition: %s %v", cond.Op, cond.Value)
+	}
+
+	qb = e.Query("Order").Filter("created_at", "between", "2024-01-01")
+	if _, ok := qb.err.(*TypeMismatchError); !ok {
+		t.Errorf("expected a TypeMismatchError for a single bound, got %v", qb.err)
+	}
+}
+
+func TestQueryBuilder_NullFilters(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	qb := e.Query("User").Filter("deleted_at", "isnull", nil).Filter("email", "notnull", "ignored")
+	if qb.err != nil {
+		t.Fatalf("unexpected error: %v", qb.err)
+	}
+	for i, op := range []string{"IsNull", "IsNotNull"} {
+		cond := qb.query.Filters[i].Condition
+		if cond.Op != op {
+			t.Errorf("filter %d op = %s, want %s", i, cond.Op, op)
+		}
+		if _, ok := cond.Value["Null"]; !ok {
+			t.Errorf("filter %d should carry no value, got %v", i, cond.Value)
+		}
+	}
+}
+
+func TestGoValueToFilter_List(t *testing.T) {
+	got := goValueToFilter([]interface{}{"paid", 3, nil})
+
+	items, ok := got["List"].([]FilterValue)
+	if !ok || len(items) != 3 {
+		t.Fatalf("expected a 3-item List, got %v", got)
+	}
+	if items[0]["String"] != "paid" || items[1]["Int"] != 3 {
+		t.Errorf("unexpected list items: %v", items)
+	}
+	if _, ok := items[2]["Null"]; !ok {
+		t.Errorf("nil element should become Null, got %v", items[2])
+	}
+
+	if got := goValueToFilter([]byte("raw")); got["String"] != "raw" {
+		t.Errorf("[]byte should stay a string, got %v", got)
+	}
+}
+
+func TestQueryBuilder_MaxIncludeDepth(t *testing.T) {
+	e := NewEngineWithoutSchema()
+
+	_, err := e.Query("User").Include("a.b.c.d.e.f").ToSQL()
+	depthErr, ok := err.(*IncludeDepthError)
+	if !ok {
+		t.Fatalf("expected IncludeDepthError, got %v", err)
+	}
+	if depthErr.Depth != 6 || depthErr.MaxDepth != DefaultMaxIncludeDepth {
+		t.Errorf("unexpected depth error: %+v", depthErr)
+	}
+
+	e.WithMaxIncludeDepth(2)
+	qb := e.Query("User").Include("orders.items").Include("orders.items.product")
+	if len(qb.query.Includes) != 1 {
+		t.Errorf("expected the too-deep include to be dropped, got %d includes", len(qb.query.Includes))
+	}
+	if _, ok := qb.err.(*IncludeDepthError); !ok {
+		t.Errorf("expected IncludeDepthError, got %v", qb.err)
+	}
+
+	e.WithMaxIncludeDepth(-1)
+	qb = e.Query("User").Include("a.b.c.d.e.f.g")
+	if qb.err != nil {
+		t.Errorf("negative depth should disable the limit, got %v", qb.err)
+	}
+}
+
 func TestQueryBuilder_NoSchema(t *testing.T) {
 	e := NewEngineWithoutSchema() // No schema loaded
 
@@ -201,3 +623,243 @@ func searchString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestQueryBuilder_RejectsUnknownFields(t *testing.T) {
+	userID := "user_id"
+	e := NewEngineWithoutSchema()
+	e.schema = &Schema{Entities: []*Entity{
+		{
+			Name: "User",
+			Fields: map[string]*Field{
+				"id":    {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+				"email": {Name: "email", Type: FieldTypeString},
+			},
+			Relations: map[string]*Relation{
+				"orders": {Name: "orders", Kind: RelationHasMany, TargetEntity: "Order", ForeignKey: &userID},
+			},
+		},
+		{
+			Name: "Order",
+			Fields: map[string]*Field{
+				"id":      {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+				"total":   {Name: "total", Type: FieldTypeDecimal},
+				"user_id": {Name: "user_id", Type: FieldTypeUUID},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name  string
+		query *QueryBuilder
+		field string
+	}{
+		{"filter", e.Query("User").Filter("email = email OR 1=1 --", "eq", "x"), "email = email OR 1=1 --"},
+		{"relation filter", e.Query("User").Filter("orders.total; DROP TABLE users", "gt", 1), "orders.total; DROP TABLE users"},
+		{"unknown relation", e.Query("User").Filter("payments.total", "gt", 1), "payments.total"},
+		{"order by", e.Query("User").OrderBy("(SELECT 1)", "asc"), "(SELECT 1)"},
+		{"select", e.Query("User").Select("id", "pg_sleep(10)"), "pg_sleep(10)"},
+		{"where has", e.Query("User").WhereHas("orders", func(q *QueryBuilder) {
+			q.Filter("total) OR (1=1", "gt", 1)
+		}), "total) OR (1=1"},
+		{"with count", e.Query("User").WithCount("orders", func(q *QueryBuilder) {
+			q.Filter("amount", "gt", 1)
+		}), "amount"},
+		{"order by relation", e.Query("User").OrderByRelation("orders", "sum", "total) FROM users --", "desc"), "total) FROM users --"},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.query.ToSQL()
+		var unknown *UnknownFieldError
+		if !errors.As(err, &unknown) {
+			t.Errorf("%s: expected UnknownFieldError, got %v", tt.name, err)
+			continue
+		}
+		if unknown.Field != tt.field {
+			t.Errorf("%s: expected field %q, got %q", tt.name, tt.field, unknown.Field)
+		}
+	}
+
+	valid := e.Query("User").
+		Filter("orders.total", "gt", 1).
+		WithCount("orders", nil).
+		OrderBy("orders_count", "desc").
+		OrderByRelation("orders", "sum", "total", "desc").
+		OrderByRelation("orders", "count", "", "asc").
+		Select("id", "email")
+	if err := valid.checkFields(); err != nil {
+		t.Errorf("declared fields should pass, got %v", err)
+	}
+}
+
+func TestQueryBuilder_LimitOffsetRange(t *testing.T) {
+	e := NewEngineWithoutSchema()
+	e.schema = &Schema{Entities: []*Entity{
+		{Name: "User", Fields: map[string]*Field{"id": {Name: "id", Type: FieldTypeUUID, PrimaryKey: true}}},
+	}}
+
+	var limitErr *LimitError
+	if _, err := e.Query("User").Limit(math.MaxUint64).ToSQL(); !errors.As(err, &limitErr) || limitErr.Clause != "LIMIT" {
+		t.Errorf("expected LIMIT LimitError, got %v", err)
+	}
+	if _, err := e.Query("User").Offset(math.MaxInt64 + 1).ToSQL(); !errors.As(err, &limitErr) || limitErr.Clause != "OFFSET" {
+		t.Errorf("expected OFFSET LimitError, got %v", err)
+	}
+
+	if err := e.Query("User").Limit(math.MaxInt64).Offset(math.MaxInt64).checkWindow(); err != nil {
+		t.Errorf("bigint maximum should be accepted, got %v", err)
+	}
+
+	// Limit(0) is kept as LIMIT 0, not dropped as "no limit"
+	qb := e.Query("User").Limit(0)
+	if err := qb.checkWindow(); err != nil {
+		t.Errorf("Limit(0) should be valid, got %v", err)
+	}
+	if qb.query.Limit == nil || *qb.query.Limit != 0 {
+		t.Errorf("Limit(0) should be serialized, got %v", qb.query.Limit)
+	}
+
+	e.WithMaxLimit(100)
+	if err := e.Query("User").Limit(100).checkWindow(); err != nil {
+		t.Errorf("limit at the cap should pass, got %v", err)
+	}
+	if err := e.Query("User").Limit(101).checkWindow(); !errors.As(err, &limitErr) || limitErr.Max != 100 {
+		t.Errorf("expected LimitError with max 100, got %v", err)
+	}
+	if err := e.Query("User").checkWindow(); err != nil {
+		t.Errorf("queries without a limit are not capped, got %v", err)
+	}
+}
+
+func TestQueryBuilder_WithLimits(t *testing.T) {
+	e := NewEngineWithoutSchema()
+	limitOf := func(qb *QueryBuilder) interface{} {
+		if qb.query.Limit == nil {
+			return nil
+		}
+		return *qb.query.Limit
+	}
+
+	// No configuration: queries are left alone
+	if got := limitOf(e.Query("User").withLimits()); got != nil {
+		t.Errorf("expected no limit, got %v", got)
+	}
+
+	e.WithLimits(LimitConfig{Default: 50, Max: 200})
+	if got := limitOf(e.Query("User").withLimits()); got != uint64(50) {
+		t.Errorf("expected default limit 50, got %v", got)
+	}
+	if got := limitOf(e.Query("User").Limit(10).withLimits()); got != uint64(10) {
+		t.Errorf("explicit limit should win, got %v", got)
+	}
+
+	// Without Clamp, a limit above Max is left for checkWindow to reject
+	over := e.Query("User").Limit(500)
+	if got := limitOf(over.withLimits()); got != uint64(500) {
+		t.Errorf("expected limit to be kept, got %v", got)
+	}
+	var limitErr *LimitError
+	if err := over.checkWindow(); !errors.As(err, &limitErr) {
+		t.Errorf("expected LimitError, got %v", err)
+	}
+
+	e.WithLimits(LimitConfig{Default: 500, Max: 200, Clamp: true})
+	clamped := e.Query("User").Limit(500).withLimits()
+	if got := limitOf(clamped); got != uint64(200) {
+		t.Errorf("expected limit clamped to 200, got %v", got)
+	}
+	if err := e.Query("User").Limit(500).checkWindow(); err != nil {
+		t.Errorf("clamping engines should not reject, got %v", err)
+	}
+	if got := limitOf(e.Query("User").withLimits()); got != uint64(200) {
+		t.Errorf("a default above Max should be capped, got %v", got)
+	}
+
+	// withLimits copies; the original builder is untouched
+	original := e.Query("User")
+	original.withLimits()
+	if original.query.Limit != nil {
+		t.Error("withLimits should not modify the builder")
+	}
+}
+
+func TestQueryBuilder_LatestOldest(t *testing.T) {
+	e := NewEngineWithoutSchema()
+	e.schema = &Schema{Entities: []*Entity{
+		{Name: "Post", Fields: map[string]*Field{
+			"id":         {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+			"created_at": {Name: "created_at", Type: FieldTypeTimestamp},
+		}},
+		{Name: "Event", Fields: map[string]*Field{
+			"id": {Name: "id", Type: FieldTypeInt, PrimaryKey: true},
+		}},
+		{Name: "Tag", Fields: map[string]*Field{
+			"id": {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+		}},
+	}}
+
+	tests := []struct {
+		qb        *QueryBuilder
+		field     string
+		direction string
+	}{
+		{e.Query("Post").Latest(5), "created_at", "Desc"},
+		{e.Query("Post").Oldest(5), "created_at", "Asc"},
+		{e.Query("Event").Latest(5), "id", "Desc"},
+	}
+	for _, tt := range tests {
+		qb := tt.qb
+		if qb.err != nil {
+			t.Fatalf("%s: unexpected error %v", qb.query.Entity, qb.err)
+		}
+		if len(qb.query.OrderBy) != 1 || !reflect.DeepEqual(qb.query.OrderBy[0], OrderByClause{Field: tt.field, Direction: tt.direction}) {
+			t.Errorf("%s: unexpected order %+v", qb.query.Entity, qb.query.OrderBy)
+		}
+		if qb.query.Limit == nil || *qb.query.Limit != 5 {
+			t.Errorf("%s: expected limit 5, got %v", qb.query.Entity, qb.query.Limit)
+		}
+	}
+
+	// A UUID key has no order to rely on
+	qb := e.Query("Tag").Latest(5)
+	if qb.err == nil || !strings.Contains(qb.err.Error(), "Latest: Tag has no created_at") {
+		t.Errorf("expected an ordering column error, got %v", qb.err)
+	}
+	if len(qb.query.OrderBy) != 0 || qb.query.Limit != nil {
+		t.Error("a failed Latest should not change the query")
+	}
+}
+
+func TestGeneratedSQL_Args(t *testing.T) {
+	resultJSON := `{"main_query":"SELECT id FROM users WHERE id = ANY($1::UUID[]) AND age != ALL($2::INTEGER[])",
+		"eager_queries":[],
+		"params":[
+			{"List":[{"String":"a"},{"String":"b"}]},
+			{"List":[{"Int":9007199254740993},{"Int":2}]},
+			{"List":[{"Int":1},"Null"]},
+			{"List":[]}
+		]}`
+
+	var generated GeneratedSQL
+	decoder := json.NewDecoder(strings.NewReader(resultJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generated); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	args := generated.Args()
+	if len(args) != 4 {
+		t.Fatalf("expected 4 args, got %d", len(args))
+	}
+	if got, ok := args[0].([]string); !ok || len(got) != 2 || got[1] != "b" {
+		t.Errorf("expected []string{a, b}, got %#v", args[0])
+	}
+	if got, ok := args[1].([]int64); !ok || got[0] != 9007199254740993 {
+		t.Errorf("expected []int64 keeping precision, got %#v", args[1])
+	}
+	if got, ok := args[2].([]interface{}); !ok || got[0] != int64(1) || got[1] != nil {
+		t.Errorf("expected []interface{}{1, nil}, got %#v", args[2])
+	}
+	if got, ok := args[3].([]interface{}); !ok || len(got) != 0 {
+		t.Errorf("expected an empty list, got %#v", args[3])
+	}
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"strings"
 	"testing"
 )
 
@@ -12,12 +13,27 @@ type mockInsertMutation struct{}
 func (m *mockInsertMutation) Set(field string, value interface{}) InsertMutation {
 	return m
 }
+func (m *mockInsertMutation) Rows(rows ...map[string]interface{}) InsertMutation {
+	return m
+}
+func (m *mockInsertMutation) Upsert(conflictFields ...string) InsertMutation {
+	return m
+}
+func (m *mockInsertMutation) WithValidatorConfig(cfg ValidatorConfig) InsertMutation {
+	return m
+}
+func (m *mockInsertMutation) AllTenants() InsertMutation {
+	return m
+}
 func (m *mockInsertMutation) Debug() InsertMutation {
 	return m
 }
 func (m *mockInsertMutation) Execute(ctx context.Context) (*InsertResult, error) {
 	return &InsertResult{}, nil
 }
+func (m *mockInsertMutation) ToSQL() (string, []interface{}, error) {
+	return "", nil, nil
+}
 
 type mockUpdateMutation struct{}
 
@@ -27,37 +43,91 @@ func (m *mockUpdateMutation) Set(field string, value interface{}) UpdateMutation
 func (m *mockUpdateMutation) Filter(field string, operator string, value interface{}) UpdateMutation {
 	return m
 }
+func (m *mockUpdateMutation) WithValidatorConfig(cfg ValidatorConfig) UpdateMutation {
+	return m
+}
+func (m *mockUpdateMutation) AllTenants() UpdateMutation {
+	return m
+}
 func (m *mockUpdateMutation) Debug() UpdateMutation {
 	return m
 }
 func (m *mockUpdateMutation) Execute(ctx context.Context) (*UpdateResult, error) {
 	return &UpdateResult{}, nil
 }
+func (m *mockUpdateMutation) ToSQL() (string, []interface{}, error) {
+	return "", nil, nil
+}
 
 type mockDeleteMutation struct{}
 
 func (m *mockDeleteMutation) Filter(field string, operator string, value interface{}) DeleteMutation {
 	return m
 }
+func (m *mockDeleteMutation) WithValidatorConfig(cfg ValidatorConfig) DeleteMutation {
+	return m
+}
+func (m *mockDeleteMutation) AllTenants() DeleteMutation {
+	return m
+}
+func (m *mockDeleteMutation) Batch(size int) DeleteMutation {
+	return m
+}
+func (m *mockDeleteMutation) OnBatch(fn func(records []map[string]interface{}) error) DeleteMutation {
+	return m
+}
 func (m *mockDeleteMutation) Debug() DeleteMutation {
 	return m
 }
 func (m *mockDeleteMutation) Execute(ctx context.Context) (*DeleteResult, error) {
 	return &DeleteResult{}, nil
 }
+func (m *mockDeleteMutation) ToSQL() (string, []interface{}, error) {
+	return "", nil, nil
+}
 
-func (m *mockMutationFactory) NewInsert(entity string, schema *Schema, connector *Connector) InsertMutation {
+type mockTruncateMutation struct{}
+
+func (m *mockTruncateMutation) Force() TruncateMutation {
+	return m
+}
+func (m *mockTruncateMutation) AllTenants() TruncateMutation {
+	return m
+}
+func (m *mockTruncateMutation) Debug() TruncateMutation {
+	return m
+}
+func (m *mockTruncateMutation) Execute(ctx context.Context) (*TruncateResult, error) {
+	return &TruncateResult{}, nil
+}
+func (m *mockTruncateMutation) ToSQL() (string, []interface{}, error) {
+	return "", nil, nil
+}
+
+func (m *mockMutationFactory) NewInsert(entity string, schema *Schema, connector *Connector, opts MutationOptions) InsertMutation {
 	return &mockInsertMutation{}
 }
 
-func (m *mockMutationFactory) NewUpdate(entity string, schema *Schema, connector *Connector) UpdateMutation {
+func (m *mockMutationFactory) NewUpdate(entity string, schema *Schema, connector *Connector, opts MutationOptions) UpdateMutation {
 	return &mockUpdateMutation{}
 }
 
-func (m *mockMutationFactory) NewDelete(entity string, schema *Schema, connector *Connector) DeleteMutation {
+func (m *mockMutationFactory) NewDelete(entity string, schema *Schema, connector *Connector, opts MutationOptions) DeleteMutation {
 	return &mockDeleteMutation{}
 }
 
+func (m *mockMutationFactory) NewTruncate(entities []string, schema *Schema, connector *Connector, opts MutationOptions) TruncateMutation {
+	return &mockTruncateMutation{}
+}
+
+func (m *mockMutationFactory) BulkLoad(ctx context.Context, entity string, rows []map[string]interface{}, schema *Schema, connector *Connector, opts MutationOptions) (*BulkLoadResult, error) {
+	return &BulkLoadResult{Loaded: int64(len(rows))}, nil
+}
+
+func (m *mockMutationFactory) TableName(entity string) string {
+	return strings.ToLower(entity) + "s"
+}
+
 func TestRegisterMutationFactory(t *testing.T) {
 	// Reset global state
 	mutationFactory = nil
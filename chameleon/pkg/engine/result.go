@@ -42,6 +42,11 @@ func (r Row) Int(field string) int64 {
 	}
 }
 
+// RelationCount returns the "<relation>_count" column added by WithCount
+func (r Row) RelationCount(relation string) int64 {
+	return r.Int(relation + "_count")
+}
+
 // QueryResult holds the result of a query execution
 type QueryResult struct {
 	// Entity name this result belongs to
@@ -50,6 +55,22 @@ type QueryResult struct {
 	Rows []Row
 	// Eager-loaded relations: relation name → rows
 	Relations map[string][]Row
+	// Columns of the main query, in SELECT order
+	Columns []ColumnMeta
+
+	// schema resolves relations for Related (nil = unknown)
+	schema *Schema
+}
+
+// ColumnMeta describes one column of a query result
+type ColumnMeta struct {
+	Name string
+	// PostgreSQL type OID and name ("text", "int8", ...); TypeName is
+	// empty for types the connection does not know
+	TypeOID  uint32
+	TypeName string
+	// From the schema field; true for columns without one
+	Nullable bool
 }
 
 // Count returns the number of rows in the main result
@@ -61,3 +82,81 @@ func (qr *QueryResult) Count() int {
 func (qr *QueryResult) IsEmpty() bool {
 	return len(qr.Rows) == 0
 }
+
+// Related returns the eager-loaded rows of relation that belong to parent,
+// matched through the relation's foreign key. Nested relations use their
+// full include path, with parent being a row of the previous level:
+//
+//	for _, user := range result.Rows {
+//		for _, order := range result.Related(user, "orders") {
+//			items := result.Related(order, "orders.items")
+//		}
+//	}
+//
+// Returns nil when the relation was not included or is unknown.
+func (qr *QueryResult) Related(parent Row, relation string) []Row {
+	children, ok := qr.Relations[relation]
+	if !ok || qr.schema == nil {
+		return nil
+	}
+
+	owner, rel := qr.resolveRelation(relation)
+	if rel == nil || rel.ForeignKey == nil {
+		return nil
+	}
+
+	// Eager loading fetches children whose foreign key holds the parent's
+	// primary key; relations without a foreign key (belongs-to) are never
+	// eager loaded
+	parentField, childField := referencedKey(owner), *rel.ForeignKey
+
+	key, ok := parent[parentField]
+	if !ok || key == nil {
+		return nil
+	}
+	key = relationKey(key)
+
+	var related []Row
+	for _, child := range children {
+		if value, ok := child[childField]; ok && value != nil && relationKey(value) == key {
+			related = append(related, child)
+		}
+	}
+	return related
+}
+
+// resolveRelation walks an include path ("orders.items") from the result
+// entity and returns the relation at its end, with the entity declaring it
+func (qr *QueryResult) resolveRelation(path string) (*Entity, *Relation) {
+	entity := qr.Entity
+	var owner *Entity
+	var rel *Relation
+	for _, name := range splitPath(path) {
+		if owner = qr.schema.GetEntity(entity); owner == nil {
+			return nil, nil
+		}
+		if rel = owner.RelationByName(name); rel == nil {
+			return nil, nil
+		}
+		entity = rel.TargetEntity
+	}
+	return owner, rel
+}
+
+// relationKey normalizes key values so the same key compares equal
+// however it was scanned
+func relationKey(v interface{}) interface{} {
+	switch key := v.(type) {
+	case [16]byte:
+		return uuidToString(key)
+	case []byte:
+		return string(key)
+	case int32:
+		return int64(key)
+	case int16:
+		return int64(key)
+	case int:
+		return int64(key)
+	}
+	return v
+}
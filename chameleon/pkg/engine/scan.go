@@ -0,0 +1,258 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ScanTree copies the result into dest, a pointer to a slice of structs
+// (or struct pointers), and hydrates relation fields from the eager-loaded
+// rows, matched to their parent as in Related.
+//
+// Struct fields map to columns by their `db` tag, or by the snake_case of
+// the field name (CreatedAt → created_at). A field whose tag or name matches
+// a relation of the entity (Orders → orders) is filled from that relation:
+// a slice for HasMany and ManyToMany, a struct or struct pointer for
+// HasOne and BelongsTo. Relations that were not included are left alone,
+// and `db:"-"` skips a field.
+//
+//	type Order struct {
+//		ID    int64
+//		Total float64
+//		Items []OrderItem
+//	}
+//	type User struct {
+//		ID     string
+//		Email  string
+//		Orders []Order
+//	}
+//
+//	result, err := eng.Query("User").Include("orders").Include("orders.items").Execute(ctx)
+//	var users []User
+//	err = result.ScanTree(&users)
+func (qr *QueryResult) ScanTree(dest interface{}) error {
+	ptr := reflect.ValueOf(dest)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("ScanTree: dest must be a pointer to a slice of structs, got %T", dest)
+	}
+	if _, ok := structType(ptr.Elem().Type().Elem()); !ok {
+		return fmt.Errorf("ScanTree: dest must be a pointer to a slice of structs, got %T", dest)
+	}
+
+	slice, err := qr.scanRows(qr.Rows, qr.Entity, "", ptr.Elem().Type())
+	if err != nil {
+		return err
+	}
+	ptr.Elem().Set(slice)
+	return nil
+}
+
+// scanRows builds a slice of sliceType from rows of entity. path is the
+// include path the rows were loaded through ("" for the main rows).
+func (qr *QueryResult) scanRows(rows []Row, entity, path string, sliceType reflect.Type) (reflect.Value, error) {
+	slice := reflect.MakeSlice(sliceType, 0, len(rows))
+	for _, row := range rows {
+		elem, err := qr.scanStruct(row, entity, path, sliceType.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		slice = reflect.Append(slice, elem)
+	}
+	return slice, nil
+}
+
+// scanStruct builds one struct (or struct pointer) of type t from row
+func (qr *QueryResult) scanStruct(row Row, entity, path string, t reflect.Type) (reflect.Value, error) {
+	st, _ := structType(t)
+	value := reflect.New(st).Elem()
+
+	var ent *Entity
+	if qr.schema != nil {
+		ent = qr.schema.GetEntity(entity)
+	}
+
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if field.PkgPath != "" || field.Anonymous {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("db"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		if rel := fieldRelation(ent, field.Name, tag); rel != nil {
+			if err := qr.scanRelation(row, rel, path, value.Field(i), field); err != nil {
+				return reflect.Value{}, err
+			}
+			continue
+		}
+
+		column := tag
+		if column == "" {
+			column = snakeCase(field.Name)
+		}
+		v, ok := row[column]
+		if !ok {
+			continue
+		}
+		if err := assignValue(value.Field(i), v); err != nil {
+			return reflect.Value{}, fmt.Errorf("ScanTree: %s.%s: %w", st.Name(), field.Name, err)
+		}
+	}
+
+	if t.Kind() == reflect.Ptr {
+		return value.Addr(), nil
+	}
+	return value, nil
+}
+
+// scanRelation fills a relation field with the related rows of row
+func (qr *QueryResult) scanRelation(row Row, rel *Relation, path string, dest reflect.Value, field reflect.StructField) error {
+	relPath := rel.Name
+	if path != "" {
+		relPath = path + "." + rel.Name
+	}
+	if _, included := qr.Relations[relPath]; !included {
+		return nil
+	}
+	related := qr.Related(row, relPath)
+
+	switch rel.Kind {
+	case RelationHasMany, RelationManyToMany:
+		if dest.Kind() != reflect.Slice {
+			return fmt.Errorf("ScanTree: field %s holds %s relation %q and must be a slice, got %s", field.Name, rel.Kind, rel.Name, dest.Type())
+		}
+		if _, ok := structType(dest.Type().Elem()); !ok {
+			return fmt.Errorf("ScanTree: field %s must be a slice of structs, got %s", field.Name, dest.Type())
+		}
+		slice, err := qr.scanRows(related, rel.TargetEntity, relPath, dest.Type())
+		if err != nil {
+			return err
+		}
+		dest.Set(slice)
+
+	default:
+		if _, ok := structType(dest.Type()); !ok {
+			return fmt.Errorf("ScanTree: field %s holds %s relation %q and must be a struct or struct pointer, got %s", field.Name, rel.Kind, rel.Name, dest.Type())
+		}
+		if len(related) == 0 {
+			dest.Set(reflect.Zero(dest.Type()))
+			return nil
+		}
+		value, err := qr.scanStruct(related[0], rel.TargetEntity, relPath, dest.Type())
+		if err != nil {
+			return err
+		}
+		dest.Set(value)
+	}
+	return nil
+}
+
+// fieldRelation returns the relation a struct field holds: the one named
+// by its tag, or the one whose name matches the field name ignoring case
+func fieldRelation(ent *Entity, fieldName, tag string) *Relation {
+	if ent == nil {
+		return nil
+	}
+	if tag != "" {
+		return ent.RelationByName(tag)
+	}
+	for _, name := range ent.RelationNames() {
+		if strings.EqualFold(name, fieldName) {
+			return ent.Relations[name]
+		}
+	}
+	return nil
+}
+
+// structType returns the struct type behind t (a struct or struct pointer)
+func structType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+// assignValue stores a scanned value in a struct field, converting between
+// numeric kinds and from UUIDs and decimals where the field asks for it
+func assignValue(dest reflect.Value, v interface{}) error {
+	if v == nil {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+	if dest.Kind() == reflect.Ptr {
+		ptr := reflect.New(dest.Type().Elem())
+		if err := assignValue(ptr.Elem(), v); err != nil {
+			return err
+		}
+		dest.Set(ptr)
+		return nil
+	}
+
+	switch value := v.(type) {
+	case [16]byte:
+		if dest.Kind() == reflect.String {
+			v = uuidToString(value)
+		}
+	case pgtype.Numeric:
+		switch dest.Kind() {
+		case reflect.String:
+			text, err := value.Value()
+			if err != nil {
+				return err
+			}
+			v = text
+		case reflect.Float32, reflect.Float64:
+			f, err := value.Float64Value()
+			if err != nil {
+				return err
+			}
+			v = f.Float64
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(dest.Type()) {
+		dest.Set(rv)
+		return nil
+	}
+	if rv.Type().ConvertibleTo(dest.Type()) && (rv.Kind() == dest.Kind() || isNumericKind(rv.Kind()) && isNumericKind(dest.Kind())) {
+		dest.Set(rv.Convert(dest.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %T to %s", v, dest.Type())
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// snakeCase converts a Go field name to a column name:
+// CreatedAt → created_at, UserID → user_id, ID → id
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			acronymEnd := i > 0 && unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || acronymEnd {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type scanItem struct {
+	ID       int64
+	Quantity int
+}
+
+type scanOrder struct {
+	ID     int32
+	Total  float64
+	Status *string
+	Items  []scanItem
+	Buyer  *scanUser `db:"user"`
+}
+
+type scanUser struct {
+	ID        string
+	Email     string `db:"email_address"`
+	CreatedAt time.Time
+	Orders    []*scanOrder
+	Profile   scanProfile
+	Internal  string `db:"-"`
+}
+
+type scanProfile struct {
+	Bio string
+}
+
+func scanTestResult() *QueryResult {
+	userFK, orderFK := "user_id", "order_id"
+	schema := &Schema{Entities: []*Entity{
+		{Name: "User", Relations: map[string]*Relation{
+			"orders":  {Name: "orders", Kind: RelationHasMany, TargetEntity: "Order", ForeignKey: &userFK},
+			"profile": {Name: "profile", Kind: RelationHasOne, TargetEntity: "Profile", ForeignKey: &userFK},
+		}},
+		{Name: "Order", Relations: map[string]*Relation{
+			"items": {Name: "items", Kind: RelationHasMany, TargetEntity: "OrderItem", ForeignKey: &orderFK},
+			"user":  {Name: "user", Kind: RelationBelongsTo, TargetEntity: "User", ForeignKey: &userFK},
+		}},
+		{Name: "OrderItem"},
+		{Name: "Profile"},
+	}}
+
+	ana := [16]byte{1}
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	return &QueryResult{
+		Entity: "User",
+		Rows: []Row{
+			{"id": ana, "email_address": "ana@mail.com", "created_at": created, "internal": "x"},
+			{"id": [16]byte{2}, "email_address": "bob@mail.com", "created_at": created},
+		},
+		Relations: map[string][]Row{
+			"orders": {
+				{"id": int32(10), "user_id": ana, "total": pgtype.Numeric{Int: big.NewInt(1250), Exp: -2, Valid: true}, "status": "paid"},
+				{"id": int32(12), "user_id": ana, "total": nil, "status": nil},
+			},
+			"orders.items": {
+				{"id": int64(100), "order_id": int32(10), "quantity": int64(2)},
+				{"id": int64(101), "order_id": int32(10), "quantity": int64(1)},
+			},
+			"profile": {
+				{"id": int64(1), "user_id": [16]byte{2}, "bio": "hi"},
+			},
+		},
+		schema: schema,
+	}
+}
+
+func TestQueryResult_ScanTree(t *testing.T) {
+	var users []scanUser
+	if err := scanTestResult().ScanTree(&users); err != nil {
+		t.Fatalf("ScanTree failed: %v", err)
+	}
+
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+	ana, bob := users[0], users[1]
+	if ana.ID != "01000000-0000-0000-0000-000000000000" || ana.Email != "ana@mail.com" || ana.CreatedAt.Year() != 2024 {
+		t.Errorf("columns not scanned: %+v", ana)
+	}
+	if ana.Internal != "" {
+		t.Errorf(`db:"-" field should be skipped, got %q`, ana.Internal)
+	}
+
+	// HasMany into a slice of pointers, nested HasMany into a slice
+	if len(ana.Orders) != 2 || ana.Orders[0].ID != 10 || ana.Orders[1].ID != 12 {
+		t.Fatalf("unexpected orders: %+v", ana.Orders)
+	}
+	first := ana.Orders[0]
+	if first.Total != 12.5 || first.Status == nil || *first.Status != "paid" {
+		t.Errorf("unexpected order values: %+v", first)
+	}
+	if ana.Orders[1].Status != nil || ana.Orders[1].Total != 0 {
+		t.Errorf("NULL columns should scan as zero values: %+v", ana.Orders[1])
+	}
+	if len(first.Items) != 2 || first.Items[0].Quantity != 2 || first.Items[1].ID != 101 {
+		t.Errorf("unexpected items: %+v", first.Items)
+	}
+	if len(ana.Orders[1].Items) != 0 {
+		t.Errorf("order 12 has no items, got %+v", ana.Orders[1].Items)
+	}
+
+	// Relations that were not included are left alone
+	if first.Buyer != nil {
+		t.Errorf("user relation was not included, got %+v", first.Buyer)
+	}
+
+	// HasOne into a struct
+	if ana.Profile.Bio != "" || bob.Profile.Bio != "hi" {
+		t.Errorf("unexpected profiles: ana=%+v bob=%+v", ana.Profile, bob.Profile)
+	}
+	if len(bob.Orders) != 0 {
+		t.Errorf("Expected no orders for bob, got %+v", bob.Orders)
+	}
+}
+
+func TestQueryResult_ScanTreeErrors(t *testing.T) {
+	result := scanTestResult()
+
+	var notSlice scanUser
+	if err := result.ScanTree(&notSlice); err == nil {
+		t.Error("Expected an error for a non-slice destination")
+	}
+
+	// A HasMany relation needs a slice field
+	type badUser struct {
+		Orders scanOrder
+	}
+	var bad []badUser
+	err := result.ScanTree(&bad)
+	if err == nil || !strings.Contains(err.Error(), "must be a slice") {
+		t.Errorf("Expected a relation kind error, got %v", err)
+	}
+
+	// Incompatible column types are reported with the field
+	type wrongType struct {
+		Email int
+	}
+	var wrong []wrongType
+	result.Rows[0]["email"] = "ana@mail.com"
+	err = result.ScanTree(&wrong)
+	if err == nil || !strings.Contains(err.Error(), "wrongType.Email") {
+		t.Errorf("Expected a field conversion error, got %v", err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":        "id",
+		"Email":     "email",
+		"CreatedAt": "created_at",
+		"UserID":    "user_id",
+		"HTTPCode":  "http_code",
+		"Address2":  "address2",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
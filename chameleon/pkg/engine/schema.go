@@ -3,18 +3,122 @@ package engine
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 )
 
 // Schema represents the complete database schema
 type Schema struct {
 	Entities []*Entity `json:"entities"`
+
+	// FormatVersion is the schema format of the core that parsed it
+	// (0 = unknown). See vault.SchemaFormat.
+	FormatVersion int `json:"format_version,omitempty"`
 }
 
 // Entity represents a database entity (table)
 type Entity struct {
-	Name      string               `json:"name"`
-	Fields    map[string]*Field    `json:"fields"`
-	Relations map[string]*Relation `json:"relations"`
+	Name          string               `json:"name"`
+	Fields        map[string]*Field    `json:"fields"`
+	FieldOrder    []string             `json:"field_order,omitempty"` // Field names in declaration order
+	Relations     map[string]*Relation `json:"relations"`
+	Checks        []*CheckConstraint   `json:"checks,omitempty"`
+	UniqueIndexes []*UniqueIndex       `json:"unique_indexes,omitempty"` // @unique(...) [where ...]
+	View          bool                 `json:"view,omitempty"`           // Backed by a database view (read-only)
+	ReadOnly      bool                 `json:"read_only,omitempty"`      // @readonly: mutations are rejected
+	Schema        string               `json:"schema,omitempty"`         // @schema("billing"): PostgreSQL namespace
+	Tenant        string               `json:"tenant,omitempty"`         // @tenant(org_id): column rows are scoped by (see WithTenant)
+	Timestamps    bool                 `json:"timestamps,omitempty"`     // @timestamps: created_at/updated_at maintained by mutations
+	Description   string               `json:"description,omitempty"`    // From /// doc comments
+}
+
+// Columns maintained on entities declared with @timestamps
+const (
+	CreatedAtField = "created_at"
+	UpdatedAtField = "updated_at"
+)
+
+// OrderFields sorts names by the entity's declared field order.
+// Names not in FieldOrder (or all of them, if the order is unknown)
+// follow in alphabetical order.
+func (e *Entity) OrderFields(names []string) []string {
+	position := make(map[string]int, len(e.FieldOrder))
+	for i, name := range e.FieldOrder {
+		position[name] = i
+	}
+
+	ordered := append([]string(nil), names...)
+	sort.Slice(ordered, func(i, j int) bool {
+		pi, iKnown := position[ordered[i]]
+		pj, jKnown := position[ordered[j]]
+		switch {
+		case iKnown && jKnown:
+			return pi < pj
+		case iKnown != jKnown:
+			return iKnown
+		}
+		return ordered[i] < ordered[j]
+	})
+	return ordered
+}
+
+// IsReadOnly reports whether mutations against the entity must be rejected.
+// Views are always read-only.
+func (e *Entity) IsReadOnly() bool {
+	return e.ReadOnly || e.View
+}
+
+// IsTimestampField reports whether name is a column @timestamps maintains:
+// inserts default both columns to now(), updates bump updated_at
+func (e *Entity) IsTimestampField(name string) bool {
+	return e.Timestamps && (name == CreatedAtField || name == UpdatedAtField) && e.Fields[name] != nil
+}
+
+// FieldNames returns the entity's field names in declaration order
+func (e *Entity) FieldNames() []string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	return e.OrderFields(names)
+}
+
+// PrimaryKeys returns the primary key fields in declaration order.
+// More than one field means a composite key.
+func (e *Entity) PrimaryKeys() []*Field {
+	var keys []*Field
+	for _, name := range e.FieldNames() {
+		if field := e.Fields[name]; field.PrimaryKey {
+			keys = append(keys, field)
+		}
+	}
+	return keys
+}
+
+// RelationByName returns the named relation, or nil if the entity has none
+func (e *Entity) RelationByName(name string) *Relation {
+	return e.Relations[name]
+}
+
+// RelationNames returns the entity's relation names, sorted
+func (e *Entity) RelationNames() []string {
+	return sortedKeys(e.Relations)
+}
+
+// CheckConstraint represents a CHECK constraint on an entity.
+// Field is nil for table-level (multi-column) checks.
+type CheckConstraint struct {
+	Expression string  `json:"expression"`
+	Field      *string `json:"field"`
+}
+
+// UniqueIndex is an entity-level unique index. A non-nil Predicate makes
+// it partial: uniqueness only holds for rows matching the SQL condition.
+// Concurrent indexes are built with CREATE INDEX CONCURRENTLY, outside
+// the migration transaction.
+type UniqueIndex struct {
+	Fields     []string `json:"fields"`
+	Predicate  *string  `json:"predicate,omitempty"`
+	Concurrent bool     `json:"concurrent,omitempty"`
 }
 
 // Field represents an entity field (column)
@@ -26,6 +130,32 @@ type Field struct {
 	PrimaryKey bool         `json:"primary_key"`
 	Default    *interface{} `json:"default,omitempty"`
 	Backend    *string      `json:"backend,omitempty"`
+	// From /// doc comments above the field; becomes COMMENT ON COLUMN
+	Description string `json:"description,omitempty"`
+}
+
+// IsRequired reports whether an insert must provide the field: it is not
+// nullable, has no default, and is not a primary key the database may generate
+func (f *Field) IsRequired() bool {
+	return !f.Nullable && f.Default == nil && !f.PrimaryKey
+}
+
+// IsAutoIncrement reports whether the field is declared `auto`: a SERIAL
+// column the database numbers itself
+func (f *Field) IsAutoIncrement() bool {
+	return f.Default != nil && *f.Default == "AutoIncrement"
+}
+
+// GeneratedExpression returns the SQL expression of a field declared
+// generated("..."), a column the database computes from other columns
+// (GENERATED ALWAYS AS ... STORED), or "" for ordinary fields
+func (f *Field) GeneratedExpression() string {
+	if f.Default == nil {
+		return ""
+	}
+	value, _ := (*f.Default).(map[string]interface{})
+	expression, _ := value["Generated"].(string)
+	return expression
 }
 
 // FieldType represents the type of a field and can be simple or complex
@@ -108,6 +238,54 @@ const (
 	RelationManyToMany RelationKind = "ManyToMany"
 )
 
+// ForeignKey is a column that references another entity's primary key
+type ForeignKey struct {
+	Field            string // Column in the referencing entity, e.g. "user_id"
+	ReferencedEntity string // e.g. "User"
+	ReferencedField  string // ReferencedEntity's primary key, e.g. "id"
+	Relation         string // The has-many relation on ReferencedEntity that declares it
+}
+
+// EntityNames returns entity names in schema order
+func (s *Schema) EntityNames() []string {
+	names := make([]string, 0, len(s.Entities))
+	for _, entity := range s.Entities {
+		names = append(names, entity.Name)
+	}
+	return names
+}
+
+// ForeignKeys returns the foreign keys held by the named entity. Foreign
+// keys are declared on the other side ("orders: [Order] via user_id"),
+// so this needs the whole schema rather than just the entity.
+func (s *Schema) ForeignKeys(entity string) []ForeignKey {
+	var keys []ForeignKey
+	for _, other := range s.Entities {
+		for _, name := range other.RelationNames() {
+			rel := other.Relations[name]
+			if rel.Kind != RelationHasMany || rel.TargetEntity != entity || rel.ForeignKey == nil {
+				continue
+			}
+			keys = append(keys, ForeignKey{
+				Field:            *rel.ForeignKey,
+				ReferencedEntity: other.Name,
+				ReferencedField:  referencedKey(other),
+				Relation:         name,
+			})
+		}
+	}
+	return keys
+}
+
+// referencedKey returns the field a foreign key to entity references: its
+// primary key, or "id" when it has none or a composite one
+func referencedKey(entity *Entity) string {
+	if keys := entity.PrimaryKeys(); len(keys) == 1 {
+		return keys[0].Name
+	}
+	return "id"
+}
+
 // ParseSchemaJSON parses a JSON string into a Schema
 func ParseSchemaJSON(jsonStr string) (*Schema, error) {
 	var schema Schema
@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func reflectionSchema() *Schema {
+	userID := "user_id"
+	var now interface{} = "Now"
+
+	return &Schema{
+		Entities: []*Entity{
+			{
+				Name: "User",
+				Fields: map[string]*Field{
+					"id":         {Name: "id", Type: FieldTypeUUID, PrimaryKey: true},
+					"email":      {Name: "email", Type: FieldTypeString, Unique: true},
+					"age":        {Name: "age", Type: FieldTypeInt, Nullable: true},
+					"created_at": {Name: "created_at", Type: FieldTypeTimestamp, Default: &now},
+				},
+				FieldOrder: []string{"id", "email", "age", "created_at"},
+				Relations: map[string]*Relation{
+					"orders": {Name: "orders", Kind: RelationHasMany, TargetEntity: "Order", ForeignKey: &userID},
+				},
+			},
+			{
+				Name: "Order",
+				Fields: map[string]*Field{
+					"tenant_id": {Name: "tenant_id", Type: FieldTypeUUID, PrimaryKey: true},
+					"number":    {Name: "number", Type: FieldTypeInt, PrimaryKey: true},
+					"user_id":   {Name: "user_id", Type: FieldTypeUUID},
+				},
+				FieldOrder: []string{"tenant_id", "number", "user_id"},
+				Relations: map[string]*Relation{
+					"user": {Name: "user", Kind: RelationBelongsTo, TargetEntity: "User"},
+				},
+			},
+		},
+	}
+}
+
+func TestSchemaReflection(t *testing.T) {
+	schema := reflectionSchema()
+	user := schema.Entities[0]
+	order := schema.Entities[1]
+
+	if got, want := schema.EntityNames(), []string{"User", "Order"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EntityNames() = %v, want %v", got, want)
+	}
+	if got, want := user.FieldNames(), []string{"id", "email", "age", "created_at"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldNames() = %v, want %v", got, want)
+	}
+
+	keys := order.PrimaryKeys()
+	if len(keys) != 2 || keys[0].Name != "tenant_id" || keys[1].Name != "number" {
+		t.Errorf("PrimaryKeys() = %v, want [tenant_id number]", keys)
+	}
+
+	if rel := order.RelationByName("user"); rel == nil || rel.TargetEntity != "User" {
+		t.Errorf("RelationByName(user) = %v", rel)
+	}
+	if rel := order.RelationByName("missing"); rel != nil {
+		t.Errorf("RelationByName(missing) = %v, want nil", rel)
+	}
+
+	want := []ForeignKey{{Field: "user_id", ReferencedEntity: "User", ReferencedField: "id", Relation: "orders"}}
+	if got := schema.ForeignKeys("Order"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ForeignKeys(Order) = %v, want %v", got, want)
+	}
+	if got := schema.ForeignKeys("User"); len(got) != 0 {
+		t.Errorf("ForeignKeys(User) = %v, want none", got)
+	}
+}
+
+func TestFieldIsRequired(t *testing.T) {
+	user := reflectionSchema().Entities[0]
+
+	tests := map[string]bool{
+		"id":         false, // primary key
+		"email":      true,
+		"age":        false, // nullable
+		"created_at": false, // has a default
+	}
+	for name, want := range tests {
+		if got := user.Fields[name].IsRequired(); got != want {
+			t.Errorf("%s.IsRequired() = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestFieldIsAutoIncrement(t *testing.T) {
+	var auto interface{} = "AutoIncrement"
+	var now interface{} = "Now"
+
+	if !(&Field{Name: "id", Type: FieldTypeInt, PrimaryKey: true, Default: &auto}).IsAutoIncrement() {
+		t.Error("int primary auto should be auto-increment")
+	}
+	if (&Field{Name: "created_at", Type: FieldTypeTimestamp, Default: &now}).IsAutoIncrement() {
+		t.Error("default now() is not auto-increment")
+	}
+	if (&Field{Name: "id", Type: FieldTypeInt, PrimaryKey: true}).IsAutoIncrement() {
+		t.Error("a field without a default is not auto-increment")
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	if err := reflectionSchema().Validate(); err != nil {
+		t.Fatalf("valid schema rejected: %v", err)
+	}
+
+	badFK := "owner_id"
+	through := "Missing"
+	schema := reflectionSchema()
+	schema.Entities[0].Relations["orders"].ForeignKey = &badFK
+	schema.Entities[0].Relations["ghosts"] = &Relation{Name: "ghosts", Kind: RelationHasMany, TargetEntity: "Ghost"}
+	schema.Entities[0].Relations["tags"] = &Relation{Name: "tags", Kind: RelationManyToMany, TargetEntity: "Order", Through: &through}
+	schema.Entities[1].Relations["lines"] = &Relation{Name: "lines", Kind: RelationHasMany, TargetEntity: "User"}
+	schema.Entities[1].UniqueIndexes = []*UniqueIndex{{Fields: []string{"user_id", "sku"}}}
+
+	err := schema.Validate()
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("expected *SchemaError, got %v", err)
+	}
+
+	want := []string{
+		"Entity 'User' references unknown entity 'Ghost' in relation 'ghosts'",
+		"Relation 'orders' in 'User' references foreign key 'owner_id', but 'Order' has no such field",
+		"Relation 'tags' in 'User' goes through unknown entity 'Missing'",
+		"Unique index in 'Order' references unknown field 'sku'",
+		"HasMany relation 'lines' in 'Order' requires a 'via' foreign key",
+	}
+	if !reflect.DeepEqual(schemaErr.Issues, want) {
+		t.Errorf("Issues =\n%v\nwant\n%v", strings.Join(schemaErr.Issues, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCheckMigrationTypes(t *testing.T) {
+	schema := reflectionSchema()
+	schema.Entities[0].Fields["tags"] = &Field{Name: "tags", Type: FieldType{Kind: "Array", Param: "String"}}
+	schema.Entities[0].Fields["embedding"] = &Field{Name: "embedding", Type: FieldType{Kind: "Vector", Param: 384.0}}
+	if err := schema.CheckMigrationTypes(); err != nil {
+		t.Fatalf("supported types rejected: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		typ  FieldType
+		kind string
+	}{
+		{"unknown kind", FieldType{Kind: "Enum", Param: []interface{}{"a", "b"}}, "Enum"},
+		{"unknown array element", FieldType{Kind: "Array", Param: map[string]interface{}{"Json": nil}}, "Json"},
+		{"nested array", FieldType{Kind: "Array", Param: map[string]interface{}{"Array": "Date"}}, "Date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := reflectionSchema()
+			schema.Entities[1].Fields["status"] = &Field{Name: "status", Type: tt.typ}
+
+			err := schema.CheckMigrationTypes()
+			var typeErr *UnsupportedTypeError
+			if !errors.As(err, &typeErr) {
+				t.Fatalf("expected *UnsupportedTypeError, got %v", err)
+			}
+			if want := "field Order.status uses unsupported type " + tt.kind + " in this version"; err.Error() != want {
+				t.Errorf("error = %q, want %q", err.Error(), want)
+			}
+		})
+	}
+
+	// GenerateMigration reports it before calling the core
+	eng := NewEngineWithoutSchema()
+	eng.schema = reflectionSchema()
+	eng.schema.Entities[1].Fields["status"] = &Field{Name: "status", Type: FieldType{Kind: "Enum"}}
+	var typeErr *UnsupportedTypeError
+	if _, err := eng.GenerateMigrationPlan(); !errors.As(err, &typeErr) {
+		t.Errorf("expected *UnsupportedTypeError from GenerateMigrationPlan, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaError reports structural problems found in a loaded schema
+type SchemaError struct {
+	Issues []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("invalid schema (%d issue(s)):\n  - %s",
+		len(e.Issues), strings.Join(e.Issues, "\n  - "))
+}
+
+// UnsupportedTypeError reports a field whose type the migration
+// generator of this version cannot turn into a column
+type UnsupportedTypeError struct {
+	Entity string
+	Field  string
+	Kind   string // The unsupported kind, e.g. "Enum", or the element kind of an Array
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("field %s.%s uses unsupported type %s in this version", e.Entity, e.Field, e.Kind)
+}
+
+// migrationKinds are the FieldType kinds the core migration generator maps
+// to PostgreSQL types
+var migrationKinds = map[string]bool{
+	"UUID": true, "String": true, "Int": true, "Decimal": true, "Bool": true,
+	"Timestamp": true, "Float": true, "Vector": true, "Array": true,
+}
+
+// CheckMigrationTypes returns an *UnsupportedTypeError for the first field,
+// in schema order, whose type the migration generator does not support.
+// GenerateMigration runs it before calling the core, whose own error for
+// an unknown type does not name the field.
+func (s *Schema) CheckMigrationTypes() error {
+	for _, entity := range s.Entities {
+		for _, name := range entity.FieldNames() {
+			if kind, ok := unsupportedKind(entity.Fields[name].Type); ok {
+				return &UnsupportedTypeError{Entity: entity.Name, Field: name, Kind: kind}
+			}
+		}
+	}
+	return nil
+}
+
+// unsupportedKind returns the first kind of ft outside migrationKinds,
+// looking into the element type of arrays
+func unsupportedKind(ft FieldType) (string, bool) {
+	if !migrationKinds[ft.Kind] {
+		return ft.Kind, true
+	}
+	if ft.Kind != "Array" {
+		return "", false
+	}
+
+	// The element type decodes as a kind name or a one-key object
+	switch inner := ft.Param.(type) {
+	case string:
+		return unsupportedKind(FieldType{Kind: inner})
+	case map[string]interface{}:
+		for kind, param := range inner {
+			return unsupportedKind(FieldType{Kind: kind, Param: param})
+		}
+	case FieldType:
+		return unsupportedKind(inner)
+	}
+	return "Array", true
+}
+
+// Validate checks cross-entity references: relation targets and
+// many-to-many join entities exist, foreign keys name a field of the
+// target, has-many relations declare one, and unique indexes name
+// existing fields. It mirrors the relation
+// checks of the core type checker so a schema that bypassed it (e.g. a
+// tampered vault snapshot) is still rejected before use.
+func (s *Schema) Validate() error {
+	var issues []string
+
+	for _, entity := range s.Entities {
+		for _, index := range entity.UniqueIndexes {
+			for _, field := range index.Fields {
+				if _, ok := entity.Fields[field]; !ok {
+					issues = append(issues, fmt.Sprintf(
+						"Unique index in '%s' references unknown field '%s'", entity.Name, field))
+				}
+			}
+		}
+
+		for _, name := range entity.RelationNames() {
+			rel := entity.Relations[name]
+
+			target := s.GetEntity(rel.TargetEntity)
+			if target == nil {
+				issues = append(issues, fmt.Sprintf(
+					"Entity '%s' references unknown entity '%s' in relation '%s'",
+					entity.Name, rel.TargetEntity, name))
+				continue
+			}
+
+			if rel.ForeignKey != nil {
+				if _, ok := target.Fields[*rel.ForeignKey]; !ok {
+					issues = append(issues, fmt.Sprintf(
+						"Relation '%s' in '%s' references foreign key '%s', but '%s' has no such field",
+						name, entity.Name, *rel.ForeignKey, target.Name))
+				}
+			}
+
+			if rel.Kind == RelationHasMany && rel.ForeignKey == nil {
+				issues = append(issues, fmt.Sprintf(
+					"HasMany relation '%s' in '%s' requires a 'via' foreign key",
+					name, entity.Name))
+			}
+
+			if rel.Through != nil && s.GetEntity(*rel.Through) == nil {
+				issues = append(issues, fmt.Sprintf(
+					"Relation '%s' in '%s' goes through unknown entity '%s'",
+					name, entity.Name, *rel.Through))
+			}
+		}
+	}
+
+	if len(issues) > 0 {
+		return &SchemaError{Issues: issues}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// targetSet holds the named database targets of an engine. It is shared
+// by the engine and every view returned by On.
+type targetSet struct {
+	mu      sync.RWMutex
+	targets map[string]*target
+}
+
+type target struct {
+	connector *Connector
+	executor  *Executor
+}
+
+// ConnectTarget connects to an additional database and registers it under
+// name, for deployments that shard tenants across databases. Route an
+// operation to it with On. Connecting a name again replaces the previous
+// target and closes its pool.
+//
+//	err := eng.ConnectTarget(ctx, "shard1", engine.ConnectorConfig{...})
+//
+// Register targets at startup, before the engine is shared between
+// goroutines.
+func (e *Engine) ConnectTarget(ctx context.Context, name string, config ConnectorConfig) error {
+	if name == "" {
+		return fmt.Errorf("target name must not be empty")
+	}
+
+	connector := NewConnector(config)
+	if err := connector.Connect(ctx); err != nil {
+		return fmt.Errorf("target %s: %w", name, err)
+	}
+
+	if e.targets == nil {
+		e.targets = &targetSet{targets: make(map[string]*target)}
+	}
+	e.targets.mu.Lock()
+	previous := e.targets.targets[name]
+	e.targets.targets[name] = &target{connector: connector, executor: NewExecutor(connector)}
+	e.targets.mu.Unlock()
+
+	if previous != nil {
+		previous.connector.Close()
+	}
+	return nil
+}
+
+// On returns a view of the engine whose queries and mutations run against
+// the named target instead of the default connection. The view shares the
+// schema and settings the engine has when On is called.
+//
+//	users, err := eng.On(shardFor(tenant)).Query("User").Execute(ctx)
+//	_, err = eng.On("shard1").Insert("User").Set("email", email).Execute(ctx)
+//
+// An unknown name is reported when the query or mutation runs.
+func (e *Engine) On(name string) *Engine {
+	view := *e
+	view.connector = nil
+	view.executor = nil
+	view.targetErr = nil
+
+	var t *target
+	if e.targets != nil {
+		e.targets.mu.RLock()
+		t = e.targets.targets[name]
+		e.targets.mu.RUnlock()
+	}
+	if t == nil {
+		view.targetErr = &UnknownTargetError{Target: name, Available: e.TargetNames()}
+		return &view
+	}
+
+	view.connector = t.connector
+	view.executor = t.executor
+	return &view
+}
+
+// TargetNames lists the registered targets, sorted
+func (e *Engine) TargetNames() []string {
+	if e.targets == nil {
+		return nil
+	}
+	e.targets.mu.RLock()
+	defer e.targets.mu.RUnlock()
+	return sortedTargetNames(e.targets.targets)
+}
+
+// UnknownTargetError is returned by operations on a view from On whose
+// target was never registered with ConnectTarget
+type UnknownTargetError struct {
+	Target    string
+	Available []string
+}
+
+func (e *UnknownTargetError) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf("unknown database target %q: no targets registered (use ConnectTarget)", e.Target)
+	}
+	return fmt.Sprintf("unknown database target %q (available: %v)", e.Target, e.Available)
+}
+
+// connectionErr reports why the engine cannot run operations, or nil
+func (e *Engine) connectionErr() error {
+	if e.targetErr != nil {
+		return e.targetErr
+	}
+	if e.connector == nil {
+		return fmt.Errorf("not connected - call Connect() first")
+	}
+	return nil
+}
+
+// connectors returns the default connection and every target, each once
+func (e *Engine) connectors() []*Connector {
+	var all []*Connector
+	seen := make(map[*Connector]bool)
+	add := func(c *Connector) {
+		if c != nil && !seen[c] {
+			seen[c] = true
+			all = append(all, c)
+		}
+	}
+
+	add(e.connector)
+	if e.targets != nil {
+		e.targets.mu.RLock()
+		for _, name := range sortedTargetNames(e.targets.targets) {
+			add(e.targets.targets[name].connector)
+		}
+		e.targets.mu.RUnlock()
+	}
+	return all
+}
+
+func sortedTargetNames(targets map[string]*target) []string {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// shutdownAll drains and closes every connection, joining drain errors
+func shutdownAll(ctx context.Context, connectors []*Connector) error {
+	var errs []error
+	for _, c := range connectors {
+		if err := c.Drain(ctx); err != nil {
+			errs = append(errs, err)
+		}
+		c.Close()
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withTargets registers unconnected connectors as targets, which is
+// enough to check routing without a database
+func withTargets(eng *Engine, names ...string) map[string]*Connector {
+	eng.targets = &targetSet{targets: make(map[string]*target)}
+	connectors := make(map[string]*Connector)
+	for _, name := range names {
+		c := NewConnector(DefaultConfig())
+		eng.targets.targets[name] = &target{connector: c, executor: NewExecutor(c)}
+		connectors[name] = c
+	}
+	return connectors
+}
+
+func TestEngineOn(t *testing.T) {
+	eng := NewEngineWithoutSchema()
+	connectors := withTargets(eng, "shard2", "shard1")
+
+	view := eng.On("shard1")
+	if view.Connector() != connectors["shard1"] || view.executor == nil {
+		t.Error("view should run on the shard1 connector")
+	}
+	if eng.Connector() != nil {
+		t.Error("On should not change the engine's own connection")
+	}
+	if view.Schema() != eng.Schema() || view.Debug != eng.Debug {
+		t.Error("view should share the engine's schema and settings")
+	}
+
+	if got, want := eng.TargetNames(), []string{"shard1", "shard2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TargetNames() = %v, want %v", got, want)
+	}
+}
+
+func TestEngineOnUnknownTarget(t *testing.T) {
+	eng := NewEngineWithoutSchema()
+	eng.schema = &Schema{}
+	withTargets(eng, "shard1")
+	ctx := context.Background()
+
+	var unknown *UnknownTargetError
+	if _, err := eng.On("shard9").Query("User").Execute(ctx); !errors.As(err, &unknown) {
+		t.Fatalf("expected UnknownTargetError from Query, got %v", err)
+	}
+	if unknown.Target != "shard9" || !reflect.DeepEqual(unknown.Available, []string{"shard1"}) {
+		t.Errorf("unexpected error details: %+v", unknown)
+	}
+
+	if _, err := eng.On("shard9").Insert("User").Set("email", "a@mail.com").Execute(ctx); !errors.As(err, &unknown) {
+		t.Errorf("expected UnknownTargetError from Insert, got %v", err)
+	}
+	if _, err := eng.On("shard9").Query("User").Paginate(ctx, 1, 20); !errors.As(err, &unknown) {
+		t.Errorf("expected UnknownTargetError from Paginate, got %v", err)
+	}
+
+	// Without any targets the message says how to register one
+	_, err := NewEngineWithoutSchema().On("shard1").Query("User").Execute(ctx)
+	if err == nil || !strings.Contains(err.Error(), "use ConnectTarget") {
+		t.Errorf("expected a hint to use ConnectTarget, got %v", err)
+	}
+}
+
+func TestEngineConnectTargetFailure(t *testing.T) {
+	config := DefaultConfig()
+	config.Host = "127.0.0.1"
+	config.Port = 1 // nothing listens here
+	config.ReadyTimeout = time.Second
+
+	eng := NewEngineWithoutSchema()
+	err := eng.ConnectTarget(context.Background(), "shard1", config)
+	if err == nil || !strings.HasPrefix(err.Error(), "target shard1: ") {
+		t.Fatalf("expected a connection error naming the target, got %v", err)
+	}
+	if names := eng.TargetNames(); len(names) != 0 {
+		t.Errorf("a failed target should not be registered, got %v", names)
+	}
+}
+
+func TestEngineShutdownClosesTargets(t *testing.T) {
+	eng := NewEngineWithoutSchema()
+	eng.connector = NewConnector(DefaultConfig())
+	connectors := withTargets(eng, "shard1", "shard2")
+
+	if err := eng.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	for name, c := range map[string]*Connector{"default": eng.connector, "shard1": connectors["shard1"], "shard2": connectors["shard2"]} {
+		if _, err := c.BeginOperation(); !errors.Is(err, ErrShuttingDown) {
+			t.Errorf("%s should be shut down, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type tenantKey struct{}
+
+// WithTenant returns a context whose queries and mutations on @tenant
+// entities are scoped to tenant: queries, updates and deletes only see its
+// rows, and inserts store it in the tenant column.
+//
+//	ctx = engine.WithTenant(ctx, orgID)
+//	docs, err := eng.Query("Document").Execute(ctx) // WHERE org_id = orgID
+//
+// Operations on @tenant entities fail without a tenant in the context,
+// unless the builder opts out with AllTenants().
+func WithTenant(ctx context.Context, tenant interface{}) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set with WithTenant
+func TenantFromContext(ctx context.Context) (interface{}, bool) {
+	tenant := ctx.Value(tenantKey{})
+	return tenant, tenant != nil
+}
+
+// TenantScope is the row filter applied to an operation on a @tenant entity
+type TenantScope struct {
+	Column string
+	Value  interface{}
+}
+
+// ResolveTenantScope returns the scope of an operation on ent: nil when
+// the entity is not tenant-scoped or allTenants is set, and a
+// *TenantScopeError when the context carries no tenant.
+func ResolveTenantScope(ctx context.Context, ent *Entity, operation string, allTenants bool) (*TenantScope, error) {
+	if ent == nil || ent.Tenant == "" || allTenants {
+		return nil, nil
+	}
+
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return nil, &TenantScopeError{
+			Operation: operation,
+			Entity:    ent.Name,
+			Column:    ent.Tenant,
+			Message:   "no tenant in context (use engine.WithTenant, or AllTenants() for cross-tenant access)",
+		}
+	}
+	if id, ok := tenant.([16]byte); ok {
+		tenant = uuidToString(id)
+	}
+	return &TenantScope{Column: ent.Tenant, Value: tenant}, nil
+}
+
+// Matches reports whether value belongs to the scoped tenant. Values are
+// compared by their text form, so a UUID matches its string.
+func (s *TenantScope) Matches(value interface{}) bool {
+	if id, ok := value.([16]byte); ok {
+		value = uuidToString(id)
+	}
+	if reflect.DeepEqual(value, s.Value) {
+		return true
+	}
+	return fmt.Sprint(value) == fmt.Sprint(s.Value)
+}
+
+// TenantScopeError is returned when an operation on a @tenant entity has
+// no tenant to scope it, or would write rows of another tenant
+type TenantScopeError struct {
+	Operation string
+	Entity    string
+	Column    string
+	Message   string
+}
+
+func (e *TenantScopeError) Error() string {
+	return fmt.Sprintf("%s on %s (scoped by %s): %s", e.Operation, e.Entity, e.Column, e.Message)
+}
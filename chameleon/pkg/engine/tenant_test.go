@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func tenantTestSchema() *Schema {
+	planFK, documentFK := "plan_id", "document_id"
+	return &Schema{Entities: []*Entity{
+		{Name: "Document", Tenant: "org_id", Fields: map[string]*Field{
+			"id":     {Name: "id", Type: FieldType{Kind: "UUID"}, PrimaryKey: true},
+			"org_id": {Name: "org_id", Type: FieldType{Kind: "UUID"}},
+		}, Relations: map[string]*Relation{
+			"comments": {Name: "comments", Kind: RelationHasMany, TargetEntity: "Comment", ForeignKey: &documentFK},
+		}},
+		{Name: "Comment", Tenant: "org_id", Fields: map[string]*Field{
+			"id":          {Name: "id", Type: FieldType{Kind: "UUID"}, PrimaryKey: true},
+			"document_id": {Name: "document_id", Type: FieldType{Kind: "UUID"}},
+			"org_id":      {Name: "org_id", Type: FieldType{Kind: "UUID"}},
+		}},
+		{Name: "Plan", Relations: map[string]*Relation{
+			"documents": {Name: "documents", Kind: RelationHasMany, TargetEntity: "Document", ForeignKey: &planFK},
+		}},
+	}}
+}
+
+func TestResolveTenantScope(t *testing.T) {
+	schema := tenantTestSchema()
+	doc := schema.GetEntity("Document")
+	ctx := context.Background()
+
+	if scope, err := ResolveTenantScope(ctx, schema.GetEntity("Plan"), OperationSelect, false); scope != nil || err != nil {
+		t.Errorf("unscoped entity should have no scope, got %v %v", scope, err)
+	}
+	if scope, err := ResolveTenantScope(ctx, doc, OperationSelect, true); scope != nil || err != nil {
+		t.Errorf("allTenants should lift the scope, got %v %v", scope, err)
+	}
+
+	var scopeErr *TenantScopeError
+	_, err := ResolveTenantScope(ctx, doc, OperationUpdate, false)
+	if !errors.As(err, &scopeErr) || scopeErr.Operation != OperationUpdate || scopeErr.Column != "org_id" {
+		t.Fatalf("expected TenantScopeError without a tenant, got %v", err)
+	}
+
+	id := [16]byte{1}
+	scope, err := ResolveTenantScope(WithTenant(ctx, id), doc, OperationSelect, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scope.Column != "org_id" || scope.Value != "01000000-0000-0000-0000-000000000000" {
+		t.Errorf("UUID tenants should be scoped by their string, got %+v", scope)
+	}
+	if !scope.Matches(id) || !scope.Matches("01000000-0000-0000-0000-000000000000") || scope.Matches([16]byte{2}) {
+		t.Error("Matches should compare tenants by their text form")
+	}
+}
+
+func TestQueryBuilder_TenantScope(t *testing.T) {
+	eng := NewEngineWithoutSchema()
+	eng.schema = tenantTestSchema()
+	ctx := WithTenant(context.Background(), "acme")
+
+	qb := eng.Query("Document").Filter("id", "eq", "doc-1")
+	scoped, err := qb.withTenant(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scoped.query.Filters) != 2 || len(qb.query.Filters) != 1 {
+		t.Fatalf("expected the tenant filter on a copy, got %d (original %d)", len(scoped.query.Filters), len(qb.query.Filters))
+	}
+	cond := scoped.query.Filters[1].Condition
+	if cond == nil || cond.Op != goOpToRust("eq") || !reflect.DeepEqual(cond.Value, goValueToFilter("acme")) {
+		t.Errorf("unexpected tenant filter: %+v", scoped.query.Filters[1])
+	}
+
+	// Scoping twice (Execute after Paginate's Count) adds one filter
+	again, _ := scoped.withTenant(ctx)
+	if len(again.query.Filters) != 2 {
+		t.Errorf("withTenant should be idempotent, got %d filters", len(again.query.Filters))
+	}
+
+	all, _ := eng.Query("Document").AllTenants().withTenant(context.Background())
+	if len(all.query.Filters) != 0 {
+		t.Errorf("AllTenants should not add a filter, got %+v", all.query.Filters)
+	}
+
+	// An unconnected executor is enough: the scope is checked first
+	eng.executor = NewExecutor(NewConnector(DefaultConfig()))
+	var scopeErr *TenantScopeError
+	if _, err := eng.Query("Document").Execute(context.Background()); !errors.As(err, &scopeErr) {
+		t.Errorf("expected TenantScopeError from Execute without a tenant, got %v", err)
+	}
+}
+
+func TestQueryBuilder_TenantScopesRelations(t *testing.T) {
+	eng := NewEngineWithoutSchema()
+	eng.schema = tenantTestSchema()
+	ctx := WithTenant(context.Background(), "acme")
+	tenant := FilterExpr{Condition: &FilterCondition{
+		Field: FieldPath{Segments: []string{"org_id"}},
+		Op:    goOpToRust("eq"),
+		Value: goValueToFilter("acme"),
+	}}
+	byID := FilterExpr{Condition: &FilterCondition{
+		Field: FieldPath{Segments: []string{"id"}},
+		Op:    goOpToRust("eq"),
+		Value: goValueToFilter("doc-1"),
+	}}
+
+	t.Run("relation filter", func(t *testing.T) {
+		scoped, err := eng.Query("Plan").Filter("documents.id", "eq", "doc-1").withTenant(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		binary := scoped.query.Filters[0].Binary
+		if len(scoped.query.Filters) != 1 || binary == nil || binary.Op != "And" ||
+			!reflect.DeepEqual(binary.Right.Condition.Field.Segments, []string{"documents", "org_id"}) {
+			t.Errorf("expected the relation filter ANDed with documents.org_id, got %+v", scoped.query.Filters)
+		}
+	})
+
+	t.Run("where has", func(t *testing.T) {
+		for _, qb := range []*QueryBuilder{
+			eng.Query("Plan").WhereHas("documents", func(q *QueryBuilder) { q.Filter("id", "eq", "doc-1") }),
+			eng.Query("Plan").WhereDoesntHave("documents", func(q *QueryBuilder) { q.Filter("id", "eq", "doc-1") }),
+		} {
+			scoped, err := qb.withTenant(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			exists := scoped.query.Filters[0].Exists
+			if !reflect.DeepEqual(exists.Filters, []FilterExpr{byID, tenant}) {
+				t.Errorf("expected the subquery limited to the tenant, got %+v", exists.Filters)
+			}
+			if len(qb.query.Filters[0].Exists.Filters) != 1 {
+				t.Error("withTenant should not change the original builder")
+			}
+		}
+	})
+
+	t.Run("nested where has", func(t *testing.T) {
+		scoped, err := eng.Query("Plan").WhereHas("documents", func(q *QueryBuilder) {
+			q.WhereHas("comments", nil)
+		}).withTenant(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		inner := scoped.query.Filters[0].Exists.Filters[0].Exists
+		if inner == nil || !reflect.DeepEqual(inner.Filters, []FilterExpr{tenant}) {
+			t.Errorf("expected the nested subquery limited to the tenant, got %+v", scoped.query.Filters)
+		}
+	})
+
+	t.Run("with count", func(t *testing.T) {
+		scoped, err := eng.Query("Plan").WithCount("documents").withTenant(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(scoped.query.Counts[0].Filters, []FilterExpr{tenant}) {
+			t.Errorf("expected the count limited to the tenant, got %+v", scoped.query.Counts)
+		}
+	})
+
+	t.Run("order by relation", func(t *testing.T) {
+		scoped, err := eng.Query("Plan").OrderByRelation("documents", "count", "", "desc").OrderBy("id", "asc").withTenant(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(scoped.query.OrderBy[0].Filters, []FilterExpr{tenant}) || scoped.query.OrderBy[1].Filters != nil {
+			t.Errorf("expected only the aggregate limited to the tenant, got %+v", scoped.query.OrderBy)
+		}
+	})
+
+	t.Run("include", func(t *testing.T) {
+		scoped, err := eng.Query("Plan").Include("documents.comments").withTenant(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, path := range []string{"documents", "documents.comments"} {
+			if scope := scoped.IncludeScope(path); scope == nil || scope.Value != "acme" {
+				t.Errorf("expected include %s scoped to acme, got %+v", path, scope)
+			}
+		}
+
+		eager, err := scopeEagerQueries([][]string{
+			{"documents", "SELECT id, org_id\nFROM documents\nWHERE plan_id IN ($PARENT_IDS)"},
+		}, scoped.IncludeScope)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "SELECT id, org_id\nFROM documents\nWHERE plan_id IN ($PARENT_IDS)\nAND org_id = 'acme'"; eager[0][1] != want {
+			t.Errorf("eager query = %q, want %q", eager[0][1], want)
+		}
+	})
+
+	t.Run("no tenant", func(t *testing.T) {
+		var scopeErr *TenantScopeError
+		for _, qb := range []*QueryBuilder{
+			eng.Query("Plan").Filter("documents.id", "eq", "doc-1"),
+			eng.Query("Plan").WhereHas("documents", nil),
+			eng.Query("Plan").WithCount("documents"),
+			eng.Query("Plan").OrderByRelation("documents", "count", "", "desc"),
+			eng.Query("Plan").Include("documents"),
+		} {
+			if _, err := qb.withTenant(context.Background()); !errors.As(err, &scopeErr) || scopeErr.Entity != "Document" {
+				t.Errorf("expected TenantScopeError for Document, got %v", err)
+			}
+			if _, err := qb.AllTenants().withTenant(context.Background()); err != nil {
+				t.Errorf("AllTenants should lift the scope, got %v", err)
+			}
+		}
+	})
+}
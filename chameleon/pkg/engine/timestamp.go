@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// timestampLayouts are the string forms accepted for Timestamp fields,
+// tried in order. Fractional seconds are accepted after the seconds of
+// any layout. Forms without a zone are read as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// CoerceFieldValue converts a string value of a Timestamp field to a
+// time.Time, so filters and writes compare instants instead of text. A
+// slice is converted element by element (for "in", "nin" and "between"). Other fields and
+// values are returned unchanged; a string that is not a date or
+// date-time returns a *FieldFormatError.
+func CoerceFieldValue(field *Field, name string, value interface{}) (interface{}, error) {
+	if field == nil || field.Type.Kind != FieldTypeTimestamp.Kind {
+		return value, nil
+	}
+
+	if s, ok := value.(string); ok {
+		return parseTimestamp(name, s)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return value, nil
+	}
+	times := make([]time.Time, rv.Len())
+	for i := range times {
+		switch item := rv.Index(i).Interface().(type) {
+		case time.Time:
+			times[i] = item
+		case string:
+			t, err := parseTimestamp(name, item)
+			if err != nil {
+				return nil, err
+			}
+			times[i] = t
+		default:
+			// Not a list of dates; leave it for the database to judge
+			return value, nil
+		}
+	}
+	return times, nil
+}
+
+// ParseTimestamp reads s in one of the forms accepted for Timestamp
+// fields. ok is false when s is not a date or date-time.
+func ParseTimestamp(s string) (t time.Time, ok bool) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseTimestamp(name, s string) (time.Time, error) {
+	if t, ok := ParseTimestamp(s); ok {
+		return t, nil
+	}
+	return time.Time{}, &FieldFormatError{
+		Field:      name,
+		Format:     "timestamp",
+		Value:      s,
+		Suggestion: fmt.Sprintf("Use a date (%q), a date-time (%q) or RFC 3339 (%q), or pass a time.Time", "2024-01-31", "2024-01-31 14:30:00", "2024-01-31T14:30:00Z"),
+	}
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCoerceFieldValue(t *testing.T) {
+	ts := &Field{Name: "created_at", Type: FieldTypeTimestamp}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2024-01-31", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-31 14:30", time.Date(2024, 1, 31, 14, 30, 0, 0, time.UTC)},
+		{"2024-01-31 14:30:05", time.Date(2024, 1, 31, 14, 30, 5, 0, time.UTC)},
+		{"2024-01-31T14:30:05.25", time.Date(2024, 1, 31, 14, 30, 5, 250000000, time.UTC)},
+		{"2024-01-31T14:30:05+02:00", time.Date(2024, 1, 31, 12, 30, 5, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := CoerceFieldValue(ts, "created_at", tt.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.input, err)
+			continue
+		}
+		if parsed, ok := got.(time.Time); !ok || !parsed.Equal(tt.want) {
+			t.Errorf("%q = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	_, err := CoerceFieldValue(ts, "created_at", "31/01/2024")
+	var format *FieldFormatError
+	if !errors.As(err, &format) || format.Field != "created_at" || format.Format != "timestamp" {
+		t.Errorf("expected a FieldFormatError, got %v", err)
+	}
+
+	list, err := CoerceFieldValue(ts, "created_at", []string{"2024-01-01", "2024-02-01"})
+	if times, ok := list.([]time.Time); err != nil || !ok || len(times) != 2 {
+		t.Errorf("a list of dates should become []time.Time, got %v (%v)", list, err)
+	}
+
+	// Other fields and non-string values are left alone
+	if got, _ := CoerceFieldValue(&Field{Type: FieldTypeString}, "name", "2024-01-31"); got != "2024-01-31" {
+		t.Errorf("String field value changed to %v", got)
+	}
+	if got, _ := CoerceFieldValue(ts, "created_at", 1706659200); got != 1706659200 {
+		t.Errorf("non-string value changed to %v", got)
+	}
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Tx is a transaction on the engine's database. Queries and mutations
+// executed with the context from Context run inside it, and PgxTx gives
+// raw pgx access to the same transaction.
+//
+//	err := eng.Transaction(ctx, func(ctx context.Context, tx *engine.Tx) error {
+//		if _, err := eng.Insert("Order").Set("user_id", userID).Execute(ctx); err != nil {
+//			return err
+//		}
+//		_, err := tx.PgxTx().Exec(ctx, "NOTIFY orders")
+//		return err
+//	})
+type Tx struct {
+	connector *Connector
+	tx        pgx.Tx
+}
+
+// Begin starts a transaction on the engine's connection (or its target,
+// for a view from On). Finish it with Commit or Rollback; prefer
+// Transaction, which does so for you.
+func (e *Engine) Begin(ctx context.Context) (*Tx, error) {
+	if err := e.connectionErr(); err != nil {
+		return nil, err
+	}
+	if !e.connector.IsConnected() {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	tx, err := e.connector.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	return &Tx{connector: e.connector, tx: tx}, nil
+}
+
+// Transaction runs fn in a transaction and commits it when fn returns
+// nil. An error from fn, or a panic, rolls it back. Builders must be
+// executed with the ctx passed to fn to join the transaction.
+func (e *Engine) Transaction(ctx context.Context, fn func(ctx context.Context, tx *Tx) error) error {
+	tx, err := e.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	return tx.run(ctx, fn)
+}
+
+// run calls fn with tx's context and commits, or rolls back when fn
+// fails or panics
+func (t *Tx) run(ctx context.Context, fn func(ctx context.Context, tx *Tx) error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			_ = t.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(t.Context(ctx), t); err != nil {
+		if rbErr := t.Rollback(ctx); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback failed: %w", rbErr))
+		}
+		return err
+	}
+	return t.Commit(ctx)
+}
+
+// Context returns ctx carrying the transaction. Queries and mutations
+// executed with it send their statements through the transaction, on
+// the engine the transaction was started from; mutation timeouts then
+// bound the context only, since statement_timeout cannot be scoped to
+// one statement of a shared transaction.
+func (t *Tx) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, txKey{}, t)
+}
+
+// PgxTx returns the underlying pgx transaction, for what the builders do
+// not cover: COPY, NOTIFY, hand-written SQL. Statements sent through it
+// skip validation, authorization hooks and the journal; identifiers,
+// parameters and error handling are the caller's responsibility. Leave
+// Commit and Rollback to Tx.
+func (t *Tx) PgxTx() pgx.Tx {
+	return t.tx
+}
+
+// Commit commits the transaction
+func (t *Tx) Commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+// Rollback rolls the transaction back. It is a no-op after Commit, so it
+// can be deferred.
+func (t *Tx) Rollback(ctx context.Context) error {
+	if err := t.tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeTx records how a transaction was finished
+type fakeTx struct {
+	pgx.Tx
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if tx.committed {
+		return pgx.ErrTxClosed
+	}
+	tx.rolledBack = true
+	return nil
+}
+
+func TestBeginRequiresConnection(t *testing.T) {
+	if _, err := NewEngineWithoutSchema().Begin(context.Background()); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected a not connected error, got %v", err)
+	}
+	if err := NewEngineWithoutSchema().Transaction(context.Background(), func(context.Context, *Tx) error { return nil }); err == nil {
+		t.Error("Transaction should fail without a connection")
+	}
+}
+
+func TestTxContextRoutesStatements(t *testing.T) {
+	connector := NewConnector(DefaultConfig())
+	other := NewConnector(DefaultConfig())
+	pgxTx := &fakeTx{}
+	tx := &Tx{connector: connector, tx: pgxTx}
+	ctx := tx.Context(context.Background())
+
+	if got := connector.Querier(ctx); got != Querier(pgxTx) {
+		t.Errorf("Querier should return the transaction, got %T", got)
+	}
+	if tx.PgxTx() != pgx.Tx(pgxTx) {
+		t.Error("PgxTx should return the underlying transaction")
+	}
+	// Another database does not join the transaction
+	if other.tx(ctx) != nil {
+		t.Error("the transaction leaked to another connector")
+	}
+
+	// Inside the transaction no transaction of its own is opened
+	err := connector.RunWithTimeout(ctx, time.Minute, func(opCtx context.Context) error {
+		if connector.Querier(opCtx) != Querier(pgxTx) {
+			t.Error("the operation should run in the caller's transaction")
+		}
+		if _, ok := opCtx.Deadline(); !ok {
+			t.Error("the timeout should still bound the operation")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunWithTimeout() error = %v", err)
+	}
+
+	joined := false
+	err = connector.RunInTx(ctx, func(opCtx context.Context) error {
+		joined = connector.Querier(opCtx) == Querier(pgxTx)
+		return nil
+	})
+	if err != nil || !joined || pgxTx.committed {
+		t.Errorf("RunInTx should join the caller's transaction without committing it (err=%v)", err)
+	}
+	if err := other.RunInTx(context.Background(), func(context.Context) error { return nil }); err == nil {
+		t.Error("RunInTx should fail without a connection")
+	}
+}
+
+func TestTxRun(t *testing.T) {
+	pgxTx := &fakeTx{}
+	tx := &Tx{connector: NewConnector(DefaultConfig()), tx: pgxTx}
+	err := tx.run(context.Background(), func(ctx context.Context, got *Tx) error {
+		if got != tx || ctx.Value(txKey{}) != tx {
+			t.Error("fn should get the transaction and a context carrying it")
+		}
+		return nil
+	})
+	if err != nil || !pgxTx.committed || pgxTx.rolledBack {
+		t.Errorf("expected a commit, got err=%v committed=%t rolledBack=%t", err, pgxTx.committed, pgxTx.rolledBack)
+	}
+	if err := tx.Rollback(context.Background()); err != nil {
+		t.Errorf("Rollback after Commit should be a no-op, got %v", err)
+	}
+
+	pgxTx = &fakeTx{}
+	tx = &Tx{connector: NewConnector(DefaultConfig()), tx: pgxTx}
+	failure := errors.New("insufficient stock")
+	if err := tx.run(context.Background(), func(context.Context, *Tx) error { return failure }); !errors.Is(err, failure) {
+		t.Errorf("expected fn's error, got %v", err)
+	}
+	if pgxTx.committed || !pgxTx.rolledBack {
+		t.Errorf("expected a rollback, got committed=%t rolledBack=%t", pgxTx.committed, pgxTx.rolledBack)
+	}
+
+	pgxTx = &fakeTx{}
+	tx = &Tx{connector: NewConnector(DefaultConfig()), tx: pgxTx}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("the panic should propagate")
+			}
+		}()
+		_ = tx.run(context.Background(), func(context.Context, *Tx) error { panic("boom") })
+	}()
+	if !pgxTx.rolledBack {
+		t.Error("a panic should roll the transaction back")
+	}
+}
@@ -38,11 +38,26 @@ import (
 // VALIDATOR CONFIG
 // ============================================================
 
+// ValidatorConfig tunes the Go-side checks for one mutation. Relaxing a
+// check moves it to the database, which still enforces column types and
+// foreign keys, only with less specific errors.
 type ValidatorConfig struct {
+	// StrictTypes checks values against their field types (UUID format,
+	// strings for String fields) before the mutation is sent
 	StrictTypes bool
-	ValidateFK  bool
+
+	// ValidateFK checks foreign key values against the type of the
+	// primary key they reference
+	ValidateFK bool
+
+	// CoerceStrings converts strings sent to Int, Float, Decimal and Bool
+	// fields ("25", "19.99", "true"), as web forms deliver every value as
+	// text. A string that is not a valid value of the type is rejected.
+	// Off by default.
+	CoerceStrings bool
 }
 
+// DefaultValidatorConfig enables every check
 func DefaultValidatorConfig() ValidatorConfig {
 	return ValidatorConfig{
 		StrictTypes: true,
@@ -87,6 +102,9 @@ func (v *Validator) ValidateInsertInput(
 			return err
 		}
 	}
+	if err := v.validateForeignKeys(ent, fields); err != nil {
+		return err
+	}
 
 	return v.validateRequiredFields(ent, fields)
 }
@@ -105,6 +123,10 @@ func (v *Validator) validateInsertField(
 		}
 	}
 
+	if field.GeneratedExpression() != "" {
+		return generatedFieldError(fieldName, value)
+	}
+
 	if err := v.validateFieldType(field, fieldName, value); err != nil {
 		return err
 	}
@@ -182,13 +204,27 @@ func (v *Validator) ValidateUpdateInput(
 				Suggestion: "Primary keys cannot be updated",
 			}
 		}
+		if field.GeneratedExpression() != "" {
+			return generatedFieldError(fieldName, value)
+		}
 
 		if err := v.validateFieldType(field, fieldName, value); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return v.validateForeignKeys(ent, updates)
+}
+
+// generatedFieldError rejects a value for a generated column, which
+// PostgreSQL computes and refuses to store
+func generatedFieldError(fieldName string, value interface{}) error {
+	return &ConstraintError{
+		Type:       "generated",
+		Field:      fieldName,
+		Value:      value,
+		Suggestion: "Generated columns are computed by the database; leave the field out",
+	}
 }
 
 // ============================================================
@@ -216,6 +252,17 @@ func (v *Validator) ValidateDeleteInput(
 		}
 	}
 
+	// Filter fields are interpolated into the WHERE clause
+	for fieldName := range filters {
+		if _, ok := ent.Fields[fieldName]; !ok {
+			return &UnknownFieldError{
+				Entity:    ent.Name,
+				Field:     fieldName,
+				Available: v.getAvailableFields(ent),
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -237,7 +284,15 @@ func (v *Validator) validateFieldType(
 			Suggestion: "This field cannot be null",
 		}
 	}
+	if !v.config.StrictTypes {
+		return nil
+	}
+
+	return checkFieldKind(field, fieldName, value)
+}
 
+// checkFieldKind checks a non-nil value against the field's type
+func checkFieldKind(field *Field, fieldName string, value interface{}) error {
 	switch field.Type.Kind {
 	case "UUID":
 		str, ok := value.(string)
@@ -265,6 +320,36 @@ func (v *Validator) validateFieldType(
 	return nil
 }
 
+// validateForeignKeys checks each foreign key value in fields against the
+// type of the primary key it references, so a malformed reference fails
+// here rather than as a database error
+func (v *Validator) validateForeignKeys(ent *Entity, fields map[string]interface{}) error {
+	if !v.config.ValidateFK {
+		return nil
+	}
+
+	for _, fk := range v.schema.ForeignKeys(ent.Name) {
+		value, ok := fields[fk.Field]
+		if !ok || value == nil {
+			continue
+		}
+
+		referenced := v.schema.GetEntity(fk.ReferencedEntity)
+		if referenced == nil {
+			continue
+		}
+		key := referenced.Fields[fk.ReferencedField]
+		if key == nil {
+			continue
+		}
+
+		if err := checkFieldKind(key, fk.Field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ============================================================
 // FORMAT VALIDATION
 // ============================================================
@@ -301,7 +386,7 @@ func (v *Validator) validateRequiredFields(
 	provided map[string]interface{},
 ) error {
 	for _, field := range ent.Fields {
-		if field.Nullable || field.Default != nil || field.PrimaryKey {
+		if !field.IsRequired() {
 			continue
 		}
 
@@ -320,19 +405,11 @@ func (v *Validator) validateRequiredFields(
 // ============================================================
 
 func (v *Validator) getAvailableFields(ent *Entity) []string {
-	var fields []string
-	for name := range ent.Fields {
-		fields = append(fields, name)
-	}
-	return fields
+	return ent.FieldNames()
 }
 
 func (v *Validator) getAvailableEntities() []string {
-	var entities []string
-	for _, ent := range v.schema.Entities {
-		entities = append(entities, ent.Name)
-	}
-	return entities
+	return v.schema.EntityNames()
 }
 
 func isValidUUID(s string) bool {
@@ -259,6 +259,116 @@ func TestValidateInsertInput_TypeMismatch(t *testing.T) {
 	}
 }
 
+func TestValidateInsertInput_StrictTypesOff(t *testing.T) {
+	schema := getTestSchema()
+	config := DefaultValidatorConfig()
+	config.StrictTypes = false
+	validator := NewValidator(schema, config)
+
+	input := map[string]interface{}{
+		"id":    12345, // not a UUID string
+		"email": "test@mail.com",
+		"name":  42, // should be string
+	}
+	if err := validator.ValidateInsertInput("User", input); err != nil {
+		t.Errorf("Expected type checks to be skipped, got: %v", err)
+	}
+
+	// Required and NOT NULL checks still apply
+	input["name"] = nil
+	if _, ok := validator.ValidateInsertInput("User", input).(*NotNullError); !ok {
+		t.Error("Expected NotNullError with StrictTypes off")
+	}
+}
+
+func TestValidateForeignKeys(t *testing.T) {
+	schema := getTestSchema()
+	userID := "user_id"
+	schema.Entities[0].Relations = map[string]*Relation{
+		"posts": {Name: "posts", Kind: RelationHasMany, TargetEntity: "Post", ForeignKey: &userID},
+	}
+	// Declared loosely as a String, but it references User.id (UUID)
+	schema.Entities[1].Fields["user_id"] = &Field{Name: "user_id", Type: FieldType{Kind: "String"}}
+
+	input := map[string]interface{}{
+		"id":      uuid.New().String(),
+		"title":   "Hello",
+		"user_id": "not-a-uuid",
+	}
+
+	err := NewValidator(schema, DefaultValidatorConfig()).ValidateInsertInput("Post", input)
+	formatErr, ok := err.(*FieldFormatError)
+	if !ok || formatErr.Field != "user_id" || formatErr.Format != "UUID" {
+		t.Errorf("Expected UUID FieldFormatError on user_id, got %v", err)
+	}
+
+	err = NewValidator(schema, DefaultValidatorConfig()).ValidateUpdateInput("Post",
+		map[string]interface{}{"id": uuid.New().String()},
+		map[string]interface{}{"user_id": "not-a-uuid"})
+	if _, ok := err.(*FieldFormatError); !ok {
+		t.Errorf("Expected FieldFormatError on update, got %v", err)
+	}
+
+	config := DefaultValidatorConfig()
+	config.ValidateFK = false
+	if err := NewValidator(schema, config).ValidateInsertInput("Post", input); err != nil {
+		t.Errorf("Expected FK check to be skipped, got: %v", err)
+	}
+
+	input["user_id"] = uuid.New().String()
+	if err := NewValidator(schema, DefaultValidatorConfig()).ValidateInsertInput("Post", input); err != nil {
+		t.Errorf("Expected valid reference to pass, got: %v", err)
+	}
+}
+
+func TestValidateForeignKeysNonIDKey(t *testing.T) {
+	schema := getTestSchema()
+	user := schema.Entities[0]
+	delete(user.Fields, "id")
+	user.Fields["user_uuid"] = &Field{Name: "user_uuid", Type: FieldType{Kind: "UUID"}, PrimaryKey: true}
+	userID := "user_id"
+	user.Relations = map[string]*Relation{
+		"posts": {Name: "posts", Kind: RelationHasMany, TargetEntity: "Post", ForeignKey: &userID},
+	}
+	schema.Entities[1].Fields["user_id"] = &Field{Name: "user_id", Type: FieldType{Kind: "String"}}
+
+	if keys := schema.ForeignKeys("Post"); len(keys) != 1 || keys[0].ReferencedField != "user_uuid" {
+		t.Fatalf("Expected the foreign key to reference User.user_uuid, got %v", keys)
+	}
+
+	input := map[string]interface{}{
+		"id":      uuid.New().String(),
+		"title":   "Hello",
+		"user_id": "not-a-uuid",
+	}
+	err := NewValidator(schema, DefaultValidatorConfig()).ValidateInsertInput("Post", input)
+	if formatErr, ok := err.(*FieldFormatError); !ok || formatErr.Field != "user_id" || formatErr.Format != "UUID" {
+		t.Errorf("Expected UUID FieldFormatError on user_id, got %v", err)
+	}
+}
+
+func TestValidateInput_GeneratedField(t *testing.T) {
+	schema := getTestSchema()
+	var generated interface{} = map[string]interface{}{"Generated": "lower(title)"}
+	schema.GetEntity("Post").Fields["slug"] = &Field{Name: "slug", Type: FieldType{Kind: "String"}, Default: &generated}
+	validator := NewValidator(schema, DefaultValidatorConfig())
+
+	// Generated fields are never required
+	if err := validator.ValidateInsertInput("Post", map[string]interface{}{"title": "Hello"}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	err := validator.ValidateInsertInput("Post", map[string]interface{}{"title": "Hello", "slug": "hello"})
+	if constraintErr, ok := err.(*ConstraintError); !ok || constraintErr.Type != "generated" || constraintErr.Field != "slug" {
+		t.Errorf("Expected generated ConstraintError on insert, got %v", err)
+	}
+
+	err = validator.ValidateUpdateInput("Post", map[string]interface{}{"title": "Hello"}, map[string]interface{}{"slug": "hello"})
+	if constraintErr, ok := err.(*ConstraintError); !ok || constraintErr.Type != "generated" {
+		t.Errorf("Expected generated ConstraintError on update, got %v", err)
+	}
+}
+
 func TestValidateUpdateInput_Success(t *testing.T) {
 	schema := getTestSchema()
 	validator := NewValidator(schema, DefaultValidatorConfig())
@@ -394,6 +504,21 @@ func TestValidateDeleteInput_ForceDeleteAll(t *testing.T) {
 	}
 }
 
+func TestValidateDeleteInput_UnknownFilterField(t *testing.T) {
+	schema := getTestSchema()
+	validator := NewValidator(schema, DefaultValidatorConfig())
+
+	filters := map[string]interface{}{"id = id OR 1=1 --": "x"}
+	err := validator.ValidateDeleteInput("User", filters, false)
+	unknownErr, ok := err.(*UnknownFieldError)
+	if !ok {
+		t.Fatalf("Expected UnknownFieldError, got %T", err)
+	}
+	if unknownErr.Field != "id = id OR 1=1 --" {
+		t.Errorf("Expected the crafted field name, got %s", unknownErr.Field)
+	}
+}
+
 func TestIsValidUUID(t *testing.T) {
 	tests := []struct {
 		name  string
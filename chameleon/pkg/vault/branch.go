@@ -0,0 +1,208 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// DefaultBranch is the branch versions are registered on until another
+// one is created
+const DefaultBranch = "main"
+
+var branchNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// CurrentBranch returns the branch new versions are registered on
+func (m *Manifest) CurrentBranch() string {
+	if m.Branch == "" {
+		return DefaultBranch
+	}
+	return m.Branch
+}
+
+// heads returns the branch heads, creating the map for manifests written
+// before branches existed, whose single lineage is main
+func (m *Manifest) heads() map[string]string {
+	if m.Heads == nil {
+		m.Heads = make(map[string]string)
+		if m.CurrentVersion != "" {
+			m.Heads[DefaultBranch] = m.CurrentVersion
+		}
+	}
+	return m.Heads
+}
+
+// Branches returns the branch names, sorted, and the head of each
+func (v *Vault) Branches() ([]string, map[string]string, error) {
+	if err := v.Load(); err != nil {
+		return nil, nil, err
+	}
+	heads := v.Manifest.heads()
+
+	names := make([]string, 0, len(heads))
+	for name := range heads {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, heads, nil
+}
+
+// CreateBranch starts a branch at the current version and switches to it,
+// so the versions registered next extend the new lineage
+func (v *Vault) CreateBranch(name string) error {
+	if !branchNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	if err := v.Load(); err != nil {
+		return err
+	}
+	heads := v.Manifest.heads()
+	if _, exists := heads[name]; exists {
+		return fmt.Errorf("branch %s already exists", name)
+	}
+	if v.Manifest.CurrentVersion == "" {
+		return fmt.Errorf("no versions registered yet; run 'chameleon migrate' first")
+	}
+
+	heads[name] = v.Manifest.CurrentVersion
+	v.Manifest.Branch = name
+	if err := v.saveManifest(v.Manifest); err != nil {
+		return err
+	}
+	return v.AppendLog("BRANCH", v.Manifest.CurrentVersion, map[string]string{
+		"action": "branch_created",
+		"branch": name,
+	})
+}
+
+// SwitchBranch makes name the current branch and its head the current
+// version
+func (v *Vault) SwitchBranch(name string) error {
+	if err := v.Load(); err != nil {
+		return err
+	}
+	head, ok := v.Manifest.heads()[name]
+	if !ok {
+		return fmt.Errorf("unknown branch %s", name)
+	}
+
+	v.Manifest.Branch = name
+	if name == DefaultBranch {
+		v.Manifest.Branch = ""
+	}
+	v.Manifest.CurrentVersion = head
+	if err := v.saveManifest(v.Manifest); err != nil {
+		return err
+	}
+	return v.AppendLog("CHECKOUT", head, map[string]string{
+		"action": "branch_switched",
+		"branch": name,
+	})
+}
+
+// Ancestors returns version and every version it descends from, through
+// both parents of merge versions
+func (v *Vault) Ancestors(version string) (map[string]bool, error) {
+	if v.Manifest == nil {
+		if err := v.Load(); err != nil {
+			return nil, err
+		}
+	}
+	entries := make(map[string]*VersionEntry, len(v.Manifest.Versions))
+	for i := range v.Manifest.Versions {
+		entries[v.Manifest.Versions[i].Version] = &v.Manifest.Versions[i]
+	}
+
+	ancestors := make(map[string]bool)
+	queue := []string{version}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if ancestors[current] {
+			continue
+		}
+		entry, ok := entries[current]
+		if !ok {
+			return nil, fmt.Errorf("version %s not found", current)
+		}
+		ancestors[current] = true
+		if entry.Parent != nil {
+			queue = append(queue, *entry.Parent)
+		}
+		if entry.MergeParent != nil {
+			queue = append(queue, *entry.MergeParent)
+		}
+	}
+	return ancestors, nil
+}
+
+// MergeBase returns the most recent version both a and b descend from,
+// or "" when their lineages share no version
+func (v *Vault) MergeBase(a, b string) (string, error) {
+	ofA, err := v.Ancestors(a)
+	if err != nil {
+		return "", err
+	}
+	ofB, err := v.Ancestors(b)
+	if err != nil {
+		return "", err
+	}
+
+	// Versions are numbered in registration order, so the latest common
+	// ancestor is the last one in the manifest
+	for i := len(v.Manifest.Versions) - 1; i >= 0; i-- {
+		version := v.Manifest.Versions[i].Version
+		if ofA[version] && ofB[version] {
+			return version, nil
+		}
+	}
+	return "", nil
+}
+
+// FastForward moves the current branch to the head of branch when the
+// current version is one of its ancestors, so no merge version is needed.
+// It reports whether the branch moved.
+func (v *Vault) FastForward(branch string) (bool, error) {
+	if err := v.Load(); err != nil {
+		return false, err
+	}
+	head, ok := v.Manifest.heads()[branch]
+	if !ok {
+		return false, fmt.Errorf("unknown branch %s", branch)
+	}
+	ancestors, err := v.Ancestors(head)
+	if err != nil {
+		return false, err
+	}
+	if v.Manifest.CurrentVersion != "" && !ancestors[v.Manifest.CurrentVersion] {
+		return false, nil
+	}
+
+	v.Manifest.heads()[v.Manifest.CurrentBranch()] = head
+	v.Manifest.CurrentVersion = head
+	if err := v.saveManifest(v.Manifest); err != nil {
+		return false, err
+	}
+	return true, v.AppendLog("MERGE", head, map[string]string{
+		"action": "fast_forward",
+		"branch": branch,
+		"into":   v.Manifest.CurrentBranch(),
+	})
+}
+
+// RegisterMerge registers schemaPath, the reconciled schema, as a merge of
+// branch into the current branch: its parents are the current version and
+// the head of branch.
+func (v *Vault) RegisterMerge(schemaPath, author, branch string) (*VersionEntry, error) {
+	if err := v.Load(); err != nil {
+		return nil, err
+	}
+	head, ok := v.Manifest.heads()[branch]
+	if !ok {
+		return nil, fmt.Errorf("unknown branch %s", branch)
+	}
+	if branch == v.Manifest.CurrentBranch() {
+		return nil, fmt.Errorf("cannot merge branch %s into itself", branch)
+	}
+	return v.registerVersion(schemaPath, author, fmt.Sprintf("Merge branch %s", branch), &head)
+}
@@ -0,0 +1,95 @@
+package vault
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func registerSchema(t *testing.T, v *Vault, content string) *VersionEntry {
+	t.Helper()
+	schemaPath := filepath.Join(v.RootPath, "schema.merged.cham")
+	if err := os.WriteFile(schemaPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := v.RegisterVersion(schemaPath, "test", "change")
+	if err != nil {
+		t.Fatalf("RegisterVersion() error = %v", err)
+	}
+	return entry
+}
+
+func TestVaultBranches(t *testing.T) {
+	v := NewVault(t.TempDir())
+	registerSchema(t, v, "entity User { id: uuid primary, }")
+
+	if err := v.CreateBranch("feature/billing"); err != nil {
+		t.Fatalf("CreateBranch() error = %v", err)
+	}
+	if err := v.CreateBranch("feature/billing"); err == nil {
+		t.Error("creating an existing branch should fail")
+	}
+	feature := registerSchema(t, v, "entity User { id: uuid primary, plan: string, }")
+	if feature.Branch != "feature/billing" || feature.Parent == nil || *feature.Parent != "v001" {
+		t.Errorf("unexpected feature version: branch %q parent %v", feature.Branch, feature.Parent)
+	}
+
+	if err := v.SwitchBranch(DefaultBranch); err != nil {
+		t.Fatalf("SwitchBranch() error = %v", err)
+	}
+	if v.Manifest.CurrentVersion != "v001" {
+		t.Errorf("main should still be at v001, got %s", v.Manifest.CurrentVersion)
+	}
+	main := registerSchema(t, v, "entity User { id: uuid primary, name: string, }")
+	if main.Parent == nil || *main.Parent != "v001" || main.Branch != "" {
+		t.Errorf("unexpected main version: branch %q parent %v", main.Branch, main.Parent)
+	}
+
+	names, heads, err := v.Branches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || heads[DefaultBranch] != "v003" || heads["feature/billing"] != "v002" {
+		t.Errorf("unexpected heads %v", heads)
+	}
+	if base, err := v.MergeBase("v003", "v002"); err != nil || base != "v001" {
+		t.Errorf("MergeBase() = %q, %v, want v001", base, err)
+	}
+
+	// Diverged: no fast-forward, a merge version with both parents
+	if moved, err := v.FastForward("feature/billing"); err != nil || moved {
+		t.Errorf("diverged branches should not fast-forward, got %v, %v", moved, err)
+	}
+	schemaPath := filepath.Join(v.RootPath, "schema.merged.cham")
+	if err := os.WriteFile(schemaPath, []byte("entity User { id: uuid primary, name: string, plan: string, }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	merge, err := v.RegisterMerge(schemaPath, "test", "feature/billing")
+	if err != nil {
+		t.Fatalf("RegisterMerge() error = %v", err)
+	}
+	if merge.Version != "v004" || *merge.Parent != "v003" || merge.MergeParent == nil || *merge.MergeParent != "v002" {
+		t.Errorf("unexpected merge version %+v", merge)
+	}
+	if _, err := v.RegisterMerge(schemaPath, "test", DefaultBranch); err == nil {
+		t.Error("merging a branch into itself should fail")
+	}
+
+	// The feature branch is behind main now and fast-forwards to the merge
+	if err := v.SwitchBranch("feature/billing"); err != nil {
+		t.Fatal(err)
+	}
+	if moved, err := v.FastForward(DefaultBranch); err != nil || !moved {
+		t.Fatalf("expected a fast-forward, got %v, %v", moved, err)
+	}
+	if reloaded := NewVault(v.RootPath); reloaded.Load() != nil || reloaded.Manifest.CurrentVersion != "v004" || reloaded.Manifest.Heads["feature/billing"] != "v004" {
+		t.Errorf("fast-forward should move the branch head to v004, got %+v", reloaded.Manifest.Heads)
+	}
+}
+
+func TestManifestHeadsLegacy(t *testing.T) {
+	m := &Manifest{CurrentVersion: "v003"}
+	if m.CurrentBranch() != DefaultBranch || m.heads()[DefaultBranch] != "v003" {
+		t.Errorf("a manifest without branches should have main at the current version, got %v", m.Heads)
+	}
+}
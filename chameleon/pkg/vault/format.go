@@ -0,0 +1,49 @@
+package vault
+
+import "fmt"
+
+// SchemaFormat is the schema format this build reads and registers. It
+// goes up when schemas gain information an older engine would silently
+// drop; SchemaFormatRequires is the first release that reads it.
+const (
+	SchemaFormat         = 1
+	SchemaFormatRequires = "0.1.0-beta"
+)
+
+// FormatError is returned when a schema was registered or parsed in a
+// newer format than this build reads
+type FormatError struct {
+	Format    int
+	Requires  string // First release reading Format, "" if unknown
+	Supported int
+}
+
+func (e *FormatError) Error() string {
+	if e.Requires == "" {
+		return fmt.Sprintf("schema format v%d requires a newer chameleon (this build reads up to v%d)", e.Format, e.Supported)
+	}
+	return fmt.Sprintf("schema format v%d requires chameleon >= %s (this build reads up to v%d)", e.Format, e.Requires, e.Supported)
+}
+
+// CheckFormat rejects a vault whose current version was registered in a
+// newer schema format. Versions registered before formats were stamped
+// are accepted.
+func (v *Vault) CheckFormat() error {
+	if v.Manifest == nil {
+		if err := v.Load(); err != nil {
+			return err
+		}
+	}
+	if v.Manifest.CurrentVersion == "" {
+		return nil
+	}
+
+	current, err := v.GetCurrentVersion()
+	if err != nil {
+		return err
+	}
+	if current.FormatVersion > SchemaFormat {
+		return &FormatError{Format: current.FormatVersion, Requires: current.FormatRequires, Supported: SchemaFormat}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package vault
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckFormat(t *testing.T) {
+	root := t.TempDir()
+	v := NewVault(root)
+
+	schemaPath := filepath.Join(root, "schema.merged.cham")
+	if err := os.WriteFile(schemaPath, []byte("entity User {\n    id: uuid primary,\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := v.RegisterVersion(schemaPath, "test", "Initial schema")
+	if err != nil {
+		t.Fatalf("RegisterVersion() error = %v", err)
+	}
+	if entry.FormatVersion != SchemaFormat || entry.FormatRequires != SchemaFormatRequires {
+		t.Errorf("version should be stamped with the schema format, got %d %q", entry.FormatVersion, entry.FormatRequires)
+	}
+	if err := NewVault(root).CheckFormat(); err != nil {
+		t.Fatalf("CheckFormat() error = %v", err)
+	}
+
+	// A version registered by a newer chameleon is refused
+	v.Manifest.Versions[0].FormatVersion = SchemaFormat + 1
+	v.Manifest.Versions[0].FormatRequires = "9.0.0"
+	if err := v.saveManifest(v.Manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewVault(root).CheckFormat()
+	var formatErr *FormatError
+	if !errors.As(err, &formatErr) || formatErr.Format != SchemaFormat+1 || formatErr.Supported != SchemaFormat {
+		t.Fatalf("expected FormatError, got %v", err)
+	}
+	if want := "requires chameleon >= 9.0.0"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error should name the release to upgrade to, got %q", err)
+	}
+}
@@ -65,6 +65,83 @@ func (v *Vault) VerifyIntegrity() (*VerificationResult, error) {
 	return result, nil
 }
 
+// Working tree schema states reported by FullVerify
+const (
+	SchemaFileMissing      = "missing"      // no schema file at the given path
+	SchemaFileMatches      = "matches"      // same hash as the current version
+	SchemaFileModified     = "modified"     // changed since the current version
+	SchemaFileUnregistered = "unregistered" // no version registered to compare with
+)
+
+// VersionCheck is the integrity result of one registered version
+type VersionCheck struct {
+	Version string
+	OK      bool
+	Issue   string // Why the check failed; "" when OK
+}
+
+// VerifyReport is the structured result of FullVerify. Valid only covers
+// vault integrity: a modified schema file is reported in SchemaFile but
+// is not an issue.
+type VerifyReport struct {
+	Valid          bool
+	ManifestError  string // Set when the manifest cannot be loaded; nothing else is checked
+	Versions       []VersionCheck
+	Issues         []string
+	CurrentVersion string
+	SchemaPath     string
+	SchemaFile     string // One of the SchemaFile* states
+}
+
+// FullVerify runs every integrity check of 'chameleon verify': the
+// manifest loads, each version's snapshot matches its stored and manifest
+// hashes, and schemaPath is compared with the current version. It only
+// fails when there is no vault; problems found are part of the report.
+func (v *Vault) FullVerify(schemaPath string) (*VerifyReport, error) {
+	if !v.Exists() {
+		return nil, fmt.Errorf("no vault found; run 'chameleon migrate' to initialize")
+	}
+
+	report := &VerifyReport{Issues: []string{}, SchemaPath: schemaPath}
+	if err := v.Load(); err != nil {
+		report.ManifestError = err.Error()
+		report.Issues = append(report.Issues, fmt.Sprintf("failed to load manifest: %v", err))
+		return report, nil
+	}
+
+	for _, entry := range v.Manifest.Versions {
+		check := VersionCheck{Version: entry.Version, OK: true}
+		if err := v.verifyVersion(&entry); err != nil {
+			check.OK = false
+			check.Issue = err.Error()
+			report.Issues = append(report.Issues, fmt.Sprintf("%s: %v", entry.Version, err))
+		}
+		report.Versions = append(report.Versions, check)
+	}
+	report.Valid = len(report.Issues) == 0
+
+	report.CurrentVersion = v.Manifest.CurrentVersion
+	switch {
+	case !fileExists(schemaPath):
+		report.SchemaFile = SchemaFileMissing
+	case report.CurrentVersion == "":
+		report.SchemaFile = SchemaFileUnregistered
+	default:
+		report.SchemaFile = SchemaFileModified
+		current, _ := v.GetCurrentVersion()
+		hash, err := v.ComputeSchemaHash(schemaPath)
+		if current != nil && err == nil && hash == current.Hash {
+			report.SchemaFile = SchemaFileMatches
+		}
+	}
+	return report, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // verifyVersion verifies a single version's integrity
 func (v *Vault) verifyVersion(entry *VersionEntry) error {
 	vaultPath := filepath.Join(v.RootPath, VaultDirName)
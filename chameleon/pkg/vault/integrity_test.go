@@ -0,0 +1,53 @@
+package vault
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFullVerify(t *testing.T) {
+	v := NewVault(t.TempDir())
+	schemaPath := filepath.Join(v.RootPath, "schema.merged.cham")
+
+	if _, err := v.FullVerify(schemaPath); err == nil {
+		t.Error("FullVerify without a vault should fail")
+	}
+
+	registerSchema(t, v, "entity User { id: uuid primary, }")
+	registerSchema(t, v, "entity User { id: uuid primary, name: string, }")
+
+	report, err := v.FullVerify(schemaPath)
+	if err != nil {
+		t.Fatalf("FullVerify() error = %v", err)
+	}
+	if !report.Valid || len(report.Versions) != 2 || report.SchemaFile != SchemaFileMatches || report.CurrentVersion != "v002" {
+		t.Errorf("unexpected report for a clean vault: %+v", report)
+	}
+
+	if err := os.WriteFile(schemaPath, []byte("entity User { id: uuid primary, email: string, }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := filepath.Join(v.RootPath, VaultDirName, VersionsDirName, "v001.json")
+	if err := os.WriteFile(snapshot, []byte(`{"tampered": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = v.FullVerify(schemaPath)
+	if err != nil {
+		t.Fatalf("FullVerify() error = %v", err)
+	}
+	if report.Valid || len(report.Issues) != 1 || report.SchemaFile != SchemaFileModified {
+		t.Errorf("expected one issue and a modified schema, got %+v", report)
+	}
+	if report.Versions[0].OK || report.Versions[0].Issue == "" || !report.Versions[1].OK {
+		t.Errorf("expected only v001 to fail, got %+v", report.Versions)
+	}
+
+	if err := os.Remove(schemaPath); err != nil {
+		t.Fatal(err)
+	}
+	if report, _ = v.FullVerify(schemaPath); report.SchemaFile != SchemaFileMissing {
+		t.Errorf("SchemaFile = %s, want %s", report.SchemaFile, SchemaFileMissing)
+	}
+}
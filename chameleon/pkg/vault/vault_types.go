@@ -15,6 +15,11 @@ type Manifest struct {
 	CurrentVersion string         `json:"current_version"`
 	Versions       []VersionEntry `json:"versions"`
 	ParanoidMode   string         `json:"paranoid_mode"` // Legacy compatibility field
+
+	// Branch is the branch new versions are registered on ("" = main);
+	// Heads maps each branch to its latest version. See branch.go.
+	Branch string            `json:"branch,omitempty"`
+	Heads  map[string]string `json:"heads,omitempty"`
 }
 
 // ModeConfig stores current security/paranoid mode (source of truth)
@@ -30,14 +35,18 @@ type ModeAuthConfig struct {
 
 // VersionEntry represents a single schema version in the vault
 type VersionEntry struct {
-	Version        string    `json:"version"`         // v001, v002, etc.
-	Hash           string    `json:"hash"`            // SHA256 hash
-	Timestamp      time.Time `json:"timestamp"`       // When registered
-	Author         string    `json:"author"`          // Who registered it
-	Parent         *string   `json:"parent"`          // Parent version (null for v001)
-	Locked         bool      `json:"locked"`          // Immutability flag
-	ChangesSummary string    `json:"changes_summary"` // Human-readable description
-	Files          []string  `json:"files"`           // Schema files included
+	Version        string    `json:"version"`                   // v001, v002, etc.
+	Hash           string    `json:"hash"`                      // SHA256 hash
+	Timestamp      time.Time `json:"timestamp"`                 // When registered
+	Author         string    `json:"author"`                    // Who registered it
+	Parent         *string   `json:"parent"`                    // Parent version (null for v001)
+	Locked         bool      `json:"locked"`                    // Immutability flag
+	ChangesSummary string    `json:"changes_summary"`           // Human-readable description
+	Files          []string  `json:"files"`                     // Schema files included
+	FormatVersion  int       `json:"format_version,omitempty"`  // SchemaFormat it was registered with (0 = before formats)
+	FormatRequires string    `json:"format_requires,omitempty"` // First release reading FormatVersion
+	Branch         string    `json:"branch,omitempty"`          // Branch it was registered on ("" = main)
+	MergeParent    *string   `json:"merge_parent,omitempty"`    // Head of the branch merged in, for merge versions
 }
 
 // IntegrityLogEntry represents a single entry in integrity.log
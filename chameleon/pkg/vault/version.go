@@ -7,8 +7,16 @@ import (
 	"time"
 )
 
-// RegisterVersion registers a new schema version in the vault
+// RegisterVersion registers a new schema version in the vault, on the
+// current branch
 func (v *Vault) RegisterVersion(schemaPath string, author string, changesSummary string) (*VersionEntry, error) {
+	return v.registerVersion(schemaPath, author, changesSummary, nil)
+}
+
+// registerVersion registers schemaPath as the next version of the current
+// branch. mergeParent is set for merge versions, which are registered even
+// when the schema itself did not change.
+func (v *Vault) registerVersion(schemaPath string, author string, changesSummary string, mergeParent *string) (*VersionEntry, error) {
 	// Ensure vault exists
 	if !v.Exists() {
 		if err := v.Initialize(); err != nil {
@@ -28,7 +36,7 @@ func (v *Vault) RegisterVersion(schemaPath string, author string, changesSummary
 	}
 
 	// Check if schema changed (compare with current version)
-	if v.Manifest.CurrentVersion != "" {
+	if v.Manifest.CurrentVersion != "" && mergeParent == nil {
 		current, err := v.GetCurrentVersion()
 		if err == nil && current.Hash == hash {
 			// No changes
@@ -43,7 +51,8 @@ func (v *Vault) RegisterVersion(schemaPath string, author string, changesSummary
 	// Determine parent
 	var parent *string
 	if v.Manifest.CurrentVersion != "" {
-		parent = &v.Manifest.CurrentVersion
+		current := v.Manifest.CurrentVersion
+		parent = &current
 	}
 
 	// Read schema content
@@ -62,6 +71,10 @@ func (v *Vault) RegisterVersion(schemaPath string, author string, changesSummary
 		Locked:         true,
 		ChangesSummary: changesSummary,
 		Files:          []string{schemaPath},
+		FormatVersion:  SchemaFormat,
+		FormatRequires: SchemaFormatRequires,
+		Branch:         v.Manifest.Branch,
+		MergeParent:    mergeParent,
 	}
 
 	// Save version snapshot
@@ -71,6 +84,7 @@ func (v *Vault) RegisterVersion(schemaPath string, author string, changesSummary
 
 	// Update manifest
 	v.Manifest.Versions = append(v.Manifest.Versions, entry)
+	v.Manifest.heads()[v.Manifest.CurrentBranch()] = version
 	v.Manifest.CurrentVersion = version
 
 	if err := v.saveManifest(v.Manifest); err != nil {
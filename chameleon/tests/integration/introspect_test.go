@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine"
 	"github.com/chameleon-db/chameleondb/chameleon/pkg/engine/introspect"
+	"github.com/jackc/pgx/v5"
 )
 
 func TestIntrospectGetAllTablesAndGenerateSchema(t *testing.T) {
@@ -70,3 +72,123 @@ func TestIntrospectGetAllTablesAndGenerateSchema(t *testing.T) {
 		t.Fatalf("expected Post entity in generated schema, got:\n%s", schema)
 	}
 }
+
+func TestIntrospectCommentsRoundTrip(t *testing.T) {
+	skipIfNoDocker(t)
+
+	eng, ctx, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	runMigration(t, eng, ctx)
+
+	conn, err := pgx.Connect(ctx, testConfig().ConnectionString())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, `
+		COMMENT ON TABLE users IS 'Registered accounts
+
+One per login';
+		COMMENT ON COLUMN users.email IS 'Used to sign in, can''t be reused';
+	`)
+	if err != nil {
+		t.Fatalf("failed to add comments: %v", err)
+	}
+
+	inspect := func() *introspect.TableInfo {
+		inspector, err := introspect.NewIntrospector(ctx, testConfig().ConnectionString())
+		if err != nil {
+			t.Fatalf("failed to create introspector: %v", err)
+		}
+		defer inspector.Close()
+
+		table, err := inspector.InspectTable(ctx, "users")
+		if err != nil {
+			t.Fatalf("InspectTable failed: %v", err)
+		}
+		return table
+	}
+
+	before := inspect()
+	source, err := introspect.GenerateChameleonSchema([]introspect.TableInfo{*before})
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchema failed: %v", err)
+	}
+	if !strings.Contains(source, "    /// Used to sign in, can't be reused\n    email:") {
+		t.Fatalf("expected email description in generated schema, got:\n%s", source)
+	}
+
+	// Recreate the table from the generated schema
+	regenerated := engine.NewEngineForCLI()
+	if _, err := regenerated.LoadSchemaFromString(source); err != nil {
+		t.Fatalf("generated schema does not parse: %v\n%s", err, source)
+	}
+	if _, err := conn.Exec(ctx, "DROP TABLE users CASCADE"); err != nil {
+		t.Fatalf("failed to drop users: %v", err)
+	}
+	runMigration(t, regenerated, ctx)
+
+	after := inspect()
+	if after.Comment != before.Comment {
+		t.Errorf("table comment = %q, want %q", after.Comment, before.Comment)
+	}
+	for i, col := range before.Columns {
+		if after.Columns[i].Comment != col.Comment {
+			t.Errorf("column %s comment = %q, want %q", col.Name, after.Columns[i].Comment, col.Comment)
+		}
+	}
+}
+
+func TestIntrospectCustomTypes(t *testing.T) {
+	skipIfNoDocker(t)
+
+	eng, ctx, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	runMigration(t, eng, ctx)
+
+	conn, err := pgx.Connect(ctx, testConfig().ConnectionString())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, `
+		CREATE TYPE user_status AS ENUM ('active', 'banned');
+		CREATE DOMAIN short_code AS varchar(8);
+		ALTER TABLE users ADD COLUMN status user_status, ADD COLUMN code short_code, ADD COLUMN location point;
+	`)
+	if err != nil {
+		t.Fatalf("failed to add custom columns: %v", err)
+	}
+
+	inspector, err := introspect.NewIntrospector(ctx, testConfig().ConnectionString())
+	if err != nil {
+		t.Fatalf("failed to create introspector: %v", err)
+	}
+	defer inspector.Close()
+
+	table, err := inspector.InspectTable(ctx, "users")
+	if err != nil {
+		t.Fatalf("InspectTable failed: %v", err)
+	}
+
+	tables := []introspect.TableInfo{*table}
+	typeMap := introspect.TypeMapping{"user_status": "string", "short_code": "string"}
+	source, err := introspect.GenerateChameleonSchemaWithOptions(tables, introspect.GenerateOptions{TypeMapping: typeMap})
+	if err != nil {
+		t.Fatalf("GenerateChameleonSchemaWithOptions failed: %v", err)
+	}
+
+	unmapped := introspect.UnmappedColumns(tables, typeMap)
+	if len(unmapped) != 1 || unmapped[0].Column != "location" || unmapped[0].Type != "point" {
+		t.Fatalf("expected only location to be unmapped, got %+v", unmapped)
+	}
+	for _, want := range []string{"    status: string nullable,\n", "    code: string nullable,\n", "    location: string nullable,\n"} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("generated schema missing %q\n%s", want, source)
+		}
+	}
+}
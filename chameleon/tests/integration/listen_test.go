@@ -0,0 +1,41 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenReceivesNotifications(t *testing.T) {
+	skipIfNoDocker(t)
+
+	eng, ctx, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	notifications, err := eng.Listen(listenCtx, "cache_invalidation")
+	require.NoError(t, err)
+
+	_, err = eng.Connector().Pool().Exec(ctx, "SELECT pg_notify('cache_invalidation', 'user:42')")
+	require.NoError(t, err)
+
+	select {
+	case n := <-notifications:
+		assert.Equal(t, "cache_invalidation", n.Channel)
+		assert.Equal(t, "user:42", n.Payload)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification received")
+	}
+
+	// Cancelling releases the connection and closes the channel
+	cancel()
+	select {
+	case _, ok := <-notifications:
+		assert.False(t, ok, "channel should be closed after cancel")
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}